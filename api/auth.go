@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"strings"
+
+	pb "github.com/textileio/go-threads/api/pb"
+	"github.com/textileio/go-threads/core/thread"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Authorizer decides whether callers may call API methods.
+type Authorizer interface {
+	// Authorize returns an error if identity may not call method on the db with threadID.
+	// method is the full gRPC method name, e.g., "/threads.pb.API/Create".
+	// threadID is undefined for methods which don't address a db, e.g., ListDBs,
+	// and identity is nil for calls without a token.
+	Authorize(ctx context.Context, method string, threadID thread.ID, identity thread.PubKey) error
+}
+
+// UnaryInterceptor returns a server interceptor which authorizes unary calls to the service
+// with the Authorizer of its config. Calls to other services are passed through.
+func (s *Service) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if s.authorizer != nil && isServiceMethod(info.FullMethod) {
+			if err := s.authorize(ctx, info.FullMethod, req); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a server interceptor which authorizes streaming calls to the service
+// with the Authorizer of its config, when the first request is received.
+// Calls to other services are passed through.
+func (s *Service) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if s.authorizer != nil && isServiceMethod(info.FullMethod) {
+			stream = &authorizedStream{ServerStream: stream, service: s, method: info.FullMethod}
+		}
+		return handler(srv, stream)
+	}
+}
+
+// authorize calls the authorizer with the db addressed by req and the identity of the
+// caller, extracted from the context like the handlers do.
+func (s *Service) authorize(ctx context.Context, method string, req interface{}) error {
+	id, err := requestThreadID(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return err
+	}
+	identity, err := token.Validate(s.issuer)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err := s.authorizer.Authorize(ctx, method, id, identity); err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// requestThreadID returns the db addressed by req, if any.
+func requestThreadID(req interface{}) (thread.ID, error) {
+	var b []byte
+	switch r := req.(type) {
+	case interface{ GetDbID() []byte }:
+		b = r.GetDbID()
+	case interface {
+		GetStartTransactionRequest() *pb.StartTransactionRequest
+	}:
+		b = r.GetStartTransactionRequest().GetDbID()
+	}
+	if len(b) == 0 {
+		return thread.Undef, nil
+	}
+	return thread.Cast(b)
+}
+
+// isServiceMethod returns whether method belongs to the API service.
+func isServiceMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+pb.API_ServiceDesc.ServiceName+"/")
+}
+
+// authorizedStream authorizes a streaming call when its first request is received.
+type authorizedStream struct {
+	grpc.ServerStream
+	service    *Service
+	method     string
+	authorized bool
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized {
+		return nil
+	}
+	if err := s.service.authorize(s.Context(), s.method, m); err != nil {
+		return err
+	}
+	s.authorized = true
+	return nil
+}
@@ -9,8 +9,10 @@ import (
 	"reflect"
 
 	"github.com/alecthomas/jsonschema"
+	"github.com/libp2p/go-libp2p-core/crypto"
 	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/textileio/go-threads/api/pb"
+	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/db"
 	"google.golang.org/grpc"
@@ -28,6 +30,9 @@ const (
 	ActionSave
 	// ActionDelete represents an event for deleting existing instance.
 	ActionDelete
+	// ActionConflict represents concurrent edits to an instance that were resolved
+	// by a save. Only sent for collections with ReportConflicts enabled.
+	ActionConflict
 )
 
 // Action represents a data event delivered to a listener.
@@ -36,6 +41,11 @@ type Action struct {
 	Type       ActionType
 	InstanceID string
 	Instance   []byte
+	Conflicts  []core.Conflict
+	// ResumeToken identifies the action when resuming a listen stream.
+	ResumeToken string
+	// Author is the identity which wrote the action, if known.
+	Author thread.PubKey
 }
 
 // ListenActionType describes the type of event action when receiving data updates.
@@ -50,6 +60,8 @@ const (
 	ListenSave
 	// ListenDelete specifies that Delete events should be listened for.
 	ListenDelete
+	// ListenConflict specifies that Conflict events should be listened for.
+	ListenConflict
 )
 
 // ListenOption represents a filter to apply when listening for data updates.
@@ -57,6 +69,11 @@ type ListenOption struct {
 	Type       ListenActionType
 	Collection string
 	InstanceID string
+	// Authors restricts events to those written by one of the given identities.
+	Authors []thread.PubKey
+	// ExcludeAuthors drops events written by any of the given identities, e.g., to ignore
+	// one's own writes.
+	ExcludeAuthors []thread.PubKey
 }
 
 // ListenEvent is used to send data or error values for Listen.
@@ -224,20 +241,31 @@ func collectionConfigToPb(c db.CollectionConfig) (*pb.CollectionConfig, error) {
 	idx := make([]*pb.Index, len(c.Indexes))
 	for i, index := range c.Indexes {
 		idx[i] = &pb.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:      index.Path,
+			Unique:    index.Unique,
+			Encrypted: index.Encrypted,
+			Paths:     index.Paths,
+			Multikey:  index.Multikey,
 		}
 	}
-	schemaBytes, err := json.Marshal(c.Schema)
-	if err != nil {
-		return nil, err
+	var schemaBytes []byte
+	if c.Schema != nil {
+		var err error
+		schemaBytes, err = json.Marshal(c.Schema)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return &pb.CollectionConfig{
-		Name:           c.Name,
-		Schema:         schemaBytes,
-		Indexes:        idx,
-		WriteValidator: c.WriteValidator,
-		ReadFilter:     c.ReadFilter,
+		Name:            c.Name,
+		Schema:          schemaBytes,
+		Indexes:         idx,
+		WriteValidator:  c.WriteValidator,
+		ReadFilter:      c.ReadFilter,
+		ReportConflicts: c.ReportConflicts,
+		Datastore:       c.Datastore,
+		EncryptValues:   c.EncryptValues,
+		EventCodec:      c.EventCodec,
 	}, nil
 }
 
@@ -373,26 +401,44 @@ func (c *Client) GetCollectionInfo(ctx context.Context, dbID thread.ID, name str
 	if err != nil {
 		return db.CollectionConfig{}, err
 	}
-	schema := &jsonschema.Schema{}
-	err = json.Unmarshal(resp.Schema, schema)
+	schema, err := schemaFromPb(resp.Schema)
 	if err != nil {
 		return db.CollectionConfig{}, err
 	}
 	return db.CollectionConfig{
-		Name:           resp.Name,
-		Schema:         schema,
-		Indexes:        indexesFromPb(resp.Indexes),
-		WriteValidator: resp.WriteValidator,
-		ReadFilter:     resp.ReadFilter,
+		Name:            resp.Name,
+		Schema:          schema,
+		Indexes:         indexesFromPb(resp.Indexes),
+		WriteValidator:  resp.WriteValidator,
+		ReadFilter:      resp.ReadFilter,
+		ReportConflicts: resp.ReportConflicts,
+		Datastore:       resp.Datastore,
+		EncryptValues:   resp.EncryptValues,
+		EventCodec:      resp.EventCodec,
 	}, nil
 }
 
+// schemaFromPb returns the schema of a collection, or nil for dynamic collections.
+func schemaFromPb(b []byte) (*jsonschema.Schema, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
 func indexesFromPb(pbindexes []*pb.Index) []db.Index {
 	indexes := make([]db.Index, len(pbindexes))
 	for i, index := range pbindexes {
 		indexes[i] = db.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:      index.Path,
+			Unique:    index.Unique,
+			Encrypted: index.Encrypted,
+			Paths:     index.Paths,
+			Multikey:  index.Multikey,
 		}
 	}
 	return indexes
@@ -429,22 +475,41 @@ func (c *Client) ListCollections(ctx context.Context, dbID thread.ID, opts ...db
 	}
 	list := make([]db.CollectionConfig, len(resp.Collections))
 	for i, c := range resp.Collections {
-		schema := &jsonschema.Schema{}
-		err = json.Unmarshal(c.Schema, schema)
+		schema, err := schemaFromPb(c.Schema)
 		if err != nil {
 			return nil, err
 		}
 		list[i] = db.CollectionConfig{
-			Name:           c.Name,
-			Schema:         schema,
-			Indexes:        indexesFromPb(c.Indexes),
-			WriteValidator: c.WriteValidator,
-			ReadFilter:     c.ReadFilter,
+			Name:            c.Name,
+			Schema:          schema,
+			Indexes:         indexesFromPb(c.Indexes),
+			WriteValidator:  c.WriteValidator,
+			ReadFilter:      c.ReadFilter,
+			ReportConflicts: c.ReportConflicts,
+			Datastore:       c.Datastore,
+			EncryptValues:   c.EncryptValues,
+			EventCodec:      c.EventCodec,
 		}
 	}
 	return list, nil
 }
 
+// GetOpenAPISpec returns a JSON-encoded OpenAPI document describing the collections of a db.
+func (c *Client) GetOpenAPISpec(ctx context.Context, dbID thread.ID, opts ...db.ManagedOption) ([]byte, error) {
+	args := &db.ManagedOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetOpenAPISpec(ctx, &pb.GetOpenAPISpecRequest{
+		DbID: dbID.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Spec, nil
+}
+
 // Create creates new instances of objects.
 func (c *Client) Create(ctx context.Context, dbID thread.ID, collectionName string, instances Instances, opts ...db.TxnOption) ([]string, error) {
 	args := &db.TxnOptions{}
@@ -560,6 +625,60 @@ func (c *Client) Find(ctx context.Context, dbID thread.ID, collectionName string
 	return processFindReply(resp, dummy)
 }
 
+// FindVerified finds instances by query, as Find, and verifies that each instance was written
+// by a record of the thread signed by one of logKeys, so that instances fabricated by an
+// untrusted service are detected. See db.VerifyInstance for what a proof covers.
+// The thread key and log keys must come from a trusted source, not from the service.
+// Instances of collections with a custom event codec, projected queries, and computed fields
+// can't be verified.
+func (c *Client) FindVerified(
+	ctx context.Context,
+	dbID thread.ID,
+	collectionName string,
+	query *db.Query,
+	dummy interface{},
+	key thread.Key,
+	logKeys []crypto.PubKey,
+	opts ...db.TxnOption,
+) (interface{}, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.Find(ctx, &pb.FindRequest{
+		DbID:           dbID.Bytes(),
+		CollectionName: collectionName,
+		QueryJSON:      queryBytes,
+		Verifiable:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := txnError(resp.TransactionError); err != nil {
+		return nil, err
+	}
+	if len(resp.Proofs) != len(resp.Instances) {
+		return nil, fmt.Errorf("%w: got %d proofs for %d instances", db.ErrInvalidProof, len(resp.Proofs), len(resp.Instances))
+	}
+	for i, instance := range resp.Instances {
+		p := resp.Proofs[i]
+		if err := db.VerifyInstance(collectionName, instance, &db.InstanceProof{
+			RecordNode: p.RecordNode,
+			EventNode:  p.EventNode,
+			HeaderNode: p.HeaderNode,
+			BodyNode:   p.BodyNode,
+		}, key, logKeys, nil); err != nil {
+			return nil, err
+		}
+	}
+	return processFindReply(resp, dummy)
+}
+
 // FindByID finds an instance by id.
 func (c *Client) FindByID(ctx context.Context, dbID thread.ID, collectionName, instanceID string, instance interface{}, opts ...db.TxnOption) error {
 	args := &db.TxnOptions{}
@@ -620,7 +739,36 @@ func (c *Client) Listen(ctx context.Context, dbID thread.ID, listenOptions []Lis
 	for _, opt := range opts {
 		opt(args)
 	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	stream, err := c.listen(ctx, dbID, listenOptions, "")
+	if err != nil {
+		return nil, err
+	}
 	channel := make(chan ListenEvent)
+	go func() {
+		defer close(channel)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				stat := status.Convert(err)
+				if stat == nil || (stat.Code() != codes.Canceled) {
+					channel <- ListenEvent{Err: err}
+				}
+				return
+			}
+			action, err := actionFromPb(event)
+			if err != nil {
+				channel <- ListenEvent{Err: err}
+				return
+			}
+			channel <- ListenEvent{Action: action}
+		}
+	}()
+	return channel, nil
+}
+
+// listen opens a listen stream, resuming after resumeToken if it's not empty.
+func (c *Client) listen(ctx context.Context, dbID thread.ID, listenOptions []ListenOption, resumeToken string) (pb.API_ListenClient, error) {
 	filters := make([]*pb.ListenRequest_Filter, len(listenOptions))
 	for i, listenOption := range listenOptions {
 		var action pb.ListenRequest_Filter_Action
@@ -633,6 +781,8 @@ func (c *Client) Listen(ctx context.Context, dbID thread.ID, listenOptions []Lis
 			action = pb.ListenRequest_Filter_DELETE
 		case ListenSave:
 			action = pb.ListenRequest_Filter_SAVE
+		case ListenConflict:
+			action = pb.ListenRequest_Filter_CONFLICT
 		default:
 			return nil, fmt.Errorf("unknown ListenOption.Type %v", listenOption.Type)
 		}
@@ -640,51 +790,73 @@ func (c *Client) Listen(ctx context.Context, dbID thread.ID, listenOptions []Lis
 			CollectionName: listenOption.Collection,
 			InstanceID:     listenOption.InstanceID,
 			Action:         action,
+			Authors:        pubKeysToPb(listenOption.Authors),
+			ExcludeAuthors: pubKeysToPb(listenOption.ExcludeAuthors),
 		}
 	}
-	ctx = thread.NewTokenContext(ctx, args.Token)
-	stream, err := c.c.Listen(ctx, &pb.ListenRequest{
-		DbID:    dbID.Bytes(),
-		Filters: filters,
+	return c.c.Listen(ctx, &pb.ListenRequest{
+		DbID:        dbID.Bytes(),
+		Filters:     filters,
+		ResumeToken: resumeToken,
 	})
-	if err != nil {
-		return nil, err
+}
+
+func actionFromPb(event *pb.ListenReply) (Action, error) {
+	var actionType ActionType
+	switch event.GetAction() {
+	case pb.ListenReply_CREATE:
+		actionType = ActionCreate
+	case pb.ListenReply_DELETE:
+		actionType = ActionDelete
+	case pb.ListenReply_SAVE:
+		actionType = ActionSave
+	case pb.ListenReply_CONFLICT:
+		actionType = ActionConflict
+	default:
+		return Action{}, fmt.Errorf("unknown listen reply action %v", event.GetAction())
+	}
+	action := Action{
+		Collection:  event.GetCollectionName(),
+		Type:        actionType,
+		InstanceID:  event.GetInstanceID(),
+		Instance:    event.GetInstance(),
+		Conflicts:   conflictsFromPb(event.GetConflicts()),
+		ResumeToken: event.GetResumeToken(),
+	}
+	if event.GetAuthor() != "" {
+		author := &thread.Libp2pPubKey{}
+		if err := author.UnmarshalString(event.GetAuthor()); err != nil {
+			return Action{}, fmt.Errorf("decoding listen reply author: %v", err)
+		}
+		action.Author = author
 	}
-	go func() {
-		defer close(channel)
+	return action, nil
+}
 
-	loop:
-		for {
-			event, err := stream.Recv()
-			if err != nil {
-				stat := status.Convert(err)
-				if stat == nil || (stat.Code() != codes.Canceled) {
-					channel <- ListenEvent{Err: err}
-				}
-				break
-			}
-			var actionType ActionType
-			switch event.GetAction() {
-			case pb.ListenReply_CREATE:
-				actionType = ActionCreate
-			case pb.ListenReply_DELETE:
-				actionType = ActionDelete
-			case pb.ListenReply_SAVE:
-				actionType = ActionSave
-			default:
-				channel <- ListenEvent{Err: fmt.Errorf("unknown listen reply action %v", event.GetAction())}
-				break loop
-			}
-			action := Action{
-				Collection: event.GetCollectionName(),
-				Type:       actionType,
-				InstanceID: event.GetInstanceID(),
-				Instance:   event.GetInstance(),
-			}
-			channel <- ListenEvent{Action: action}
+func pubKeysToPb(keys []thread.PubKey) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+	pbkeys := make([]string, len(keys))
+	for i, k := range keys {
+		pbkeys[i] = k.String()
+	}
+	return pbkeys
+}
+
+func conflictsFromPb(pbconflicts []*pb.ListenReply_Conflict) []core.Conflict {
+	if len(pbconflicts) == 0 {
+		return nil
+	}
+	conflicts := make([]core.Conflict, len(pbconflicts))
+	for i, c := range pbconflicts {
+		conflicts[i] = core.Conflict{
+			Path:   c.Path,
+			Winner: c.Winner,
+			Loser:  c.Loser,
 		}
-	}()
-	return channel, nil
+	}
+	return conflicts
 }
 
 func processFindReply(reply *pb.FindReply, dummy interface{}) (interface{}, error) {
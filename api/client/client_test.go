@@ -4,15 +4,21 @@ import (
 	"bytes"
 	"context"
 	crand "crypto/rand"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"math/rand"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -29,6 +35,8 @@ import (
 	"github.com/textileio/go-threads/db"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestMain(m *testing.M) {
@@ -277,6 +285,7 @@ func TestClient_GetCollectionInfo(t *testing.T) {
 				Path:   "lastName",
 				Unique: true,
 			}},
+			ReportConflicts: true,
 		})
 		checkErr(t, err)
 		info, err := client.GetCollectionInfo(context.Background(), id, collectionName)
@@ -294,6 +303,9 @@ func TestClient_GetCollectionInfo(t *testing.T) {
 		if len(info.Indexes) != 1 {
 			t.Fatalf("expected 1 indexes, but got %v", len(info.Indexes))
 		}
+		if !info.ReportConflicts {
+			t.Fatal("expected report conflicts to be enabled")
+		}
 	})
 }
 
@@ -359,6 +371,32 @@ func TestClient_ListCollections(t *testing.T) {
 	})
 }
 
+func TestClient_GetOpenAPISpec(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	t.Run("test get openapi spec", func(t *testing.T) {
+		id := thread.NewIDV1(thread.Raw, 32)
+		err := client.NewDB(context.Background(), id)
+		checkErr(t, err)
+		err = client.NewCollection(context.Background(), id, db.CollectionConfig{
+			Name:   collectionName,
+			Schema: util.SchemaFromSchemaString(schema),
+		})
+		checkErr(t, err)
+		spec, err := client.GetOpenAPISpec(context.Background(), id)
+		checkErr(t, err)
+		var doc struct {
+			Paths map[string]interface{}
+		}
+		checkErr(t, json.Unmarshal(spec, &doc))
+		if _, ok := doc.Paths["/collections/"+collectionName+"/instances"]; !ok {
+			t.Fatalf("expected paths for collection %s", collectionName)
+		}
+	})
+}
+
 func TestClient_Create(t *testing.T) {
 	t.Parallel()
 	client, done := setup(t)
@@ -582,6 +620,19 @@ func TestClient_Find(t *testing.T) {
 		if !reflect.DeepEqual(results[0], person) {
 			t.Fatal("collection found by query does't equal the original")
 		}
+
+		rawResults, err = client.Find(context.Background(), id, collectionName, q.Select("firstName"), &Person{})
+		if err != nil {
+			t.Fatalf("failed to find: %v", err)
+		}
+		results = rawResults.([]*Person)
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, but got %v", len(results))
+		}
+		expected := &Person{ID: person.ID, FirstName: person.FirstName}
+		if !reflect.DeepEqual(results[0], expected) {
+			t.Fatalf("expected selected fields %v, got %v", expected, results[0])
+		}
 	})
 }
 
@@ -626,6 +677,73 @@ func TestClient_FindWithIndex(t *testing.T) {
 	})
 }
 
+func TestClient_Collection(t *testing.T) {
+	t.Parallel()
+	client, done := setup(t)
+	defer done()
+
+	ctx := context.Background()
+	id := thread.NewIDV1(thread.Raw, 32)
+	err := client.NewDB(ctx, id)
+	checkErr(t, err)
+	err = client.NewCollection(ctx, id, db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)})
+	checkErr(t, err)
+	coll := client.Collection(id, collectionName)
+
+	people := []interface{}{
+		&Person{FirstName: "Adam", LastName: "Doe", Age: 21},
+		&Person{FirstName: "Bob", LastName: "Doe", Age: 30},
+		&Person{FirstName: "Carl", LastName: "Doe", Age: 40},
+		&Person{FirstName: "Dave", LastName: "Roe", Age: 50},
+	}
+	ids, err := coll.Create(ctx, people...)
+	checkErr(t, err)
+	if len(ids) != len(people) {
+		t.Fatalf("expected %d ids, got %d", len(people), len(ids))
+	}
+
+	var results []Person
+	err = coll.Find().Where("lastName").Eq("Doe").And("age").Ge(30).OrderByDesc("age").Limit(10).All(ctx, &results)
+	checkErr(t, err)
+	if len(results) != 2 || results[0].FirstName != "Carl" || results[1].FirstName != "Bob" {
+		t.Fatalf("unexpected results: %v", results)
+	}
+
+	var first Person
+	found, err := client.FindIn(id, collectionName).Where("age").Eq(50).First(ctx, &first)
+	checkErr(t, err)
+	if !found || first.FirstName != "Dave" {
+		t.Fatalf("expected to find Dave, got %v", first)
+	}
+	found, err = coll.Find().Where("age").Gt(100).First(ctx, &first)
+	checkErr(t, err)
+	if found {
+		t.Fatal("expected no result")
+	}
+
+	first.Age = 51
+	err = coll.Save(ctx, &first)
+	checkErr(t, err)
+	var saved Person
+	err = coll.FindByID(ctx, first.ID, &saved)
+	checkErr(t, err)
+	if saved.Age != 51 {
+		t.Fatalf("expected saved age 51, got %d", saved.Age)
+	}
+
+	err = coll.Delete(ctx, first.ID)
+	checkErr(t, err)
+	exists, err := coll.Has(ctx, first.ID)
+	checkErr(t, err)
+	if exists {
+		t.Fatal("expected instance to be deleted")
+	}
+
+	if err := coll.Find().All(ctx, results); err == nil {
+		t.Fatal("expected error decoding into a non-pointer")
+	}
+}
+
 func TestClient_FindByID(t *testing.T) {
 	t.Parallel()
 	client, done := setup(t)
@@ -956,6 +1074,84 @@ func TestClient_Listen(t *testing.T) {
 	})
 }
 
+func TestClient_ListenReconnecting(t *testing.T) {
+	t.Parallel()
+	service, stop := makeService(t)
+	defer stop()
+	newClient := func() (*Client, ma.Multiaddr, *grpc.Server) {
+		port, err := freeport.GetFreePort()
+		checkErr(t, err)
+		addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+		server := serve(t, service, addr)
+		target, err := util.TCPAddrFromMultiAddr(addr)
+		checkErr(t, err)
+		client, err := NewClient(target, grpc.WithInsecure())
+		checkErr(t, err)
+		return client, addr, server
+	}
+	// The listening client is served by a server that is restarted,
+	// while the other client keeps writing through a second server.
+	client, addr, server := newClient()
+	defer client.Close()
+	writer, _, writerServer := newClient()
+	defer util.StopGRPCServer(writerServer)
+	defer writer.Close()
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	err := writer.NewDB(context.Background(), id)
+	checkErr(t, err)
+	err = writer.NewCollection(
+		context.Background(),
+		id,
+		db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+	)
+	checkErr(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, statuses, err := client.ListenReconnecting(
+		ctx,
+		id,
+		[]ListenOption{{Type: ListenCreate, Collection: collectionName}},
+		ReconnectConfig{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second},
+	)
+	checkErr(t, err)
+	waitStatus := func(expected ConnectionStatus) {
+		t.Helper()
+		for s := range statuses {
+			if s == expected {
+				return
+			}
+		}
+		t.Fatalf("status channel closed before %v", expected)
+	}
+	waitStatus(StatusConnected)
+
+	first, err := writer.Create(context.Background(), id, collectionName, Instances{createPerson()})
+	checkErr(t, err)
+	if e := <-events; e.Err != nil || e.Action.InstanceID != first[0] {
+		t.Fatalf("unexpected event %v", e)
+	}
+
+	server.Stop()
+	waitStatus(StatusReconnecting)
+	missed, err := writer.Create(context.Background(), id, collectionName, Instances{createPerson()})
+	checkErr(t, err)
+	server = serve(t, service, addr)
+	defer util.StopGRPCServer(server)
+	waitStatus(StatusConnected)
+
+	last, err := writer.Create(context.Background(), id, collectionName, Instances{createPerson()})
+	checkErr(t, err)
+	for _, expected := range []string{missed[0], last[0]} {
+		e := <-events
+		checkErr(t, e.Err)
+		if e.Action.InstanceID != expected {
+			t.Fatalf("expected create of %s, got %s", expected, e.Action.InstanceID)
+		}
+	}
+}
+
 func TestClient_Close(t *testing.T) {
 	t.Parallel()
 	addr, shutdown := makeServer(t)
@@ -976,8 +1172,312 @@ func TestClient_Close(t *testing.T) {
 	})
 }
 
+func TestClient_Authorizer(t *testing.T) {
+	t.Parallel()
+	denied := thread.NewIDV1(thread.Raw, 32)
+	auth := &testAuthorizer{denied: denied}
+	client, done := setupWithConfig(t, api.Config{Debug: true, Authorizer: auth})
+	defer done()
+
+	t.Run("test allowed call", func(t *testing.T) {
+		identity := createIdentity(t)
+		tok, err := client.GetToken(context.Background(), identity)
+		checkErr(t, err)
+		id := thread.NewIDV1(thread.Raw, 32)
+		err = client.NewDB(context.Background(), id, db.WithNewManagedToken(tok))
+		checkErr(t, err)
+		threadID, pk := auth.last()
+		if !threadID.Equals(id) {
+			t.Fatalf("expected authorized thread %s, got %s", id, threadID)
+		}
+		if pk == nil || !pk.Equals(identity.GetPublic()) {
+			t.Fatal("expected authorized identity to match token")
+		}
+	})
+
+	t.Run("test call without thread", func(t *testing.T) {
+		_, err := client.ListDBs(context.Background())
+		checkErr(t, err)
+		threadID, pk := auth.last()
+		if threadID != thread.Undef {
+			t.Fatalf("expected undefined thread, got %s", threadID)
+		}
+		if pk != nil {
+			t.Fatal("expected nil identity without token")
+		}
+	})
+
+	t.Run("test denied call", func(t *testing.T) {
+		err := client.NewDB(context.Background(), denied)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied, got %v", err)
+		}
+	})
+
+	t.Run("test denied stream", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		channel, err := client.Listen(ctx, denied, []ListenOption{{Type: ListenAll}})
+		checkErr(t, err)
+		val, ok := <-channel
+		if !ok {
+			t.Fatal("channel closed without error")
+		}
+		if status.Code(val.Err) != codes.PermissionDenied {
+			t.Fatalf("expected permission denied, got %v", val.Err)
+		}
+	})
+}
+
+func TestService_RESTHandler(t *testing.T) {
+	t.Parallel()
+	denied := thread.NewIDV1(thread.Raw, 32)
+	service, stop := makeServiceWithConfig(t, api.Config{Debug: true, Authorizer: &testAuthorizer{denied: denied}})
+	port, err := freeport.GetFreePort()
+	checkErr(t, err)
+	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+	server := serve(t, service, addr)
+	target, err := util.TCPAddrFromMultiAddr(addr)
+	checkErr(t, err)
+	client, err := NewClient(target, grpc.WithInsecure())
+	checkErr(t, err)
+	rest := httptest.NewServer(service.RESTHandler())
+	defer func() {
+		rest.Close()
+		_ = client.Close()
+		util.StopGRPCServer(server)
+		stop()
+	}()
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	err = client.NewDB(context.Background(), id)
+	checkErr(t, err)
+	err = client.NewCollection(
+		context.Background(),
+		id,
+		db.CollectionConfig{Name: collectionName, Schema: util.SchemaFromSchemaString(schema)},
+	)
+	checkErr(t, err)
+	ids, err := client.Create(context.Background(), id, collectionName, Instances{createPerson(), createPerson()})
+	checkErr(t, err)
+
+	get := func(t *testing.T, path string, params url.Values) (int, []byte) {
+		u := rest.URL + path
+		if params != nil {
+			u += "?" + params.Encode()
+		}
+		res, err := http.Get(u)
+		checkErr(t, err)
+		defer res.Body.Close()
+		body, err := ioutil.ReadAll(res.Body)
+		checkErr(t, err)
+		if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+			t.Fatalf("expected json content type, got %s", ct)
+		}
+		return res.StatusCode, body
+	}
+	base := api.RESTPrefix + id.String() + "/" + collectionName
+
+	t.Run("test find by id", func(t *testing.T) {
+		code, body := get(t, base+"/"+ids[0], nil)
+		if code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", code, body)
+		}
+		person := &Person{}
+		checkErr(t, json.Unmarshal(body, person))
+		if person.ID != ids[0] {
+			t.Fatalf("expected instance %s, got %s", ids[0], person.ID)
+		}
+	})
+
+	t.Run("test find pages", func(t *testing.T) {
+		q, err := json.Marshal(db.Where("lastName").Eq("Doe"))
+		checkErr(t, err)
+		params := url.Values{"query": {string(q)}, "limit": {"1"}}
+		found := make(map[string]struct{})
+		for i := 0; i < len(ids); i++ {
+			code, body := get(t, base, params)
+			if code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", code, body)
+			}
+			reply := &api.RESTFindReply{}
+			checkErr(t, json.Unmarshal(body, reply))
+			if len(reply.Instances) != 1 {
+				t.Fatalf("expected 1 instance, got %d", len(reply.Instances))
+			}
+			person := &Person{}
+			checkErr(t, json.Unmarshal(reply.Instances[0], person))
+			found[person.ID] = struct{}{}
+			params.Set("after", reply.Next)
+		}
+		if len(found) != len(ids) {
+			t.Fatalf("expected %d distinct instances, got %d", len(ids), len(found))
+		}
+	})
+
+	t.Run("test export", func(t *testing.T) {
+		export := func(t *testing.T, format string) (string, []byte) {
+			res, err := http.Get(rest.URL + base + "/export?format=" + format)
+			checkErr(t, err)
+			defer res.Body.Close()
+			body, err := ioutil.ReadAll(res.Body)
+			checkErr(t, err)
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", res.StatusCode, body)
+			}
+			return res.Header.Get("Content-Type"), body
+		}
+
+		ct, body := export(t, "csv")
+		if ct != "text/csv" {
+			t.Fatalf("expected csv content type, got %s", ct)
+		}
+		rows, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		checkErr(t, err)
+		if len(rows) != len(ids)+1 {
+			t.Fatalf("expected header and %d rows, got %d rows", len(ids), len(rows))
+		}
+		header := []string{"_id", "age", "firstName", "lastName"}
+		if !reflect.DeepEqual(rows[0], header) {
+			t.Fatalf("expected header %v, got %v", header, rows[0])
+		}
+		for _, row := range rows[1:] {
+			if row[1] != "21" || row[2] != "Adam" || row[3] != "Doe" {
+				t.Fatalf("unexpected row %v", row)
+			}
+		}
+
+		ct, body = export(t, "ndjson")
+		if ct != "application/x-ndjson" {
+			t.Fatalf("expected ndjson content type, got %s", ct)
+		}
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+		if len(lines) != len(ids) {
+			t.Fatalf("expected %d lines, got %d", len(ids), len(lines))
+		}
+		for _, line := range lines {
+			person := &Person{}
+			checkErr(t, json.Unmarshal([]byte(line), person))
+			if person.FirstName != "Adam" {
+				t.Fatalf("unexpected instance %s", line)
+			}
+		}
+
+		if code, _ := get(t, base+"/export", url.Values{"format": {"xls"}}); code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for bad format, got %d", code)
+		}
+	})
+
+	t.Run("test hosts", func(t *testing.T) {
+		hosts := httptest.NewServer(service.RESTHostsHandler([]api.RESTHost{
+			{Name: "a.example"},
+			{Name: "b.example", Subdomains: true},
+		}))
+		defer hosts.Close()
+		getHost := func(t *testing.T, host, path string) (int, []byte) {
+			req, err := http.NewRequest(http.MethodGet, hosts.URL+path, nil)
+			checkErr(t, err)
+			req.Host = host
+			res, err := http.DefaultClient.Do(req)
+			checkErr(t, err)
+			defer res.Body.Close()
+			body, err := ioutil.ReadAll(res.Body)
+			checkErr(t, err)
+			return res.StatusCode, body
+		}
+		for _, c := range []struct {
+			host string
+			path string
+			code int
+		}{
+			{host: "a.example", path: base, code: http.StatusOK},
+			{host: "A.example:8080", path: base + "/" + ids[0], code: http.StatusOK},
+			{host: "b.example", path: base, code: http.StatusOK},
+			{host: id.String() + ".b.example", path: "/" + collectionName, code: http.StatusOK},
+			{host: id.String() + ".b.example", path: "/" + collectionName + "/" + ids[0], code: http.StatusOK},
+			{host: id.String() + ".B.example:8443", path: "/" + collectionName + "/" + ids[0], code: http.StatusOK},
+			{host: id.String() + ".a.example", path: "/" + collectionName, code: http.StatusNotFound},
+			// Subdomains other than a thread ID are rewritten, and rejected like bad thread IDs
+			{host: "unknown.b.example", path: "/" + collectionName, code: http.StatusBadRequest},
+			{host: "unknown.b.example:8443", path: "/" + collectionName, code: http.StatusBadRequest},
+			{host: "x." + id.String() + ".b.example", path: "/" + collectionName, code: http.StatusNotFound},
+			{host: "c.example", path: base, code: http.StatusNotFound},
+		} {
+			if code, _ := getHost(t, c.host, c.path); code != c.code {
+				t.Fatalf("expected status %d for %s%s, got %d", c.code, c.host, c.path, code)
+			}
+		}
+
+		// Subdomain requests are served at the path of the thread
+		_, want := getHost(t, "b.example:8443", base+"/"+ids[0])
+		_, got := getHost(t, id.String()+".b.example:8443", "/"+collectionName+"/"+ids[0])
+		if !bytes.Equal(got, want) {
+			t.Fatalf("expected instance %s at subdomain, got %s", want, got)
+		}
+	})
+
+	t.Run("test errors", func(t *testing.T) {
+		if code, _ := get(t, base+"/missing", nil); code != http.StatusNotFound {
+			t.Fatalf("expected status 404 for missing instance, got %d", code)
+		}
+		if code, _ := get(t, api.RESTPrefix+id.String()+"/Missing", nil); code != http.StatusNotFound {
+			t.Fatalf("expected status 404 for missing collection, got %d", code)
+		}
+		if code, _ := get(t, base, url.Values{"limit": {"x"}}); code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for bad limit, got %d", code)
+		}
+		if code, _ := get(t, api.RESTPrefix+denied.String()+"/"+collectionName, nil); code != http.StatusForbidden {
+			t.Fatalf("expected status 403 for denied thread, got %d", code)
+		}
+		req, err := http.NewRequest(http.MethodGet, rest.URL+base, nil)
+		checkErr(t, err)
+		req.Header.Set("Authorization", "Bearer bad")
+		res, err := http.DefaultClient.Do(req)
+		checkErr(t, err)
+		res.Body.Close()
+		if res.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("expected status 401 for bad token, got %d", res.StatusCode)
+		}
+		res, err = http.Post(rest.URL+base, "application/json", strings.NewReader("{}"))
+		checkErr(t, err)
+		res.Body.Close()
+		if res.StatusCode != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status 405 for post, got %d", res.StatusCode)
+		}
+	})
+}
+
+type testAuthorizer struct {
+	denied thread.ID
+
+	lk       sync.Mutex
+	threadID thread.ID
+	identity thread.PubKey
+}
+
+func (a *testAuthorizer) Authorize(_ context.Context, _ string, id thread.ID, identity thread.PubKey) error {
+	a.lk.Lock()
+	defer a.lk.Unlock()
+	a.threadID = id
+	a.identity = identity
+	if id.Equals(a.denied) {
+		return errors.New("thread is denied")
+	}
+	return nil
+}
+
+func (a *testAuthorizer) last() (thread.ID, thread.PubKey) {
+	a.lk.Lock()
+	defer a.lk.Unlock()
+	return a.threadID, a.identity
+}
+
 func setup(t *testing.T) (*Client, func()) {
-	addr, shutdown := makeServer(t)
+	return setupWithConfig(t, api.Config{Debug: true})
+}
+
+func setupWithConfig(t *testing.T, conf api.Config) (*Client, func()) {
+	addr, shutdown := makeServerWithConfig(t, conf)
 	target, err := util.TCPAddrFromMultiAddr(addr)
 	if err != nil {
 		t.Fatal(err)
@@ -994,6 +1494,29 @@ func setup(t *testing.T) (*Client, func()) {
 }
 
 func makeServer(t *testing.T) (ma.Multiaddr, func()) {
+	return makeServerWithConfig(t, api.Config{Debug: true})
+}
+
+func makeServerWithConfig(t *testing.T, conf api.Config) (ma.Multiaddr, func()) {
+	service, stop := makeServiceWithConfig(t, conf)
+	port, err := freeport.GetFreePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+	server := serve(t, service, addr)
+	return addr, func() {
+		time.Sleep(time.Second) // Give threads a chance to finish work
+		util.StopGRPCServer(server)
+		stop()
+	}
+}
+
+func makeService(t *testing.T) (*api.Service, func()) {
+	return makeServiceWithConfig(t, api.Config{Debug: true})
+}
+
+func makeServiceWithConfig(t *testing.T, conf api.Config) (*api.Service, func()) {
 	time.Sleep(time.Second * time.Duration(rand.Intn(5)))
 	n, err := common.DefaultNetwork(
 		common.WithNetMongoPersistence(test.GetMongoUri(), util.MakeToken(12)),
@@ -1014,22 +1537,30 @@ func makeServer(t *testing.T) (ma.Multiaddr, func()) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	service, err := api.NewService(store, n, api.Config{
-		Debug: true,
-	})
+	service, err := api.NewService(store, n, conf)
 	if err != nil {
 		t.Fatal(err)
 	}
-	port, err := freeport.GetFreePort()
-	if err != nil {
-		t.Fatal(err)
+	return service, func() {
+		if err := n.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+		cancel()
 	}
-	addr := util.MustParseAddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port))
+}
+
+func serve(t *testing.T, service *api.Service, addr ma.Multiaddr) *grpc.Server {
 	target, err := util.TCPAddrFromMultiAddr(addr)
 	if err != nil {
 		t.Fatal(err)
 	}
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(service.UnaryInterceptor()),
+		grpc.StreamInterceptor(service.StreamInterceptor()),
+	)
 	listener, err := net.Listen("tcp", target)
 	if err != nil {
 		t.Fatal(err)
@@ -1040,18 +1571,7 @@ func makeServer(t *testing.T) (ma.Multiaddr, func()) {
 			log.Fatalf("serve error: %v", err)
 		}
 	}()
-
-	return addr, func() {
-		time.Sleep(time.Second) // Give threads a chance to finish work
-		util.StopGRPCServer(server)
-		if err := n.Close(); err != nil {
-			t.Fatal(err)
-		}
-		if err := store.Close(); err != nil {
-			t.Fatal(err)
-		}
-		cancel()
-	}
+	return server
 }
 
 func checkErr(t *testing.T, err error) {
@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	pb "github.com/textileio/go-threads/api/pb"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+)
+
+// Collection is a handle to a collection of a db, which reads and writes instances as Go values.
+// Instances are marshaled to JSON, so they must match the schema of the collection.
+type Collection struct {
+	client *Client
+	dbID   thread.ID
+	name   string
+	opts   []db.TxnOption
+}
+
+// Collection returns a handle to the collection name of db dbID.
+// Options are applied to every call made with the handle.
+func (c *Client) Collection(dbID thread.ID, name string, opts ...db.TxnOption) *Collection {
+	return &Collection{client: c, dbID: dbID, name: name, opts: opts}
+}
+
+// Create creates new instances, returning their ids.
+func (c *Collection) Create(ctx context.Context, instances ...interface{}) ([]string, error) {
+	return c.client.Create(ctx, c.dbID, c.name, instances, c.opts...)
+}
+
+// Save saves existing instances.
+func (c *Collection) Save(ctx context.Context, instances ...interface{}) error {
+	return c.client.Save(ctx, c.dbID, c.name, instances, c.opts...)
+}
+
+// Delete deletes instances by id.
+func (c *Collection) Delete(ctx context.Context, instanceIDs ...string) error {
+	return c.client.Delete(ctx, c.dbID, c.name, instanceIDs, c.opts...)
+}
+
+// Has returns whether all the instances exist.
+func (c *Collection) Has(ctx context.Context, instanceIDs ...string) (bool, error) {
+	return c.client.Has(ctx, c.dbID, c.name, instanceIDs, c.opts...)
+}
+
+// FindByID decodes the instance with the given id into instance, which must be a pointer.
+func (c *Collection) FindByID(ctx context.Context, instanceID string, instance interface{}) error {
+	return c.client.FindByID(ctx, c.dbID, c.name, instanceID, instance, c.opts...)
+}
+
+// Find starts a query of the collection, e.g.,
+//
+//	coll.Find().Where("age").Ge(18).OrderBy("name").Limit(10).All(ctx, &people)
+func (c *Collection) Find() *QueryBuilder {
+	return &QueryBuilder{coll: c, query: &db.Query{}}
+}
+
+// FindIn starts a query of the collection name of db dbID. See Collection.Find.
+func (c *Client) FindIn(dbID thread.ID, name string, opts ...db.TxnOption) *QueryBuilder {
+	return c.Collection(dbID, name, opts...).Find()
+}
+
+// QueryBuilder builds a db.Query, and runs it against a collection.
+type QueryBuilder struct {
+	coll  *Collection
+	query *db.Query
+}
+
+// FieldBuilder adds a condition on a field to a query.
+type FieldBuilder struct {
+	qb        *QueryBuilder
+	criterion *db.Criterion
+}
+
+// Where starts a condition on a field, which instances must satisfy in addition to the
+// previous conditions.
+func (qb *QueryBuilder) Where(field string) *FieldBuilder {
+	return &FieldBuilder{qb: qb, criterion: qb.query.And(field)}
+}
+
+// And is the same as Where.
+func (qb *QueryBuilder) And(field string) *FieldBuilder {
+	return qb.Where(field)
+}
+
+// Or adds an alternative query which is sufficient for instances to satisfy.
+// Has left-associativity as: (a And b) Or c
+func (qb *QueryBuilder) Or(other *QueryBuilder) *QueryBuilder {
+	qb.query.Or(other.query)
+	return qb
+}
+
+// OrderBy specifies ascending order of results.
+func (qb *QueryBuilder) OrderBy(field string) *QueryBuilder {
+	qb.query.OrderBy(field)
+	return qb
+}
+
+// OrderByDesc specifies descending order of results.
+func (qb *QueryBuilder) OrderByDesc(field string) *QueryBuilder {
+	qb.query.OrderByDesc(field)
+	return qb
+}
+
+// ThenBy specifies ascending order of results which are equal in the previous orders.
+func (qb *QueryBuilder) ThenBy(field string) *QueryBuilder {
+	qb.query.ThenBy(field)
+	return qb
+}
+
+// ThenByDesc specifies descending order of results which are equal in the previous orders.
+func (qb *QueryBuilder) ThenByDesc(field string) *QueryBuilder {
+	qb.query.ThenByDesc(field)
+	return qb
+}
+
+// Limit sets the maximum number of results.
+func (qb *QueryBuilder) Limit(limit int) *QueryBuilder {
+	qb.query.LimitTo(limit)
+	return qb
+}
+
+// Skip skips the given number of results.
+func (qb *QueryBuilder) Skip(num int) *QueryBuilder {
+	qb.query.SkipNum(num)
+	return qb
+}
+
+// Select restricts results to the given field paths, plus the instance ID.
+func (qb *QueryBuilder) Select(paths ...string) *QueryBuilder {
+	qb.query.Select(paths...)
+	return qb
+}
+
+// UseIndex specifies the index to use when running the query.
+func (qb *QueryBuilder) UseIndex(path string) *QueryBuilder {
+	qb.query.UseIndex(path)
+	return qb
+}
+
+// Query returns the built query.
+func (qb *QueryBuilder) Query() *db.Query {
+	return qb.query
+}
+
+// All runs the query, and decodes the results into results, which must be a pointer to a slice,
+// e.g., *[]Person or *[]*Person.
+func (qb *QueryBuilder) All(ctx context.Context, results interface{}) error {
+	rv := reflect.ValueOf(results)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("results must be a pointer to a slice, got %T", results)
+	}
+	instances, err := qb.find(ctx)
+	if err != nil {
+		return err
+	}
+	slice := rv.Elem()
+	out := reflect.MakeSlice(slice.Type(), len(instances), len(instances))
+	for i, instance := range instances {
+		if err := json.Unmarshal(instance, out.Index(i).Addr().Interface()); err != nil {
+			return err
+		}
+	}
+	slice.Set(out)
+	return nil
+}
+
+// First runs the query, and decodes the first result into result, which must be a pointer.
+// It returns false if there are no results.
+func (qb *QueryBuilder) First(ctx context.Context, result interface{}) (bool, error) {
+	limit := qb.query.Limit
+	qb.query.LimitTo(1)
+	instances, err := qb.find(ctx)
+	qb.query.LimitTo(limit)
+	if err != nil || len(instances) == 0 {
+		return false, err
+	}
+	return true, json.Unmarshal(instances[0], result)
+}
+
+func (qb *QueryBuilder) find(ctx context.Context) ([][]byte, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range qb.coll.opts {
+		opt(args)
+	}
+	queryBytes, err := json.Marshal(qb.query)
+	if err != nil {
+		return nil, err
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := qb.coll.client.c.Find(ctx, &pb.FindRequest{
+		DbID:           qb.coll.dbID.Bytes(),
+		CollectionName: qb.coll.name,
+		QueryJSON:      queryBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := txnError(resp.TransactionError); err != nil {
+		return nil, err
+	}
+	return resp.GetInstances(), nil
+}
+
+// Eq requires the field to equal value.
+func (fb *FieldBuilder) Eq(value interface{}) *QueryBuilder {
+	fb.criterion.Eq(queryValue(value))
+	return fb.qb
+}
+
+// Ne requires the field not to equal value.
+func (fb *FieldBuilder) Ne(value interface{}) *QueryBuilder {
+	fb.criterion.Ne(queryValue(value))
+	return fb.qb
+}
+
+// Gt requires the field to be greater than value.
+func (fb *FieldBuilder) Gt(value interface{}) *QueryBuilder {
+	fb.criterion.Gt(queryValue(value))
+	return fb.qb
+}
+
+// Lt requires the field to be less than value.
+func (fb *FieldBuilder) Lt(value interface{}) *QueryBuilder {
+	fb.criterion.Lt(queryValue(value))
+	return fb.qb
+}
+
+// Ge requires the field to be greater than or equal to value.
+func (fb *FieldBuilder) Ge(value interface{}) *QueryBuilder {
+	fb.criterion.Ge(queryValue(value))
+	return fb.qb
+}
+
+// Le requires the field to be less than or equal to value.
+func (fb *FieldBuilder) Le(value interface{}) *QueryBuilder {
+	fb.criterion.Le(queryValue(value))
+	return fb.qb
+}
+
+// Contains requires the field, which must be an array, to contain value.
+func (fb *FieldBuilder) Contains(value interface{}) *QueryBuilder {
+	fb.criterion.Contains(queryValue(value))
+	return fb.qb
+}
+
+// queryValue converts numbers to float64, which is how JSON numbers are compared in queries.
+func queryValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32:
+		return v.Float()
+	default:
+		return value
+	}
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConnectionStatus describes the state of a reconnecting listen stream.
+type ConnectionStatus int
+
+const (
+	// StatusConnected indicates the stream is connected and delivering events.
+	StatusConnected ConnectionStatus = iota
+	// StatusReconnecting indicates the stream was lost and is being reopened.
+	StatusReconnecting
+	// StatusFailed indicates the stream can't be reopened. No more events will be delivered.
+	StatusFailed
+)
+
+func (s ConnectionStatus) String() string {
+	switch s {
+	case StatusConnected:
+		return "connected"
+	case StatusReconnecting:
+		return "reconnecting"
+	case StatusFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// ReconnectConfig controls how ListenReconnecting reopens lost streams.
+type ReconnectConfig struct {
+	// MaxAttempts is the number of consecutive failed attempts after which the
+	// stream fails. Zero means unlimited.
+	MaxAttempts int
+	// MinBackoff is the delay before the first attempt. Defaults to 100ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, which doubles after each
+	// failure. Defaults to 30s.
+	MaxBackoff time.Duration
+}
+
+// ListenReconnecting is like Listen, but transparently reopens the stream when it's lost,
+// resuming after the last received action so that no actions are dropped or repeated.
+// Actions are only resumable once one has been received; a stream lost before that is
+// reopened from the current state.
+// Status changes are sent on the returned status channel, which only holds the latest status.
+// If the server can no longer resume the stream, e.g., because it restarted, an error event
+// is sent, followed by StatusFailed.
+// Both channels are closed when ctx is done or the stream fails.
+func (c *Client) ListenReconnecting(
+	ctx context.Context,
+	dbID thread.ID,
+	listenOptions []ListenOption,
+	config ReconnectConfig,
+	opts ...db.TxnOption,
+) (<-chan ListenEvent, <-chan ConnectionStatus, error) {
+	args := &db.TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if config.MinBackoff <= 0 {
+		config.MinBackoff = 100 * time.Millisecond
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = 30 * time.Second
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	stream, err := c.listen(ctx, dbID, listenOptions, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	events := make(chan ListenEvent)
+	statuses := make(chan ConnectionStatus, 1)
+	setStatus := func(s ConnectionStatus) {
+		select {
+		case <-statuses:
+		default:
+		}
+		statuses <- s
+	}
+	send := func(e ListenEvent) bool {
+		select {
+		case events <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(statuses)
+		defer close(events)
+		fail := func(err error) {
+			send(ListenEvent{Err: err})
+			setStatus(StatusFailed)
+		}
+		setStatus(StatusConnected)
+		var resumeToken string
+		for {
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					if status.Code(err) == codes.FailedPrecondition {
+						fail(err) // The resume token expired
+						return
+					}
+					break
+				}
+				action, err := actionFromPb(event)
+				if err != nil {
+					fail(err)
+					return
+				}
+				resumeToken = action.ResumeToken
+				if !send(ListenEvent{Action: action}) {
+					return
+				}
+			}
+
+			setStatus(StatusReconnecting)
+			backoff := config.MinBackoff
+			for attempt := 1; ; attempt++ {
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Done():
+					return
+				}
+				if stream, err = c.listen(ctx, dbID, listenOptions, resumeToken); err == nil {
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				if config.MaxAttempts > 0 && attempt >= config.MaxAttempts {
+					fail(err)
+					return
+				}
+				if backoff *= 2; backoff > config.MaxBackoff {
+					backoff = config.MaxBackoff
+				}
+			}
+			setStatus(StatusConnected)
+		}
+	}()
+	return events, statuses, nil
+}
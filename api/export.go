@@ -0,0 +1,231 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/textileio/go-threads/db"
+	"github.com/tidwall/gjson"
+)
+
+// restExportRoute is the last path segment of the export route of a collection.
+const restExportRoute = "export"
+
+// maxExportSchemaDepth bounds the nesting of schema objects flattened into CSV columns,
+// which also stops recursive schema definitions.
+const maxExportSchemaDepth = 8
+
+// errRESTBadExportFormat indicates an export was requested in an unsupported format.
+var errRESTBadExportFormat = errors.New("format parameter must be csv or ndjson")
+
+// exportColumn is a CSV column of an export.
+type exportColumn struct {
+	// header is the dotted path of the field.
+	header string
+	// path is the gjson path of the field.
+	path string
+}
+
+// serveExport streams the instances of c matching the query parameters of r, as CSV or
+// newline-delimited JSON. Instances are streamed as they're read, so errors occurring after
+// the first instance is written can't be reported in the response, and end it early.
+func serveExport(w http.ResponseWriter, r *http.Request, c *db.Collection, opts ...db.TxnOption) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "ndjson" {
+		writeRESTError(w, http.StatusBadRequest, errRESTBadExportFormat)
+		return
+	}
+	q, err := restQuery(r)
+	if err != nil {
+		writeRESTError(w, http.StatusBadRequest, err)
+		return
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	results, err := c.FindStream(ctx, q, opts...)
+	if errors.Is(err, db.ErrCantStreamSortedQuery) {
+		writeRESTError(w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		writeRESTError(w, restErrorCode(err), err)
+		return
+	}
+
+	name := c.GetName() + "." + format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	if format == "ndjson" {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for res := range results {
+			if res.Err != nil {
+				log.Errorf("exporting %s: %v", c.GetName(), res.Err)
+				return
+			}
+			if _, err := w.Write(append(res.Instance, '\n')); err != nil {
+				log.Errorf("writing export of %s: %v", c.GetName(), err)
+				return
+			}
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+	columns := schemaColumns(c.GetSchema())
+	var wroteHeader bool
+	writeHeader := func() error {
+		if wroteHeader {
+			return nil
+		}
+		wroteHeader = true
+		headers := make([]string, len(columns))
+		for i, col := range columns {
+			headers[i] = col.header
+		}
+		return cw.Write(headers)
+	}
+	for res := range results {
+		if res.Err != nil {
+			log.Errorf("exporting %s: %v", c.GetName(), res.Err)
+			return
+		}
+		if columns == nil {
+			// Schemaless collections are exported with the fields of the first instance
+			columns = instanceColumns(res.Instance)
+		}
+		if err := writeHeader(); err != nil {
+			log.Errorf("writing export of %s: %v", c.GetName(), err)
+			return
+		}
+		if err := cw.Write(csvRecord(columns, res.Instance)); err != nil {
+			log.Errorf("writing export of %s: %v", c.GetName(), err)
+			return
+		}
+	}
+	if columns != nil {
+		if err := writeHeader(); err != nil {
+			log.Errorf("writing export of %s: %v", c.GetName(), err)
+		}
+	}
+}
+
+// csvRecord returns the values of columns in instance. Strings are written as is, and other
+// values as JSON. Missing and null values are empty.
+func csvRecord(columns []exportColumn, instance []byte) []string {
+	record := make([]string, len(columns))
+	for i, col := range columns {
+		v := gjson.GetBytes(instance, col.path)
+		switch v.Type {
+		case gjson.Null:
+		case gjson.String:
+			record[i] = v.String()
+		default:
+			record[i] = v.Raw
+		}
+	}
+	return record
+}
+
+// schemaColumns returns a column for each leaf property of a JSON schema, flattening nested
+// objects into dotted paths. Properties are sorted by name, after the instance ID. Arrays and
+// objects without properties are single columns. It returns nil if the schema has no properties.
+func schemaColumns(schema []byte) []exportColumn {
+	var root map[string]interface{}
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil
+	}
+	defs, _ := root["definitions"].(map[string]interface{})
+	var columns []exportColumn
+	var walk func(node map[string]interface{}, header, path []string)
+	walk = func(node map[string]interface{}, header, path []string) {
+		if ref, ok := node["$ref"].(string); ok {
+			if def, ok := defs[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{}); ok {
+				node = def
+			}
+		}
+		props, _ := node["properties"].(map[string]interface{})
+		if len(props) == 0 || len(header) >= maxExportSchemaDepth {
+			if len(header) > 0 {
+				columns = append(columns, exportColumn{
+					header: strings.Join(header, "."),
+					path:   strings.Join(path, "."),
+				})
+			}
+			return
+		}
+		for _, name := range sortedFields(props) {
+			prop, _ := props[name].(map[string]interface{})
+			walk(prop, childPath(header, name), childPath(path, escapePath(name)))
+		}
+	}
+	walk(root, nil, nil)
+	return columns
+}
+
+// instanceColumns returns a column for each leaf field of a JSON instance, like schemaColumns.
+func instanceColumns(instance []byte) []exportColumn {
+	var columns []exportColumn
+	var walk func(v gjson.Result, header, path []string)
+	walk = func(v gjson.Result, header, path []string) {
+		fields := v.Map()
+		if !v.IsObject() || len(fields) == 0 || len(header) >= maxExportSchemaDepth {
+			if len(header) > 0 {
+				columns = append(columns, exportColumn{
+					header: strings.Join(header, "."),
+					path:   strings.Join(path, "."),
+				})
+			}
+			return
+		}
+		names := make(map[string]interface{}, len(fields))
+		for name := range fields {
+			names[name] = nil
+		}
+		for _, name := range sortedFields(names) {
+			walk(fields[name], childPath(header, name), childPath(path, escapePath(name)))
+		}
+	}
+	walk(gjson.ParseBytes(instance), nil, nil)
+	return columns
+}
+
+// sortedFields returns the names of fields sorted, with the instance ID first.
+func sortedFields(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "_id" || names[j] == "_id" {
+			return names[i] == "_id"
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// childPath returns path followed by name, without sharing the backing array of path.
+func childPath(path []string, name string) []string {
+	return append(path[:len(path):len(path)], name)
+}
+
+// escapePath escapes the characters of a field name which are special in gjson paths.
+func escapePath(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch r {
+		case '.', '*', '?', '|', '#', '@', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
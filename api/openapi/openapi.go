@@ -0,0 +1,158 @@
+// Package openapi generates OpenAPI documents describing the collections of a DB.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/textileio/go-threads/db"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.0.3"
+
+const (
+	definitionsPrefix = "#/definitions/"
+	schemasPrefix     = "#/components/schemas/"
+)
+
+// Spec returns a JSON-encoded OpenAPI document for the collections of a DB named name.
+// Each collection schema is added to the document components, and each collection
+// gets REST-style paths for creating, finding, getting, saving, and deleting instances.
+func Spec(name string, collections []db.CollectionConfig) ([]byte, error) {
+	sorted := make([]db.CollectionConfig, len(collections))
+	copy(sorted, collections)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	schemas := make(map[string]interface{})
+	paths := make(map[string]interface{})
+	for _, c := range sorted {
+		if err := addSchemas(schemas, c); err != nil {
+			return nil, fmt.Errorf("converting schema of %s: %v", c.Name, err)
+		}
+		ref := map[string]interface{}{"$ref": schemasPrefix + c.Name}
+		list := map[string]interface{}{"type": "array", "items": ref}
+		instances := fmt.Sprintf("/collections/%s/instances", c.Name)
+		paths[instances] = map[string]interface{}{
+			"get": operation(c.Name, "find", "Find instances matching a query.",
+				[]interface{}{queryParam()}, nil, list),
+			"post": operation(c.Name, "create", "Create instances.",
+				nil, list, idList()),
+		}
+		paths[instances+"/{id}"] = map[string]interface{}{
+			"parameters": []interface{}{idParam()},
+			"get":        operation(c.Name, "findByID", "Get an instance by ID.", nil, nil, ref),
+			"put":        operation(c.Name, "save", "Save an existing instance.", nil, ref, nil),
+			"delete":     operation(c.Name, "delete", "Delete an instance by ID.", nil, nil, nil),
+		}
+	}
+	doc := map[string]interface{}{
+		"openapi": Version,
+		"info": map[string]interface{}{
+			"title":   name,
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// addSchemas adds the schema of c to schemas.
+// JSON Schema definitions are hoisted to schemas as "<collection>.<definition>",
+// since OpenAPI schema objects can't hold them.
+func addSchemas(schemas map[string]interface{}, c db.CollectionConfig) error {
+	b, err := json.Marshal(c.Schema)
+	if err != nil {
+		return err
+	}
+	var s map[string]interface{}
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	defs, _ := s["definitions"].(map[string]interface{})
+	delete(s, "definitions")
+	delete(s, "$schema")
+	for n, d := range defs {
+		schemas[c.Name+"."+n] = rewriteRefs(d, c.Name)
+	}
+	schemas[c.Name] = rewriteRefs(s, c.Name)
+	return nil
+}
+
+// rewriteRefs points local definition references at hoisted component schemas.
+func rewriteRefs(v interface{}, collection string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if r, ok := e.(string); ok && k == "$ref" && strings.HasPrefix(r, definitionsPrefix) {
+				v[k] = schemasPrefix + collection + "." + strings.TrimPrefix(r, definitionsPrefix)
+				continue
+			}
+			v[k] = rewriteRefs(e, collection)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = rewriteRefs(e, collection)
+		}
+	}
+	return v
+}
+
+func operation(collection, verb, summary string, params []interface{}, body, result interface{}) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": verb + collection,
+		"summary":     summary,
+		"tags":        []interface{}{collection},
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+	if body != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(body),
+		}
+	}
+	ok := map[string]interface{}{"description": "OK"}
+	if result != nil {
+		ok["content"] = jsonContent(result)
+	}
+	op["responses"] = map[string]interface{}{"200": ok}
+	return op
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+func queryParam() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "query",
+		"in":          "query",
+		"description": "A JSON-encoded db.Query.",
+		"content":     jsonContent(map[string]interface{}{"type": "object"}),
+	}
+}
+
+func idParam() map[string]interface{} {
+	return map[string]interface{}{
+		"name":     "id",
+		"in":       "path",
+		"required": true,
+		"schema":   map[string]interface{}{"type": "string"},
+	}
+}
+
+func idList() map[string]interface{} {
+	return map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"type": "string"},
+	}
+}
@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/textileio/go-threads/db"
+	"github.com/textileio/go-threads/util"
+)
+
+type address struct {
+	City string
+}
+
+type person struct {
+	ID      string `json:"_id"`
+	Name    string
+	Address address
+}
+
+type dog struct {
+	ID   string `json:"_id"`
+	Name string
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+	b, err := Spec("pets", []db.CollectionConfig{
+		{Name: "Person", Schema: util.SchemaFromInstance(&person{}, false)},
+		{Name: "Dog", Schema: util.SchemaFromInstance(&dog{}, false)},
+	})
+	checkErr(t, err)
+	var doc map[string]interface{}
+	checkErr(t, json.Unmarshal(b, &doc))
+
+	if doc["openapi"] != Version {
+		t.Fatalf("expected openapi version %s, got %v", Version, doc["openapi"])
+	}
+	paths := doc["paths"].(map[string]interface{})
+	for _, p := range []string{
+		"/collections/Person/instances",
+		"/collections/Person/instances/{id}",
+		"/collections/Dog/instances",
+		"/collections/Dog/instances/{id}",
+	} {
+		if _, ok := paths[p]; !ok {
+			t.Fatalf("missing path %s", p)
+		}
+	}
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	if _, ok := schemas["Person.address"]; !ok {
+		t.Fatal("nested definitions should be hoisted to components")
+	}
+
+	ids := make(map[string]bool)
+	walk(doc, func(k string, v interface{}) {
+		switch k {
+		case "definitions", "$schema":
+			t.Fatalf("unexpected JSON Schema keyword %s", k)
+		case "$ref":
+			ref := v.(string)
+			if !strings.HasPrefix(ref, schemasPrefix) {
+				t.Fatalf("unexpected reference %s", ref)
+			}
+			if _, ok := schemas[strings.TrimPrefix(ref, schemasPrefix)]; !ok {
+				t.Fatalf("unresolved reference %s", ref)
+			}
+		case "operationId":
+			if ids[v.(string)] {
+				t.Fatalf("duplicate operation id %s", v)
+			}
+			ids[v.(string)] = true
+		}
+	})
+	if len(ids) != 10 {
+		t.Fatalf("expected %d operations, got %d", 10, len(ids))
+	}
+}
+
+func walk(v interface{}, fn func(k string, v interface{})) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			fn(k, e)
+			walk(e, fn)
+		}
+	case []interface{}:
+		for _, e := range v {
+			walk(e, fn)
+		}
+	}
+}
+
+func checkErr(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
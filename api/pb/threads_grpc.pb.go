@@ -31,6 +31,7 @@ type APIClient interface {
 	// Deprecated: Do not use.
 	GetCollectionIndexes(ctx context.Context, in *GetCollectionIndexesRequest, opts ...grpc.CallOption) (*GetCollectionIndexesReply, error)
 	ListCollections(ctx context.Context, in *ListCollectionsRequest, opts ...grpc.CallOption) (*ListCollectionsReply, error)
+	GetOpenAPISpec(ctx context.Context, in *GetOpenAPISpecRequest, opts ...grpc.CallOption) (*GetOpenAPISpecReply, error)
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateReply, error)
 	Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*VerifyReply, error)
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveReply, error)
@@ -182,6 +183,15 @@ func (c *aPIClient) ListCollections(ctx context.Context, in *ListCollectionsRequ
 	return out, nil
 }
 
+func (c *aPIClient) GetOpenAPISpec(ctx context.Context, in *GetOpenAPISpecRequest, opts ...grpc.CallOption) (*GetOpenAPISpecReply, error) {
+	out := new(GetOpenAPISpecReply)
+	err := c.cc.Invoke(ctx, "/threads.pb.API/GetOpenAPISpec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateReply, error) {
 	out := new(CreateReply)
 	err := c.cc.Invoke(ctx, "/threads.pb.API/Create", in, out, opts...)
@@ -356,6 +366,7 @@ type APIServer interface {
 	// Deprecated: Do not use.
 	GetCollectionIndexes(context.Context, *GetCollectionIndexesRequest) (*GetCollectionIndexesReply, error)
 	ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsReply, error)
+	GetOpenAPISpec(context.Context, *GetOpenAPISpecRequest) (*GetOpenAPISpecReply, error)
 	Create(context.Context, *CreateRequest) (*CreateReply, error)
 	Verify(context.Context, *VerifyRequest) (*VerifyReply, error)
 	Save(context.Context, *SaveRequest) (*SaveReply, error)
@@ -409,6 +420,9 @@ func (UnimplementedAPIServer) GetCollectionIndexes(context.Context, *GetCollecti
 func (UnimplementedAPIServer) ListCollections(context.Context, *ListCollectionsRequest) (*ListCollectionsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
 }
+func (UnimplementedAPIServer) GetOpenAPISpec(context.Context, *GetOpenAPISpecRequest) (*GetOpenAPISpecReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOpenAPISpec not implemented")
+}
 func (UnimplementedAPIServer) Create(context.Context, *CreateRequest) (*CreateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
@@ -676,6 +690,24 @@ func _API_ListCollections_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetOpenAPISpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOpenAPISpecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetOpenAPISpec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.pb.API/GetOpenAPISpec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetOpenAPISpec(ctx, req.(*GetOpenAPISpecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRequest)
 	if err := dec(in); err != nil {
@@ -926,6 +958,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListCollections",
 			Handler:    _API_ListCollections_Handler,
 		},
+		{
+			MethodName: "GetOpenAPISpec",
+			Handler:    _API_GetOpenAPISpec_Handler,
+		},
 		{
 			MethodName: "Create",
 			Handler:    _API_Create_Handler,
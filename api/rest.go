@@ -0,0 +1,270 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pb "github.com/textileio/go-threads/api/pb"
+	core "github.com/textileio/go-threads/core/db"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/db"
+)
+
+// RESTPrefix is the path prefix of the routes served by RESTHandler.
+const RESTPrefix = "/thread/"
+
+var (
+	// Full gRPC method names passed to the Authorizer for REST reads, so the same
+	// policy applies to both transports.
+	restFindMethod     = "/" + pb.API_ServiceDesc.ServiceName + "/Find"
+	restFindByIDMethod = "/" + pb.API_ServiceDesc.ServiceName + "/FindByID"
+
+	// errRESTMethodNotAllowed indicates a REST route was called with a method other than GET.
+	errRESTMethodNotAllowed = errors.New("method not allowed")
+	// errRESTBadRoute indicates a REST path doesn't match a route.
+	errRESTBadRoute = errors.New("path must be /thread/{id}/{collection}[/{instanceID}|/export]")
+	// errRESTUnknownHost indicates a request was sent to a host which isn't served.
+	errRESTUnknownHost = errors.New("unknown host")
+)
+
+// RESTHost is a host served by RESTHostsHandler.
+type RESTHost struct {
+	// Name is the hostname, without port.
+	Name string
+	// Subdomains sets whether the collections of a thread are also served at a subdomain
+	// of the host named after the thread ID, e.g., {id}.{name}/{collection}.
+	Subdomains bool
+}
+
+// RESTFindReply is the response body of a collection query.
+type RESTFindReply struct {
+	// Instances are the JSON instances matching the query.
+	Instances []json.RawMessage `json:"instances"`
+	// Next is a cursor for the following page, passed as the "after" parameter.
+	// It's empty when there are no more results.
+	Next string `json:"next,omitempty"`
+}
+
+// Manager returns the db manager of the service.
+func (s *Service) Manager() *db.Manager {
+	return s.manager
+}
+
+// RESTHandler returns a read-only JSON handler for collections.
+// GET /thread/{id}/{collection} returns the instances matching the "query" parameter, a JSON db.Query,
+// paged with the "limit", "skip" and "after" parameters. GET /thread/{id}/{collection}/{instanceID}
+// returns an instance. GET /thread/{id}/{collection}/export streams the instances matching the query
+// as CSV, with a column for each field of the collection schema, nested fields having dotted names,
+// or as newline-delimited JSON with the "format" parameter set to "ndjson". Exports can't be sorted
+// by a field other than the instance ID, and instances with the ID "export" can't be got.
+// A thread token can be passed with an "Authorization: Bearer <token>" header.
+func (s *Service) RESTHandler() http.Handler {
+	return http.HandlerFunc(s.serveREST)
+}
+
+// RESTHostsHandler returns a handler serving RESTHandler at each of hosts, dispatching requests
+// by their Host header, so that a single service can be served under several hostnames.
+// Requests to other hosts get a 404.
+func (s *Service) RESTHostsHandler(hosts []RESTHost) http.Handler {
+	byName := make(map[string]RESTHost, len(hosts))
+	for _, h := range hosts {
+		byName[restHostname(h.Name)] = h
+	}
+	rest := s.RESTHandler()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := restHostname(r.Host)
+		if _, ok := byName[name]; ok {
+			rest.ServeHTTP(w, r)
+			return
+		}
+		if i := strings.IndexByte(name, '.'); i > 0 {
+			if h, ok := byName[name[i+1:]]; ok && h.Subdomains {
+				sr := r.Clone(r.Context())
+				sr.URL.Path = RESTPrefix + name[:i] + "/" + strings.TrimPrefix(r.URL.Path, "/")
+				rest.ServeHTTP(w, sr)
+				return
+			}
+		}
+		writeRESTError(w, http.StatusNotFound, errRESTUnknownHost)
+	})
+}
+
+// restHostname returns host without its port, if any, in lower case.
+func restHostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+func (s *Service) serveREST(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeRESTError(w, http.StatusMethodNotAllowed, errRESTMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, RESTPrefix), "/"), "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		writeRESTError(w, http.StatusNotFound, errRESTBadRoute)
+		return
+	}
+	id, err := thread.Decode(parts[0])
+	if err != nil {
+		writeRESTError(w, http.StatusBadRequest, err)
+		return
+	}
+	token := thread.Token(strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")))
+
+	export := len(parts) == 3 && parts[2] == restExportRoute
+	method := restFindMethod
+	if len(parts) == 3 && !export {
+		method = restFindByIDMethod
+	}
+	if err := s.authorizeREST(r, method, id, token); err != nil {
+		writeRESTError(w, restErrorCode(err), err)
+		return
+	}
+	d, err := s.manager.GetDB(r.Context(), id, db.WithManagedToken(token))
+	if err != nil {
+		writeRESTError(w, restErrorCode(err), err)
+		return
+	}
+	c := d.GetCollection(parts[1])
+	if c == nil {
+		writeRESTError(w, http.StatusNotFound, db.ErrCollectionNotFound)
+		return
+	}
+
+	if export {
+		serveExport(w, r, c, db.WithTxnToken(token))
+		return
+	}
+	if len(parts) == 3 {
+		instance, err := c.FindByID(core.InstanceID(parts[2]), db.WithTxnToken(token))
+		if err != nil {
+			writeRESTError(w, restErrorCode(err), err)
+			return
+		}
+		writeRESTJSON(w, json.RawMessage(instance))
+		return
+	}
+
+	q, err := restQuery(r)
+	if err != nil {
+		writeRESTError(w, http.StatusBadRequest, err)
+		return
+	}
+	instances, err := c.Find(q, db.WithTxnToken(token))
+	if err != nil {
+		writeRESTError(w, restErrorCode(err), err)
+		return
+	}
+	reply := RESTFindReply{Instances: make([]json.RawMessage, len(instances))}
+	for i, instance := range instances {
+		reply.Instances[i] = instance
+	}
+	if q.Limit > 0 && len(instances) == q.Limit {
+		reply.Next, err = q.Cursor(instances[len(instances)-1])
+		if err != nil {
+			writeRESTError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	writeRESTJSON(w, reply)
+}
+
+// authorizeREST calls the authorizer, if any, like the gRPC interceptors do.
+func (s *Service) authorizeREST(r *http.Request, method string, id thread.ID, token thread.Token) error {
+	if s.authorizer == nil {
+		return nil
+	}
+	identity, err := token.Validate(s.issuer)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizer.Authorize(r.Context(), method, id, identity); err != nil {
+		return errRESTForbidden{err}
+	}
+	return nil
+}
+
+// restQuery builds a query from the "query", "limit", "skip" and "after" parameters of r.
+func restQuery(r *http.Request) (*db.Query, error) {
+	params := r.URL.Query()
+	q := &db.Query{}
+	if v := params.Get("query"); v != "" {
+		if err := json.Unmarshal([]byte(v), q); err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{
+		{"limit", &q.Limit},
+		{"skip", &q.Skip},
+	} {
+		v := params.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, errors.New("invalid " + p.name + " parameter")
+		}
+		*p.dst = n
+	}
+	if v := params.Get("after"); v != "" {
+		q.After(v)
+	}
+	return q, nil
+}
+
+// errRESTForbidden wraps an error returned by the authorizer.
+type errRESTForbidden struct {
+	error
+}
+
+func (e errRESTForbidden) Unwrap() error {
+	return e.error
+}
+
+// restErrorCode maps err to an HTTP status code.
+func restErrorCode(err error) int {
+	var forbidden errRESTForbidden
+	switch {
+	case errors.As(err, &forbidden), errors.Is(err, db.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, thread.ErrInvalidToken), errors.Is(err, thread.ErrTokenNotFound):
+		return http.StatusUnauthorized
+	case errors.Is(err, lstore.ErrThreadNotFound),
+		errors.Is(err, db.ErrDBNotFound),
+		errors.Is(err, db.ErrCollectionNotFound),
+		errors.Is(err, db.ErrInstanceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, db.ErrInvalidCursor), errors.Is(err, db.ErrCursorMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeRESTJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("writing rest response: %v", err)
+	}
+}
+
+func writeRESTError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
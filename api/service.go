@@ -12,6 +12,9 @@ import (
 	logging "github.com/ipfs/go-log/v2"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/api/openapi"
 	pb "github.com/textileio/go-threads/api/pb"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
@@ -20,6 +23,7 @@ import (
 	"github.com/textileio/go-threads/db"
 	kt "github.com/textileio/go-threads/db/keytransform"
 	"github.com/textileio/go-threads/util"
+	"github.com/tidwall/gjson"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -31,12 +35,23 @@ var (
 // Service is a gRPC DB API service backed by a DB manager.
 type Service struct {
 	pb.UnimplementedAPIServer
-	manager *db.Manager
+	manager    *db.Manager
+	authorizer Authorizer
+	issuer     crypto.PrivKey
 }
 
 // Config specifies service settings.
 type Config struct {
 	Debug bool
+	// Datastores are named datastores collections can be bound to.
+	Datastores map[string]kt.TxnDatastoreExtended
+	// ValueKey encrypts the stored values of collections with encrypted values.
+	ValueKey *sym.Key
+	// Authorizer authorizes calls made through UnaryInterceptor and StreamInterceptor.
+	// All calls are allowed if nil.
+	Authorizer Authorizer
+	// Metrics registers query and transaction counts of dbs, if not nil.
+	Metrics prometheus.Registerer
 }
 
 // NewService starts and returns a new service with the given network.
@@ -48,11 +63,25 @@ func NewService(store kt.TxnDatastoreExtended, network app.Net, conf Config) (*S
 		return nil, err
 	}
 
-	manager, err := db.NewManager(store, network, db.WithNewDebug(conf.Debug))
+	opts := []db.NewOption{db.WithNewDebug(conf.Debug)}
+	for name, s := range conf.Datastores {
+		opts = append(opts, db.WithNewDatastore(name, s))
+	}
+	if conf.ValueKey != nil {
+		opts = append(opts, db.WithNewValueKey(conf.ValueKey))
+	}
+	if conf.Metrics != nil {
+		opts = append(opts, db.WithNewMetrics(conf.Metrics))
+	}
+	manager, err := db.NewManager(store, network, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return &Service{manager: manager}, nil
+	return &Service{
+		manager:    manager,
+		authorizer: conf.Authorizer,
+		issuer:     network.Host().Peerstore().PrivKey(network.Host().ID()),
+	}, nil
 }
 
 func (s *Service) Close() error {
@@ -265,23 +294,50 @@ func collectionConfigFromPb(pbc *pb.CollectionConfig) (db.CollectionConfig, erro
 	indexes := make([]db.Index, len(pbc.Indexes))
 	for i, index := range pbc.Indexes {
 		indexes[i] = db.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:      index.Path,
+			Unique:    index.Unique,
+			Encrypted: index.Encrypted,
+			Paths:     index.Paths,
+			Multikey:  index.Multikey,
 		}
 	}
-	schema := &jsonschema.Schema{}
-	if err := json.Unmarshal(pbc.GetSchema(), schema); err != nil {
+	schema, err := schemaFromPb(pbc.GetSchema())
+	if err != nil {
 		return db.CollectionConfig{}, err
 	}
 	return db.CollectionConfig{
-		Name:           pbc.Name,
-		Schema:         schema,
-		Indexes:        indexes,
-		WriteValidator: pbc.WriteValidator,
-		ReadFilter:     pbc.ReadFilter,
+		Name:            pbc.Name,
+		Schema:          schema,
+		Indexes:         indexes,
+		WriteValidator:  pbc.WriteValidator,
+		ReadFilter:      pbc.ReadFilter,
+		ReportConflicts: pbc.ReportConflicts,
+		Datastore:       pbc.Datastore,
+		EncryptValues:   pbc.EncryptValues,
+		EventCodec:      pbc.EventCodec,
 	}, nil
 }
 
+// schemaFromPb returns the schema of a collection config, or nil for dynamic collections.
+func schemaFromPb(b []byte) (*jsonschema.Schema, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+	schema := &jsonschema.Schema{}
+	if err := json.Unmarshal(b, schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// schemaToPb returns the schema of a collection, which is empty for dynamic collections.
+func schemaToPb(c *db.Collection) []byte {
+	if c.IsDynamic() {
+		return nil
+	}
+	return c.GetSchema()
+}
+
 func (s *Service) ListDBs(ctx context.Context, _ *pb.ListDBsRequest) (*pb.ListDBsReply, error) {
 	log.Debug("received list dbs request")
 	token, err := thread.NewTokenFromMD(ctx)
@@ -447,11 +503,15 @@ func (s *Service) GetCollectionInfo(ctx context.Context, req *pb.GetCollectionIn
 		return nil, err
 	}
 	return &pb.GetCollectionInfoReply{
-		Name:           collection.GetName(),
-		Schema:         collection.GetSchema(),
-		Indexes:        indexesToPb(collection.GetIndexes()),
-		WriteValidator: string(collection.GetWriteValidator()),
-		ReadFilter:     string(collection.GetReadFilter()),
+		Name:            collection.GetName(),
+		Schema:          schemaToPb(collection),
+		Indexes:         indexesToPb(collection.GetIndexes()),
+		WriteValidator:  string(collection.GetWriteValidator()),
+		ReadFilter:      string(collection.GetReadFilter()),
+		ReportConflicts: collection.GetReportConflicts(),
+		Datastore:       collection.GetDatastore(),
+		EncryptValues:   collection.GetEncryptValues(),
+		EventCodec:      collection.GetEventCodec(),
 	}, nil
 }
 
@@ -459,8 +519,11 @@ func indexesToPb(indexes []db.Index) []*pb.Index {
 	pbindexes := make([]*pb.Index, len(indexes))
 	for i, index := range indexes {
 		pbindexes[i] = &pb.Index{
-			Path:   index.Path,
-			Unique: index.Unique,
+			Path:      index.Path,
+			Unique:    index.Unique,
+			Encrypted: index.Encrypted,
+			Paths:     index.Paths,
+			Multikey:  index.Multikey,
 		}
 	}
 	return pbindexes
@@ -503,16 +566,58 @@ func (s *Service) ListCollections(ctx context.Context, req *pb.ListCollectionsRe
 	pblist := make([]*pb.GetCollectionInfoReply, len(list))
 	for i, c := range list {
 		pblist[i] = &pb.GetCollectionInfoReply{
-			Name:           c.GetName(),
-			Schema:         c.GetSchema(),
-			Indexes:        indexesToPb(c.GetIndexes()),
-			WriteValidator: string(c.GetWriteValidator()),
-			ReadFilter:     string(c.GetReadFilter()),
+			Name:            c.GetName(),
+			Schema:          schemaToPb(c),
+			Indexes:         indexesToPb(c.GetIndexes()),
+			WriteValidator:  string(c.GetWriteValidator()),
+			ReadFilter:      string(c.GetReadFilter()),
+			ReportConflicts: c.GetReportConflicts(),
+			Datastore:       c.GetDatastore(),
+			EncryptValues:   c.GetEncryptValues(),
+			EventCodec:      c.GetEventCodec(),
 		}
 	}
 	return &pb.ListCollectionsReply{Collections: pblist}, nil
 }
 
+func (s *Service) GetOpenAPISpec(ctx context.Context, req *pb.GetOpenAPISpecRequest) (*pb.GetOpenAPISpecReply, error) {
+	log.Debug("received get openapi spec request")
+	id, err := thread.Cast(req.DbID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.getDB(ctx, id, token)
+	if err != nil {
+		return nil, err
+	}
+	info, err := d.GetDBInfo(db.WithToken(token))
+	if err != nil {
+		return nil, err
+	}
+	list := d.ListCollections(db.WithToken(token))
+	configs := make([]db.CollectionConfig, len(list))
+	for i, c := range list {
+		schema := &jsonschema.Schema{}
+		if err := json.Unmarshal(c.GetSchema(), schema); err != nil {
+			return nil, err
+		}
+		configs[i] = db.CollectionConfig{
+			Name:    c.GetName(),
+			Schema:  schema,
+			Indexes: c.GetIndexes(),
+		}
+	}
+	spec, err := openapi.Spec(info.Name, configs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetOpenAPISpecReply{Spec: spec}, nil
+}
+
 func (s *Service) Create(ctx context.Context, req *pb.CreateRequest) (*pb.CreateReply, error) {
 	log.Debug("received create request")
 	id, err := thread.Cast(req.DbID)
@@ -595,7 +700,9 @@ func (s *Service) Has(ctx context.Context, req *pb.HasRequest) (*pb.HasReply, er
 	if err != nil {
 		return nil, err
 	}
-	return s.processHasRequest(req, token, collection.HasMany)
+	return s.processHasRequest(req, token, func(ids []core.InstanceID, opts ...db.TxnOption) (bool, error) {
+		return collection.HasManyContext(ctx, ids, opts...)
+	})
 }
 
 func (s *Service) Find(ctx context.Context, req *pb.FindRequest) (*pb.FindReply, error) {
@@ -612,7 +719,27 @@ func (s *Service) Find(ctx context.Context, req *pb.FindRequest) (*pb.FindReply,
 	if err != nil {
 		return nil, err
 	}
-	return s.processFindRequest(req, token, collection.Find)
+	reply, err := s.processFindRequest(req, token, func(q *db.Query, opts ...db.TxnOption) ([][]byte, error) {
+		return collection.FindContext(ctx, q, opts...)
+	})
+	if err != nil || !req.Verifiable {
+		return reply, err
+	}
+	reply.Proofs = make([]*pb.InstanceProof, len(reply.Instances))
+	for i, instance := range reply.Instances {
+		id := core.InstanceID(gjson.GetBytes(instance, "_id").String())
+		proof, err := collection.Prove(ctx, id, db.WithTxnToken(token))
+		if err != nil {
+			return nil, fmt.Errorf("proving instance %s: %w", id, err)
+		}
+		reply.Proofs[i] = &pb.InstanceProof{
+			RecordNode: proof.RecordNode,
+			EventNode:  proof.EventNode,
+			HeaderNode: proof.HeaderNode,
+			BodyNode:   proof.BodyNode,
+		}
+	}
+	return reply, nil
 }
 
 func (s *Service) FindByID(ctx context.Context, req *pb.FindByIDRequest) (*pb.FindByIDReply, error) {
@@ -629,7 +756,9 @@ func (s *Service) FindByID(ctx context.Context, req *pb.FindByIDRequest) (*pb.Fi
 	if err != nil {
 		return nil, err
 	}
-	return s.processFindByIDRequest(req, token, collection.FindByID)
+	return s.processFindByIDRequest(req, token, func(id core.InstanceID, opts ...db.TxnOption) ([]byte, error) {
+		return collection.FindByIDContext(ctx, id, opts...)
+	})
 }
 
 func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
@@ -678,7 +807,7 @@ func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 			switch x := req.Option.(type) {
 			case *pb.ReadTransactionRequest_HasRequest:
 				innerReply, err := s.processHasRequest(x.HasRequest, token, func(ids []core.InstanceID, _ ...db.TxnOption) (bool, error) {
-					return txn.Has(ids...)
+					return txn.HasContext(stream.Context(), ids...)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -689,7 +818,7 @@ func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 				}
 			case *pb.ReadTransactionRequest_FindByIDRequest:
 				innerReply, err := s.processFindByIDRequest(x.FindByIDRequest, token, func(id core.InstanceID, _ ...db.TxnOption) ([]byte, error) {
-					return txn.FindByID(id)
+					return txn.FindByIDContext(stream.Context(), id)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -700,7 +829,7 @@ func (s *Service) ReadTransaction(stream pb.API_ReadTransactionServer) error {
 				}
 			case *pb.ReadTransactionRequest_FindRequest:
 				innerReply, err := s.processFindRequest(x.FindRequest, token, func(q *db.Query, _ ...db.TxnOption) (ret [][]byte, err error) {
-					return txn.Find(q)
+					return txn.FindContext(stream.Context(), q)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -764,7 +893,7 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 			switch x := req.Option.(type) {
 			case *pb.WriteTransactionRequest_HasRequest:
 				innerReply, err := s.processHasRequest(x.HasRequest, token, func(ids []core.InstanceID, _ ...db.TxnOption) (bool, error) {
-					return txn.Has(ids...)
+					return txn.HasContext(stream.Context(), ids...)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -775,7 +904,7 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 				}
 			case *pb.WriteTransactionRequest_FindByIDRequest:
 				innerReply, err := s.processFindByIDRequest(x.FindByIDRequest, token, func(id core.InstanceID, _ ...db.TxnOption) ([]byte, error) {
-					return txn.FindByID(id)
+					return txn.FindByIDContext(stream.Context(), id)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -786,7 +915,7 @@ func (s *Service) WriteTransaction(stream pb.API_WriteTransactionServer) error {
 				}
 			case *pb.WriteTransactionRequest_FindRequest:
 				innerReply, err := s.processFindRequest(x.FindRequest, token, func(q *db.Query, _ ...db.TxnOption) (ret [][]byte, err error) {
-					return txn.Find(q)
+					return txn.FindContext(stream.Context(), q)
 				})
 				if err != nil {
 					innerReply.TransactionError = err.Error()
@@ -881,17 +1010,37 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 			listenActionType = db.ListenDelete
 		case pb.ListenRequest_Filter_SAVE:
 			listenActionType = db.ListenSave
+		case pb.ListenRequest_Filter_CONFLICT:
+			listenActionType = db.ListenConflict
 		default:
 			return status.Errorf(codes.InvalidArgument, "invalid filter action %v", filter.Action)
 		}
+		authors, err := pubKeysFromPb(filter.Authors)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid filter author: %v", err)
+		}
+		excludeAuthors, err := pubKeysFromPb(filter.ExcludeAuthors)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid filter author: %v", err)
+		}
 		options[i] = db.ListenOption{
-			Type:       listenActionType,
-			Collection: filter.CollectionName,
-			ID:         core.InstanceID(filter.InstanceID),
+			Type:           listenActionType,
+			Collection:     filter.CollectionName,
+			ID:             core.InstanceID(filter.InstanceID),
+			Authors:        authors,
+			ExcludeAuthors: excludeAuthors,
 		}
 	}
 
-	l, err := d.Listen(options...)
+	var l db.Listener
+	if req.ResumeToken != "" {
+		l, err = d.ListenResume(db.ResumeToken(req.ResumeToken), options...)
+		if errors.Is(err, db.ErrResumeTokenExpired) {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+	} else {
+		l, err = d.Listen(options...)
+	}
 	if err != nil {
 		return err
 	}
@@ -908,6 +1057,7 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 			}
 			var replyAction pb.ListenReply_Action
 			var instance []byte
+			var conflicts []*pb.ListenReply_Conflict
 			switch action.Type {
 			case db.ActionCreate:
 				replyAction = pb.ListenReply_CREATE
@@ -917,6 +1067,9 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 			case db.ActionSave:
 				replyAction = pb.ListenReply_SAVE
 				instance, err = s.instanceForAction(d, action, token)
+			case db.ActionConflict:
+				replyAction = pb.ListenReply_CONFLICT
+				conflicts = conflictsToPb(action.Conflicts)
 			default:
 				err = status.Errorf(codes.Internal, "unknown action type %v", action.Type)
 			}
@@ -930,6 +1083,11 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 				InstanceID:     action.ID.String(),
 				Action:         replyAction,
 				Instance:       instance,
+				Conflicts:      conflicts,
+				ResumeToken:    string(action.ResumeToken),
+			}
+			if action.Author != nil {
+				reply.Author = action.Author.String()
 			}
 			if err := server.Send(reply); err != nil {
 				return err
@@ -938,6 +1096,31 @@ func (s *Service) Listen(req *pb.ListenRequest, server pb.API_ListenServer) erro
 	}
 }
 
+// pubKeysFromPb decodes identities from their string encodings.
+func pubKeysFromPb(keys []string) ([]thread.PubKey, error) {
+	pks := make([]thread.PubKey, len(keys))
+	for i, k := range keys {
+		pk := &thread.Libp2pPubKey{}
+		if err := pk.UnmarshalString(k); err != nil {
+			return nil, err
+		}
+		pks[i] = pk
+	}
+	return pks, nil
+}
+
+func conflictsToPb(conflicts []core.Conflict) []*pb.ListenReply_Conflict {
+	pbconflicts := make([]*pb.ListenReply_Conflict, len(conflicts))
+	for i, c := range conflicts {
+		pbconflicts[i] = &pb.ListenReply_Conflict{
+			Path:   c.Path,
+			Winner: c.Winner,
+			Loser:  c.Loser,
+		}
+	}
+	return pbconflicts
+}
+
 func (s *Service) instanceForAction(d *db.DB, action db.Action, token thread.Token) ([]byte, error) {
 	log.Debug("getting instance for action")
 	collection := d.GetCollection(action.Collection, db.WithToken(token))
@@ -10,23 +10,33 @@ import (
 
 // EncodeBlock returns a node by encrypting the block's raw bytes with key.
 func EncodeBlock(block blocks.Block, key crypto.EncryptionKey) (format.Node, error) {
-	coded, err := key.Encrypt(block.RawData())
+	return encodeBytes(block.RawData(), key)
+}
+
+// DecodeBlock returns a node by decrypting the block's raw bytes with key.
+func DecodeBlock(block blocks.Block, key crypto.DecryptionKey) (format.Node, error) {
+	decoded, err := decodeBytes(block, key)
 	if err != nil {
 		return nil, err
 	}
-	return cbornode.WrapObject(coded, mh.SHA2_256, -1)
+	return cbornode.Decode(decoded, mh.SHA2_256, -1)
 }
 
-// DecodeBlock returns a node by decrypting the block's raw bytes with key.
-func DecodeBlock(block blocks.Block, key crypto.DecryptionKey) (format.Node, error) {
-	var raw []byte
-	err := cbornode.DecodeInto(block.RawData(), &raw)
+// encodeBytes returns a node holding data encrypted with key.
+func encodeBytes(data []byte, key crypto.EncryptionKey) (format.Node, error) {
+	coded, err := key.Encrypt(data)
 	if err != nil {
 		return nil, err
 	}
-	decoded, err := key.Decrypt(raw)
+	return cbornode.WrapObject(coded, mh.SHA2_256, -1)
+}
+
+// decodeBytes returns the bytes of a node created by encodeBytes, decrypted with key.
+func decodeBytes(block blocks.Block, key crypto.DecryptionKey) ([]byte, error) {
+	var raw []byte
+	err := cbornode.DecodeInto(block.RawData(), &raw)
 	if err != nil {
 		return nil, err
 	}
-	return cbornode.Decode(decoded, mh.SHA2_256, -1)
+	return key.Decrypt(raw)
 }
@@ -0,0 +1,100 @@
+package cbor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+var (
+	// ErrUnsupportedEncoding indicates a record body is compressed with a codec that isn't registered.
+	ErrUnsupportedEncoding = errors.New("unsupported record encoding")
+
+	// MaxRecordBodySize is the hard limit of the encoded size of record bodies, including
+	// bodies which only exceed it once they're decompressed.
+	MaxRecordBodySize = 64 << 20
+
+	// ErrRecordTooLarge indicates a record body is larger than MaxRecordBodySize.
+	ErrRecordTooLarge = errors.New("record body is too large")
+)
+
+// Codec compresses event bodies before they're encrypted.
+type Codec interface {
+	// Name identifies the codec in event headers, so peers can decompress bodies.
+	Name() string
+	// Compress returns the compressed data.
+	Compress(data []byte) ([]byte, error)
+	// Decompress returns the data which was compressed. It must fail with ErrRecordTooLarge
+	// rather than return more than MaxRecordBodySize bytes, since data comes from peers.
+	Decompress(data []byte) ([]byte, error)
+}
+
+var (
+	codecs    = map[string]Codec{}
+	codecLock sync.RWMutex
+)
+
+func init() {
+	RegisterCodec(Gzip)
+}
+
+// RegisterCodec makes bodies compressed with c readable.
+// Codecs are identified by name, so registering another codec with the same name replaces it.
+func RegisterCodec(c Codec) {
+	codecLock.Lock()
+	defer codecLock.Unlock()
+	codecs[c.Name()] = c
+}
+
+// getCodec returns the registered codec named name.
+func getCodec(name string) (Codec, error) {
+	codecLock.RLock()
+	defer codecLock.RUnlock()
+	c, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEncoding, name)
+	}
+	return c, nil
+}
+
+// Gzip is a Codec which compresses with gzip.
+var Gzip Codec = gzipCodec{}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Name() string {
+	return "gzip"
+}
+
+func (gzipCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gzipCodec) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	// Stop reading past the limit, so a small body can't expand to exhaust memory
+	out, err := ioutil.ReadAll(io.LimitReader(r, int64(MaxRecordBodySize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxRecordBodySize {
+		return nil, fmt.Errorf("%w: decompresses to more than %d bytes", ErrRecordTooLarge, MaxRecordBodySize)
+	}
+	return out, nil
+}
@@ -0,0 +1,35 @@
+package cbor
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestGzipDecompressLimit(t *testing.T) {
+	limit := MaxRecordBodySize
+	MaxRecordBodySize = 1 << 10
+	defer func() { MaxRecordBodySize = limit }()
+
+	data := bytes.Repeat([]byte{0}, MaxRecordBodySize)
+	compressed, err := Gzip.Compress(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := Gzip.Decompress(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decompressed, data) {
+		t.Fatal("expected decompressed data to equal the input")
+	}
+
+	// Bodies expanding past the limit are rejected
+	compressed, err = Gzip.Compress(append(data, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Gzip.Decompress(compressed); !errors.Is(err, ErrRecordTooLarge) {
+		t.Fatalf("expected error %v, got %v", ErrRecordTooLarge, err)
+	}
+}
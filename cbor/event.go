@@ -16,6 +16,7 @@ import (
 func init() {
 	cbornode.RegisterCborType(event{})
 	cbornode.RegisterCborType(eventHeader{})
+	cbornode.RegisterCborType(bodyChunks{})
 }
 
 // event defines the node structure of an event.
@@ -25,26 +26,90 @@ type event struct {
 }
 
 // eventHeader defines the node structure of an event header.
+// Encoding is the name of the codec the body is compressed with, if any. It's omitted
+// for uncompressed bodies, so their headers are readable by peers that don't know it.
+// Chunked is set if the body node links to the chunks of the encrypted body, instead of
+// holding it. It's omitted for single-block bodies for the same reason.
 type eventHeader struct {
-	Key []byte `refmt:",omitempty"`
+	Key      []byte `refmt:",omitempty"`
+	Encoding string `refmt:",omitempty"`
+	Chunked  bool   `refmt:",omitempty"`
+}
+
+// bodyChunks defines the node structure of a chunked body, which links to the blocks
+// holding consecutive parts of the encrypted body.
+type bodyChunks struct {
+	Chunks []cid.Cid
 }
 
 // CreateEvent create a new event by wrapping the body node.
 func CreateEvent(ctx context.Context, dag format.DAGService, body format.Node, rkey crypto.EncryptionKey) (net.Event, error) {
+	return CreateCompressedEvent(ctx, dag, body, rkey, nil)
+}
+
+// CreateCompressedEvent creates a new event by wrapping the body node, which is compressed
+// with codec before it's encrypted. If codec is nil, the body isn't compressed.
+// Peers must have codec registered with RegisterCodec to read the body.
+func CreateCompressedEvent(
+	ctx context.Context,
+	dag format.DAGService,
+	body format.Node,
+	rkey crypto.EncryptionKey,
+	codec Codec,
+) (net.Event, error) {
+	return CreateChunkedEvent(ctx, dag, body, rkey, codec, 0)
+}
+
+// CreateChunkedEvent creates a new event as CreateCompressedEvent, splitting the encrypted
+// body into blocks of at most chunkSize bytes if it's larger. The body node then links to
+// the chunks, which are fetched from dag and reassembled by GetBody. Bodies of at most
+// chunkSize bytes, or any size if chunkSize isn't positive, are kept in a single block.
+func CreateChunkedEvent(
+	ctx context.Context,
+	dag format.DAGService,
+	body format.Node,
+	rkey crypto.EncryptionKey,
+	codec Codec,
+	chunkSize int,
+) (net.Event, error) {
 	key, err := sym.NewRandom()
 	if err != nil {
 		return nil, err
 	}
-	codedBody, err := EncodeBlock(body, key)
+	data := body.RawData()
+	var encoding string
+	if codec != nil {
+		if data, err = codec.Compress(data); err != nil {
+			return nil, fmt.Errorf("compressing body: %w", err)
+		}
+		encoding = codec.Name()
+	}
+	coded, err := key.Encrypt(data)
 	if err != nil {
 		return nil, err
 	}
+	if len(coded) > MaxRecordBodySize {
+		return nil, fmt.Errorf("%w: %d bytes encoded, limit is %d", ErrRecordTooLarge, len(coded), MaxRecordBodySize)
+	}
+	var (
+		codedBody format.Node
+		chunks    []format.Node
+	)
+	if chunkSize > 0 && len(coded) > chunkSize {
+		if codedBody, chunks, err = chunkBytes(coded, chunkSize); err != nil {
+			return nil, err
+		}
+	} else if codedBody, err = cbornode.WrapObject(coded, mh.SHA2_256, -1); err != nil {
+		return nil, err
+	}
 	keyb, err := key.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
 	eventHeader := &eventHeader{
-		Key: keyb,
+		Key:      keyb,
+		Encoding: encoding,
+		Chunked:  chunks != nil,
 	}
 	header, err := cbornode.WrapObject(eventHeader, mh.SHA2_256, -1)
 	if err != nil {
@@ -64,7 +129,7 @@ func CreateEvent(ctx context.Context, dag format.DAGService, body format.Node, r
 	}
 
 	if dag != nil {
-		if err = dag.AddMany(ctx, []format.Node{node, codedHeader, codedBody}); err != nil {
+		if err = dag.AddMany(ctx, append([]format.Node{node, codedHeader, codedBody}, chunks...)); err != nil {
 			return nil, err
 		}
 	}
@@ -76,10 +141,102 @@ func CreateEvent(ctx context.Context, dag format.DAGService, body format.Node, r
 			Node: codedHeader,
 			obj:  eventHeader,
 		},
-		body: codedBody,
+		body:   codedBody,
+		chunks: chunks,
 	}, nil
 }
 
+// chunkBytes splits data into chunk nodes of at most size bytes, and returns them with a
+// node linking to them in order.
+func chunkBytes(data []byte, size int) (format.Node, []format.Node, error) {
+	var (
+		chunks = make([]format.Node, 0, (len(data)+size-1)/size)
+		obj    = &bodyChunks{}
+	)
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		chunk, err := cbornode.WrapObject(data[:n], mh.SHA2_256, -1)
+		if err != nil {
+			return nil, nil, err
+		}
+		chunks = append(chunks, chunk)
+		obj.Chunks = append(obj.Chunks, chunk.Cid())
+		data = data[n:]
+	}
+	node, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return node, chunks, nil
+}
+
+// joinChunks returns the bytes of chunks, in order.
+func joinChunks(chunks []format.Node) ([]byte, error) {
+	var data []byte
+	for _, chunk := range chunks {
+		var part []byte
+		if err := cbornode.DecodeInto(chunk.RawData(), &part); err != nil {
+			return nil, fmt.Errorf("decoding body chunk %s: %w", chunk.Cid(), err)
+		}
+		data = append(data, part...)
+	}
+	return data, nil
+}
+
+// chunkLinks returns the ids of the chunks linked from a chunked body node, or nil if the
+// body is held in a single block. Single-block bodies are byte strings, which don't decode
+// as chunk links.
+func chunkLinks(body format.Node) []cid.Cid {
+	obj := new(bodyChunks)
+	if err := cbornode.DecodeInto(body.RawData(), obj); err != nil {
+		return nil
+	}
+	return obj.Chunks
+}
+
+// codedSize returns the length of the byte string encoded in raw, without decoding it, or
+// the length of raw if it doesn't hold a byte string.
+func codedSize(raw []byte) int {
+	if len(raw) == 0 || raw[0]>>5 != 2 {
+		return len(raw)
+	}
+	n := int(raw[0] & 0x1f)
+	if n < 24 {
+		return n
+	}
+	size := 1 << (n - 24)
+	if n > 27 || len(raw) < 1+size {
+		return len(raw)
+	}
+	var l uint64
+	for _, b := range raw[1 : 1+size] {
+		l = l<<8 | uint64(b)
+	}
+	if l > uint64(len(raw)) {
+		return len(raw)
+	}
+	return int(l)
+}
+
+// checkBodySize returns ErrRecordTooLarge if the encrypted body held by body, or by chunks if
+// it's chunked, is larger than MaxRecordBodySize.
+func checkBodySize(body format.Node, chunks []format.Node) error {
+	size := 0
+	if chunks == nil {
+		size = codedSize(body.RawData())
+	}
+	for _, chunk := range chunks {
+		size += codedSize(chunk.RawData())
+	}
+	if size > MaxRecordBodySize {
+		return fmt.Errorf("%w: %d bytes encoded, limit is %d", ErrRecordTooLarge, size, MaxRecordBodySize)
+	}
+	return nil
+}
+
 // GetEvent returns the event node for the given cid.
 func GetEvent(ctx context.Context, dag format.DAGService, id cid.Cid) (net.Event, error) {
 	node, err := dag.Get(ctx, id)
@@ -115,9 +272,17 @@ func EventFromRecord(ctx context.Context, dag format.DAGService, rec net.Record)
 	return event, nil
 }
 
-// RemoveEvent removes an event from the dag service.
+// RemoveEvent removes an event from the dag service, including the chunks of its body.
 func RemoveEvent(ctx context.Context, dag format.DAGService, e *Event) error {
-	return dag.RemoveMany(ctx, []cid.Cid{e.Cid(), e.HeaderID(), e.BodyID()})
+	ids := []cid.Cid{e.Cid(), e.HeaderID(), e.BodyID()}
+	body := e.body
+	if body == nil {
+		body, _ = dag.Get(ctx, e.BodyID())
+	}
+	if body != nil {
+		ids = append(ids, chunkLinks(body)...)
+	}
+	return dag.RemoveMany(ctx, ids)
 }
 
 // Event is a IPLD node representing an event.
@@ -127,6 +292,7 @@ type Event struct {
 	obj    *event
 	header *EventHeader
 	body   format.Node
+	chunks []format.Node
 }
 
 func (e *Event) HeaderID() cid.Cid {
@@ -167,7 +333,11 @@ func (e *Event) BodyID() cid.Cid {
 }
 
 func (e *Event) GetBody(ctx context.Context, dag format.DAGService, key crypto.DecryptionKey) (format.Node, error) {
-	var k crypto.DecryptionKey
+	var (
+		k        crypto.DecryptionKey
+		encoding string
+		chunked  bool
+	)
 	if key != nil {
 		header, err := e.GetHeader(ctx, dag, key)
 		if err != nil {
@@ -177,6 +347,8 @@ func (e *Event) GetBody(ctx context.Context, dag format.DAGService, key crypto.D
 		if err != nil {
 			return nil, err
 		}
+		encoding = e.header.obj.Encoding
+		chunked = e.header.obj.Chunked
 	}
 
 	var err error
@@ -185,13 +357,92 @@ func (e *Event) GetBody(ctx context.Context, dag format.DAGService, key crypto.D
 		if err != nil {
 			return nil, err
 		}
+		if err = checkBodySize(e.body, nil); err != nil {
+			return nil, err
+		}
 	}
 
 	if k == nil {
 		return e.body, nil
-	} else {
+	} else if encoding == "" && !chunked {
 		return DecodeBlock(e.body, k)
 	}
+	var data []byte
+	if chunked {
+		chunks, err := e.GetChunks(ctx, dag)
+		if err != nil {
+			return nil, err
+		}
+		coded, err := joinChunks(chunks)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = k.Decrypt(coded); err != nil {
+			return nil, err
+		}
+	} else if data, err = decodeBytes(e.body, k); err != nil {
+		return nil, err
+	}
+	if encoding != "" {
+		codec, err := getCodec(encoding)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = codec.Decompress(data); err != nil {
+			return nil, fmt.Errorf("decompressing body: %w", err)
+		}
+		if len(data) > MaxRecordBodySize {
+			return nil, fmt.Errorf("%w: %d bytes decompressed, limit is %d", ErrRecordTooLarge, len(data), MaxRecordBodySize)
+		}
+	}
+	return cbornode.Decode(data, mh.SHA2_256, -1)
+}
+
+// ChunkIDs returns the ids of the chunks of a chunked body, in order, or nil if the body is
+// held in a single block, without fetching the chunks.
+func (e *Event) ChunkIDs(ctx context.Context, dag format.DAGService) ([]cid.Cid, error) {
+	if e.body == nil {
+		if dag == nil {
+			return nil, fmt.Errorf("body not loaded")
+		}
+		body, err := dag.Get(ctx, e.obj.Body)
+		if err != nil {
+			return nil, err
+		}
+		e.body = body
+	}
+	return chunkLinks(e.body), nil
+}
+
+// GetChunks returns the chunks of a chunked body, in order, or nil if the body is held in a
+// single block. Chunks received with the event are returned as is, others are fetched from dag.
+// Since the header is encrypted, chunked bodies are told apart by whether they decode as links.
+func (e *Event) GetChunks(ctx context.Context, dag format.DAGService) ([]format.Node, error) {
+	if e.chunks != nil {
+		return e.chunks, nil
+	}
+	links, err := e.ChunkIDs(ctx, dag)
+	if err != nil || len(links) == 0 {
+		return nil, err
+	}
+	if dag == nil {
+		return nil, fmt.Errorf("body chunks not loaded")
+	}
+	chunks := make([]format.Node, len(links))
+	var size int
+	for i, c := range links {
+		chunk, err := dag.Get(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("getting body chunk %s: %w", c, err)
+		}
+		// Stop fetching as soon as the body is too large
+		if size += codedSize(chunk.RawData()); size > MaxRecordBodySize {
+			return nil, fmt.Errorf("%w: more than %d bytes encoded", ErrRecordTooLarge, MaxRecordBodySize)
+		}
+		chunks[i] = chunk
+	}
+	e.chunks = chunks
+	return chunks, nil
 }
 
 // EventHeader is an IPLD node representing an event header.
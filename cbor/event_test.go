@@ -0,0 +1,120 @@
+package cbor
+
+import (
+	"context"
+	"testing"
+
+	bserv "github.com/ipfs/go-blockservice"
+	ds "github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	dag "github.com/ipfs/go-merkledag"
+	mh "github.com/multiformats/go-multihash"
+	sym "github.com/textileio/crypto/symmetric"
+)
+
+type testBody struct {
+	Name  string
+	Count int
+	Tags  []string
+}
+
+func TestCanonicalEncoding(t *testing.T) {
+	cbornode.RegisterCborType(testBody{})
+
+	first := map[string]interface{}{}
+	first["Name"] = "foo"
+	first["Count"] = 1
+	first["Tags"] = []string{"a", "b"}
+	second := map[string]interface{}{
+		"Tags":  []string{"a", "b"},
+		"Count": 1,
+		"Name":  "foo",
+	}
+	n1, err := cbornode.WrapObject(first, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n2, err := cbornode.WrapObject(second, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n3, err := cbornode.WrapObject(&testBody{Name: "foo", Count: 1, Tags: []string{"a", "b"}}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n1.Cid().Equals(n2.Cid()) || !n1.Cid().Equals(n3.Cid()) {
+		t.Fatalf("expected identical content to have the same cid, got %s, %s and %s", n1.Cid(), n2.Cid(), n3.Cid())
+	}
+
+	// Decoding and re-encoding a node yields the same cid
+	var obj interface{}
+	if err := cbornode.DecodeInto(n1.RawData(), &obj); err != nil {
+		t.Fatal(err)
+	}
+	n4, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n1.Cid().Equals(n4.Cid()) {
+		t.Fatalf("expected re-encoded node to have cid %s, got %s", n1.Cid(), n4.Cid())
+	}
+
+	// Bodies of separately created events decode to the same node
+	ctx := context.Background()
+	bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
+	dagService := dag.NewDAGService(bserv.New(bs, offline.Exchange(bs)))
+	rkey, err := sym.NewRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, body := range []*cbornode.Node{n1, n2, n3} {
+		e, err := CreateEvent(ctx, dagService, body, rkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Decoding and re-encoding the event and its header yields the same cids
+		checkReencoding(t, e, new(event))
+		header, err := e.GetHeader(ctx, dagService, rkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkReencoding(t, header, new([]byte))
+		plainHeader, err := DecodeBlock(header, rkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkReencoding(t, plainHeader, new(eventHeader))
+
+		event, err := GetEvent(ctx, dagService, e.Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := event.GetBody(ctx, dagService, rkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Cid().Equals(n1.Cid()) {
+			t.Fatalf("expected decoded body to have cid %s, got %s", n1.Cid(), decoded.Cid())
+		}
+	}
+}
+
+// checkReencoding decodes node into obj, and checks that encoding obj yields the same cid.
+func checkReencoding(t *testing.T, node format.Node, obj interface{}) {
+	t.Helper()
+	if err := cbornode.DecodeInto(node.RawData(), obj); err != nil {
+		t.Fatal(err)
+	}
+	n, err := cbornode.WrapObject(obj, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !n.Cid().Equals(node.Cid()) {
+		t.Fatalf("expected re-encoded node to have cid %s, got %s", node.Cid(), n.Cid())
+	}
+}
@@ -0,0 +1,48 @@
+package cbor
+
+import (
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+)
+
+func init() {
+	cbornode.RegisterCborType(logMerge{})
+}
+
+// logMerge defines the node structure of the body of a record announcing that logs of its
+// author were merged into the log of the record, which is followed by the merged records.
+type logMerge struct {
+	MergedLogs    [][]byte
+	MergedRecords []cid.Cid
+}
+
+// NewLogMerge returns an event body announcing that logs were merged into the log of the record
+// holding it, and that the next records of that log re-anchor records, in order.
+func NewLogMerge(logs []peer.ID, records []cid.Cid) (format.Node, error) {
+	obj := &logMerge{MergedLogs: make([][]byte, len(logs)), MergedRecords: records}
+	for i, lid := range logs {
+		obj.MergedLogs[i] = []byte(lid)
+	}
+	return cbornode.WrapObject(obj, mh.SHA2_256, -1)
+}
+
+// LogMergeFromNode returns the merged logs and records announced by a decrypted event body,
+// or false if the body isn't a log merge.
+func LogMergeFromNode(body format.Node) ([]peer.ID, []cid.Cid, bool) {
+	merge := new(logMerge)
+	if err := cbornode.DecodeInto(body.RawData(), merge); err != nil || len(merge.MergedLogs) == 0 {
+		return nil, nil, false
+	}
+	logs := make([]peer.ID, len(merge.MergedLogs))
+	for i, b := range merge.MergedLogs {
+		lid, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, nil, false
+		}
+		logs[i] = lid
+	}
+	return logs, merge.MergedRecords, true
+}
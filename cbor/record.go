@@ -1,4 +1,9 @@
 // Package cbor provides utilities to create or convert data to and from CBOR format (https://cbor.io/).
+//
+// Nodes are encoded canonically, with map keys and struct fields sorted as in RFC 7049, so
+// identical content has the same CID regardless of how it was built. Events and records are
+// encrypted with a random event key and nonce though, so only the CIDs of the plaintext
+// bodies and headers are reproducible, not those of the events and records wrapping them.
 package cbor
 
 import (
@@ -31,9 +36,11 @@ type record struct {
 
 // CreateRecordConfig wraps all the elements needed for creating a new record.
 type CreateRecordConfig struct {
-	Block      format.Node
-	Prev       cid.Cid
-	Key        ic.PrivKey
+	Block format.Node
+	Prev  cid.Cid
+	Key   ic.PrivKey
+	// Signer signs the record instead of Key, if set.
+	Signer     thread.Signer
 	PubKey     thread.PubKey
 	ServiceKey crypto.EncryptionKey
 }
@@ -50,7 +57,12 @@ func CreateRecord(ctx context.Context, dag format.DAGService, config CreateRecor
 	} else {
 		payload = pkb
 	}
-	sig, err := config.Key.Sign(payload)
+	var sig []byte
+	if config.Signer != nil {
+		sig, err = config.Signer.Sign(ctx, payload)
+	} else {
+		sig, err = config.Key.Sign(payload)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +125,7 @@ func RemoveRecord(ctx context.Context, dag format.DAGService, rec net.Record) er
 }
 
 // RecordToProto returns a proto version of a record for transport.
-// Nodes are sent encrypted.
+// Nodes are sent encrypted, including the chunks of chunked bodies.
 func RecordToProto(ctx context.Context, dag format.DAGService, rec net.Record) (*pb.Log_Record, error) {
 	block, err := rec.GetBlock(ctx, dag)
 	if err != nil {
@@ -134,12 +146,21 @@ func RecordToProto(ctx context.Context, dag format.DAGService, rec net.Record) (
 	if err != nil {
 		return nil, err
 	}
+	chunks, err := event.GetChunks(ctx, dag)
+	if err != nil {
+		return nil, err
+	}
+	var chunkNodes [][]byte
+	for _, chunk := range chunks {
+		chunkNodes = append(chunkNodes, chunk.RawData())
+	}
 
 	return &pb.Log_Record{
 		RecordNode: rec.RawData(),
 		EventNode:  block.RawData(),
 		HeaderNode: header.RawData(),
 		BodyNode:   body.RawData(),
+		ChunkNodes: chunkNodes,
 	}, nil
 }
 
@@ -165,6 +186,27 @@ func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (net.Record,
 	if err != nil {
 		return nil, err
 	}
+	// Records from peers without chunking support come without chunks, which are then
+	// fetched when the body is read
+	var chunks []format.Node
+	if len(rec.ChunkNodes) > 0 {
+		links := chunkLinks(body)
+		if len(links) != len(rec.ChunkNodes) {
+			return nil, fmt.Errorf("body links to %d chunks, got %d", len(links), len(rec.ChunkNodes))
+		}
+		chunks = make([]format.Node, len(rec.ChunkNodes))
+		for i, raw := range rec.ChunkNodes {
+			if chunks[i], err = cbornode.Decode(raw, mh.SHA2_256, -1); err != nil {
+				return nil, err
+			}
+			if !chunks[i].Cid().Equals(links[i]) {
+				return nil, fmt.Errorf("body chunk %d is %s, expected %s", i, chunks[i].Cid(), links[i])
+			}
+		}
+	}
+	if err = checkBodySize(body, chunks); err != nil {
+		return nil, err
+	}
 
 	decoded, err := DecodeBlock(rnode, key)
 	if err != nil {
@@ -185,7 +227,8 @@ func RecordFromProto(rec *pb.Log_Record, key crypto.DecryptionKey) (net.Record,
 		header: &EventHeader{
 			Node: hnode,
 		},
-		body: body,
+		body:   body,
+		chunks: chunks,
 	}
 	return &Record{
 		Node:  rnode,
@@ -0,0 +1,54 @@
+package cbor
+
+import (
+	"context"
+	"fmt"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/ipfs/go-ipld-format"
+	mh "github.com/multiformats/go-multihash"
+	sym "github.com/textileio/crypto/symmetric"
+)
+
+func init() {
+	cbornode.RegisterCborType(keyRotation{})
+}
+
+// keyRotation defines the node structure of the body of a record announcing a new
+// read key of its thread.
+type keyRotation struct {
+	RotatedReadKey []byte
+}
+
+// NewKeyRotation returns an event body announcing rk as the new read key of a thread.
+// Like any event body, it is encrypted under the read key in use when the record is created.
+func NewKeyRotation(rk *sym.Key) (format.Node, error) {
+	return cbornode.WrapObject(&keyRotation{RotatedReadKey: rk.Bytes()}, mh.SHA2_256, -1)
+}
+
+// KeyRotationFromNode returns the read key announced by a decrypted event body,
+// or false if the body isn't a key rotation.
+func KeyRotationFromNode(body format.Node) (*sym.Key, bool) {
+	rot := new(keyRotation)
+	if err := cbornode.DecodeInto(body.RawData(), rot); err != nil || len(rot.RotatedReadKey) == 0 {
+		return nil, false
+	}
+	rk, err := sym.FromBytes(rot.RotatedReadKey)
+	if err != nil {
+		return nil, false
+	}
+	return rk, true
+}
+
+// GetBodyWithKeys returns the body of e decrypted with the first of keys which decrypts its
+// header, and that key. Keys are tried in order, so the most recent read key should come first.
+func (e *Event) GetBodyWithKeys(ctx context.Context, dag format.DAGService, keys []*sym.Key) (format.Node, *sym.Key, error) {
+	err := fmt.Errorf("no read key")
+	for _, k := range keys {
+		var body format.Node
+		if body, err = e.GetBody(ctx, dag, k); err == nil {
+			return body, k, nil
+		}
+	}
+	return nil, nil, err
+}
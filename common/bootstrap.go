@@ -0,0 +1,112 @@
+package common
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// BootstrapInterval is the interval at which a net reconnects to its bootstrap peers,
+// once Bootstrap has been called.
+var BootstrapInterval = time.Minute * 5
+
+func (tsb *netBoostrapper) Bootstrap(addrs []peer.AddrInfo) {
+	tsb.SetBootstrapPeers(addrs)
+	tsb.bootstrap()
+	tsb.bootOnce.Do(func() {
+		go tsb.bootstrapLoop()
+	})
+}
+
+func (tsb *netBoostrapper) SetBootstrapPeers(addrs []peer.AddrInfo) {
+	tsb.bootLock.Lock()
+	defer tsb.bootLock.Unlock()
+	tsb.bootPeers = tsb.bootPeers[:0]
+	for _, ai := range addrs {
+		tsb.addBootstrapPeer(ai)
+	}
+}
+
+func (tsb *netBoostrapper) AddBootstrapPeer(addr peer.AddrInfo) {
+	tsb.bootLock.Lock()
+	defer tsb.bootLock.Unlock()
+	tsb.addBootstrapPeer(addr)
+}
+
+// addBootstrapPeer adds addr to the bootstrap peers, merging the addresses of peers
+// which are listed more than once. It must be called with bootLock held.
+func (tsb *netBoostrapper) addBootstrapPeer(addr peer.AddrInfo) {
+	for i, ai := range tsb.bootPeers {
+		if ai.ID != addr.ID {
+			continue
+		}
+		addrs := append([]ma.Multiaddr{}, ai.Addrs...)
+		for _, a := range addr.Addrs {
+			if !containsAddr(addrs, a) {
+				addrs = append(addrs, a)
+			}
+		}
+		tsb.bootPeers[i].Addrs = addrs
+		return
+	}
+	tsb.bootPeers = append(tsb.bootPeers, peer.AddrInfo{
+		ID:    addr.ID,
+		Addrs: append([]ma.Multiaddr{}, addr.Addrs...),
+	})
+}
+
+func (tsb *netBoostrapper) RemoveBootstrapPeer(id peer.ID) {
+	tsb.bootLock.Lock()
+	defer tsb.bootLock.Unlock()
+	for i, ai := range tsb.bootPeers {
+		if ai.ID == id {
+			tsb.bootPeers = append(tsb.bootPeers[:i], tsb.bootPeers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (tsb *netBoostrapper) BootstrapPeers() []peer.AddrInfo {
+	tsb.bootLock.Lock()
+	defer tsb.bootLock.Unlock()
+	peers := make([]peer.AddrInfo, len(tsb.bootPeers))
+	copy(peers, tsb.bootPeers)
+	return peers
+}
+
+// bootstrap connects to the bootstrap peers which aren't connected, and bootstraps the DHT.
+func (tsb *netBoostrapper) bootstrap() {
+	var peers []peer.AddrInfo
+	for _, ai := range tsb.BootstrapPeers() {
+		if tsb.Host().Network().Connectedness(ai.ID) != network.Connected {
+			peers = append(peers, ai)
+		}
+	}
+	tsb.litepeer.Bootstrap(peers)
+}
+
+// bootstrapLoop bootstraps every BootstrapInterval until the net is closed,
+// so changes of the bootstrap peers take effect without a restart.
+func (tsb *netBoostrapper) bootstrapLoop() {
+	ticker := time.NewTicker(BootstrapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			tsb.bootstrap()
+		case <-tsb.ctx.Done():
+			return
+		}
+	}
+}
+
+func containsAddr(addrs []ma.Multiaddr, addr ma.Multiaddr) bool {
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
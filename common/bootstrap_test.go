@@ -0,0 +1,54 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/test"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+func TestBootstrapPeers(t *testing.T) {
+	var peers []peer.AddrInfo
+	for i := 0; i < 3; i++ {
+		p, err := test.RandPeerID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		peers = append(peers, peer.AddrInfo{
+			ID:    p,
+			Addrs: []ma.Multiaddr{ma.StringCast("/ip4/127.0.0.1/tcp/4006")},
+		})
+	}
+	tsb := &netBoostrapper{}
+
+	tsb.SetBootstrapPeers(peers[:2])
+	if got := tsb.BootstrapPeers(); len(got) != 2 || got[0].ID != peers[0].ID || got[1].ID != peers[1].ID {
+		t.Fatalf("expected first two peers, got %v", got)
+	}
+
+	tsb.AddBootstrapPeer(peers[2])
+	other := peer.AddrInfo{
+		ID:    peers[0].ID,
+		Addrs: []ma.Multiaddr{ma.StringCast("/ip4/127.0.0.1/tcp/4007"), peers[0].Addrs[0]},
+	}
+	tsb.AddBootstrapPeer(other)
+	got := tsb.BootstrapPeers()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 peers, got %d", len(got))
+	}
+	if len(got[0].Addrs) != 2 {
+		t.Fatalf("expected addresses of an existing peer to be merged, got %v", got[0].Addrs)
+	}
+
+	tsb.RemoveBootstrapPeer(peers[1].ID)
+	got = tsb.BootstrapPeers()
+	if len(got) != 2 || got[0].ID != peers[0].ID || got[1].ID != peers[2].ID {
+		t.Fatalf("expected second peer to be removed, got %v", got)
+	}
+
+	tsb.SetBootstrapPeers(nil)
+	if got := tsb.BootstrapPeers(); len(got) != 0 {
+		t.Fatalf("expected no peers, got %v", got)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	ipfslite "github.com/hsanjuan/ipfs-lite"
@@ -17,24 +18,48 @@ import (
 	cconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/routing"
 	"github.com/libp2p/go-libp2p-peerstore/pstoreds"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 	badger "github.com/textileio/go-ds-badger"
 	mongods "github.com/textileio/go-ds-mongo"
 	"github.com/textileio/go-libp2p-pubsub-rpc/finalizer"
+	"github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/logstore"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	"github.com/textileio/go-threads/logstore/lstorehybrid"
 	"github.com/textileio/go-threads/logstore/lstoremem"
 	"github.com/textileio/go-threads/net"
+	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
 )
 
+const (
+	// LowMemoryConnLowWater is the low watermark of libp2p connections of the low memory profile.
+	LowMemoryConnLowWater = 20
+	// LowMemoryConnHighWater is the high watermark of libp2p connections of the low memory profile.
+	LowMemoryConnHighWater = 50
+	// LowMemoryNetPullingLimit is the maximum number of records pulled from a peer at once
+	// with the low memory profile.
+	LowMemoryNetPullingLimit = 1000
+)
+
 type NetBoostrapper interface {
 	app.Net
 	GetIpfsLite() *ipfslite.Peer
+	// Bootstrap replaces the bootstrap peers with addrs, and connects to them and bootstraps the
+	// DHT. Bootstrap peers are then reconnected to every BootstrapInterval.
 	Bootstrap(addrs []peer.AddrInfo)
+	// SetBootstrapPeers replaces the bootstrap peers, which are used from the next bootstrap cycle.
+	SetBootstrapPeers(addrs []peer.AddrInfo)
+	// AddBootstrapPeer adds a bootstrap peer, or addresses of an existing one.
+	AddBootstrapPeer(addr peer.AddrInfo)
+	// RemoveBootstrapPeer removes a bootstrap peer. Connections to it aren't closed.
+	RemoveBootstrapPeer(id peer.ID)
+	// BootstrapPeers returns the bootstrap peers.
+	BootstrapPeers() []peer.AddrInfo
 }
 
 // DefaultNetwork is a boostrapable default Net with sane defaults.
@@ -78,11 +103,15 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		libp2p.ConnectionManager(config.ConnManager),
 		libp2p.DisableRelay(),
 	}
+	if config.ConnectionGater != nil {
+		libp2pOptions = append(libp2pOptions, libp2p.ConnectionGater(config.ConnectionGater))
+	}
 	if config.AnnounceAddr != nil {
 		libp2pOptions = append(libp2pOptions, libp2p.AddrsFactory(func([]ma.Multiaddr) []ma.Multiaddr {
 			return []ma.Multiaddr{config.AnnounceAddr}
 		}))
 	}
+	libp2pOptions = append(libp2pOptions, config.Libp2pOptions...)
 
 	h, d, err := ipfslite.SetupLibp2p(
 		ctx,
@@ -96,6 +125,17 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		return nil, fin.Cleanup(err)
 	}
 
+	if config.Metrics != nil {
+		if err := registerConnMetrics(config.Metrics, h, config.ConnManager); err != nil {
+			return nil, fin.Cleanup(err)
+		}
+	}
+
+	var discovery routing.ContentRouting
+	if config.ThreadDiscovery {
+		discovery = d
+	}
+
 	lite, err := ipfslite.New(ctx, litestore, h, d, nil)
 	if err != nil {
 		return nil, fin.Cleanup(err)
@@ -115,6 +155,15 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 		NoNetPulling:              config.NoNetPulling,
 		NoExchangeEdgesMigration:  config.NoExchangeEdgesMigration,
 		PubSub:                    config.PubSub,
+		PubSubPeerRateLimit:       config.PubSubPeerRateLimit,
+		PubSubThreadRateLimit:     config.PubSubThreadRateLimit,
+		DialRetryMax:              config.NetDialRetryMax,
+		DialRetryBase:             config.NetDialRetryBase,
+		Metrics:                   config.Metrics,
+		RecordErrorHandler:        config.RecordErrorHandler,
+		RecordCodec:               config.RecordCompression,
+		MaxRecordSize:             config.MaxRecordSize,
+		Discovery:                 discovery,
 		Debug:                     config.Debug,
 	}, config.GRPCServerOptions, config.GRPCDialOptions)
 	if err != nil {
@@ -124,6 +173,7 @@ func DefaultNetwork(opts ...NetOption) (NetBoostrapper, error) {
 
 	return &netBoostrapper{
 		Net:       api,
+		ctx:       ctx,
 		litepeer:  lite,
 		finalizer: fin,
 	}, nil
@@ -162,16 +212,20 @@ func persistentStore(ctx context.Context, config NetConfig, name string, fin *fi
 	if len(config.MongoUri) != 0 {
 		return mongoStore(ctx, config.MongoUri, config.MongoDB, name, fin)
 	} else {
-		return badgerStore(filepath.Join(config.BadgerRepoPath, name), fin)
+		return badgerStore(filepath.Join(config.BadgerRepoPath, name), config.BadgerLowMem, fin)
 	}
 }
 
-func badgerStore(repoPath string, fin *finalizer.Finalizer) (ds.Batching, error) {
+func badgerStore(repoPath string, lowMem bool, fin *finalizer.Finalizer) (ds.Batching, error) {
 	if err := os.MkdirAll(repoPath, os.ModePerm); err != nil {
 		return nil, err
 	}
 
-	dstore, err := badger.NewDatastore(repoPath, &badger.DefaultOptions)
+	opts := badger.DefaultOptions
+	if lowMem {
+		opts = util.LowMemBadgerOptions()
+	}
+	dstore, err := util.OpenBadgerDatastore(repoPath, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -296,15 +350,27 @@ type NetConfig struct {
 	NoNetPulling              bool
 	NoExchangeEdgesMigration  bool
 	PubSub                    bool
+	PubSubPeerRateLimit       int
+	PubSubThreadRateLimit     int
+	NetDialRetryMax           int
+	NetDialRetryBase          time.Duration
 	LSType                    LogstoreType
 	BadgerRepoPath            string
+	BadgerLowMem              bool
 	MongoUri                  string
 	MongoDB                   string
 	HostAddr                  ma.Multiaddr
 	AnnounceAddr              ma.Multiaddr
 	ConnManager               cconnmgr.ConnManager
+	ConnectionGater           cconnmgr.ConnectionGater
+	Libp2pOptions             []libp2p.Option
 	GRPCServerOptions         []grpc.ServerOption
 	GRPCDialOptions           []grpc.DialOption
+	Metrics                   prometheus.Registerer
+	RecordErrorHandler        net.RecordErrorHandler
+	RecordCompression         cbor.Codec
+	MaxRecordSize             int
+	ThreadDiscovery           bool
 	Debug                     bool
 }
 
@@ -320,6 +386,100 @@ func WithNetPulling(threadLimit uint, startAfter, initialInterval, interval time
 	}
 }
 
+// WithNetPullInterval sets the default interval at which threads are periodically pulled.
+// Pull cycles are randomly shortened or lengthened by net.PullJitter. Intervals of single
+// threads can be overridden with SetPullInterval.
+func WithNetPullInterval(d time.Duration) NetOption {
+	return func(c *NetConfig) error {
+		c.NetPullingInterval = d
+		return nil
+	}
+}
+
+// WithMetrics registers metrics of records, pulls and connections with reg.
+// Nothing is measured if reg is nil.
+func WithMetrics(reg prometheus.Registerer) NetOption {
+	return func(c *NetConfig) error {
+		c.Metrics = reg
+		return nil
+	}
+}
+
+// WithNetDialRetry retries transient dial and stream failures of AddReplicator and pulls
+// up to max times, waiting base before the first retry and twice as long before each
+// following one. Permanent failures, e.g., a request rejected by the peer, aren't retried.
+func WithNetDialRetry(max int, base time.Duration) NetOption {
+	return func(c *NetConfig) error {
+		c.NetDialRetryMax = max
+		c.NetDialRetryBase = base
+		return nil
+	}
+}
+
+// WithNetRecordErrorHandler calls h with records received from peers which failed to be
+// applied, e.g., to keep them for inspection. h is called from a separate goroutine, so it
+// doesn't hold up other records. Errors are dropped while net.RecordErrorQueueSize of them
+// wait for h.
+func WithNetRecordErrorHandler(h net.RecordErrorHandler) NetOption {
+	return func(c *NetConfig) error {
+		c.RecordErrorHandler = h
+		return nil
+	}
+}
+
+// WithNetRecordCompression compresses the bodies of records created by the net with codec,
+// e.g., cbor.Gzip. Records are uncompressed by default. Codecs are identified by name, so
+// peers must register codec with cbor.RegisterCodec to read the records, and peers running
+// versions without compression can't read them.
+func WithNetRecordCompression(codec cbor.Codec) NetOption {
+	return func(c *NetConfig) error {
+		c.RecordCompression = codec
+		return nil
+	}
+}
+
+// WithNetMaxRecordSize splits the encrypted bodies of records created by the net into
+// blocks of at most n bytes if they're larger, which are reassembled when the records are
+// read. Smaller bodies are kept in a single block, so they're readable by peers running
+// versions without chunking. Bodies are never chunked if n is zero, which is the default.
+// Bodies above cbor.MaxRecordBodySize are rejected in any case.
+func WithNetMaxRecordSize(n int) NetOption {
+	return func(c *NetConfig) error {
+		c.MaxRecordSize = n
+		return nil
+	}
+}
+
+// WithNetThreadDiscovery advertises threads in the DHT when they're created or added, and
+// periodically connects to the other advertisers of each thread to exchange logs and records
+// with them. Threads are advertised under a hash of their id, which doesn't reveal the id.
+func WithNetThreadDiscovery(enabled bool) NetOption {
+	return func(c *NetConfig) error {
+		c.ThreadDiscovery = enabled
+		return nil
+	}
+}
+
+// WithLowMemoryProfile configures the network for devices with little memory, such as
+// single-board computers, where it runs in a few hundred MB:
+//   - libp2p connections are kept between LowMemoryConnLowWater and LowMemoryConnHighWater,
+//     instead of 100 and 400.
+//   - Pulls request at most LowMemoryNetPullingLimit records, instead of 10000, which bounds
+//     the records held in memory while they're fetched and applied.
+//   - Badger datastores use util.LowMemBadgerOptions.
+//
+// Options applied after it override its settings, e.g., WithConnectionManager.
+// Datastores of dbs aren't created by the network, so they must be opened with
+// util.NewBadgerDatastore in low memory mode too.
+func WithLowMemoryProfile() NetOption {
+	return func(c *NetConfig) error {
+		c.ConnManager = connmgr.NewConnManager(LowMemoryConnLowWater, LowMemoryConnHighWater, time.Second*20)
+		c.NetPullingLimit = LowMemoryNetPullingLimit
+		c.BadgerLowMem = true
+		return nil
+	}
+}
+
 func WithNoNetPulling(disable bool) NetOption {
 	return func(c *NetConfig) error {
 		c.NoNetPulling = disable
@@ -341,6 +501,14 @@ func WithNetPubSub(enabled bool) NetOption {
 	}
 }
 
+func WithNetPubSubRateLimit(perPeer, perThread int) NetOption {
+	return func(c *NetConfig) error {
+		c.PubSubPeerRateLimit = perPeer
+		c.PubSubThreadRateLimit = perThread
+		return nil
+	}
+}
+
 func WithNetLogstore(lt LogstoreType) NetOption {
 	return func(c *NetConfig) error {
 		c.LSType = lt
@@ -377,6 +545,25 @@ func WithConnectionManager(cm cconnmgr.ConnManager) NetOption {
 	}
 }
 
+// WithConnectionGater filters the connections of the host with g, e.g., a PeerGater.
+func WithConnectionGater(g cconnmgr.ConnectionGater) NetOption {
+	return func(c *NetConfig) error {
+		c.ConnectionGater = g
+		return nil
+	}
+}
+
+// WithLibp2pOptions adds options to the construction of the host, which are applied after the
+// default ones, so they can override them, e.g., libp2p.EnableRelay() enables the relay transport,
+// which is disabled by default. Transports added with libp2p.Transport replace the default
+// transports, which can be kept with libp2p.DefaultTransports.
+func WithLibp2pOptions(opts ...libp2p.Option) NetOption {
+	return func(c *NetConfig) error {
+		c.Libp2pOptions = append(c.Libp2pOptions, opts...)
+		return nil
+	}
+}
+
 func WithNetGRPCServerOptions(opts ...grpc.ServerOption) NetOption {
 	return func(c *NetConfig) error {
 		c.GRPCServerOptions = opts
@@ -407,16 +594,17 @@ func WithAnnounceAddr(addr ma.Multiaddr) NetOption {
 
 type netBoostrapper struct {
 	app.Net
+	ctx       context.Context
 	litepeer  *ipfslite.Peer
 	finalizer *finalizer.Finalizer
+
+	bootLock  sync.Mutex
+	bootPeers []peer.AddrInfo
+	bootOnce  sync.Once
 }
 
 var _ NetBoostrapper = (*netBoostrapper)(nil)
 
-func (tsb *netBoostrapper) Bootstrap(addrs []peer.AddrInfo) {
-	tsb.litepeer.Bootstrap(addrs)
-}
-
 func (tsb *netBoostrapper) GetIpfsLite() *ipfslite.Peer {
 	return tsb.litepeer
 }
@@ -0,0 +1,125 @@
+package common
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/libp2p/go-libp2p"
+	circuit "github.com/libp2p/go-libp2p-circuit"
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestWithLowMemoryProfile(t *testing.T) {
+	t.Run("Config", func(t *testing.T) {
+		var config NetConfig
+		if err := WithLowMemoryProfile()(&config); err != nil {
+			t.Fatal(err)
+		}
+		if err := setDefaults(&config); err != nil {
+			t.Fatal(err)
+		}
+		if config.NetPullingLimit != LowMemoryNetPullingLimit {
+			t.Fatalf("expected pulling limit %d, got %d", LowMemoryNetPullingLimit, config.NetPullingLimit)
+		}
+		if !config.BadgerLowMem {
+			t.Fatal("expected low memory badger options")
+		}
+		cm, ok := config.ConnManager.(*connmgr.BasicConnMgr)
+		if !ok {
+			t.Fatalf("expected basic conn manager, got %T", config.ConnManager)
+		}
+		info := cm.GetInfo()
+		if info.LowWater != LowMemoryConnLowWater || info.HighWater != LowMemoryConnHighWater {
+			t.Fatalf("expected watermarks %d/%d, got %d/%d",
+				LowMemoryConnLowWater, LowMemoryConnHighWater, info.LowWater, info.HighWater)
+		}
+	})
+
+	t.Run("Network", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		n, err := DefaultNetwork(
+			WithNetBadgerPersistence(dir),
+			WithNetHostAddr(util.FreeLocalAddr()),
+			WithLowMemoryProfile(),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer n.Close()
+
+		ctx := context.Background()
+		info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestWithLibp2pOptions(t *testing.T) {
+	makeNetwork := func(opts ...libp2p.Option) NetBoostrapper {
+		dir, err := ioutil.TempDir("", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = os.RemoveAll(dir) })
+		n, err := DefaultNetwork(
+			WithNetBadgerPersistence(dir),
+			WithNetHostAddr(util.FreeLocalAddr()),
+			WithLibp2pOptions(opts...),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = n.Close() })
+		return n
+	}
+	relay := makeNetwork(libp2p.EnableRelay(circuit.OptHop))
+	n1 := makeNetwork(libp2p.EnableRelay())
+	n2 := makeNetwork(libp2p.EnableRelay())
+
+	ctx := context.Background()
+	relayInfo := peer.AddrInfo{ID: relay.Host().ID(), Addrs: relay.Host().Addrs()}
+	for _, n := range []NetBoostrapper{n1, n2} {
+		if err := n.Host().Connect(ctx, relayInfo); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// n1 only knows how to reach n2 through the relay
+	addr, err := ma.NewMultiaddr("/p2p/" + relay.Host().ID().String() + "/p2p-circuit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := peer.AddrInfo{ID: n2.Host().ID(), Addrs: []ma.Multiaddr{relay.Host().Addrs()[0].Encapsulate(addr)}}
+	if err := n1.Host().Connect(ctx, info); err != nil {
+		t.Fatal(err)
+	}
+	conns := n1.Host().Network().ConnsToPeer(n2.Host().ID())
+	if len(conns) == 0 {
+		t.Fatal("expected a connection to the relayed peer")
+	}
+	if _, err := conns[0].RemoteMultiaddr().ValueForProtocol(ma.P_CIRCUIT); err != nil {
+		t.Fatalf("expected a relayed connection, got %s", conns[0].RemoteMultiaddr())
+	}
+}
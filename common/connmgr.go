@@ -0,0 +1,167 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	cconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// ResizableConnManager is a basic connection manager whose limits can be changed while
+// the host is running.
+type ResizableConnManager struct {
+	lk        sync.RWMutex
+	cm        *connmgr.BasicConnMgr
+	net       network.Network
+	protected map[peer.ID]map[string]struct{}
+}
+
+var _ cconnmgr.ConnManager = (*ResizableConnManager)(nil)
+
+// NewResizableConnManager creates a connection manager with the given limits.
+// See connmgr.NewConnManager.
+func NewResizableConnManager(low, high int, grace time.Duration) *ResizableConnManager {
+	return &ResizableConnManager{
+		cm:        connmgr.NewConnManager(low, high, grace),
+		protected: make(map[peer.ID]map[string]struct{}),
+	}
+}
+
+// SetLimits replaces the limits of the connection manager.
+// Open connections, tags, and protections are kept.
+func (m *ResizableConnManager) SetLimits(low, high int, grace time.Duration) error {
+	cm := connmgr.NewConnManager(low, high, grace)
+
+	m.lk.Lock()
+	old := m.cm
+	if m.net != nil {
+		for _, c := range m.net.Conns() {
+			cm.Notifee().Connected(m.net, c)
+		}
+		for _, p := range m.net.Peers() {
+			if info := old.GetTagInfo(p); info != nil {
+				for tag, v := range info.Tags {
+					cm.TagPeer(p, tag, v)
+				}
+			}
+		}
+	}
+	for p, tags := range m.protected {
+		for tag := range tags {
+			cm.Protect(p, tag)
+		}
+	}
+	m.cm = cm
+	m.lk.Unlock()
+
+	return old.Close()
+}
+
+// GetInfo returns the configuration and status of the connection manager.
+func (m *ResizableConnManager) GetInfo() connmgr.CMInfo {
+	return m.current().GetInfo()
+}
+
+func (m *ResizableConnManager) current() *connmgr.BasicConnMgr {
+	m.lk.RLock()
+	defer m.lk.RUnlock()
+	return m.cm
+}
+
+func (m *ResizableConnManager) TagPeer(p peer.ID, tag string, v int) {
+	m.current().TagPeer(p, tag, v)
+}
+
+func (m *ResizableConnManager) UntagPeer(p peer.ID, tag string) {
+	m.current().UntagPeer(p, tag)
+}
+
+func (m *ResizableConnManager) UpsertTag(p peer.ID, tag string, upsert func(int) int) {
+	m.current().UpsertTag(p, tag, upsert)
+}
+
+func (m *ResizableConnManager) GetTagInfo(p peer.ID) *cconnmgr.TagInfo {
+	return m.current().GetTagInfo(p)
+}
+
+func (m *ResizableConnManager) TrimOpenConns(ctx context.Context) {
+	m.current().TrimOpenConns(ctx)
+}
+
+func (m *ResizableConnManager) Notifee() network.Notifiee {
+	return (*resizableNotifee)(m)
+}
+
+func (m *ResizableConnManager) Protect(p peer.ID, tag string) {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	tags, ok := m.protected[p]
+	if !ok {
+		tags = make(map[string]struct{})
+		m.protected[p] = tags
+	}
+	tags[tag] = struct{}{}
+	m.cm.Protect(p, tag)
+}
+
+func (m *ResizableConnManager) Unprotect(p peer.ID, tag string) bool {
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	if tags, ok := m.protected[p]; ok {
+		delete(tags, tag)
+		if len(tags) == 0 {
+			delete(m.protected, p)
+		}
+	}
+	return m.cm.Unprotect(p, tag)
+}
+
+func (m *ResizableConnManager) IsProtected(p peer.ID, tag string) bool {
+	return m.current().IsProtected(p, tag)
+}
+
+func (m *ResizableConnManager) Close() error {
+	return m.current().Close()
+}
+
+// resizableNotifee forwards network notifications to the current connection manager.
+type resizableNotifee ResizableConnManager
+
+// notify calls fn with the notifee of the current connection manager.
+// Notifications are serialized with limit changes, so none is lost while replacing it.
+func (n *resizableNotifee) notify(net network.Network, fn func(network.Notifiee)) {
+	m := (*ResizableConnManager)(n)
+	m.lk.Lock()
+	defer m.lk.Unlock()
+	m.net = net
+	fn(m.cm.Notifee())
+}
+
+func (n *resizableNotifee) Listen(net network.Network, addr ma.Multiaddr) {
+	n.notify(net, func(nn network.Notifiee) { nn.Listen(net, addr) })
+}
+
+func (n *resizableNotifee) ListenClose(net network.Network, addr ma.Multiaddr) {
+	n.notify(net, func(nn network.Notifiee) { nn.ListenClose(net, addr) })
+}
+
+func (n *resizableNotifee) Connected(net network.Network, c network.Conn) {
+	n.notify(net, func(nn network.Notifiee) { nn.Connected(net, c) })
+}
+
+func (n *resizableNotifee) Disconnected(net network.Network, c network.Conn) {
+	n.notify(net, func(nn network.Notifiee) { nn.Disconnected(net, c) })
+}
+
+func (n *resizableNotifee) OpenedStream(net network.Network, s network.Stream) {
+	n.notify(net, func(nn network.Notifiee) { nn.OpenedStream(net, s) })
+}
+
+func (n *resizableNotifee) ClosedStream(net network.Network, s network.Stream) {
+	n.notify(net, func(nn network.Notifiee) { nn.ClosedStream(net, s) })
+}
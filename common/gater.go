@@ -0,0 +1,102 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	cconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/control"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// PeerGater is a connection gater which rejects connections with peers of a deny list, and,
+// if an allow list is given, with peers which aren't on it. Dials to rejected peers are
+// dropped before connecting. Inbound connections are dropped once the remote peer is
+// authenticated by the security handshake, which is the earliest its id is known, so no
+// stream is opened and no protocol is negotiated with rejected peers.
+type PeerGater struct {
+	allow map[peer.ID]struct{}
+	deny  map[peer.ID]struct{}
+}
+
+var _ cconnmgr.ConnectionGater = (*PeerGater)(nil)
+
+// NewPeerGater returns a gater rejecting peers of deny, and peers not in allow unless it's empty.
+// Peers on both lists are rejected.
+func NewPeerGater(allow, deny []peer.ID) *PeerGater {
+	g := &PeerGater{
+		allow: make(map[peer.ID]struct{}, len(allow)),
+		deny:  make(map[peer.ID]struct{}, len(deny)),
+	}
+	for _, p := range allow {
+		g.allow[p] = struct{}{}
+	}
+	for _, p := range deny {
+		g.deny[p] = struct{}{}
+	}
+	return g
+}
+
+// Allowed returns whether connections with peer p are allowed.
+func (g *PeerGater) Allowed(p peer.ID) bool {
+	if _, ok := g.deny[p]; ok {
+		return false
+	}
+	if len(g.allow) == 0 {
+		return true
+	}
+	_, ok := g.allow[p]
+	return ok
+}
+
+func (g *PeerGater) InterceptPeerDial(p peer.ID) bool {
+	return g.Allowed(p)
+}
+
+func (g *PeerGater) InterceptAddrDial(p peer.ID, _ ma.Multiaddr) bool {
+	return g.Allowed(p)
+}
+
+func (g *PeerGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	// The remote peer isn't known until the connection is secured.
+	return true
+}
+
+func (g *PeerGater) InterceptSecured(_ network.Direction, p peer.ID, _ network.ConnMultiaddrs) bool {
+	return g.Allowed(p)
+}
+
+func (g *PeerGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// LoadPeerList reads a list of peer ids from the file at path, one per line.
+// Blank lines and lines starting with # are ignored.
+func LoadPeerList(path string) ([]peer.ID, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var peers []peer.ID
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		p, err := peer.Decode(text)
+		if err != nil {
+			return nil, fmt.Errorf("parsing peer id on line %d of %s: %w", line, path, err)
+		}
+		peers = append(peers, p)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return peers, nil
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/test"
+)
+
+func TestPeerGater(t *testing.T) {
+	var peers []peer.ID
+	for i := 0; i < 3; i++ {
+		p, err := test.RandPeerID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		peers = append(peers, p)
+	}
+
+	t.Run("Deny", func(t *testing.T) {
+		g := NewPeerGater(nil, peers[:1])
+		if g.InterceptPeerDial(peers[0]) || g.InterceptSecured(0, peers[0], nil) {
+			t.Fatal("expected denied peer to be rejected")
+		}
+		if !g.InterceptPeerDial(peers[1]) || !g.InterceptSecured(0, peers[1], nil) {
+			t.Fatal("expected other peer to be allowed")
+		}
+	})
+	t.Run("Allow", func(t *testing.T) {
+		g := NewPeerGater(peers[:2], peers[1:2])
+		if !g.Allowed(peers[0]) {
+			t.Fatal("expected allowed peer to be allowed")
+		}
+		if g.Allowed(peers[1]) {
+			t.Fatal("expected peer on both lists to be rejected")
+		}
+		if g.Allowed(peers[2]) {
+			t.Fatal("expected peer not on allow list to be rejected")
+		}
+	})
+	t.Run("LoadPeerList", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "peers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(f.Name())
+		content := "# consortium members\n" + peers[0].String() + "\n\n  " + peers[1].String() + "  \n"
+		if _, err := f.WriteString(content); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadPeerList(f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(loaded, peers[:2]) {
+			t.Fatalf("expected peers %v, got %v", peers[:2], loaded)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	bserv "github.com/ipfs/go-blockservice"
+	ds "github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	dag "github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p-core/peerstore"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/textileio/go-threads/core/app"
+	"github.com/textileio/go-threads/logstore/lstoremem"
+	"github.com/textileio/go-threads/net"
+)
+
+// NewMemoryNetwork returns n nets which are connected to each other over an in-process
+// libp2p network, along with a function that closes them.
+// Nets keep logs and blocks in memory, and exchange records the same way as nets
+// connected over sockets, so it's meant for tests of thread replication.
+// Options which set the host, the logstore, persistence or metrics are ignored.
+func NewMemoryNetwork(n int, opts ...NetOption) ([]app.Net, func(), error) {
+	var config NetConfig
+	for _, opt := range opts {
+		if err := opt(&config); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := setDefaults(&config); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mn := mocknet.New(ctx)
+	nets := make([]app.Net, 0, n)
+	closeAll := func() {
+		for _, api := range nets {
+			_ = api.Close()
+		}
+		_ = mn.Close()
+		cancel()
+	}
+
+	for i := 0; i < n; i++ {
+		h, err := mn.GenPeer()
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("creating host: %w", err)
+		}
+		bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
+		bsrv := bserv.New(bs, offline.Exchange(bs))
+		api, err := net.NewNetwork(ctx, h, bsrv.Blockstore(), dag.NewDAGService(bsrv), lstoremem.NewLogstore(), net.Config{
+			NetPullingLimit:           config.NetPullingLimit,
+			NetPullingStartAfter:      config.NetPullingStartAfter,
+			NetPullingInitialInterval: config.NetPullingInitialInterval,
+			NetPullingInterval:        config.NetPullingInterval,
+			NoNetPulling:              config.NoNetPulling,
+			NoExchangeEdgesMigration:  config.NoExchangeEdgesMigration,
+			PubSub:                    config.PubSub,
+			PubSubPeerRateLimit:       config.PubSubPeerRateLimit,
+			PubSubThreadRateLimit:     config.PubSubThreadRateLimit,
+			DialRetryMax:              config.NetDialRetryMax,
+			DialRetryBase:             config.NetDialRetryBase,
+			RecordErrorHandler:        config.RecordErrorHandler,
+			RecordCodec:               config.RecordCompression,
+			MaxRecordSize:             config.MaxRecordSize,
+			Debug:                     config.Debug,
+		}, config.GRPCServerOptions, config.GRPCDialOptions)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("creating net: %w", err)
+		}
+		nets = append(nets, api)
+	}
+
+	if err := mn.LinkAll(); err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf("linking hosts: %w", err)
+	}
+	if err := mn.ConnectAllButSelf(); err != nil {
+		closeAll()
+		return nil, nil, fmt.Errorf("connecting hosts: %w", err)
+	}
+	// Thread addresses are resolved through the peerstore, which connections don't fill in
+	for _, h := range mn.Hosts() {
+		for _, other := range mn.Hosts() {
+			if h.ID() != other.ID() {
+				h.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			}
+		}
+	}
+	return nets, closeAll, nil
+}
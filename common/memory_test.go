@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	ma "github.com/multiformats/go-multiaddr"
+	mh "github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+func TestNewMemoryNetwork(t *testing.T) {
+	nets, done, err := NewMemoryNetwork(3, WithNetPubSub(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+
+	ctx := context.Background()
+	info, err := nets[0].CreateThread(ctx, thread.NewIDV1(thread.Raw, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := ma.NewMultiaddr("/p2p/" + nets[0].Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range nets[1:] {
+		if _, err := n.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := nets[0].CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range nets[1:] {
+		var found bool
+		for i := 0; i < 50 && !found; i++ {
+			if _, err := n.GetRecord(ctx, info.ID, r.Value().Cid()); err == nil {
+				found = true
+			} else {
+				time.Sleep(time.Millisecond * 100)
+			}
+		}
+		if !found {
+			t.Fatalf("expected record to be replicated to %s", n.Host().ID())
+		}
+	}
+}
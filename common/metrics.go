@@ -0,0 +1,57 @@
+package common
+
+import (
+	cconnmgr "github.com/libp2p/go-libp2p-core/connmgr"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/textileio/go-threads/util"
+)
+
+// registerConnMetrics registers gauges of the connections of h with reg.
+// Limits are also reported if cm is a ResizableConnManager.
+func registerConnMetrics(reg prometheus.Registerer, h host.Host, cm cconnmgr.ConnManager) error {
+	gauges := []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "threads",
+			Subsystem: "connmgr",
+			Name:      "connections",
+			Help:      "Number of open connections.",
+		}, func() float64 {
+			return float64(len(h.Network().Conns()))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "threads",
+			Subsystem: "connmgr",
+			Name:      "peers",
+			Help:      "Number of connected peers.",
+		}, func() float64 {
+			return float64(len(h.Network().Peers()))
+		}),
+	}
+	if rcm, ok := cm.(*ResizableConnManager); ok {
+		gauges = append(gauges,
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace: "threads",
+				Subsystem: "connmgr",
+				Name:      "low_water",
+				Help:      "Number of connections the connection manager trims down to.",
+			}, func() float64 {
+				return float64(rcm.GetInfo().LowWater)
+			}),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Namespace: "threads",
+				Subsystem: "connmgr",
+				Name:      "high_water",
+				Help:      "Number of connections above which the connection manager trims connections.",
+			}, func() float64 {
+				return float64(rcm.GetInfo().HighWater)
+			}),
+		)
+	}
+	for _, g := range gauges {
+		if _, err := util.RegisterCollector(reg, g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	format "github.com/ipfs/go-ipld-format"
+	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/broadcast"
 	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
@@ -35,6 +36,13 @@ type App interface {
 	HandleNetRecord(ctx context.Context, rec net.ThreadRecord, key thread.Key) error
 }
 
+// Resetter is implemented by apps which can drop the state built from thread records, so that
+// it's rebuilt when the records of a thread are handled again from its start.
+type Resetter interface {
+	// ResetNetRecords drops the state built from handled records.
+	ResetNetRecords(ctx context.Context) error
+}
+
 // LocalEventsBus wraps a broadcaster for local events.
 type LocalEventsBus struct {
 	bus *broadcast.Broadcaster
@@ -123,7 +131,7 @@ type Connection func(context.Context, thread.ID) (<-chan net.ThreadRecord, error
 
 // NewConnector creates bidirectional connection between an app and a thread.
 func NewConnector(app App, net Net, tinfo thread.Info) (*Connector, error) {
-	if !tinfo.Key.CanRead() {
+	if !tinfo.Key.CanReadAny() {
 		return nil, fmt.Errorf("read key not found for thread %s", tinfo.ID)
 	}
 	return &Connector{
@@ -146,8 +154,9 @@ func (c *Connector) Token() net.Token {
 }
 
 // CreateNetRecord calls net.CreateRecord while supplying thread ID and API token.
-func (c *Connector) CreateNetRecord(ctx context.Context, body format.Node, token thread.Token) (net.ThreadRecord, error) {
-	return c.Net.CreateRecord(ctx, c.threadID, body, net.WithThreadToken(token), net.WithAPIToken(c.token))
+func (c *Connector) CreateNetRecord(ctx context.Context, body format.Node, token thread.Token, opts ...net.ThreadOption) (net.ThreadRecord, error) {
+	opts = append([]net.ThreadOption{net.WithThreadToken(token), net.WithAPIToken(c.token)}, opts...)
+	return c.Net.CreateRecord(ctx, c.threadID, body, opts...)
 }
 
 // Validate thread token against the net host.
@@ -161,7 +170,16 @@ func (c *Connector) ValidateNetRecordBody(ctx context.Context, body format.Node,
 	return c.app.ValidateNetRecordBody(ctx, body, identity)
 }
 
-// HandleNetRecord calls the connection app's HandleNetRecord while supplying thread key.
-func (c *Connector) HandleNetRecord(ctx context.Context, rec net.ThreadRecord) error {
-	return c.app.HandleNetRecord(ctx, rec, c.threadKey)
+// HandleNetRecord calls the connection app's HandleNetRecord while supplying thread key,
+// with rk, the read key the record is encrypted under, as its read key.
+func (c *Connector) HandleNetRecord(ctx context.Context, rec net.ThreadRecord, rk *sym.Key) error {
+	return c.app.HandleNetRecord(ctx, rec, thread.NewKey(c.threadKey.Service(), rk))
+}
+
+// ResetNetRecords calls the connection app's ResetNetRecords, if the app is a Resetter.
+func (c *Connector) ResetNetRecords(ctx context.Context) error {
+	if r, ok := c.app.(Resetter); ok {
+		return r.ResetNetRecords(ctx)
+	}
+	return nil
 }
@@ -64,6 +64,9 @@ type Action struct {
 	Previous []byte
 	// Current is the instance after the action was done.
 	Current []byte
+	// DetectConflicts indicates that the event should record the values it
+	// overwrites, so that conflicts can be detected when it's reduced.
+	DetectConflicts bool
 }
 
 type ReduceAction struct {
@@ -73,16 +76,47 @@ type ReduceAction struct {
 	Collection string
 	// InstanceID of the instance in reduced action.
 	InstanceID InstanceID
+	// Conflicts detected while reducing the action.
+	// Only populated for collections accepted by the ConflictFunc.
+	Conflicts []Conflict
+}
+
+// Conflict describes a field of an instance that was changed by a save
+// made without knowledge of another writer's change to the same field.
+// Conflicts are detected by comparing the field's value when the save was made
+// with its value when the save is reduced, so they don't depend on wall clocks.
+// A field changed back to its original value by the other writer isn't detected.
+type Conflict struct {
+	// Path is the dot-separated path of the conflicting field.
+	Path string
+	// Winner is the JSON-encoded value of the field after the save was applied.
+	// Saves are applied in the order they're reduced, so the winner is the save reduced last,
+	// which isn't necessarily the most recent one.
+	// A nil Winner indicates the field was removed.
+	Winner []byte
+	// Loser is the JSON-encoded value of the field that was overwritten.
+	// A nil Loser indicates the field didn't exist.
+	Loser []byte
 }
 
 // IndexFunc handles index updates.
 type IndexFunc func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error
 
+// ConflictFunc reports whether conflicts should be detected for a collection.
+type ConflictFunc func(collection string) bool
+
 // EventCodec transforms actions generated in collections to
 // events dispatched to thread logs, and viceversa.
 type EventCodec interface {
 	// Reduce applies generated events into state.
-	Reduce(events []Event, store ds.TxnDatastore, baseKey ds.Key, indexFunc IndexFunc) ([]ReduceAction, error)
+	// Conflicts are only reported for collections accepted by conflictFunc, which may be nil.
+	Reduce(
+		events []Event,
+		store ds.TxnDatastore,
+		baseKey ds.Key,
+		indexFunc IndexFunc,
+		conflictFunc ConflictFunc,
+	) ([]ReduceAction, error)
 	// Create corresponding events to be dispatched.
 	Create(ops []Action) ([]Event, format.Node, error)
 	// EventsFromBytes deserializes a format.Node bytes payload into Events.
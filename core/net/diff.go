@@ -0,0 +1,43 @@
+package net
+
+import (
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// DiffReport reports how the records of a thread on the host differ from those on a peer.
+type DiffReport struct {
+	// ThreadID is the thread id.
+	ThreadID thread.ID
+	// Peer is the peer the host was compared with.
+	Peer peer.ID
+	// Logs holds the logs whose records differ, in order of log id.
+	Logs []LogDiff
+}
+
+// Equal returns whether the host and the peer have the same records.
+func (r DiffReport) Equal() bool {
+	return len(r.Logs) == 0
+}
+
+// LogDiff reports how the records of a log differ between the host and a peer.
+type LogDiff struct {
+	// ID is the log id.
+	ID peer.ID
+	// LocalHead and RemoteHead are the heads of the log on the host and the peer.
+	// A head is undefined if the log is unknown or empty.
+	LocalHead  thread.Head
+	RemoteHead thread.Head
+	// Index is the position of the first record which differs, counting from the first record
+	// of the log.
+	Index int
+	// Local and Remote are the records at Index on the host and the peer, or cid.Undef if the log
+	// ends before Index.
+	Local  cid.Cid
+	Remote cid.Cid
+	// MissingLocal are the records the peer has which the host doesn't, from oldest to newest.
+	MissingLocal []cid.Cid
+	// MissingRemote are the records the host has which the peer doesn't, from oldest to newest.
+	MissingRemote []cid.Cid
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
+	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/core/thread"
 )
 
@@ -22,6 +24,94 @@ type Net interface {
 
 	// Host provides a network identity.
 	Host() host.Host
+
+	// Compact removes the events of records older than before from the local blockstore.
+	// Records are kept, so logs can still be traversed and records returned by GetRecord.
+	// Records which a replicator of the thread hasn't acknowledged are never removed.
+	Compact(ctx context.Context, id thread.ID, before cid.Cid, opts ...ThreadOption) error
+
+	// CompactDryRun returns the number of bytes Compact would reclaim, without removing anything.
+	CompactDryRun(ctx context.Context, id thread.ID, before cid.Cid, opts ...ThreadOption) (int64, error)
+
+	// ExportThread writes the key, logs and records of a thread to w as a portable archive.
+	ExportThread(ctx context.Context, id thread.ID, w io.Writer, opts ...ThreadOption) error
+
+	// ImportThread adds a thread from an archive written by ExportThread, returning its id.
+	// Archives containing records which don't verify against the keys of their logs are rejected.
+	ImportThread(ctx context.Context, r io.Reader, opts ...ThreadOption) (thread.ID, error)
+
+	// SetPullInterval overrides the interval at which a thread is periodically pulled.
+	// A non-positive interval restores the default interval.
+	SetPullInterval(id thread.ID, d time.Duration) error
+
+	// PullThreadDepth pulls at most maxRecords of the newest records of each log of a thread,
+	// skipping older records which haven't been pulled, and marks the thread as partial.
+	// This lets light clients follow the recent activity of a thread without its history.
+	PullThreadDepth(ctx context.Context, id thread.ID, maxRecords int, opts ...ThreadOption) error
+
+	// IsPartial returns whether records of a thread were skipped by PullThreadDepth.
+	// ResyncThread pulls the skipped records, after which the thread is no longer partial.
+	IsPartial(id thread.ID) (bool, error)
+
+	// PauseThread stops a thread from being pulled, pushed to peers, and updated by peers, until
+	// it's resumed. Records created locally while paused are rejected, not queued.
+	PauseThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// ResumeThread resumes a paused thread, and pulls records from its peers to catch up.
+	ResumeThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// RotateKey replaces the read key of a thread with a new random key, which encrypts records
+	// created from then on. The new key is announced to the other hosts of the thread in a record
+	// encrypted under the previous key, which is retained so that older records remain readable.
+	// Rotation doesn't protect records which were readable with a leaked key, and because the
+	// announcement is encrypted under the previous key, it only keeps new records from a holder of
+	// the leaked read key who doesn't also hold the service key, and so can't read records.
+	// The service key isn't rotated.
+	RotateKey(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
+	// RegisterLogSigner makes signer sign the records of the logs of its public key, e.g., to
+	// keep the log key in an HSM or a KMS. Logs of the key are created by passing its public
+	// key to WithLogKey. Signers aren't persisted, and must be registered again on restart.
+	RegisterLogSigner(signer thread.Signer) error
+
+	// MergeLogs consolidates the logs of a single author in a thread, e.g., after a reinstall.
+	// The records of the from logs are re-anchored in order, log by log, after the head of the
+	// into log, which must be signable by this host, and the from logs are retired. The records
+	// are re-signed with the key of the into log, which supersedes their original signatures,
+	// but their events, and so their authors, are unchanged. Other hosts of the thread are told
+	// of the merge by a record preceding the merged records, so they retire the from logs too,
+	// and only handle merged records whose originals they haven't. Records of logs with
+	// different authors can't be merged.
+	MergeLogs(ctx context.Context, id thread.ID, into peer.ID, from ...peer.ID) error
+
+	// RegisterPubSubValidator adds a validator of the messages received over the pubsub topics
+	// of threads. Messages rejected by a validator are dropped before they're handled, and
+	// their publisher is penalized. Validators aren't persisted, and must be registered again
+	// on restart.
+	RegisterPubSubValidator(v PubSubValidator)
+
+	// SetThreadPubSub joins or leaves the pubsub topic of a thread, independently of whether it's
+	// hosted. Threads use pubsub by default when it's enabled for the host, and the setting is
+	// kept across restarts. Records of threads without pubsub are still pushed to and pulled
+	// from peers directly.
+	SetThreadPubSub(id thread.ID, enabled bool) error
+
+	// ReadKeys returns the read keys of a thread, from the current key to the oldest key
+	// retained by RotateKey.
+	ReadKeys(ctx context.Context, id thread.ID, opts ...ThreadOption) ([]*sym.Key, error)
+
+	// VerifyThread checks that the stored records of each log of a thread hash to their cids,
+	// are signed by the key of their log, and link back to the start of the log, e.g., to
+	// catch disk corruption. It's read-only and doesn't block the thread from being updated
+	// while it runs. Events removed by Compact, and records skipped by PullThreadDepth,
+	// aren't reported.
+	VerifyThread(ctx context.Context, id thread.ID, opts ...ThreadOption) (VerifyReport, error)
+
+	// DiffThread compares the records of each log of a thread with those of a peer hosting it,
+	// reporting the first record which differs in each log, and the records either side lacks.
+	// Logs are compared by the ids of their records, so records with different contents and
+	// the same id aren't told apart. Nothing is pulled or pushed.
+	DiffThread(ctx context.Context, id thread.ID, p peer.ID, opts ...ThreadOption) (DiffReport, error)
 }
 
 // API is the network interface for thread orchestration.
@@ -44,6 +134,10 @@ type API interface {
 	// GetThread returns thread info by id.
 	GetThread(ctx context.Context, id thread.ID, opts ...ThreadOption) (thread.Info, error)
 
+	// ListThreads returns info for each thread managed by the host, in order of id.
+	// The number of records of a log is the counter of its head.
+	ListThreads(ctx context.Context, opts ...ListThreadsOption) ([]thread.Info, error)
+
 	// PullThread requests new records from each known thread host.
 	// This method is called internally on an interval as part of the orchestration protocol.
 	// Calling it manually can be useful when new records are known to be available.
@@ -52,10 +146,19 @@ type API interface {
 	// DeleteThread removes a thread by id and opts.
 	DeleteThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
 
+	// ResyncThread drops the local records of a thread, keeping its keys, and pulls them again
+	// from the thread's peers, verifying each record. Apps connected to the thread rebuild their
+	// state from the pulled records.
+	ResyncThread(ctx context.Context, id thread.ID, opts ...ThreadOption) error
+
 	// AddReplicator replicates a thread by id on a different host.
 	// All logs and records are pushed to the new host.
 	AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...ThreadOption) (peer.ID, error)
 
+	// GetReplicators returns the state of a thread by id on each peer replicating it.
+	// A replicator acknowledges records by requesting the records which follow them.
+	GetReplicators(ctx context.Context, id thread.ID, opts ...ThreadOption) ([]ReplicatorInfo, error)
+
 	// CreateRecord creates and adds a new record with body to a thread by id.
 	CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...ThreadOption) (ThreadRecord, error)
 
@@ -79,3 +182,7 @@ type Token []byte
 func (t Token) Equal(b Token) bool {
 	return bytes.Equal(t, b)
 }
+
+// PubSubValidator validates a message published by from to the pubsub topic of a thread, before
+// it's handled. Returning an error rejects the message, e.g., because it's malformed or oversized.
+type PubSubValidator func(ctx context.Context, from peer.ID, id thread.ID, msg []byte) error
@@ -2,6 +2,7 @@ package net
 
 import (
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/textileio/go-threads/core/thread"
 )
 
@@ -43,6 +44,7 @@ func WithNewThreadToken(t thread.Token) NewThreadOption {
 type ThreadOptions struct {
 	Token    thread.Token
 	APIToken Token
+	ReadKey  string
 }
 
 // ThreadOption specifies thread options.
@@ -65,10 +67,56 @@ func WithAPIToken(t Token) ThreadOption {
 	}
 }
 
+// WithReadKeyName selects the named read key of the thread which encrypts a created record,
+// instead of the read key. See thread.Key.WithNamedRead.
+func WithReadKeyName(name string) ThreadOption {
+	return func(args *ThreadOptions) {
+		args.ReadKey = name
+	}
+}
+
+// ListThreadsOptions defines options for listing threads.
+type ListThreadsOptions struct {
+	Token  thread.Token
+	Offset thread.ID
+	Limit  int
+}
+
+// ListThreadsOption specifies list threads options.
+type ListThreadsOption func(*ListThreadsOptions)
+
+// WithListToken provides authorization for listing threads.
+func WithListToken(t thread.Token) ListThreadsOption {
+	return func(args *ListThreadsOptions) {
+		args.Token = t
+	}
+}
+
+// WithListOffset skips threads up to and including offset.
+// Threads are listed in order of id, so the id of the last thread listed
+// can be used as the offset of the next page.
+func WithListOffset(offset thread.ID) ListThreadsOption {
+	return func(args *ListThreadsOptions) {
+		args.Offset = offset
+	}
+}
+
+// WithListLimit limits the number of threads listed.
+// A non-positive limit lists all threads.
+func WithListLimit(limit int) ListThreadsOption {
+	return func(args *ListThreadsOptions) {
+		args.Limit = limit
+	}
+}
+
 // SubOptions defines options for a thread subscription.
 type SubOptions struct {
-	ThreadIDs thread.IDSlice
-	Token     thread.Token
+	ThreadIDs      thread.IDSlice
+	LogIDs         []peer.ID
+	Authors        []thread.PubKey
+	ExcludeAuthors []thread.PubKey
+	Membership     bool
+	Token          thread.Token
 }
 
 // SubOption is a thread subscription option.
@@ -82,6 +130,39 @@ func WithSubFilter(id thread.ID) SubOption {
 	}
 }
 
+// WithSubLogs restricts the subscription to records of the given logs.
+// Combined with WithSubFilter, only records of the given logs within the given threads are delivered.
+func WithSubLogs(ids ...peer.ID) SubOption {
+	return func(args *SubOptions) {
+		args.LogIDs = append(args.LogIDs, ids...)
+	}
+}
+
+// WithSubAuthors restricts the subscription to records authored by one of the given identities.
+func WithSubAuthors(authors ...thread.PubKey) SubOption {
+	return func(args *SubOptions) {
+		args.Authors = append(args.Authors, authors...)
+	}
+}
+
+// WithSubExcludeAuthors drops records authored by any of the given identities from the
+// subscription, e.g., to ignore records created with one's own identity.
+func WithSubExcludeAuthors(authors ...thread.PubKey) SubOption {
+	return func(args *SubOptions) {
+		args.ExcludeAuthors = append(args.ExcludeAuthors, authors...)
+	}
+}
+
+// WithSubMembership adds membership records to the subscription, which are sent when a log
+// is added to or removed from a subscribed thread, or when its read key is received.
+// Use a type assertion to MembershipRecord to tell them apart from records.
+// Author filters don't apply to membership records.
+func WithSubMembership() SubOption {
+	return func(args *SubOptions) {
+		args.Membership = true
+	}
+}
+
 // WithSubToken provides authorization for a subscription.
 func WithSubToken(t thread.Token) SubOption {
 	return func(args *SubOptions) {
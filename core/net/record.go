@@ -44,3 +44,43 @@ type ThreadRecord interface {
 	// LogID returns the record's log ID.
 	LogID() peer.ID
 }
+
+// MembershipChange is a change to the logs of a thread.
+type MembershipChange int
+
+const (
+	// LogAdded indicates a log was added to the thread.
+	LogAdded MembershipChange = iota
+	// LogRemoved indicates a log was removed from the thread.
+	LogRemoved
+	// KeyReceived indicates the read key of the thread was received, making the log readable.
+	KeyReceived
+)
+
+func (c MembershipChange) String() string {
+	switch c {
+	case LogAdded:
+		return "log added"
+	case LogRemoved:
+		return "log removed"
+	case KeyReceived:
+		return "key received"
+	default:
+		return "unknown"
+	}
+}
+
+// MembershipRecord reports a change to a log of a thread. It's only sent to subscriptions
+// with WithSubMembership, and its Value is always nil.
+type MembershipRecord interface {
+	ThreadRecord
+
+	// Change returns the kind of change.
+	Change() MembershipChange
+
+	// PubKey returns the public key of the log.
+	PubKey() crypto.PubKey
+
+	// Readable returns whether the read key of the thread is known to this node.
+	Readable() bool
+}
@@ -0,0 +1,29 @@
+package net
+
+import (
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// ReplicatorInfo reports the state of a thread on a peer replicating it.
+type ReplicatorInfo struct {
+	// ID is the peer id of the replicator.
+	ID peer.ID
+	// Logs holds the state of each thread log on the replicator.
+	Logs []ReplicatorLogInfo
+	// LastContact is the last time the replicator was reached or reached the host.
+	// It's zero if that didn't happen since the host started.
+	LastContact time.Time
+}
+
+// ReplicatorLogInfo reports the state of a thread log on a replicator.
+type ReplicatorLogInfo struct {
+	// ID is the log id.
+	ID peer.ID
+	// Head is the last record of the log acknowledged by the replicator, or cid.Undef if none.
+	Head cid.Cid
+	// Lag is the number of local records of the log the replicator hasn't acknowledged.
+	Lag int64
+}
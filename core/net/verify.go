@@ -0,0 +1,60 @@
+package net
+
+import (
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// VerifyReport reports the integrity of the records of a thread stored by the host.
+type VerifyReport struct {
+	// ThreadID is the thread id.
+	ThreadID thread.ID
+	// Records is the number of records checked.
+	Records int64
+	// Problems lists the records which didn't verify, log by log, from newest to oldest.
+	Problems []VerifyProblem
+}
+
+// OK returns whether all the checked records verified.
+func (r VerifyReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// VerifyProblemKind is the kind of a problem found by VerifyThread.
+type VerifyProblemKind int
+
+const (
+	// RecordCorrupt indicates a stored block doesn't hash to its cid, can't be decoded, or
+	// a record isn't signed by the key of its log.
+	RecordCorrupt VerifyProblemKind = iota
+	// RecordMissing indicates a record, or a block of its event, isn't stored.
+	RecordMissing
+	// ChainBroken indicates the records of a log don't add up to the counter of its head.
+	ChainBroken
+)
+
+func (k VerifyProblemKind) String() string {
+	switch k {
+	case RecordCorrupt:
+		return "corrupt"
+	case RecordMissing:
+		return "missing"
+	case ChainBroken:
+		return "broken chain"
+	default:
+		return "unknown"
+	}
+}
+
+// VerifyProblem is a record of a thread which didn't verify.
+type VerifyProblem struct {
+	// Log is the id of the log containing the record.
+	Log peer.ID
+	// Record is the cid of the record.
+	Record cid.Cid
+	// Kind is the kind of problem.
+	Kind VerifyProblemKind
+	// Reason describes the problem.
+	Reason string
+}
@@ -2,8 +2,10 @@ package thread
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"time"
 
 	"github.com/libp2p/go-libp2p-core/crypto"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -58,7 +60,26 @@ func NewIDV1(variant Variant, size uint8) ID {
 	if err != nil {
 		panic("random read failed")
 	}
+	return newIDV1(variant, num)
+}
+
+// seedIDPrefix separates seed digests used as IDs from other digests of the seed.
+const seedIDPrefix = "/threads/id/seed/"
+
+// NewIDFromSeed returns an ID using the given variant, whose number is derived from seed.
+// The same variant and seed always result in the same ID, which allows a thread to be declared
+// in configuration and recreated in each environment. The number is the 32 byte SHA2-256 digest
+// of seed, so IDs of distinct seeds don't collide in practice. However, the ID of a seed can be
+// derived by anyone who guesses it: short or common seeds, like "prod" or a service name, are
+// likely to be chosen by other deployments, resulting in the same ID. Seeds should include a
+// namespace unique to the deployment, or at least 16 random bytes.
+func NewIDFromSeed(variant Variant, seed []byte) ID {
+	digest := sha256.Sum256(append([]byte(seedIDPrefix), seed...))
+	return newIDV1(variant, digest[:])
+}
 
+// newIDV1 returns an ID using the given variant and number.
+func newIDV1(variant Variant, num []byte) ID {
 	numlen := len(num)
 	// two 8 bytes (max) numbers plus num
 	buf := make([]byte, 2*binary.MaxVarintLen64+numlen)
@@ -350,6 +371,9 @@ type Info struct {
 	Key   Key
 	Logs  []LogInfo
 	Addrs []ma.Multiaddr
+	// Updated is the last time a record was added to the thread by the host.
+	// It's zero if no record was added, or the host doesn't track it.
+	Updated time.Time
 }
 
 // GetFirstPrivKeyLog returns the first log found with a private key.
@@ -31,6 +31,32 @@ func TestDecode(t *testing.T) {
 	t.Logf("Decoded ID: %s", j.String())
 }
 
+func TestNewIDFromSeed(t *testing.T) {
+	i := NewIDFromSeed(Raw, []byte("staging/app"))
+	if err := i.Validate(); err != nil {
+		t.Fatalf("invalid ID %s: %s", i.String(), err)
+	}
+	if j := NewIDFromSeed(Raw, []byte("staging/app")); i != j {
+		t.Errorf("id %v not equal to id %v", i.String(), j.String())
+	}
+	j, err := Decode(i.String())
+	if err != nil {
+		t.Fatalf("failed to decode ID %s: %s", i.String(), err)
+	}
+	if i != j {
+		t.Errorf("id %v not equal to id %v", i.String(), j.String())
+	}
+	if j.Variant() != Raw {
+		t.Errorf("got wrong variant from %s: %d", j.String(), j.Variant())
+	}
+	if k := NewIDFromSeed(Raw, []byte("prod/app")); i == k {
+		t.Errorf("ids of different seeds are equal: %v", i.String())
+	}
+	if k := NewIDFromSeed(AccessControlled, []byte("staging/app")); i == k {
+		t.Errorf("ids of different variants are equal: %v", i.String())
+	}
+}
+
 func TestExtractEncoding(t *testing.T) {
 	i := NewIDV1(Raw, 16)
 
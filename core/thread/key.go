@@ -2,6 +2,7 @@ package thread
 
 import (
 	"fmt"
+	"sort"
 
 	mbase "github.com/multiformats/go-multibase"
 	sym "github.com/textileio/crypto/symmetric"
@@ -15,9 +16,12 @@ var (
 // Key is a thread encryption key with two components.
 // Service key is used to encrypt outer log record linkages.
 // Read key is used to encrypt inner record events.
+// A key may also hold named read keys, which encrypt the events of records created with them
+// instead of the read key, so that their holders can be given access to a subset of a thread.
 type Key struct {
-	sk *sym.Key
-	rk *sym.Key
+	sk    *sym.Key
+	rk    *sym.Key
+	named map[string]*sym.Key
 }
 
 // NewKey wraps service and read keys.
@@ -45,7 +49,7 @@ func NewRandomServiceKey() Key {
 
 // KeyFromBytes returns a key by wrapping k.
 func KeyFromBytes(b []byte) (k Key, err error) {
-	if len(b) != sym.KeyBytes && len(b) != sym.KeyBytes*2 {
+	if len(b) != sym.KeyBytes && len(b) < sym.KeyBytes*2 {
 		return k, ErrInvalidKey
 	}
 	sk, err := sym.FromBytes(b[:sym.KeyBytes])
@@ -53,13 +57,40 @@ func KeyFromBytes(b []byte) (k Key, err error) {
 		return k, err
 	}
 	var rk *sym.Key
-	if len(b) == sym.KeyBytes*2 {
-		rk, err = sym.FromBytes(b[sym.KeyBytes:])
+	if len(b) >= sym.KeyBytes*2 && !isZero(b[sym.KeyBytes:sym.KeyBytes*2]) {
+		rk, err = sym.FromBytes(b[sym.KeyBytes : sym.KeyBytes*2])
 		if err != nil {
 			return k, err
 		}
 	}
-	return Key{sk: sk, rk: rk}, nil
+	k = Key{sk: sk, rk: rk}
+	if len(b) <= sym.KeyBytes*2 {
+		return k, nil
+	}
+	// Named read keys follow as a name length byte, the name, and the key
+	for rest := b[sym.KeyBytes*2:]; len(rest) > 0; {
+		l := int(rest[0])
+		if l == 0 || len(rest) < 1+l+sym.KeyBytes {
+			return Key{}, ErrInvalidKey
+		}
+		nk, err := sym.FromBytes(rest[1+l : 1+l+sym.KeyBytes])
+		if err != nil {
+			return Key{}, err
+		}
+		k = k.WithNamedRead(string(rest[1:1+l]), nk)
+		rest = rest[1+l+sym.KeyBytes:]
+	}
+	return k, nil
+}
+
+// isZero returns whether b only holds zeros.
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
 }
 
 // KeyFromString returns a key by decoding a base32-encoded string.
@@ -81,6 +112,35 @@ func (k Key) Read() *sym.Key {
 	return k.rk
 }
 
+// WithNamedRead returns a copy of the key holding rk as the read key named name,
+// replacing the key of that name, if any. Names must be 1 to 255 bytes long.
+func (k Key) WithNamedRead(name string, rk *sym.Key) Key {
+	if len(name) == 0 || len(name) > 255 {
+		panic("read key name must be 1 to 255 bytes long")
+	}
+	named := make(map[string]*sym.Key, len(k.named)+1)
+	for n, nk := range k.named {
+		named[n] = nk
+	}
+	named[name] = rk
+	k.named = named
+	return k
+}
+
+// NamedRead returns the read key named name, or nil if the key doesn't hold it.
+func (k Key) NamedRead(name string) *sym.Key {
+	return k.named[name]
+}
+
+// NamedReads returns the named read keys by name.
+func (k Key) NamedReads() map[string]*sym.Key {
+	named := make(map[string]*sym.Key, len(k.named))
+	for n, nk := range k.named {
+		named[n] = nk
+	}
+	return named
+}
+
 // Defined returns whether or not key has any defined components.
 // Since it's not possible to have a read key w/o a service key,
 // we just need to check service key.
@@ -93,14 +153,38 @@ func (k Key) CanRead() bool {
 	return k.rk != nil
 }
 
+// CanReadAny returns whether or not the read key or any named read key is available.
+func (k Key) CanReadAny() bool {
+	return k.rk != nil || len(k.named) > 0
+}
+
 // MarshalBinary implements BinaryMarshaler.
 func (k Key) MarshalBinary() ([]byte, error) {
 	return k.Bytes(), nil
 }
 
 // Bytes returns raw key bytes.
+// Named read keys follow the service and read keys, with zeros in place of a missing read key.
 func (k Key) Bytes() []byte {
-	if k.rk != nil {
+	if k.sk != nil && len(k.named) > 0 {
+		b := append([]byte{}, k.sk.Bytes()...)
+		if k.rk != nil {
+			b = append(b, k.rk.Bytes()...)
+		} else {
+			b = append(b, make([]byte, sym.KeyBytes)...)
+		}
+		names := make([]string, 0, len(k.named))
+		for n := range k.named {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			b = append(b, byte(len(n)))
+			b = append(b, n...)
+			b = append(b, k.named[n].Bytes()...)
+		}
+		return b
+	} else if k.rk != nil {
 		return append(k.sk.Bytes(), k.rk.Bytes()...)
 	} else if k.sk != nil {
 		return k.sk.Bytes()
@@ -3,6 +3,8 @@ package thread
 import (
 	"bytes"
 	"testing"
+
+	sym "github.com/textileio/crypto/symmetric"
 )
 
 func TestNewRandomKey(t *testing.T) {
@@ -54,6 +56,40 @@ func TestKey_FromBytes(t *testing.T) {
 			t.Fatal("read key should be nil")
 		}
 	})
+	t.Run("named", func(t *testing.T) {
+		a, b := sym.New(), sym.New()
+		k1 := NewRandomKey().WithNamedRead("a", a).WithNamedRead("b", b)
+		k2, err := KeyFromBytes(k1.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(k2.rk.Bytes(), k1.rk.Bytes()) {
+			t.Fatal("read keys are not equal")
+		}
+		if !bytes.Equal(k2.NamedRead("a").Bytes(), a.Bytes()) || !bytes.Equal(k2.NamedRead("b").Bytes(), b.Bytes()) {
+			t.Fatal("named read keys are not equal")
+		}
+	})
+	t.Run("named only", func(t *testing.T) {
+		a := sym.New()
+		k1 := NewRandomServiceKey().WithNamedRead("a", a)
+		k2, err := KeyFromBytes(k1.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k2.rk != nil {
+			t.Fatal("read key should be nil")
+		}
+		if !k2.CanReadAny() || k2.CanRead() {
+			t.Fatal("only a named read key should be available")
+		}
+		if !bytes.Equal(k2.NamedRead("a").Bytes(), a.Bytes()) {
+			t.Fatal("named read keys are not equal")
+		}
+		if k2.NamedRead("b") != nil {
+			t.Fatal("unknown named read key should be nil")
+		}
+	})
 }
 
 func TestKey_FromString(t *testing.T) {
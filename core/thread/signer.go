@@ -0,0 +1,35 @@
+package thread
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+)
+
+// Signer signs the records of a log with a key that may be held outside of the process,
+// e.g., by an HSM or a cloud KMS, so that the private key of the log is never in memory.
+type Signer interface {
+	// Sign returns the signature of msg by the log key. Signing may block on a remote
+	// device, in which case it should return once ctx is done.
+	Sign(ctx context.Context, msg []byte) ([]byte, error)
+	// PublicKey returns the public key of the log.
+	PublicKey() crypto.PubKey
+}
+
+// PrivKeySigner is a Signer signing with an in-process private key.
+type PrivKeySigner struct {
+	crypto.PrivKey
+}
+
+// NewPrivKeySigner returns a Signer signing with key.
+func NewPrivKeySigner(key crypto.PrivKey) Signer {
+	return &PrivKeySigner{PrivKey: key}
+}
+
+func (s *PrivKeySigner) Sign(_ context.Context, msg []byte) ([]byte, error) {
+	return s.PrivKey.Sign(msg)
+}
+
+func (s *PrivKeySigner) PublicKey() crypto.PubKey {
+	return s.PrivKey.GetPublic()
+}
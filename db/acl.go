@@ -0,0 +1,150 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrPermissionDenied indicates an identity lacks the role required by an operation.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Role is the access level of an identity in a collection.
+// Each role includes the permissions of the roles below it.
+type Role int
+
+const (
+	// RoleNone grants no access.
+	RoleNone Role = iota
+	// RoleReader may read instances.
+	RoleReader
+	// RoleWriter may also create, save, and delete instances.
+	RoleWriter
+	// RoleAdmin may also update and delete the collection.
+	RoleAdmin
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleNone:
+		return "none"
+	case RoleReader:
+		return "reader"
+	case RoleWriter:
+		return "writer"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return fmt.Sprintf("role(%d)", int(r))
+	}
+}
+
+// ACL is a policy that decides the role of identities in collections.
+type ACL interface {
+	// Role returns the role of identity in collection.
+	// identity is nil for operations without a token.
+	Role(collection string, identity thread.PubKey) (Role, error)
+}
+
+// PermissionError is returned when an identity lacks the role required by an operation.
+type PermissionError struct {
+	Collection string
+	Identity   thread.PubKey
+	Role       Role
+}
+
+func (e *PermissionError) Error() string {
+	identity := "anonymous"
+	if e.Identity != nil {
+		identity = e.Identity.String()
+	}
+	return fmt.Sprintf("%v: %s requires role %s in collection %s", ErrPermissionDenied, identity, e.Role, e.Collection)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return ErrPermissionDenied
+}
+
+// RoleACL is an ACL holding the roles granted to identities in each collection.
+type RoleACL struct {
+	defaultRole Role
+	roles       map[string]map[string]Role
+	lk          sync.RWMutex
+}
+
+var _ ACL = (*RoleACL)(nil)
+
+// NewRoleACL returns an ACL which gives defaultRole to identities without a granted role,
+// including operations without a token.
+func NewRoleACL(defaultRole Role) *RoleACL {
+	return &RoleACL{
+		defaultRole: defaultRole,
+		roles:       make(map[string]map[string]Role),
+	}
+}
+
+// Grant gives role to identity in collection, replacing its previous role.
+func (a *RoleACL) Grant(collection string, identity thread.PubKey, role Role) {
+	a.lk.Lock()
+	defer a.lk.Unlock()
+	roles, ok := a.roles[collection]
+	if !ok {
+		roles = make(map[string]Role)
+		a.roles[collection] = roles
+	}
+	roles[identity.String()] = role
+}
+
+// Revoke removes the role granted to identity in collection.
+func (a *RoleACL) Revoke(collection string, identity thread.PubKey) {
+	a.lk.Lock()
+	defer a.lk.Unlock()
+	if roles, ok := a.roles[collection]; ok {
+		delete(roles, identity.String())
+		if len(roles) == 0 {
+			delete(a.roles, collection)
+		}
+	}
+}
+
+func (a *RoleACL) Role(collection string, identity thread.PubKey) (Role, error) {
+	if identity == nil {
+		return a.defaultRole, nil
+	}
+	a.lk.RLock()
+	defer a.lk.RUnlock()
+	if role, ok := a.roles[collection][identity.String()]; ok {
+		return role, nil
+	}
+	return a.defaultRole, nil
+}
+
+// checkRole returns a PermissionError if identity lacks role in collection.
+// Everything is allowed if the db has no ACL.
+func (d *DB) checkRole(collection string, identity thread.PubKey, role Role) error {
+	if d.acl == nil {
+		return nil
+	}
+	r, err := d.acl.Role(collection, identity)
+	if err != nil {
+		return err
+	}
+	if r < role {
+		return &PermissionError{Collection: collection, Identity: identity, Role: role}
+	}
+	return nil
+}
+
+// checkTokenRole is like checkRole for the identity of token, which must have been issued by the host.
+func (d *DB) checkTokenRole(collection string, token thread.Token, role Role) error {
+	if d.acl == nil {
+		return nil
+	}
+	identity, err := d.connector.Net.Validate(d.connector.ThreadID(), token, role == RoleReader)
+	if err != nil {
+		return err
+	}
+	return d.checkRole(collection, identity, role)
+}
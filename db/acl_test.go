@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestACL(t *testing.T) {
+	t.Parallel()
+	acl := NewRoleACL(RoleNone)
+	db, clean := createTestDB(t, WithNewACL(acl))
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Dog",
+		Schema: util.SchemaFromInstance(&Dog{}, false),
+	})
+	checkErr(t, err)
+
+	writer, writerTok := createACLIdentity(t, db)
+	reader, readerTok := createACLIdentity(t, db)
+	acl.Grant("Dog", writer, RoleWriter)
+	acl.Grant("Dog", reader, RoleReader)
+
+	dog := Dog{Name: "Fido", Comments: []Comment{}}
+	id, err := c.Create(util.JSONFromInstance(dog), WithTxnToken(writerTok))
+	checkErr(t, err)
+	dog.ID = id
+
+	t.Run("ReaderCannotWrite", func(t *testing.T) {
+		_, err := c.Create(util.JSONFromInstance(Dog{Name: "Clyde", Comments: []Comment{}}), WithTxnToken(readerTok))
+		checkPermissionError(t, err, reader, RoleWriter)
+		dog.Name = "Bob"
+		err = c.Save(util.JSONFromInstance(dog), WithTxnToken(readerTok))
+		checkPermissionError(t, err, reader, RoleWriter)
+		err = c.Delete(dog.ID, WithTxnToken(readerTok))
+		checkPermissionError(t, err, reader, RoleWriter)
+
+		var found []*Dog
+		res, err := c.Find(&Query{}, WithTxnToken(readerTok))
+		checkErr(t, err)
+		for _, r := range res {
+			d := &Dog{}
+			util.InstanceFromJSON(r, d)
+			found = append(found, d)
+		}
+		if len(found) != 1 || found[0].Name != "Fido" {
+			t.Fatalf("expected only the writer's instance, got %v", found)
+		}
+	})
+
+	t.Run("WriterCanWrite", func(t *testing.T) {
+		dog.Name = "Bob"
+		err := c.Save(util.JSONFromInstance(dog), WithTxnToken(writerTok))
+		checkErr(t, err)
+		err = c.Delete(dog.ID, WithTxnToken(writerTok))
+		checkErr(t, err)
+	})
+
+	t.Run("AnonymousCannotRead", func(t *testing.T) {
+		_, err := c.Find(&Query{})
+		checkPermissionError(t, err, nil, RoleReader)
+	})
+
+	t.Run("WriterCannotAdminister", func(t *testing.T) {
+		err := db.DeleteCollection("Dog", WithToken(writerTok))
+		checkPermissionError(t, err, writer, RoleAdmin)
+		acl.Grant("Dog", writer, RoleAdmin)
+		err = db.DeleteCollection("Dog", WithToken(writerTok))
+		checkErr(t, err)
+	})
+}
+
+func createACLIdentity(t *testing.T, db *DB) (thread.PubKey, thread.Token) {
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	checkErr(t, err)
+	identity := thread.NewLibp2pIdentity(sk)
+	tok, err := db.connector.Net.GetToken(context.Background(), identity)
+	checkErr(t, err)
+	return identity.GetPublic(), tok
+}
+
+func checkPermissionError(t *testing.T, err error, identity thread.PubKey, role Role) {
+	t.Helper()
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+	var perr *PermissionError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected a permission error, got %v", err)
+	}
+	if perr.Role != role {
+		t.Fatalf("expected required role %s, got %s", role, perr.Role)
+	}
+	if (identity == nil) != (perr.Identity == nil) || (identity != nil && !identity.Equals(perr.Identity)) {
+		t.Fatalf("expected identity %v, got %v", identity, perr.Identity)
+	}
+}
@@ -257,3 +257,58 @@ func BenchmarkIndexFind(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkNoIndexFindAnd(b *testing.B) {
+	benchmarkFindAnd(b, nil)
+}
+
+func BenchmarkCompoundIndexFindAnd(b *testing.B) {
+	benchmarkFindAnd(b, []Index{{Paths: []string{"Name", "Age"}}})
+}
+
+func BenchmarkCompoundIndexFindPrefix(b *testing.B) {
+	benchmarkFindAnd(b, []Index{{Paths: []string{"Name", "Age", "_id"}}})
+}
+
+// benchmarkFindAnd finds instances by an And chain on Name and Age, which is answered
+// by scanning the collection unless a compound index covers a prefix of the chain.
+func benchmarkFindAnd(b *testing.B, indexes []Index) {
+	db, clean := createBenchDB(b)
+	defer clean()
+	collection, err := db.NewCollection(CollectionConfig{
+		Name:    "Dog",
+		Schema:  util.SchemaFromSchemaString(testBenchSchema),
+		Indexes: indexes,
+	})
+	checkBenchErr(b, err)
+
+	for j := 0; j < 10; j++ {
+		for i := 0; i < nameSize; i++ {
+			var benchItem = []byte(`{"_id": "", "Name": "Name", "Age": 7}`)
+			newItem, err := sjson.SetBytes(benchItem, "Name", fmt.Sprintf("Name%d", j))
+			if err != nil {
+				b.Fatalf("Error modifying instance: %s", err)
+			}
+			newItem, err = sjson.SetBytes(newItem, "Age", i%10)
+			if err != nil {
+				b.Fatalf("Error modifying instance: %s", err)
+			}
+			_, err = collection.Create(newItem)
+			if err != nil {
+				b.Fatalf("Error creating instance: %s", err)
+			}
+		}
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		result, err := collection.Find(Where("Name").Eq("Name0").And("Age").Eq(7.0))
+		if err != nil {
+			b.Fatalf("Error finding data: %s", err)
+		}
+		if len(result) != nameSize/10 {
+			b.Fatalf("Unexpected length %d, should be %d", len(result), nameSize/10)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package db
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/replacer"
+)
+
+// ReplaceEventCodec is the name of the built-in codec whose events hold whole instances.
+// See replacer.New.
+const ReplaceEventCodec = "replace"
+
+// ErrEventCodecNotFound indicates a collection or event uses a codec which isn't registered.
+var ErrEventCodecNotFound = errors.New("event codec not found")
+
+func init() {
+	cbornode.RegisterCborType(codecRecord{})
+	cbornode.RegisterCborType(codecBody{})
+}
+
+// codecRecord is the body of records holding events of codecs other than the db's EventCodec.
+type codecRecord struct {
+	Codecs []codecBody
+}
+
+// codecBody holds the body created by a codec for a run of actions.
+type codecBody struct {
+	Codec string
+	Body  []byte
+}
+
+// codecEvent is an event with the name of the codec which created it.
+type codecEvent struct {
+	core.Event
+	codec string
+}
+
+// gobCodecEvent is the gob encoding of a codecEvent. Event is encoded with its concrete
+// type, so codecs must register the types of their events with gob.Register.
+type gobCodecEvent struct {
+	Codec string
+	Event core.Event
+}
+
+// GobEncode encodes the event with the name of its codec, for the dispatcher's event store.
+func (e codecEvent) GobEncode() ([]byte, error) {
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(gobCodecEvent{Codec: e.codec, Event: e.Event}); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// GobDecode decodes an event encoded by GobEncode, so it's reduced by the codec which created it.
+func (e *codecEvent) GobDecode(data []byte) error {
+	var ge gobCodecEvent
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ge); err != nil {
+		return err
+	}
+	if ge.Event == nil {
+		return fmt.Errorf("decoding event of codec %s: missing event", ge.Codec)
+	}
+	e.Event, e.codec = ge.Event, ge.Codec
+	return nil
+}
+
+// eventCodecs is the EventCodec of a db, which encodes the actions of each collection
+// with the codec named by its config. Records only holding events of the db's EventCodec
+// keep its encoding, so they can be read by peers which don't know other codecs.
+// Otherwise, records hold the body created by each codec with the codec's name.
+type eventCodecs struct {
+	db *DB
+}
+
+var _ core.EventCodec = (*eventCodecs)(nil)
+
+// newEventCodecs returns the codecs of a db: def, named by the empty name,
+// the built-in codecs, and named, which may replace the built-in codecs.
+func newEventCodecs(def core.EventCodec, named map[string]core.EventCodec) map[string]core.EventCodec {
+	codecs := map[string]core.EventCodec{
+		"":                def,
+		ReplaceEventCodec: replacer.New(),
+	}
+	for name, c := range named {
+		if name != "" {
+			codecs[name] = c
+		}
+	}
+	return codecs
+}
+
+func (c *eventCodecs) codec(name string) (core.EventCodec, error) {
+	codec, ok := c.db.codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEventCodecNotFound, name)
+	}
+	return codec, nil
+}
+
+func (c *eventCodecs) Create(actions []core.Action) ([]core.Event, format.Node, error) {
+	names := make([]string, len(actions))
+	tagged := false
+	for i, a := range actions {
+		if col := c.db.GetCollection(a.CollectionName); col != nil {
+			names[i] = col.eventCodec
+		}
+		tagged = tagged || names[i] != ""
+	}
+	if !tagged {
+		return c.db.codecs[""].Create(actions)
+	}
+
+	var events []core.Event
+	rec := codecRecord{}
+	for len(actions) > 0 {
+		// Encode runs of actions of the same codec together.
+		n := 1
+		for n < len(actions) && names[n] == names[0] {
+			n++
+		}
+		codec, err := c.codec(names[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		evs, node, err := codec.Create(actions[:n])
+		if err != nil {
+			return nil, nil, err
+		}
+		if node != nil {
+			for _, e := range evs {
+				events = append(events, codecEvent{Event: e, codec: names[0]})
+			}
+			rec.Codecs = append(rec.Codecs, codecBody{Codec: names[0], Body: node.RawData()})
+		}
+		actions, names = actions[n:], names[n:]
+	}
+	if len(rec.Codecs) == 0 {
+		return nil, nil, nil
+	}
+	node, err := cbornode.WrapObject(rec, multihash.SHA2_256, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, node, nil
+}
+
+func (c *eventCodecs) Reduce(
+	events []core.Event,
+	store ds.TxnDatastore,
+	baseKey ds.Key,
+	indexFunc core.IndexFunc,
+	conflictFunc core.ConflictFunc,
+) ([]core.ReduceAction, error) {
+	var actions []core.ReduceAction
+	for len(events) > 0 {
+		// Reduce runs of events of the same codec together.
+		name := eventCodecName(events[0])
+		n := 1
+		for n < len(events) && eventCodecName(events[n]) == name {
+			n++
+		}
+		codec, err := c.codec(name)
+		if err != nil {
+			return nil, err
+		}
+		run := make([]core.Event, n)
+		for i, e := range events[:n] {
+			if ce, ok := e.(codecEvent); ok {
+				e = ce.Event
+			}
+			run[i] = e
+		}
+		ca, err := codec.Reduce(run, store, baseKey, indexFunc, conflictFunc)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, ca...)
+		events = events[n:]
+	}
+	return actions, nil
+}
+
+func (c *eventCodecs) EventsFromBytes(data []byte) ([]core.Event, error) {
+	rec := codecRecord{}
+	if err := cbornode.DecodeInto(data, &rec); err != nil || len(rec.Codecs) == 0 {
+		// The record was created by the db's EventCodec.
+		return c.db.codecs[""].EventsFromBytes(data)
+	}
+	var events []core.Event
+	for _, b := range rec.Codecs {
+		codec, err := c.codec(b.Codec)
+		if err != nil {
+			return nil, err
+		}
+		evs, err := codec.EventsFromBytes(b.Body)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range evs {
+			events = append(events, codecEvent{Event: e, codec: b.Codec})
+		}
+	}
+	return events, nil
+}
+
+// eventCodecName returns the name of the codec which created e.
+func eventCodecName(e core.Event) string {
+	if ce, ok := e.(codecEvent); ok {
+		return ce.codec
+	}
+	return ""
+}
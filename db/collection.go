@@ -18,7 +18,9 @@ import (
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
+	kt "github.com/textileio/go-threads/db/keytransform"
 	"github.com/xeipuuv/gojsonschema"
 )
 
@@ -37,19 +39,67 @@ var (
 	// ErrInvalidSchemaInstance indicates the current operation is from an
 	// instance that doesn't satisfy the collection schema.
 	ErrInvalidSchemaInstance = errors.New("instance doesn't correspond to schema")
-
-	errMissingInstanceID           = errors.New("invalid instance: missing _id attribute")
-	errAlreadyDiscardedCommitedTxn = errors.New("can't commit discarded/committed txn")
-	errCantCreateExistingInstance  = errors.New("can't create already existing instance")
+	// ErrTxnDiscarded indicates a transaction was used after being discarded.
+	ErrTxnDiscarded = errors.New("transaction already discarded")
+	// ErrTxnCommitted indicates a transaction was used after being committed.
+	ErrTxnCommitted = errors.New("transaction already committed")
+	// ErrInstanceExists indicates an instance was created with the ID of an existing instance.
+	ErrInstanceExists = errors.New("can't create already existing instance")
+	// ErrInstanceIDMismatch indicates an instance holds an _id other than the ID it's written with.
+	ErrInstanceIDMismatch = errors.New("instance _id doesn't match id")
+
+	errMissingInstanceID = errors.New("invalid instance: missing _id attribute")
+	errCommitBatchedTxn  = errors.New("can't commit txn of a db txn, commit the db txn instead")
 
 	baseKey = dsPrefix.ChildString("collection")
 
 	vmTimeout = time.Second
 )
 
+// InstanceError is an error caused by one of the instances of a multi-instance write.
+// The whole write is rejected.
+type InstanceError struct {
+	// Index is the position of the offending instance in the write.
+	Index int
+	Err   error
+}
+
+func (e *InstanceError) Error() string {
+	return fmt.Sprintf("instance %d: %v", e.Index, e.Err)
+}
+
+func (e *InstanceError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError holds the errors of all the invalid instances of a validation.
+type ValidationError struct {
+	Errs []*InstanceError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid instances: %s", len(e.Errs), strings.Join(msgs, "; "))
+}
+
+// instanceError wraps err with the index of the offending instance
+// when it belongs to a write of n > 1 instances.
+func instanceError(i, n int, err error) error {
+	if n == 1 {
+		return err
+	}
+	return &InstanceError{Index: i, Err: err}
+}
+
 const (
 	writeValidatorFn = "_validate"
 	readFilterFn     = "_filter"
+
+	// dynamicSchema is the schema of dynamic collections, which accepts any object with an ID.
+	dynamicSchema = `{"type":"object","properties":{"_id":{"type":"string"}},"required":["_id"]}`
 )
 
 // Collection is a group of instances sharing a schema.
@@ -57,6 +107,7 @@ const (
 type Collection struct {
 	name              string
 	schemaLoader      gojsonschema.JSONLoader
+	schema            *gojsonschema.Schema
 	db                *DB
 	indexes           map[string]Index
 	vm                *goja.Runtime
@@ -64,6 +115,29 @@ type Collection struct {
 	writeValidator    goja.Callable
 	rawReadFilter     []byte
 	readFilter        goja.Callable
+	reportConflicts   bool
+	datastoreName     string
+	store             kt.TxnDatastoreExtended
+	// indexKey is the key of index tokens, which is set when values are encrypted.
+	indexKey SearchKey
+	computed *computedFields
+	// maxInstances is the instance limit, or zero if the collection isn't limited.
+	maxInstances int
+	// eventCodec is the name of the codec of the collection's events, or empty for the db's EventCodec.
+	eventCodec string
+	// expiry is when instances expire, or nil if they don't.
+	expiry *expiry
+	// versionField is the field holding instance versions, or empty if they aren't versioned.
+	versionField string
+	// trash is how instances are soft-deleted, or nil if they can't be.
+	trash *trash
+	// ignoreFormats is set if format keywords of the schema aren't asserted.
+	ignoreFormats bool
+	// dynamic is set if the collection was created without a schema.
+	dynamic bool
+	// readKey is the name of the thread read key encrypting records of the collection,
+	// or empty if it's the thread read key.
+	readKey string
 	sync.Mutex
 }
 
@@ -72,17 +146,60 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 	if config.Name != "" && !nameRx.MatchString(config.Name) {
 		return nil, ErrInvalidName
 	}
-	idType, err := getSchemaTypeAtPath(config.Schema, idFieldName)
-	if err != nil {
-		if errors.Is(err, ErrInvalidCollectionSchemaPath) {
+	dynamic := config.Schema == nil
+	if !dynamic {
+		idType, err := getSchemaTypeAtPath(config.Schema, idFieldName)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCollectionSchemaPath) {
+				return nil, ErrInvalidCollectionSchema
+			}
+			return nil, err
+		}
+		if idType.Type != "string" {
 			return nil, ErrInvalidCollectionSchema
 		}
+	}
+	if _, ok := d.codecs[config.EventCodec]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEventCodecNotFound, config.EventCodec)
+	}
+	store := d.datastore
+	if config.Datastore != "" {
+		var ok bool
+		if store, ok = d.datastores[config.Datastore]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrDatastoreNotFound, config.Datastore)
+		}
+	}
+	var indexKey SearchKey
+	if config.EncryptValues {
+		if d.valueKey == nil {
+			return nil, ErrValueKeyRequired
+		}
+		store = newEncryptedStore(store, d.valueKey, baseKey.ChildString(config.Name))
+		indexKey = newIndexKey(d.valueKey)
+	}
+	computed, err := newComputedFields(config.Computed, config.Indexes)
+	if err != nil {
+		return nil, err
+	}
+	ex, err := newExpiry(config.TTLField, config.TTL)
+	if err != nil {
 		return nil, err
 	}
-	if idType.Type != "string" {
-		return nil, ErrInvalidCollectionSchema
+	if err := validVersionField(config.VersionField); err != nil {
+		return nil, err
+	}
+	tr, err := newTrash(config.TrashField, config.TrashRetention)
+	if err != nil {
+		return nil, err
+	}
+	sb := []byte(dynamicSchema)
+	if !dynamic {
+		sb, err = json.Marshal(config.Schema)
+		if err != nil {
+			return nil, err
+		}
 	}
-	sb, err := json.Marshal(config.Schema)
+	schema, err := compileSchema(sb, d.schemaDocs, config.IgnoreFormats)
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +209,25 @@ func newCollection(d *DB, config CollectionConfig) (*Collection, error) {
 	c := &Collection{
 		name:              config.Name,
 		schemaLoader:      gojsonschema.NewBytesLoader(sb),
+		schema:            schema,
 		db:                d,
 		indexes:           make(map[string]Index),
 		vm:                vm,
 		rawWriteValidator: wv,
 		rawReadFilter:     rf,
+		reportConflicts:   config.ReportConflicts,
+		datastoreName:     config.Datastore,
+		store:             store,
+		indexKey:          indexKey,
+		computed:          computed,
+		maxInstances:      config.MaxInstances,
+		eventCodec:        config.EventCodec,
+		expiry:            ex,
+		versionField:      config.VersionField,
+		trash:             tr,
+		ignoreFormats:     config.IgnoreFormats,
+		dynamic:           dynamic,
+		readKey:           config.ReadKey,
 	}
 	wvObj, err := compileJSFunc(wv, writeValidatorFn, "writer", "event", "instance")
 	if err != nil {
@@ -146,24 +277,100 @@ func (c *Collection) GetReadFilter() []byte {
 	return c.rawReadFilter
 }
 
+// GetEncryptValues returns whether stored instances of the collection are encrypted.
+func (c *Collection) GetEncryptValues() bool {
+	return c.indexKey != nil
+}
+
+// GetMaxInstances returns the instance limit of the collection, or zero if it isn't limited.
+func (c *Collection) GetMaxInstances() int {
+	return c.maxInstances
+}
+
+// GetTTL returns the TTL field and duration of the collection. Both are empty if instances don't expire.
+func (c *Collection) GetTTL() (field string, ttl time.Duration) {
+	if c.expiry == nil {
+		return "", 0
+	}
+	return c.expiry.Field, c.expiry.TTL
+}
+
+// GetVersionField returns the field holding instance versions, or an empty string if instances
+// aren't versioned.
+func (c *Collection) GetVersionField() string {
+	return c.versionField
+}
+
+// GetTrash returns the trash field and retention of the collection. Both are empty if instances
+// can't be soft-deleted.
+func (c *Collection) GetTrash() (field string, retention time.Duration) {
+	if c.trash == nil {
+		return "", 0
+	}
+	return c.trash.Field, c.trash.Retention
+}
+
+// IsDynamic returns whether the collection is dynamic, i.e., was created without a schema.
+func (c *Collection) IsDynamic() bool {
+	return c.dynamic
+}
+
+// GetIgnoreFormats returns whether format keywords of the collection schema are ignored.
+func (c *Collection) GetIgnoreFormats() bool {
+	return c.ignoreFormats
+}
+
+// GetReportConflicts returns whether conflicts are reported for the collection.
+func (c *Collection) GetReportConflicts() bool {
+	return c.reportConflicts
+}
+
+// GetEventCodec returns the name of the codec of the collection's events.
+// An empty name means the db's EventCodec.
+func (c *Collection) GetEventCodec() string {
+	return c.eventCodec
+}
+
+// GetDatastore returns the name of the datastore the collection is bound to.
+// An empty name means the db's datastore.
+func (c *Collection) GetDatastore() string {
+	return c.datastoreName
+}
+
+// GetReadKey returns the name of the thread read key encrypting the records of the collection.
+// An empty name means the thread read key.
+func (c *Collection) GetReadKey() string {
+	return c.readKey
+}
+
 // ReadTxn creates an explicit readonly transaction. Any operation
 // that tries to mutate an instance of the collection will ErrReadonlyTx.
 // Provides serializable isolation gurantees.
 func (c *Collection) ReadTxn(f func(txn *Txn) error, opts ...TxnOption) error {
-	return c.db.readTxn(c, f, opts...)
+	return c.read(context.Background(), OpReadTxn, func(_ context.Context, txn *Txn) error {
+		return f(txn)
+	}, opts...)
 }
 
 // WriteTxn creates an explicit write transaction. Provides
 // serializable isolation gurantees.
 func (c *Collection) WriteTxn(f func(txn *Txn) error, opts ...TxnOption) error {
-	return c.db.writeTxn(c, f, opts...)
+	return c.write(context.Background(), OpWriteTxn, func(_ context.Context, txn *Txn) error {
+		return f(txn)
+	}, opts...)
 }
 
 // FindByID finds an instance by its ID.
 // If doesn't exists returns ErrInstanceNotFound.
 func (c *Collection) FindByID(id core.InstanceID, opts ...TxnOption) (instance []byte, err error) {
-	err = c.ReadTxn(func(txn *Txn) error {
-		instance, err = txn.FindByID(id)
+	return c.FindByIDContext(context.Background(), id, opts...)
+}
+
+// FindByIDContext finds an instance by its ID, unless ctx is done before it's read.
+// If doesn't exists returns ErrInstanceNotFound.
+func (c *Collection) FindByIDContext(ctx context.Context, id core.InstanceID, opts ...TxnOption) (instance []byte, err error) {
+	err = c.read(ctx, OpFindByID, func(ctx context.Context, txn *Txn) error {
+		instance, err = txn.FindByIDContext(ctx, id)
 		return err
 	}, opts...)
 	return
@@ -171,7 +378,7 @@ func (c *Collection) FindByID(id core.InstanceID, opts ...TxnOption) (instance [
 
 // Create creates an instance in the collection.
 func (c *Collection) Create(v []byte, opts ...TxnOption) (id core.InstanceID, err error) {
-	err = c.WriteTxn(func(txn *Txn) error {
+	err = c.write(context.Background(), OpCreate, func(_ context.Context, txn *Txn) error {
 		var ids []core.InstanceID
 		ids, err = txn.Create(v)
 		if err != nil {
@@ -186,73 +393,149 @@ func (c *Collection) Create(v []byte, opts ...TxnOption) (id core.InstanceID, er
 }
 
 // CreateMany creates multiple instances in the collection.
+// The instances are written in a single transaction, and so are sent to the network in
+// a single record. If any instance is invalid, none are created and the returned
+// *InstanceError holds the index of the offending instance.
+// Returned IDs are in the same order as vs.
 func (c *Collection) CreateMany(vs [][]byte, opts ...TxnOption) (ids []core.InstanceID, err error) {
-	err = c.WriteTxn(func(txn *Txn) error {
+	err = c.write(context.Background(), OpCreate, func(_ context.Context, txn *Txn) error {
 		ids, err = txn.Create(vs...)
 		return err
 	}, opts...)
 	return
 }
 
+// CreateWithID creates an instance in the collection with id instead of a generated ID.
+// If v holds an _id, it must be id. ErrInstanceExists is returned if an instance with id exists.
+func (c *Collection) CreateWithID(id core.InstanceID, v []byte, opts ...TxnOption) error {
+	v, err := withInstanceID(id, v)
+	if err != nil {
+		return err
+	}
+	_, err = c.Create(v, opts...)
+	return err
+}
+
+// Upsert creates an instance in the collection with id, or overwrites the instance with id
+// if it exists. If v holds an _id, it must be id.
+func (c *Collection) Upsert(id core.InstanceID, v []byte, opts ...TxnOption) error {
+	v, err := withInstanceID(id, v)
+	if err != nil {
+		return err
+	}
+	return c.write(context.Background(), OpUpsert, func(_ context.Context, txn *Txn) error {
+		exists, err := c.store.Has(baseKey.ChildString(c.name).ChildString(id.String()))
+		if err != nil {
+			return err
+		}
+		if exists {
+			return txn.Save(v)
+		}
+		_, err = txn.Create(v)
+		return err
+	}, opts...)
+}
+
 // Delete deletes an instance by its ID. It doesn't
 // fail if the ID doesn't exist.
 func (c *Collection) Delete(id core.InstanceID, opts ...TxnOption) error {
-	return c.WriteTxn(func(txn *Txn) error {
+	return c.write(context.Background(), OpDelete, func(_ context.Context, txn *Txn) error {
 		return txn.Delete(id)
 	}, opts...)
 }
 
+// SoftDelete moves an instance to the trash. See Txn.SoftDelete for details.
+func (c *Collection) SoftDelete(id core.InstanceID, opts ...TxnOption) error {
+	return c.write(context.Background(), OpSoftDelete, func(_ context.Context, txn *Txn) error {
+		return txn.SoftDelete(id)
+	}, opts...)
+}
+
+// Restore moves an instance out of the trash. See Txn.Restore for details.
+func (c *Collection) Restore(id core.InstanceID, opts ...TxnOption) error {
+	return c.write(context.Background(), OpRestore, func(_ context.Context, txn *Txn) error {
+		return txn.Restore(id)
+	}, opts...)
+}
+
 // DeleteMany deletes multiple instances by ID. It doesn't
 // fail if one of the IDs don't exist.
 func (c *Collection) DeleteMany(ids []core.InstanceID, opts ...TxnOption) error {
-	return c.WriteTxn(func(txn *Txn) error {
+	return c.write(context.Background(), OpDelete, func(_ context.Context, txn *Txn) error {
 		return txn.Delete(ids...)
 	}, opts...)
 }
 
 // Save saves changes of an instance in the collection.
 func (c *Collection) Save(v []byte, opts ...TxnOption) error {
-	return c.WriteTxn(func(txn *Txn) error {
+	return c.write(context.Background(), OpSave, func(_ context.Context, txn *Txn) error {
 		return txn.Save(v)
 	}, opts...)
 }
 
 // SaveMany saves changes of multiple instances in the collection.
+// Like CreateMany, the changes are written in a single transaction, and are all rejected
+// with an *InstanceError if any instance is invalid.
 func (c *Collection) SaveMany(vs [][]byte, opts ...TxnOption) error {
-	return c.WriteTxn(func(txn *Txn) error {
+	return c.write(context.Background(), OpSave, func(_ context.Context, txn *Txn) error {
 		return txn.Save(vs...)
 	}, opts...)
 }
 
 // Verify verifies changes of an instance in the collection.
 func (c *Collection) Verify(v []byte, opts ...TxnOption) error {
-	return c.WriteTxn(func(txn *Txn) error {
+	return c.write(context.Background(), OpVerify, func(_ context.Context, txn *Txn) error {
 		return txn.Verify(v)
 	}, opts...)
 }
 
 // VerifyMany verifies changes of multiple instances in the collection.
 func (c *Collection) VerifyMany(vs [][]byte, opts ...TxnOption) error {
-	return c.WriteTxn(func(txn *Txn) error {
+	return c.write(context.Background(), OpVerify, func(_ context.Context, txn *Txn) error {
 		return txn.Verify(vs...)
 	}, opts...)
 }
 
+// Validate validates an instance against the collection schema and unique indexes
+// without writing it.
+func (c *Collection) Validate(v []byte, opts ...TxnOption) error {
+	return c.read(context.Background(), OpValidate, func(_ context.Context, txn *Txn) error {
+		return txn.Validate(v)
+	}, opts...)
+}
+
+// ValidateMany validates multiple instances without writing them.
+// Unlike CreateMany and SaveMany, all the instances are validated, and a *ValidationError
+// holding an *InstanceError for each invalid instance is returned.
+func (c *Collection) ValidateMany(vs [][]byte, opts ...TxnOption) error {
+	return c.read(context.Background(), OpValidate, func(_ context.Context, txn *Txn) error {
+		return txn.Validate(vs...)
+	}, opts...)
+}
+
 // Has returns true if ID exists in the collection, false
 // otherwise.
 func (c *Collection) Has(id core.InstanceID, opts ...TxnOption) (exists bool, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
-		exists, err = txn.Has(id)
-		return err
-	}, opts...)
-	return
+	return c.HasManyContext(context.Background(), []core.InstanceID{id}, opts...)
+}
+
+// HasContext returns true if ID exists in the collection, false
+// otherwise, unless ctx is done before it's checked.
+func (c *Collection) HasContext(ctx context.Context, id core.InstanceID, opts ...TxnOption) (exists bool, err error) {
+	return c.HasManyContext(ctx, []core.InstanceID{id}, opts...)
 }
 
 // HasMany returns true if all IDs exist in the collection, false
 // otherwise.
 func (c *Collection) HasMany(ids []core.InstanceID, opts ...TxnOption) (exists bool, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
-		exists, err = txn.Has(ids...)
+	return c.HasManyContext(context.Background(), ids, opts...)
+}
+
+// HasManyContext returns true if all IDs exist in the collection, false
+// otherwise. It stops checking IDs when ctx is done.
+func (c *Collection) HasManyContext(ctx context.Context, ids []core.InstanceID, opts ...TxnOption) (exists bool, err error) {
+	err = c.read(ctx, OpHas, func(ctx context.Context, txn *Txn) error {
+		exists, err = txn.HasContext(ctx, ids...)
 		return err
 	}, opts...)
 	return
@@ -260,8 +543,95 @@ func (c *Collection) HasMany(ids []core.InstanceID, opts ...TxnOption) (exists b
 
 // Find executes a Query and returns the result.
 func (c *Collection) Find(q *Query, opts ...TxnOption) (instances [][]byte, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
-		instances, err = txn.Find(q)
+	return c.FindContext(context.Background(), q, opts...)
+}
+
+// FindContext executes a Query and returns the result, and stops reading
+// instances when ctx is done. See Txn.FindContext for details.
+func (c *Collection) FindContext(ctx context.Context, q *Query, opts ...TxnOption) (instances [][]byte, err error) {
+	err = c.read(ctx, OpFind, func(ctx context.Context, txn *Txn) error {
+		instances, err = txn.FindContext(ctx, q)
+		return err
+	}, opts...)
+	return
+}
+
+// Count returns the number of instances matching a Query.
+// See Txn.Count for details.
+func (c *Collection) Count(q *Query, opts ...TxnOption) (n int, err error) {
+	err = c.read(context.Background(), OpCount, func(_ context.Context, txn *Txn) error {
+		n, err = txn.Count(q)
+		return err
+	}, opts...)
+	return
+}
+
+// Explain returns how a Query would be executed, without reading instances.
+// See Txn.Explain for details.
+func (c *Collection) Explain(q *Query, opts ...TxnOption) (plan QueryPlan, err error) {
+	err = c.read(context.Background(), OpExplain, func(_ context.Context, txn *Txn) error {
+		plan, err = txn.Explain(q)
+		return err
+	}, opts...)
+	return
+}
+
+// FindStream executes a Query and streams the result.
+// See Txn.FindStream for details.
+func (c *Collection) FindStream(ctx context.Context, q *Query, opts ...TxnOption) (results <-chan Result, err error) {
+	err = c.read(ctx, OpFindStream, func(ctx context.Context, txn *Txn) error {
+		results, err = txn.FindStream(ctx, q)
+		return err
+	}, opts...)
+	return
+}
+
+// Iterate calls fn with each instance matching a Query as it's read.
+// See Txn.Iterate for details.
+func (c *Collection) Iterate(ctx context.Context, q *Query, fn func(instance []byte) error, opts ...TxnOption) error {
+	return c.read(ctx, OpIterate, func(ctx context.Context, txn *Txn) error {
+		return txn.Iterate(ctx, q, fn)
+	}, opts...)
+}
+
+// Reduce folds the instances matching a Query into a single value.
+// See Txn.Reduce for details.
+func (c *Collection) Reduce(
+	ctx context.Context,
+	q *Query,
+	init interface{},
+	fn ReduceFunc,
+	opts ...TxnOption,
+) (acc interface{}, err error) {
+	err = c.read(ctx, OpReduce, func(ctx context.Context, txn *Txn) error {
+		acc, err = txn.Reduce(ctx, q, init, fn)
+		return err
+	}, opts...)
+	return
+}
+
+// Distinct returns the distinct values of a field of the instances, in sorted order.
+// See Txn.Distinct for details.
+func (c *Collection) Distinct(path string, opts ...TxnOption) (values []interface{}, err error) {
+	err = c.read(context.Background(), OpDistinct, func(_ context.Context, txn *Txn) error {
+		values, err = txn.Distinct(path)
+		return err
+	}, opts...)
+	return
+}
+
+// TimeSeries buckets instances matching q by timeField into intervals, and computes aggs for each bucket.
+// See Txn.TimeSeries for details.
+func (c *Collection) TimeSeries(
+	timeField string,
+	interval time.Duration,
+	aggs []AggSpec,
+	q *Query,
+	align TimeAlignment,
+	opts ...TxnOption,
+) (series []TimeBucket, err error) {
+	err = c.read(context.Background(), OpTimeSeries, func(_ context.Context, txn *Txn) error {
+		series, err = txn.TimeSeries(timeField, interval, aggs, q, align)
 		return err
 	}, opts...)
 	return
@@ -284,7 +654,7 @@ func (f filter) Filter(e query.Entry) bool {
 
 // ModifiedSince returns a list of all instances that have been modified (and/or touched) since `time`.
 func (c *Collection) ModifiedSince(time int64, opts ...TxnOption) (ids []core.InstanceID, err error) {
-	_ = c.ReadTxn(func(txn *Txn) error {
+	err = c.read(context.Background(), OpModifiedSince, func(_ context.Context, txn *Txn) error {
 		ids, err = txn.ModifiedSince(time)
 		return err
 	}, opts...)
@@ -293,7 +663,7 @@ func (c *Collection) ModifiedSince(time int64, opts ...TxnOption) (ids []core.In
 
 // validInstance validates the json object against the collection schema.
 func (c *Collection) validInstance(v []byte) error {
-	r, err := gojsonschema.Validate(c.schemaLoader, gojsonschema.NewBytesLoader(v))
+	r, err := c.schema.Validate(gojsonschema.NewBytesLoader(v))
 	if err != nil {
 		return err
 	}
@@ -331,7 +701,7 @@ func (c *Collection) validWrite(identity thread.PubKey, e core.Event) error {
 		return fmt.Errorf("parsing event in validate write: %v", err)
 	}
 	var inv goja.Value
-	val, err := c.db.datastore.Get(baseKey.ChildString(c.name).ChildString(e.InstanceID().String()))
+	val, err := c.store.Get(baseKey.ChildString(c.name).ChildString(e.InstanceID().String()))
 	if err != nil && !errors.Is(err, ds.ErrNotFound) {
 		return err
 	}
@@ -365,7 +735,25 @@ func (c *Collection) validWrite(identity thread.PubKey, e core.Event) error {
 }
 
 // filterRead filters an instance against the identity and user-defined read filter function.
+// Expired instances are filtered out, even if they haven't been deleted yet, and so are
+// trashed instances.
 func (c *Collection) filterRead(identity thread.PubKey, instance []byte) ([]byte, error) {
+	return c.filterQueryRead(identity, instance, nil)
+}
+
+// filterQueryRead is like filterRead, but keeps trashed instances if q includes them.
+func (c *Collection) filterQueryRead(identity thread.PubKey, instance []byte, q *Query) ([]byte, error) {
+	if c.expiry.expired(instance, time.Now()) {
+		return nil, nil
+	}
+	if (q == nil || !q.Trashed) && c.trash.trashed(instance) {
+		return nil, nil
+	}
+	return c.runReadFilter(identity, instance)
+}
+
+// runReadFilter filters an instance against the identity and user-defined read filter function.
+func (c *Collection) runReadFilter(identity thread.PubKey, instance []byte) ([]byte, error) {
 	c.Lock()
 	defer c.Unlock()
 	if c.readFilter == nil {
@@ -405,47 +793,80 @@ type Txn struct {
 	discarded  bool
 	committed  bool
 	readonly   bool
+	// batched is set for txns of a DBTxn, whose actions are committed by the DBTxn.
+	batched bool
+	// sync is set when the txn must be durable once committed.
+	sync bool
+	// stale is set for strong reads which fell back to the local state.
+	stale bool
 
 	actions []core.Action
+	// savepoints holds the number of actions buffered when each savepoint was set.
+	savepoints []int
 }
 
 // Create creates new instances in the collection
 // If the ID value on the instance is nil or otherwise a null value (e.g., ""),
 // and ID is generated and used to store the instance.
 func (t *Txn) Create(new ...[]byte) ([]core.InstanceID, error) {
+	if len(new) > 0 {
+		if err := t.checkWrite(); err != nil {
+			return nil, err
+		}
+	}
 	results := make([]core.InstanceID, len(new))
+	pending := make(map[core.InstanceID]struct{})
+	var pendingBytes int64
+	for _, a := range t.actions {
+		if a.Type == core.Create {
+			pending[a.InstanceID] = struct{}{}
+			pendingBytes += int64(len(a.Current))
+		}
+	}
 	for i := range new {
-		if t.readonly {
-			return nil, ErrReadonlyTx
+		updated, err := t.collection.computed.strip(append([]byte(nil), new[i]...))
+		if err != nil {
+			return nil, instanceError(i, len(new), err)
 		}
 
-		updated := make([]byte, len(new[i]))
-		copy(updated, new[i])
-
 		id, err := getInstanceID(updated)
 		if err != nil && !errors.Is(err, errMissingInstanceID) {
-			return nil, err
+			return nil, instanceError(i, len(new), err)
 		}
 		if id == core.EmptyInstanceID {
 			id, updated = setNewInstanceID(updated)
 		}
 
 		if err := t.collection.validInstance(updated); err != nil {
-			return nil, err
+			return nil, instanceError(i, len(new), err)
+		}
+		if err := t.collection.validEncryptedInstance(updated); err != nil {
+			return nil, instanceError(i, len(new), err)
 		}
 
 		results[i] = id
 		key := baseKey.ChildString(t.collection.name).ChildString(id.String())
-		exists, err := t.collection.db.datastore.Has(key)
+		exists, err := t.collection.store.Has(key)
 		if err != nil {
 			return nil, err
 		}
-		if exists {
-			return nil, errCantCreateExistingInstance
+		if _, ok := pending[id]; exists || ok {
+			return nil, instanceError(i, len(new), ErrInstanceExists)
 		}
+		pending[id] = struct{}{}
 
 		// Update readonly/protected mod tag
 		_, updated = setModifiedTag(updated)
+		if t.collection.versionField != "" {
+			if updated, err = t.collection.setVersion(updated, 1); err != nil {
+				return nil, instanceError(i, len(new), err)
+			}
+		}
+
+		pendingBytes += int64(len(updated))
+		if err := t.collection.db.quota.check(t.collection, len(pending), pendingBytes); err != nil {
+			return nil, instanceError(i, len(new), err)
+		}
 
 		a := core.Action{
 			Type:           core.Create,
@@ -485,6 +906,81 @@ func (t *Txn) Verify(updated ...[]byte) error {
 	return nil
 }
 
+// Validate validates instances against the collection schema and unique indexes, as they
+// would be validated by Create or Save, but does not write them.
+// Unique values are checked against existing instances other than the instance itself,
+// and against the other validated instances. Instances without an ID are validated as new.
+func (t *Txn) Validate(instances ...[]byte) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	var errs []*InstanceError
+	seen := make(map[ds.Key]ds.Key)
+	for i := range instances {
+		if err := t.validate(instances[i], seen); err != nil {
+			errs = append(errs, &InstanceError{Index: i, Err: err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(instances) == 1 {
+		return errs[0].Err
+	}
+	return &ValidationError{Errs: errs}
+}
+
+// validate validates instance, recording its unique index entries in seen.
+func (t *Txn) validate(instance []byte, seen map[ds.Key]ds.Key) error {
+	c := t.collection
+	v, err := c.computed.strip(append([]byte(nil), instance...))
+	if err != nil {
+		return err
+	}
+	id, err := getInstanceID(v)
+	if err != nil && !errors.Is(err, errMissingInstanceID) {
+		return err
+	}
+	if id == core.EmptyInstanceID {
+		id, v = setNewInstanceID(v)
+	}
+	if err := c.validInstance(v); err != nil {
+		return err
+	}
+	if err := c.validEncryptedInstance(v); err != nil {
+		return err
+	}
+
+	key := baseKey.ChildString(c.name).ChildString(id.String())
+	for _, index := range c.indexes {
+		indexKey, unique, ok, err := c.indexEntryKey(index, v)
+		if err != nil {
+			return err
+		}
+		if !ok || !unique {
+			continue
+		}
+		if other, ok := seen[indexKey]; ok && !other.Equal(key) {
+			return ErrUniqueExists
+		}
+		seen[indexKey] = key
+		data, err := c.store.Get(indexKey)
+		if err == ds.ErrNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		keys := make(keyList, 0)
+		if err := DefaultDecode(data, &keys); err != nil {
+			return err
+		}
+		if len(keys) > 1 || !keys.in(key) {
+			return ErrUniqueExists
+		}
+	}
+	return nil
+}
+
 // Save saves an instance changes to be committed when the current transaction commits.
 func (t *Txn) Save(updated ...[]byte) error {
 	identity, err := t.token.PubKey()
@@ -501,17 +997,23 @@ func (t *Txn) Save(updated ...[]byte) error {
 }
 
 func (t *Txn) createSaveActions(identity thread.PubKey, updated ...[]byte) ([]core.Action, error) {
+	if len(updated) > 0 {
+		if err := t.checkWrite(); err != nil {
+			return nil, err
+		}
+	}
 	var actions []core.Action
 	for i := range updated {
-		if t.readonly {
-			return nil, ErrReadonlyTx
+		next, err := t.collection.computed.strip(append([]byte(nil), updated[i]...))
+		if err != nil {
+			return nil, instanceError(i, len(updated), err)
 		}
 
-		next := make([]byte, len(updated[i]))
-		copy(next, updated[i])
-
 		if err := t.collection.validInstance(next); err != nil {
-			return nil, err
+			return nil, instanceError(i, len(updated), err)
+		}
+		if err := t.collection.validEncryptedInstance(next); err != nil {
+			return nil, instanceError(i, len(updated), err)
 		}
 
 		// Update readonly/protected mod tag
@@ -521,29 +1023,40 @@ func (t *Txn) createSaveActions(identity thread.PubKey, updated ...[]byte) ([]co
 		// it has to have a valid _id ahead of time.
 		id, err := getInstanceID(next)
 		if err != nil {
-			return nil, err
+			return nil, instanceError(i, len(updated), err)
 		}
 		key := baseKey.ChildString(t.collection.name).ChildString(id.String())
-		previous, err := t.collection.db.datastore.Get(key)
+		previous, err := t.collection.store.Get(key)
+		if err != nil && err != ds.ErrNotFound {
+			return nil, err
+		}
+		if t.collection.versionField != "" {
+			// The stored version is read before the read filter, which may remove it
+			versioned, verr := t.collection.nextVersion(id, next, previous, t.actions, actions)
+			if verr != nil {
+				return nil, instanceError(i, len(updated), verr)
+			}
+			next = versioned
+		}
 		if err == ds.ErrNotFound {
 			// Default to an empty doc, downstream reducer will take care of patching, etc
 			previous = []byte("{}")
-		} else if err != nil {
-			return nil, err
 		} else {
-			// No errors, carry on
-			previous, err = t.collection.filterRead(identity, previous)
+			// No errors, carry on. Expired instances which haven't been deleted yet are saved
+			// as usual, so the save may renew them.
+			previous, err = t.collection.runReadFilter(identity, previous)
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		actions = append(actions, core.Action{
-			Type:           core.Save,
-			InstanceID:     id,
-			CollectionName: t.collection.name,
-			Previous:       previous,
-			Current:        next,
+			Type:            core.Save,
+			InstanceID:      id,
+			CollectionName:  t.collection.name,
+			Previous:        previous,
+			Current:         next,
+			DetectConflicts: t.collection.reportConflicts,
 		})
 	}
 	return actions, nil
@@ -551,12 +1064,14 @@ func (t *Txn) createSaveActions(identity thread.PubKey, updated ...[]byte) ([]co
 
 // Delete deletes instances by ID when the current transaction commits.
 func (t *Txn) Delete(ids ...core.InstanceID) error {
-	for i := range ids {
-		if t.readonly {
-			return ErrReadonlyTx
+	if len(ids) > 0 {
+		if err := t.checkWrite(); err != nil {
+			return err
 		}
+	}
+	for i := range ids {
 		key := baseKey.ChildString(t.collection.name).ChildString(ids[i].String())
-		exists, err := t.collection.db.datastore.Has(key)
+		exists, err := t.collection.store.Has(key)
 		if err != nil {
 			return err
 		}
@@ -576,8 +1091,23 @@ func (t *Txn) Delete(ids ...core.InstanceID) error {
 	return nil
 }
 
+// checkWrite returns an error if the transaction may not write instances of the collection.
+func (t *Txn) checkWrite() error {
+	if t.readonly {
+		return ErrReadonlyTx
+	}
+	return t.collection.db.checkTokenRole(t.collection.name, t.token, RoleWriter)
+}
+
 // Has returns true if all IDs exists in the collection, false otherwise.
 func (t *Txn) Has(ids ...core.InstanceID) (bool, error) {
+	return t.HasContext(context.Background(), ids...)
+}
+
+// HasContext returns true if all IDs exists in the collection, false otherwise.
+// It stops checking IDs when ctx is done.
+func (t *Txn) HasContext(ctx context.Context, ids ...core.InstanceID) (bool, error) {
+	t.collection.db.metrics.query("has")
 	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
 		return false, err
 	}
@@ -586,16 +1116,19 @@ func (t *Txn) Has(ids ...core.InstanceID) (bool, error) {
 		return false, err
 	}
 	for i := range ids {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
 		key := baseKey.ChildString(t.collection.name).ChildString(ids[i].String())
-		exists, err := t.collection.db.datastore.Has(key)
+		exists, err := t.collection.store.Has(key)
 		if err != nil {
 			return false, err
 		}
 		if exists {
-			if t.collection.readFilter == nil {
+			if t.collection.readFilter == nil && t.collection.expiry == nil && t.collection.trash == nil {
 				continue
 			}
-			bytes, err := t.collection.db.datastore.Get(key)
+			bytes, err := t.collection.store.Get(key)
 			if err != nil {
 				return false, err
 			}
@@ -615,11 +1148,21 @@ func (t *Txn) Has(ids ...core.InstanceID) (bool, error) {
 
 // FindByID gets an instance by ID in the current txn scope.
 func (t *Txn) FindByID(id core.InstanceID) ([]byte, error) {
+	return t.FindByIDContext(context.Background(), id)
+}
+
+// FindByIDContext gets an instance by ID in the current txn scope,
+// unless ctx is done before it's read.
+func (t *Txn) FindByIDContext(ctx context.Context, id core.InstanceID) ([]byte, error) {
+	t.collection.db.metrics.query("find_by_id")
 	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
 		return nil, err
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	key := baseKey.ChildString(t.collection.name).ChildString(id.String())
-	bytes, err := t.collection.db.datastore.Get(key)
+	bytes, err := t.collection.store.Get(key)
 	if errors.Is(err, ds.ErrNotFound) {
 		return nil, ErrInstanceNotFound
 	}
@@ -637,35 +1180,78 @@ func (t *Txn) FindByID(id core.InstanceID) ([]byte, error) {
 	if bytes == nil {
 		return nil, ErrInstanceNotFound
 	}
-	return bytes, err
+	return t.collection.computed.add(bytes)
 }
 
 // Commit applies all changes done in the current transaction
 // to the collection. This is a syncrhonous call so changes can
 // be assumed to be applied on function return.
+// It returns ErrTxnDiscarded if the transaction was discarded, and ErrTxnCommitted
+// if it was already committed. A transaction whose commit fails can't be committed again.
 func (t *Txn) Commit() error {
+	if t.batched {
+		return errCommitBatchedTxn
+	}
+	return t.commit(context.Background())
+}
+
+// commit creates a net record holding the events of the transaction actions,
+// and dispatches them.
+func (t *Txn) commit(ctx context.Context) error {
 	events, node, err := t.createEvents(t.actions)
 	if err != nil {
 		return err
 	}
+	t.committed = true
 	if node == nil {
 		return nil
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), createNetRecordTimeout)
+	ctx, cancel := context.WithTimeout(ctx, createNetRecordTimeout)
 	defer cancel()
-	if _, err = t.collection.db.connector.CreateNetRecord(ctx, node, t.token); err != nil {
+	rec, err := t.collection.db.connector.CreateNetRecord(ctx, node, t.token, net.WithReadKeyName(t.collection.readKey))
+	if err != nil {
 		return err
 	}
-	if err = t.collection.db.dispatcher.Dispatch(events); err != nil {
+	if err = t.collection.db.dispatchRecord(rec.Value().Cid(), recordAuthor(rec.Value()), events); err != nil {
 		return err
 	}
+	if t.sync || t.collection.db.syncWrites {
+		if err = t.collection.db.sync(); err != nil {
+			return err
+		}
+	}
 	return t.collection.db.notifyTxnEvents(node, t.token)
 }
 
+// Sync makes the transaction durable once committed, by flushing the db datastores to disk
+// before Commit returns, as with WithNewSyncWrites. Syncing reduces write throughput,
+// so it's best reserved for writes which can't be lost on a crash.
+func (t *Txn) Sync() {
+	t.sync = true
+}
+
 // Discard discards all changes done in the current transaction.
+// Since changes are only buffered until commit, nothing is written or dispatched.
+// It's a no-op if the transaction was already committed or discarded.
 func (t *Txn) Discard() {
+	if t.committed {
+		return
+	}
 	t.discarded = true
+	t.actions = nil
+	t.savepoints = nil
+}
+
+// checkOpen returns an error if the transaction was committed or discarded.
+func (t *Txn) checkOpen() error {
+	if t.discarded {
+		return ErrTxnDiscarded
+	}
+	if t.committed {
+		return ErrTxnCommitted
+	}
+	return nil
 }
 
 // RefreshCollection updates the transaction's collection reference from the master db map,
@@ -738,6 +1324,24 @@ func setNewInstanceID(t []byte) (core.InstanceID, []byte) {
 	return newID, patchedValue
 }
 
+// withInstanceID returns v with its _id set to id. It fails if v holds another _id.
+func withInstanceID(id core.InstanceID, v []byte) ([]byte, error) {
+	if id == core.EmptyInstanceID {
+		return nil, errMissingInstanceID
+	}
+	x, err := getInstanceID(v)
+	if err != nil && !errors.Is(err, errMissingInstanceID) {
+		return nil, err
+	}
+	if x == id {
+		return v, nil
+	}
+	if x != core.EmptyInstanceID {
+		return nil, fmt.Errorf("%w: %s", ErrInstanceIDMismatch, x)
+	}
+	return jsonpatch.MergePatch(v, []byte(fmt.Sprintf(`{"%s": %q}`, idFieldName, id.String())))
+}
+
 func setModifiedTag(t []byte) (newTime int64, patchedValue []byte) {
 	newTime = time.Now().UnixNano()
 	patchedValue, err := jsonpatch.MergePatch(t, []byte(fmt.Sprintf(`{"%s": %d}`, modFieldName, newTime)))
@@ -748,8 +1352,8 @@ func setModifiedTag(t []byte) (newTime int64, patchedValue []byte) {
 }
 
 func (t *Txn) createEvents(actions []core.Action) (events []core.Event, node format.Node, err error) {
-	if t.discarded || t.committed {
-		return nil, nil, errAlreadyDiscardedCommitedTxn
+	if err := t.checkOpen(); err != nil {
+		return nil, nil, err
 	}
 	events, node, err = t.collection.db.eventcodec.Create(actions)
 	if err != nil {
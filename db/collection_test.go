@@ -2,15 +2,20 @@ package db
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	logging "github.com/ipfs/go-log/v2"
+	sym "github.com/textileio/crypto/symmetric"
 	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/util"
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -304,8 +309,59 @@ func TestNewCollection(t *testing.T) {
 			t.Fatal("index path should not be valid")
 		}
 	})
+	t.Run("WithSchemaDocuments", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t, WithNewSchemaDocument("shared.json", json.RawMessage(sharedSchema)))
+		defer clean()
+		for _, name := range []string{"Person", "Company"} {
+			c, err := db.NewCollection(CollectionConfig{
+				Name:   name,
+				Schema: util.SchemaFromSchemaString(addressedSchema),
+			})
+			checkErr(t, err)
+			_, err = c.Create([]byte(`{"name": "Alice", "address": {"city": "Paris"}}`))
+			checkErr(t, err)
+			_, err = c.Create([]byte(`{"name": "Bob", "address": {"city": 42}}`))
+			if !errors.Is(err, ErrInvalidSchemaInstance) {
+				t.Fatalf("expected invalid instance, got %v", err)
+			}
+		}
+	})
+	t.Run("Fail/UnresolvedSchemaRef", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		_, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromSchemaString(addressedSchema),
+		})
+		if !errors.Is(err, ErrUnresolvedSchemaRef) {
+			t.Fatalf("expected unresolved schema ref, got %v", err)
+		}
+	})
 }
 
+const (
+	sharedSchema = `{
+		"definitions": {
+			"Address": {
+				"type": "object",
+				"properties": {"city": {"type": "string"}},
+				"required": ["city"]
+			}
+		}
+	}`
+	addressedSchema = `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {
+			"_id": {"type": "string"},
+			"name": {"type": "string"},
+			"address": {"$ref": "shared.json#/definitions/Address"}
+		}
+	}`
+)
+
 func TestUpdateCollection(t *testing.T) {
 	t.Parallel()
 	t.Run("AddFields", func(t *testing.T) {
@@ -491,6 +547,129 @@ func TestEmptySchema(t *testing.T) {
 	}
 }
 
+func TestDynamicCollection(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Doc",
+		Indexes: []Index{{Path: "kind"}, {Path: "tags", Multikey: true}},
+	})
+	checkErr(t, err)
+	if !c.IsDynamic() {
+		t.Fatal("expected collection to be dynamic")
+	}
+	note, err := c.Create([]byte(`{"kind": "note", "tags": ["a", "b"], "nested": {"x": 1}}`))
+	checkErr(t, err)
+	num, err := c.Create([]byte(`{"kind": 42}`))
+	checkErr(t, err)
+	other, err := c.Create([]byte(`{"other": true}`))
+	checkErr(t, err)
+	if _, err := c.Create([]byte(`["not", "an", "object"]`)); err == nil {
+		t.Fatal("expected non-object instance to be invalid")
+	}
+
+	res, err := c.Find(Where("kind").Eq("note").UseIndex("kind"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 instance of kind note, got %d", len(res))
+	}
+	res, err = c.Find(Where("tags").Contains("b").UseIndex("tags"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 instance tagged b, got %d", len(res))
+	}
+	res, err = c.Find(&Query{})
+	checkErr(t, err)
+	if len(res) != 3 {
+		t.Fatalf("expected 3 instances, got %d", len(res))
+	}
+
+	// Fields which are missing or hold another type don't match
+	for _, q := range []*Query{
+		Where("kind").Eq("note"),
+		Where("nested.x").Eq(float64(1)),
+		Where("tags").Contains("a"),
+		Where("kind").Eq("note").Or(Where("missing.field").Eq(true)),
+	} {
+		res, err = c.Find(q)
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance, got %d", len(res))
+		}
+		checkInstanceIDs(t, res, note)
+	}
+	res, err = c.Find(Where("kind").Ge(float64(0)))
+	checkErr(t, err)
+	checkInstanceIDs(t, res, num)
+	res, err = c.Find(Where("other").Eq(true))
+	checkErr(t, err)
+	checkInstanceIDs(t, res, other)
+	n, err := c.Count(Where("kind").Eq("note"))
+	checkErr(t, err)
+	if n != 1 {
+		t.Fatalf("expected count 1, got %d", n)
+	}
+
+	// Numbers come before strings, and missing fields come last in both directions
+	res, err = c.Find(OrderBy("kind"))
+	checkErr(t, err)
+	checkInstanceIDs(t, res, num, note, other)
+	res, err = c.Find(OrderByDesc("kind"))
+	checkErr(t, err)
+	checkInstanceIDs(t, res, num, note, other)
+	res, err = c.Find(OrderBy("nested"))
+	checkErr(t, err)
+	if len(res) != 3 {
+		t.Fatalf("expected 3 instances, got %d", len(res))
+	}
+}
+
+// checkInstanceIDs checks that instances have the expected IDs, in order.
+func checkInstanceIDs(t *testing.T, instances [][]byte, ids ...core.InstanceID) {
+	t.Helper()
+	if len(instances) != len(ids) {
+		t.Fatalf("expected %d instances, got %d", len(ids), len(instances))
+	}
+	for i, data := range instances {
+		var v struct {
+			ID core.InstanceID `json:"_id"`
+		}
+		checkErr(t, json.Unmarshal(data, &v))
+		if v.ID != ids[i] {
+			t.Fatalf("expected instance %d to be %s, got %s", i, ids[i], v.ID)
+		}
+	}
+}
+
+func TestCollectionReadKey(t *testing.T) {
+	t.Parallel()
+	key := thread.NewRandomKey().WithNamedRead("a", sym.New()).WithNamedRead("b", sym.New())
+	db, clean := createTestDB(t, WithNewKey(key))
+	defer clean()
+	if _, err := db.NewCollection(CollectionConfig{Name: "Unknown", ReadKey: "c"}); !errors.Is(err, ErrReadKeyNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrReadKeyNotFound, err)
+	}
+	ca, err := db.NewCollection(CollectionConfig{Name: "A", ReadKey: "a"})
+	checkErr(t, err)
+	if ca.GetReadKey() != "a" {
+		t.Fatalf("expected read key a, got %s", ca.GetReadKey())
+	}
+	_, err = db.NewCollection(CollectionConfig{Name: "B", ReadKey: "b"})
+	checkErr(t, err)
+	_, err = ca.Create([]byte(`{"msg": "yo!"}`))
+	checkErr(t, err)
+
+	txn, err := db.WriteTxn(context.Background())
+	checkErr(t, err)
+	defer txn.Discard()
+	_, err = txn.Collection("A")
+	checkErr(t, err)
+	if _, err := txn.Collection("B"); !errors.Is(err, ErrReadKeyMismatch) {
+		t.Fatalf("expected error %v, got %v", ErrReadKeyMismatch, err)
+	}
+}
+
 func TestGetName(t *testing.T) {
 	t.Parallel()
 	db, clean := createTestDB(t)
@@ -620,6 +799,288 @@ func TestDropIndex(t *testing.T) {
 	})
 }
 
+func TestEncryptedIndex(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	schema := util.SchemaFromInstance(&Person{}, false)
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  schema,
+		Indexes: []Index{{Path: "Name", Encrypted: true}},
+	})
+	checkErr(t, err)
+	key := NewSearchKey()
+
+	t.Run("Fail/NonStringPath", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Path: "Age", Encrypted: true})
+		if !errors.Is(err, ErrEncryptedIndexType) {
+			t.Fatalf("expected error %v, got %v", ErrEncryptedIndexType, err)
+		}
+	})
+	t.Run("FindByToken", func(t *testing.T) {
+		for _, name := range []string{"Alice", "Bob", "Alice"} {
+			p, err := key.EncryptFields(util.JSONFromInstance(Person{Name: name, Age: 42}), "Name")
+			checkErr(t, err)
+			if bytes.Contains(p, []byte(name)) {
+				t.Fatal("encrypted instance should not contain plaintext")
+			}
+			_, err = c.Create(p)
+			checkErr(t, err)
+		}
+		res, err := c.Find(Where("Name").EqEncrypted(key, "Alice").UseIndex("Name"))
+		checkErr(t, err)
+		if len(res) != 2 {
+			t.Fatalf("expected %d results, got %d", 2, len(res))
+		}
+		res, err = c.Find(Where("Name").EqEncrypted(NewSearchKey(), "Alice"))
+		checkErr(t, err)
+		if len(res) != 0 {
+			t.Fatalf("expected %d results, got %d", 0, len(res))
+		}
+	})
+	t.Run("Fail/PlaintextWrite", func(t *testing.T) {
+		_, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+		if !errors.Is(err, ErrInvalidSearchToken) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSearchToken, err)
+		}
+		p, err := key.EncryptFields(util.JSONFromInstance(Person{Name: "Carol", Age: 42}), "Name")
+		checkErr(t, err)
+		id, err := c.Create(p)
+		checkErr(t, err)
+		p, err = c.FindByID(id)
+		checkErr(t, err)
+		err = c.Save(util.SetJSONProperty("Name", "Carol", p))
+		if !errors.Is(err, ErrInvalidSearchToken) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSearchToken, err)
+		}
+	})
+	t.Run("Fail/InvalidKey", func(t *testing.T) {
+		_, err := c.Find(Where("Name").EqEncrypted(SearchKey("short"), "Alice"))
+		if !errors.Is(err, ErrInvalidSearchKey) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSearchKey, err)
+		}
+	})
+	t.Run("Fail/RangeQuery", func(t *testing.T) {
+		_, err := c.Find(Where("Name").Gt("a"))
+		if !errors.Is(err, ErrEncryptedIndexOperation) {
+			t.Fatalf("expected error %v, got %v", ErrEncryptedIndexOperation, err)
+		}
+		_, err = c.Find(OrderBy("Name"))
+		if !errors.Is(err, ErrEncryptedIndexOperation) {
+			t.Fatalf("expected error %v, got %v", ErrEncryptedIndexOperation, err)
+		}
+	})
+}
+
+func TestCompoundIndex(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	schema := util.SchemaFromInstance(&Person2{}, false)
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  schema,
+		Indexes: []Index{{Paths: []string{"Name", "Age", "Toys.Favorite"}}},
+	})
+	checkErr(t, err)
+	for _, p := range []Person2{
+		{Name: "Alice", Age: 30, Toys: Toys{Favorite: "Ball"}},
+		{Name: "Alice", Age: 30, Toys: Toys{Favorite: "Bone"}},
+		{Name: "Alice", Age: 40, Toys: Toys{Favorite: "Ball"}},
+		{Name: "Bob", Age: 30, Toys: Toys{Favorite: "Ball"}},
+		{Name: "Alice/Bob", Age: 30, Toys: Toys{Favorite: "Ball"}},
+	} {
+		p.Toys.Names = []string{}
+		p.Comments = []Comment{}
+		_, err := c.Create(util.JSONFromInstance(p))
+		checkErr(t, err)
+	}
+
+	t.Run("Fail/InvalidIndex", func(t *testing.T) {
+		for _, index := range []Index{
+			{Paths: []string{"Name"}},
+			{Paths: []string{"Name", "Name"}},
+			{Path: "Age", Paths: []string{"Name", "Age"}},
+			{Paths: []string{"Name", "Age"}, Encrypted: true},
+		} {
+			if err := c.addIndex(schema, index); !errors.Is(err, ErrInvalidCompoundIndex) {
+				t.Fatalf("expected error %v, got %v", ErrInvalidCompoundIndex, err)
+			}
+		}
+		if err := c.addIndex(schema, Index{Paths: []string{"Name", "Comments"}}); err == nil {
+			t.Fatal("index path should not be valid")
+		}
+	})
+	t.Run("Plan", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			query  *Query
+			values []string
+		}{
+			{"FullPrefix", Where("Name").Eq("Alice").And("Age").Eq(30.0).And("Toys.Favorite").Eq("Ball"), []string{"Alice", "30", "Ball"}},
+			{"PartialPrefix", Where("Age").Eq(30.0).And("Name").Eq("Alice"), []string{"Alice", "30"}},
+			{"PartialPrefixWithRange", Where("Name").Eq("Alice").And("Age").Gt(35.0), []string{"Alice"}},
+			{"NoPrefix", Where("Age").Eq(30.0), nil},
+			{"Or", Where("Name").Eq("Alice").Or(Where("Name").Eq("Bob")), nil},
+			{"SortByID", Where("Name").Eq("Alice").OrderByID(), nil},
+		}
+		for _, tc := range tests {
+			_, values, ok := c.planIndex(tc.query)
+			if ok != (tc.values != nil) || !reflect.DeepEqual(values, tc.values) {
+				t.Fatalf("%s: expected plan %v, got %v", tc.name, tc.values, values)
+			}
+		}
+	})
+	t.Run("Find", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			query *Query
+			count int
+		}{
+			{"FullPrefix", Where("Name").Eq("Alice").And("Age").Eq(30.0).And("Toys.Favorite").Eq("Ball"), 1},
+			{"PartialPrefix", Where("Name").Eq("Alice").And("Age").Eq(30.0), 2},
+			{"PartialPrefixWithRange", Where("Name").Eq("Alice").And("Age").Gt(35.0), 1},
+			{"PrefixWithOtherCriteria", Where("Name").Eq("Alice").And("Toys.Favorite").Eq("Ball"), 2},
+			{"EscapedValue", Where("Name").Eq("Alice/Bob"), 1},
+			{"UseIndex", Where("Age").Eq(30.0).UseIndex("Name,Age,Toys.Favorite"), 4},
+			{"UseIndexWithOr", Where("Name").Eq("Bob").Or(Where("Age").Eq(40.0)).UseIndex("Name,Age,Toys.Favorite"), 2},
+		}
+		for _, tc := range tests {
+			res, err := c.Find(tc.query)
+			checkErr(t, err)
+			if len(res) != tc.count {
+				t.Fatalf("%s: expected %d results, got %d", tc.name, tc.count, len(res))
+			}
+		}
+	})
+	t.Run("UpdateEntries", func(t *testing.T) {
+		res, err := c.Find(Where("Name").Eq("Bob"))
+		checkErr(t, err)
+		checkErr(t, c.Save(util.SetJSONProperty("Name", "Carol", res[0])))
+		res, err = c.Find(Where("Name").Eq("Bob"))
+		checkErr(t, err)
+		if len(res) != 0 {
+			t.Fatalf("expected %d results, got %d", 0, len(res))
+		}
+		res, err = c.Find(Where("Name").Eq("Carol").And("Age").Eq(30.0))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected %d results, got %d", 1, len(res))
+		}
+	})
+	t.Run("Unique", func(t *testing.T) {
+		err := c.addIndex(schema, Index{Paths: []string{"Name", "Age"}, Unique: true})
+		if !errors.Is(err, ErrCantCreateUniqueIndex) {
+			t.Fatalf("expected error %v, got %v", ErrCantCreateUniqueIndex, err)
+		}
+		err = c.addIndex(schema, Index{Paths: []string{"Age", "Toys.Favorite", "Name"}, Unique: true})
+		checkErr(t, err)
+		_, err = c.Create(util.JSONFromInstance(Person2{Name: "Alice", Age: 30, Toys: Toys{Favorite: "Ball", Names: []string{}}, Comments: []Comment{}}))
+		if !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+		_, err = c.Create(util.JSONFromInstance(Person2{Name: "Alice", Age: 31, Toys: Toys{Favorite: "Ball", Names: []string{}}, Comments: []Comment{}}))
+		checkErr(t, err)
+		res, err := c.Find(Where("Name").Eq("Alice").And("Age").Eq(40.0))
+		checkErr(t, err)
+		err = c.Save(util.SetJSONProperty("Age", 30, res[0]))
+		if !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+	})
+	t.Run("GetIndexes", func(t *testing.T) {
+		indexes := c.GetIndexes()
+		if len(indexes) != 2 {
+			t.Fatalf("expected %d indexes, got %d", 2, len(indexes))
+		}
+		for _, index := range indexes {
+			if !index.isCompound() {
+				t.Fatalf("expected compound index, got %v", index)
+			}
+		}
+	})
+}
+
+type Account struct {
+	ID      core.InstanceID `json:"_id"`
+	Email   string
+	Deleted bool
+}
+
+func TestPartialIndex(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Account",
+		Schema: util.SchemaFromInstance(&Account{}, false),
+		Indexes: []Index{{
+			Path:   "Email",
+			Unique: true,
+			Filter: Where("Deleted").Eq(false),
+		}},
+	})
+	checkErr(t, err)
+	byEmail := func(email string) int {
+		res, err := c.Find(Where("Email").Eq(email).UseIndex("Email"))
+		checkErr(t, err)
+		return len(res)
+	}
+
+	alice := Account{Email: "alice@example.com"}
+	id, err := c.Create(util.JSONFromInstance(alice))
+	checkErr(t, err)
+	alice.ID = id
+
+	t.Run("Unique", func(t *testing.T) {
+		_, err := c.Create(util.JSONFromInstance(Account{Email: "alice@example.com"}))
+		if !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+		_, err = c.Create(util.JSONFromInstance(Account{Email: "alice@example.com", Deleted: true}))
+		checkErr(t, err)
+		if n := byEmail("alice@example.com"); n != 1 {
+			t.Fatalf("expected %d indexed instances, got %d", 1, n)
+		}
+	})
+	t.Run("StopMatching", func(t *testing.T) {
+		alice.Deleted = true
+		checkErr(t, c.Save(util.JSONFromInstance(alice)))
+		if n := byEmail("alice@example.com"); n != 0 {
+			t.Fatalf("expected %d indexed instances, got %d", 0, n)
+		}
+		// The email is free among instances that aren't deleted
+		_, err := c.Create(util.JSONFromInstance(Account{Email: "alice@example.com"}))
+		checkErr(t, err)
+		if n := byEmail("alice@example.com"); n != 1 {
+			t.Fatalf("expected %d indexed instances, got %d", 1, n)
+		}
+	})
+	t.Run("StartMatching", func(t *testing.T) {
+		alice.Deleted = false
+		err := c.Save(util.JSONFromInstance(alice))
+		if !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+		alice.Email = "alice@example.org"
+		checkErr(t, c.Save(util.JSONFromInstance(alice)))
+		if n := byEmail("alice@example.org"); n != 1 {
+			t.Fatalf("expected %d indexed instances, got %d", 1, n)
+		}
+	})
+	t.Run("InvalidFilter", func(t *testing.T) {
+		_, err := db.UpdateCollection(CollectionConfig{
+			Name:    "Account",
+			Schema:  util.SchemaFromInstance(&Account{}, false),
+			Indexes: []Index{{Path: "Email", Filter: &Query{Ands: []*Criterion{{FieldPath: "Deleted"}}}}},
+		})
+		if !errors.Is(err, ErrInvalidIndexFilter) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidIndexFilter, err)
+		}
+	})
+}
+
 func TestCreateInstance(t *testing.T) {
 	t.Parallel()
 	t.Run("Single", func(t *testing.T) {
@@ -716,12 +1177,148 @@ func TestCreateInstance(t *testing.T) {
 		checkErr(t, err)
 		p2 := util.JSONFromInstance(Person{ID: res, Name: "Fool2", Age: 43})
 		_, err = m.Create(p2)
-		if !errors.Is(err, errCantCreateExistingInstance) {
+		if !errors.Is(err, ErrInstanceExists) {
 			t.Fatal("shouldn't create already existing instance")
 		}
 	})
 }
 
+func TestCreateWithID(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	id := core.InstanceID("external-42")
+	err = c.CreateWithID(id, util.JSONFromInstance(Person{Name: "Foo", Age: 42}))
+	checkErr(t, err)
+	assertPersonInCollection(t, c, util.JSONFromInstance(Person{ID: id, Name: "Foo", Age: 42}))
+
+	err = c.CreateWithID(id, util.JSONFromInstance(Person{Name: "Bar", Age: 43}))
+	if !errors.Is(err, ErrInstanceExists) {
+		t.Fatalf("expected error %v, got %v", ErrInstanceExists, err)
+	}
+	err = c.CreateWithID("other", util.JSONFromInstance(Person{ID: id, Name: "Bar", Age: 43}))
+	if !errors.Is(err, ErrInstanceIDMismatch) {
+		t.Fatalf("expected error %v, got %v", ErrInstanceIDMismatch, err)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	l, err := db.Listen()
+	checkErr(t, err)
+	defer l.Close()
+
+	id := core.InstanceID("external-42")
+	checkErr(t, c.Upsert(id, util.JSONFromInstance(Person{Name: "Foo", Age: 42})))
+	if a := <-l.Channel(); a.Type != ActionCreate || a.ID != id {
+		t.Fatalf("expected create of %s, got %v", id, a)
+	}
+	checkErr(t, c.Upsert(id, util.JSONFromInstance(Person{Name: "Bar", Age: 43})))
+	if a := <-l.Channel(); a.Type != ActionSave || a.ID != id {
+		t.Fatalf("expected save of %s, got %v", id, a)
+	}
+	assertPersonInCollection(t, c, util.JSONFromInstance(Person{ID: id, Name: "Bar", Age: 43}))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name", Unique: true}},
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+
+	checkErr(t, c.Validate(util.JSONFromInstance(Person{Name: "Bob", Age: 42})))
+	checkErr(t, c.Validate(util.JSONFromInstance(Person{ID: id, Name: "Alice", Age: 43})))
+	err = c.Validate(util.JSONFromInstance(Person{Name: "Alice", Age: 43}))
+	if !errors.Is(err, ErrUniqueExists) {
+		t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+	}
+
+	err = c.ValidateMany([][]byte{
+		util.JSONFromInstance(Person{Name: "Bob", Age: 42}),
+		[]byte(`{"Name": 42}`),
+		util.JSONFromInstance(Person{Name: "Bob", Age: 43}),
+		util.JSONFromInstance(Person{Name: "Carl", Age: 43}),
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if len(verr.Errs) != 2 {
+		t.Fatalf("expected 2 invalid instances, got %d", len(verr.Errs))
+	}
+	if verr.Errs[0].Index != 1 || !errors.Is(verr.Errs[0], ErrInvalidSchemaInstance) {
+		t.Fatalf("expected instance 1 to be invalid, got %v", verr.Errs[0])
+	}
+	if verr.Errs[1].Index != 2 || !errors.Is(verr.Errs[1], ErrUniqueExists) {
+		t.Fatalf("expected instance 2 to violate a unique index, got %v", verr.Errs[1])
+	}
+
+	n, err := c.Count(&Query{})
+	checkErr(t, err)
+	if n != 1 {
+		t.Fatalf("expected 1 instance, got %d", n)
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	schema := util.SchemaFromSchemaString(`{
+		"type": "object",
+		"properties": {
+			"_id": {"type": "string"},
+			"email": {"type": "string", "format": "email"},
+			"site": {"type": "string", "format": "uri"},
+			"format": {"type": "string", "format": "uuid"}
+		}
+	}`)
+	strict, err := db.NewCollection(CollectionConfig{Name: "Strict", Schema: schema})
+	checkErr(t, err)
+	lenient, err := db.NewCollection(CollectionConfig{Name: "Lenient", Schema: schema, IgnoreFormats: true})
+	checkErr(t, err)
+	if strict.GetIgnoreFormats() || !lenient.GetIgnoreFormats() {
+		t.Fatal("expected only the lenient collection to ignore formats")
+	}
+
+	valid := []byte(`{"email": "alice@example.com", "site": "https://example.com", "format": "3e4666bf-d5e5-4aa7-b8ce-cefe41c7568a"}`)
+	checkErr(t, strict.Validate(valid))
+	invalid := []byte(`{"email": "garbage", "site": "https://example.com"}`)
+	err = strict.Validate(invalid)
+	if !errors.Is(err, ErrInvalidSchemaInstance) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+	}
+	if !strings.Contains(err.Error(), "email") || !strings.Contains(err.Error(), "format 'email'") {
+		t.Fatalf("expected error to name the field and format, got %v", err)
+	}
+	if _, err := strict.Create(invalid); !errors.Is(err, ErrInvalidSchemaInstance) {
+		t.Fatalf("expected create to fail with %v, got %v", ErrInvalidSchemaInstance, err)
+	}
+	if _, err := lenient.Create(invalid); err != nil {
+		t.Fatalf("expected formats to be ignored, got %v", err)
+	}
+}
+
 func TestReadTxnValidation(t *testing.T) {
 	t.Parallel()
 	t.Run("TryCreate", func(t *testing.T) {
@@ -830,6 +1427,52 @@ func TestVariadic(t *testing.T) {
 	}
 }
 
+func TestVariadicInvalid(t *testing.T) {
+	t.Parallel()
+
+	db, clean := createTestDB(t)
+	defer clean()
+	m, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	valid := util.JSONFromInstance(&Person{Name: "Foo1", Age: 42})
+	assertRejected := func(err error, index int, target error) {
+		t.Helper()
+		var ie *InstanceError
+		if !errors.As(err, &ie) || ie.Index != index || !errors.Is(err, target) {
+			t.Fatalf("expected error %v for instance %d, got %v", target, index, err)
+		}
+		res, err := m.Find(nil)
+		checkErr(t, err)
+		if len(res) != 0 {
+			t.Fatalf("expected no instances, got %d", len(res))
+		}
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		_, err := m.CreateMany([][]byte{valid, []byte(`{"_id": "", "Name": "Foo2", "Age": "old"}`)})
+		assertRejected(err, 1, ErrInvalidSchemaInstance)
+	})
+	t.Run("CreateDuplicateID", func(t *testing.T) {
+		dup := util.JSONFromInstance(&Person{ID: "dup", Name: "Foo", Age: 1})
+		_, err := m.CreateMany([][]byte{valid, dup, dup})
+		assertRejected(err, 2, ErrInstanceExists)
+	})
+	t.Run("Save", func(t *testing.T) {
+		ids, err := m.CreateMany([][]byte{valid, valid})
+		checkErr(t, err)
+		p0 := util.SetJSONID(ids[0], valid)
+		err = m.SaveMany([][]byte{p0, []byte(`{"_id": "` + ids[1].String() + `", "Age": "old"}`)})
+		var ie *InstanceError
+		if !errors.As(err, &ie) || ie.Index != 1 || !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v for instance %d, got %v", ErrInvalidSchemaInstance, 1, err)
+		}
+	})
+}
+
 func TestGetInstance(t *testing.T) {
 	t.Parallel()
 
@@ -1181,6 +1824,175 @@ func TestSaveInstance(t *testing.T) {
 	})
 }
 
+func TestModifyByID(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+	get := func() Person {
+		instance, err := c.FindByID(id)
+		checkErr(t, err)
+		var p Person
+		util.InstanceFromJSON(instance, &p)
+		return p
+	}
+
+	t.Run("MergePatch", func(t *testing.T) {
+		checkErr(t, c.ModifyByID(id, []byte(`{"Name": "Bob"}`)))
+		if p := get(); p.Name != "Bob" || p.Age != 42 {
+			t.Fatalf(errInvalidInstanceState)
+		}
+	})
+	t.Run("JSONPatch", func(t *testing.T) {
+		checkErr(t, c.ModifyByID(id, []byte(`[{"op": "replace", "path": "/Age", "value": 43}]`)))
+		if p := get(); p.Name != "Bob" || p.Age != 43 {
+			t.Fatalf(errInvalidInstanceState)
+		}
+	})
+	t.Run("PendingWrites", func(t *testing.T) {
+		err := c.WriteTxn(func(txn *Txn) error {
+			ids, err := txn.Create(util.JSONFromInstance(Person{Name: "Carol", Age: 20}))
+			if err != nil {
+				return err
+			}
+			return txn.ModifyByID(ids[0], []byte(`{"Age": 21}`))
+		})
+		checkErr(t, err)
+		res, err := c.Find(Where("Name").Eq("Carol"))
+		checkErr(t, err)
+		var p Person
+		util.InstanceFromJSON(res[0], &p)
+		if p.Age != 21 {
+			t.Fatalf(errInvalidInstanceState)
+		}
+	})
+	t.Run("ConcurrentDisjointFields", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		for _, patch := range []string{`{"Name": "Dave"}`, `{"Age": 50}`} {
+			wg.Add(1)
+			go func(patch string) {
+				defer wg.Done()
+				errs <- c.ModifyByID(id, []byte(patch))
+			}(patch)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			checkErr(t, err)
+		}
+		if p := get(); p.Name != "Dave" || p.Age != 50 {
+			t.Fatalf(errInvalidInstanceState)
+		}
+	})
+	t.Run("Fail/InvalidPatch", func(t *testing.T) {
+		for _, patch := range []string{
+			`{"Name": `,
+			`"Name"`,
+			`[{"op": "bogus", "path": "/Name"}]`,
+			`[{"op": "remove", "path": "/Missing"}]`,
+		} {
+			if err := c.ModifyByID(id, []byte(patch)); !errors.Is(err, ErrInvalidPatch) {
+				t.Fatalf("expected error %v, got %v", ErrInvalidPatch, err)
+			}
+		}
+	})
+	t.Run("Fail/ChangeID", func(t *testing.T) {
+		if err := c.ModifyByID(id, []byte(`{"_id": "other"}`)); !errors.Is(err, ErrCantPatchID) {
+			t.Fatalf("expected error %v, got %v", ErrCantPatchID, err)
+		}
+	})
+	t.Run("Fail/InvalidSchema", func(t *testing.T) {
+		if err := c.ModifyByID(id, []byte(`{"Age": "old"}`)); !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+		}
+	})
+	t.Run("Fail/NotFound", func(t *testing.T) {
+		if err := c.ModifyByID(core.NewInstanceID(), []byte(`{"Age": 1}`)); !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+	})
+}
+
+func TestReadContext(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	res, err := c.FindContext(ctx, Where("Age").Eq(42))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(res))
+	}
+
+	cancel()
+	t.Run("Find", func(t *testing.T) {
+		if _, err := c.FindContext(ctx, Where("Age").Eq(42)); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error %v, got %v", context.Canceled, err)
+		}
+	})
+	t.Run("FindWithIndex", func(t *testing.T) {
+		if _, err := c.FindContext(ctx, Where("Name").Eq("Alice").UseIndex("Name")); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error %v, got %v", context.Canceled, err)
+		}
+	})
+	t.Run("FindByID", func(t *testing.T) {
+		if _, err := c.FindByIDContext(ctx, id); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error %v, got %v", context.Canceled, err)
+		}
+	})
+	t.Run("Has", func(t *testing.T) {
+		if _, err := c.HasContext(ctx, id); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestReadConsistency(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+
+	stale := true
+	res, err := c.Find(&Query{}, WithReadConsistency(Strong), WithReadStaleness(&stale))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 instance, got %d", len(res))
+	}
+	if stale {
+		t.Fatal("expected strong read of a thread without peers not to be stale")
+	}
+	checkErr(t, c.ReadTxn(func(txn *Txn) error {
+		if txn.Stale() {
+			t.Fatal("expected strong read txn not to be stale")
+		}
+		_, err := txn.FindByID(id)
+		return err
+	}, WithReadConsistency(Strong)))
+}
+
 func TestModTagIncrement(t *testing.T) {
 	t.Parallel()
 	t.Run("Simple", func(t *testing.T) {
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/sjson"
+)
+
+var (
+	// ErrInvalidComputedField indicates a computed field has an empty or reserved path.
+	ErrInvalidComputedField = errors.New("invalid computed field")
+	// ErrCannotIndexComputedField indicates an index was specified on a computed field path.
+	ErrCannotIndexComputedField = errors.New("computed fields can't be indexed")
+)
+
+// computedFields derives read-only fields of instances at read time.
+type computedFields struct {
+	paths []string
+	funcs map[string]func(instance []byte) (interface{}, error)
+}
+
+// newComputedFields validates computed against indexes and returns computed fields,
+// which are nil if computed is empty.
+func newComputedFields(computed map[string]func(instance []byte) (interface{}, error), indexes []Index) (*computedFields, error) {
+	if len(computed) == 0 {
+		return nil, nil
+	}
+	cf := &computedFields{funcs: make(map[string]func(instance []byte) (interface{}, error))}
+	for pth, fn := range computed {
+		if pth == "" || pth == idFieldName || pth == modFieldName || fn == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidComputedField, pth)
+		}
+		for _, index := range indexes {
+			paths := index.Paths
+			if !index.isCompound() {
+				paths = []string{index.Path}
+			}
+			for _, ip := range paths {
+				if ip == pth || strings.HasPrefix(ip, pth+".") {
+					return nil, fmt.Errorf("%w: %s", ErrCannotIndexComputedField, ip)
+				}
+			}
+		}
+		cf.paths = append(cf.paths, pth)
+		cf.funcs[pth] = fn
+	}
+	sort.Strings(cf.paths)
+	return cf, nil
+}
+
+// add sets the computed fields of instance, which are derived from the instance as read.
+func (cf *computedFields) add(instance []byte) ([]byte, error) {
+	if cf == nil || instance == nil {
+		return instance, nil
+	}
+	res := instance
+	for _, pth := range cf.paths {
+		v, err := cf.funcs[pth](instance)
+		if err != nil {
+			return nil, fmt.Errorf("computing field %s: %w", pth, err)
+		}
+		if res, err = sjson.SetBytes(res, pth, v); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// strip removes computed fields from instance, so instances that were read can be written back.
+func (cf *computedFields) strip(instance []byte) ([]byte, error) {
+	if cf == nil {
+		return instance, nil
+	}
+	var err error
+	for _, pth := range cf.paths {
+		if instance, err = sjson.DeleteBytes(instance, pth); err != nil {
+			return nil, err
+		}
+	}
+	return instance, nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/textileio/go-threads/util"
+	"github.com/tidwall/gjson"
+)
+
+func TestComputedFields(t *testing.T) {
+	t.Parallel()
+	label := func(instance []byte) (interface{}, error) {
+		return fmt.Sprintf("%s (%d)", gjson.GetBytes(instance, "Name").String(), gjson.GetBytes(instance, "Age").Int()), nil
+	}
+
+	t.Run("Read", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		c, err := db.NewCollection(CollectionConfig{
+			Name:     "Person",
+			Schema:   util.SchemaFromInstance(&Person{}, false),
+			Computed: map[string]func(instance []byte) (interface{}, error){"Label": label},
+		})
+		checkErr(t, err)
+		id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+		checkErr(t, err)
+
+		instance, err := c.FindByID(id)
+		checkErr(t, err)
+		if l := gjson.GetBytes(instance, "Label").String(); l != "Alice (42)" {
+			t.Fatalf("expected computed label, got %q", l)
+		}
+		res, err := c.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 1 || gjson.GetBytes(res[0], "Label").String() != "Alice (42)" {
+			t.Fatal("expected computed label in find results")
+		}
+
+		// Instances holding computed fields are written without them
+		err = c.Save(instance)
+		checkErr(t, err)
+		stored, err := c.store.Get(baseKey.ChildString(c.name).ChildString(id.String()))
+		checkErr(t, err)
+		if gjson.GetBytes(stored, "Label").Exists() {
+			t.Fatal("computed field should not be stored")
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		errCompute := errors.New("compute failed")
+		c, err := db.NewCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+			Computed: map[string]func(instance []byte) (interface{}, error){"Label": func([]byte) (interface{}, error) {
+				return nil, errCompute
+			}},
+		})
+		checkErr(t, err)
+		id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+		checkErr(t, err)
+		if _, err := c.FindByID(id); !errors.Is(err, errCompute) {
+			t.Fatalf("expected compute error, got %v", err)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		_, err := db.NewCollection(CollectionConfig{
+			Name:     "Person",
+			Schema:   util.SchemaFromInstance(&Person{}, false),
+			Indexes:  []Index{{Path: "Name"}},
+			Computed: map[string]func(instance []byte) (interface{}, error){"Name": label},
+		})
+		if !errors.Is(err, ErrCannotIndexComputedField) {
+			t.Fatalf("expected indexed computed field error, got %v", err)
+		}
+		_, err = db.NewCollection(CollectionConfig{
+			Name:     "Person",
+			Schema:   util.SchemaFromInstance(&Person{}, false),
+			Computed: map[string]func(instance []byte) (interface{}, error){idFieldName: label},
+		})
+		if !errors.Is(err, ErrInvalidComputedField) {
+			t.Fatalf("expected invalid computed field error, got %v", err)
+		}
+	})
+}
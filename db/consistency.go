@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// strongReadTimeout bounds the pull preceding a strong read.
+const strongReadTimeout = time.Second * 10
+
+// ReadConsistency is how fresh the state read by a transaction is.
+type ReadConsistency int
+
+const (
+	// Local reads the local state, which may be missing records of other peers which
+	// haven't been pulled yet. It's the default.
+	Local ReadConsistency = iota
+	// Strong pulls the thread from its peers before reading, so records they had when
+	// the read started are read. If the pull fails or times out, the local state is read,
+	// and the read is reported stale, see WithReadStaleness and Txn.Stale. Views, which
+	// are fixed at a past record, are read locally.
+	Strong
+)
+
+// pullForRead pulls the thread of the db for a strong read, returning whether the
+// pull failed, so the read is stale.
+func (d *DB) pullForRead(token thread.Token) (stale bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), strongReadTimeout)
+	defer cancel()
+	start := time.Now()
+	if err := d.connector.Net.PullThread(ctx, d.connector.ThreadID(), net.WithThreadToken(token)); err != nil {
+		log.Warnf("pulling thread %s for strong read, reading local state: %v", d.connector.ThreadID(), err)
+		return true
+	}
+	log.Debugf("pulled thread %s for strong read in %s", d.connector.ThreadID(), time.Since(start))
+	return false
+}
+
+// Stale returns whether a strong read fell back to the local state, because the
+// thread couldn't be pulled.
+func (t *Txn) Stale() bool {
+	return t.stale
+}
@@ -0,0 +1,254 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	"github.com/tidwall/gjson"
+)
+
+// Count returns the number of instances matching q, taking Skip and Limit into account.
+// Instances are counted without decoding them where possible. An empty query counts
+// instance keys, and a query covered by an index counts index entries. Other queries
+// only read the fields they refer to.
+// Like Find, single-field indexes other than the ID index are only used when named by
+// q.Index, since they may not cover instances created before they were added.
+// Instances of collections with a read filter, a TTL or a trash field are always read, since
+// the filter, expiry or trash decides which of them are visible. So are instances of collections with encrypted values, whose
+// index entries hold tokens instead of values.
+func (t *Txn) Count(q *Query) (int, error) {
+	t.collection.db.metrics.query("count")
+	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
+		return 0, err
+	}
+	if q == nil {
+		q = &Query{}
+	}
+	if err := q.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid query: %w", err)
+	}
+	if err := t.collection.validEncryptedQuery(q); err != nil {
+		return 0, err
+	}
+	txn, err := t.collection.store.NewTransactionExtended(true)
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Discard()
+
+	var n int
+	if t.collection.hasReadFilter() || q.Seek != "" {
+		n, err = t.countScan(txn, q)
+	} else if len(q.Ands) == 0 && len(q.Ors) == 0 {
+		n, err = countEntries(txn, t.collection.baseKey(), false)
+	} else if index, values, ok := t.collection.coveringIndex(q); ok && t.collection.indexKey == nil {
+		n, err = t.countIndex(txn, q, index, values)
+	} else {
+		n, err = t.countScan(txn, q)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if n -= q.Skip; n < 0 {
+		n = 0
+	}
+	if q.Limit > 0 && n > q.Limit {
+		n = q.Limit
+	}
+	return n, nil
+}
+
+// hasReadFilter returns whether the collection has a read filter, a TTL, which filters
+// out expired instances on read, or a trash field, which filters out trashed instances.
+func (c *Collection) hasReadFilter() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.readFilter != nil || c.expiry != nil || c.trash != nil
+}
+
+// coveringIndex returns an index that can answer q without reading instances, along with
+// the leading values to which compound indexes are restricted.
+// Compound indexes cover queries that only have equality criteria on a prefix of their paths.
+// The ID index, or the single-field index named by q.Index, covers queries that only have
+// criteria on its path.
+func (c *Collection) coveringIndex(q *Query) (Index, []string, bool) {
+	if len(q.Ors) == 0 {
+		for _, index := range c.indexes {
+			if !index.isCompound() || index.Filter != nil {
+				continue
+			}
+			if values := q.eqPrefix(index.Paths); len(values) != 0 && len(values) == len(q.Ands) {
+				return index, values, true
+			}
+		}
+	}
+	fq := *q
+	fq.Sort = Sort{}
+	fq.Then = nil
+	for _, pth := range []string{idFieldName, q.Index} {
+		index, ok := c.indexes[pth]
+		// Multikey indexes hold an instance once per element, so their entries can't be counted.
+		if ok && !index.isCompound() && !index.Multikey && queryOnlyOn(&fq, index.Path) {
+			return index, nil, true
+		}
+	}
+	return Index{}, nil, false
+}
+
+// countIndex counts the instances in the entries of index that match q.
+func (t *Txn) countIndex(txn dse.TxnExt, q *Query, index Index, values []string) (int, error) {
+	prefix := indexPrefix.Child(t.collection.baseKey()).ChildString(index.Name())
+	if index.isCompound() {
+		for _, v := range values {
+			prefix = prefix.ChildString(encodeIndexComponent(v))
+		}
+		return countEntries(txn, prefix, true)
+	}
+
+	res, err := txn.Query(query.Query{Prefix: prefix.String()})
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+	entryPrefix := prefix.String() + "/"
+	var n int
+	for r := range res.Next() {
+		if r.Error != nil {
+			return 0, r.Error
+		}
+		if !strings.HasPrefix(r.Key, entryPrefix) {
+			continue
+		}
+		ok, err := matchIndexEntry(q, index.Path, false, t.collection.dynamic, ds.RawKey(r.Key))
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		keys := make(keyList, 0)
+		if err := DefaultDecode(r.Value, &keys); err != nil {
+			return 0, err
+		}
+		n += len(keys)
+	}
+	return n, nil
+}
+
+// countEntries counts the keys under prefix. If lists is true, the values are index
+// key lists, and their lengths are counted instead.
+func countEntries(txn dse.TxnExt, prefix ds.Key, lists bool) (int, error) {
+	res, err := txn.Query(query.Query{Prefix: prefix.String(), KeysOnly: !lists})
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+	entryPrefix := prefix.String() + "/"
+	var n int
+	for r := range res.Next() {
+		if r.Error != nil {
+			return 0, r.Error
+		}
+		if !strings.HasPrefix(r.Key, entryPrefix) {
+			continue
+		}
+		if !lists {
+			n++
+			continue
+		}
+		keys := make(keyList, 0)
+		if err := DefaultDecode(r.Value, &keys); err != nil {
+			return 0, err
+		}
+		n += len(keys)
+	}
+	return n, nil
+}
+
+// countScan counts the instances matching q by reading only the fields q refers to.
+func (t *Txn) countScan(txn dse.TxnExt, q *Query) (int, error) {
+	pk, err := t.token.PubKey()
+	if err != nil {
+		return 0, err
+	}
+	filter := t.collection.hasReadFilter()
+	prefix := t.collection.baseKey()
+	dsq := dse.QueryExt{
+		Query: query.Query{Prefix: prefix.String()},
+	}
+	if q.Seek != "" {
+		dsq.SeekPrefix = prefix.Child(ds.NewKey(string(q.Seek))).String()
+	}
+	res, err := txn.QueryExtended(dsq)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Close()
+	paths := q.fieldPaths()
+	entryPrefix := prefix.String() + "/"
+	var n int
+	for r := range res.Next() {
+		if r.Error != nil {
+			return 0, r.Error
+		}
+		if !strings.HasPrefix(r.Key, entryPrefix) {
+			continue
+		}
+		ok, err := matchInstance(q, partialInstance(r.Value, paths), t.collection.dynamic)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		if filter {
+			v, err := t.collection.filterQueryRead(pk, r.Value, q)
+			if err != nil {
+				return 0, err
+			}
+			if v == nil {
+				continue
+			}
+		}
+		n++
+	}
+	return n, nil
+}
+
+// fieldPaths returns the field paths of all criteria in q.
+func (q *Query) fieldPaths() []string {
+	var paths []string
+	for _, c := range q.Ands {
+		paths = append(paths, c.FieldPath)
+	}
+	for _, o := range q.Ors {
+		paths = append(paths, o.fieldPaths()...)
+	}
+	return paths
+}
+
+// partialInstance returns the values at paths in data, without decoding the rest of it.
+func partialInstance(data []byte, paths []string) map[string]interface{} {
+	doc := make(map[string]interface{})
+	for i, res := range gjson.GetManyBytes(data, paths...) {
+		if !res.Exists() {
+			continue
+		}
+		m := doc
+		fields := strings.Split(paths[i], ".")
+		for _, f := range fields[:len(fields)-1] {
+			next, ok := m[f].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				m[f] = next
+			}
+			m = next
+		}
+		m[fields[len(fields)-1]] = res.Value()
+	}
+	return doc
+}
@@ -0,0 +1,334 @@
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrInvalidCursor indicates a cursor token can't be decoded, or an instance
+	// can't be used to create one.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrCursorMismatch indicates a cursor token was applied to a query ordered
+	// differently from the one that created it.
+	ErrCursorMismatch = errors.New("cursor doesn't match query ordering")
+)
+
+// cursor is a position in the results of a query ordering.
+type cursor struct {
+	Sort  Sort            `json:"sort"`
+	Value json.RawMessage `json:"value,omitempty"`
+	// Then and ThenValues hold the secondary orders of the query, and the position in them.
+	Then       []Sort            `json:"then,omitempty"`
+	ThenValues []json.RawMessage `json:"thenValues,omitempty"`
+	ID         core.InstanceID   `json:"id"`
+
+	// key is the decoded position.
+	key orderKey
+}
+
+// orderKey is the position of an instance in a query ordering.
+// values holds the values of the sort fields, which are nil when missing.
+// Instances with equal sort field values are ordered by ID.
+type orderKey struct {
+	id     string
+	values []interface{}
+}
+
+// Cursor returns an opaque continuation token for the results of q that come after instance,
+// which is usually the last instance of a page.
+// Pass the token to After on a query with the same ordering to get the next page. Since the
+// token holds the position of instance in the ordering, pages stay stable when instances are
+// created or deleted between requests.
+func (q *Query) Cursor(instance []byte) (string, error) {
+	sorts := q.orderings()
+	id := gjson.GetBytes(instance, idFieldName)
+	if id.String() == "" {
+		return "", ErrInvalidCursor
+	}
+	c := cursor{Sort: sorts[0], Then: sorts[1:], ID: core.InstanceID(id.String())}
+	for i, s := range sorts {
+		if s.FieldPath == idFieldName {
+			continue
+		}
+		value := json.RawMessage("null")
+		if v := gjson.GetBytes(instance, s.FieldPath); v.Exists() {
+			value = json.RawMessage(v.Raw)
+		}
+		if i == 0 {
+			c.Value = value
+		} else {
+			c.ThenValues = append(c.ThenValues, value)
+		}
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// After restricts the results to those that come after the position of a cursor
+// token returned by Cursor.
+// Queries with a cursor and no ordering are ordered by ID.
+func (q *Query) After(token string) *Query {
+	q.AfterCursor = token
+	return q
+}
+
+// ordering returns the sort order of q, which is by ID when unspecified.
+func (q *Query) ordering() Sort {
+	if q.Sort.FieldPath == "" {
+		return Sort{FieldPath: idFieldName}
+	}
+	return q.Sort
+}
+
+// orderings returns the sort order of q followed by its secondary orders.
+// Secondary orders of queries ordered by ID are ignored, since IDs are unique.
+func (q *Query) orderings() []Sort {
+	s := q.ordering()
+	if s.FieldPath == idFieldName {
+		return []Sort{s}
+	}
+	return append([]Sort{s}, q.Then...)
+}
+
+// sorts returns the orderings of the query which created c.
+func (c *cursor) sorts() []Sort {
+	return append([]Sort{c.Sort}, c.Then...)
+}
+
+func equalOrderings(a, b []Sort) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// cursor decodes the cursor token of q, if any.
+func (q *Query) cursor() (*cursor, error) {
+	if q.AfterCursor == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(q.AfterCursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	c := &cursor{}
+	if err := json.Unmarshal(b, c); err != nil || c.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	sorts := c.sorts()
+	if !equalOrderings(sorts, q.orderings()) {
+		return nil, ErrCursorMismatch
+	}
+	c.key.id = c.ID.String()
+	c.key.values = make([]interface{}, len(sorts))
+	if sorts[0].FieldPath != idFieldName {
+		if len(c.ThenValues) != len(c.Then) {
+			return nil, ErrInvalidCursor
+		}
+		for i, v := range append([]json.RawMessage{c.Value}, c.ThenValues...) {
+			if err := json.Unmarshal(v, &c.key.values[i]); err != nil {
+				return nil, ErrInvalidCursor
+			}
+		}
+	}
+	return c, nil
+}
+
+// precedes returns whether c comes before res. If dynamic is set, res is an instance of
+// a dynamic collection, see compareKeys.
+func (c *cursor) precedes(res MarshaledResult, dynamic bool) (bool, error) {
+	sorts := c.sorts()
+	k, err := resultOrderKey(sorts, res)
+	if err != nil {
+		return false, err
+	}
+	r, err := compareKeys(sorts, c.key, k, dynamic)
+	if err != nil {
+		return false, err
+	}
+	return r < 0, nil
+}
+
+// resultOrderKey returns the position of res in the orderings sorts.
+// Sort fields which are missing from res, or null, have nil values. A sort field path which goes
+// through a value that isn't an object is invalid.
+func resultOrderKey(sorts []Sort, res MarshaledResult) (orderKey, error) {
+	k := orderKey{id: ds.RawKey(res.Key).Name(), values: make([]interface{}, len(sorts))}
+	for i, s := range sorts {
+		if s.FieldPath == idFieldName {
+			continue
+		}
+		v, ok, err := lookupSortField(res.MarshaledValue, s.FieldPath)
+		if err != nil {
+			return orderKey{}, err
+		}
+		if ok {
+			k.values[i] = v
+		}
+	}
+	return k, nil
+}
+
+// lookupSortField returns the value at path in instance, and whether it exists.
+func lookupSortField(instance map[string]interface{}, path string) (interface{}, bool, error) {
+	var value interface{} = instance
+	for _, f := range strings.Split(path, ".") {
+		if value == nil {
+			return nil, false, nil
+		}
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false, ErrInvalidSortingField
+		}
+		if value, ok = m[f]; !ok {
+			return nil, false, nil
+		}
+	}
+	return value, value != nil, nil
+}
+
+// compareKeys compares the positions a and b in the orderings sorts.
+// Missing and null values come after all others, in ascending and descending order.
+// Positions with equal values are ordered by ID, in the direction of the first ordering.
+// If dynamic is set, the positions are of instances of a dynamic collection, whose fields may
+// hold values of any type, see dynamicRank.
+func compareKeys(sorts []Sort, a, b orderKey, dynamic bool) (int, error) {
+	for i, s := range sorts {
+		if s.FieldPath == idFieldName {
+			break
+		}
+		av, bv := a.values[i], b.values[i]
+		if dynamic {
+			ra, rb := dynamicRank(av), dynamicRank(bv)
+			switch {
+			case ra < rb:
+				return -1, nil
+			case ra > rb:
+				return 1, nil
+			case ra == unorderedRank:
+				continue
+			}
+		}
+		switch {
+		case av == nil && bv == nil:
+			continue
+		case av == nil:
+			return 1, nil
+		case bv == nil:
+			return -1, nil
+		}
+		r, err := compare(av, bv)
+		if err != nil {
+			return 0, err
+		}
+		if s.Desc {
+			r *= -1
+		}
+		if r != 0 {
+			return r, nil
+		}
+	}
+	r := strings.Compare(a.id, b.id)
+	if sorts[0].Desc {
+		r *= -1
+	}
+	return r, nil
+}
+
+// unorderedRank is the rank of missing values, and values which can't be ordered,
+// in the orderings of dynamic collections.
+const unorderedRank = 3
+
+// dynamicRank returns the rank of the type of v in the orderings of dynamic collections.
+// Values of different types are ordered by type: numbers, then strings, then booleans.
+// Objects and arrays can't be ordered, so they come last with missing values.
+// Types are ranked the same in ascending and descending order.
+func dynamicRank(v interface{}) int {
+	switch v.(type) {
+	case float64:
+		return 0
+	case string:
+		return 1
+	case bool:
+		return 2
+	default:
+		return unorderedRank
+	}
+}
+
+// sortResults sorts values by sorts, and applies the cursor c, skip, and limit.
+// If dynamic is set, values are instances of a dynamic collection, see compareKeys.
+// Values of the same field which can't be compared are an error.
+func sortResults(values []MarshaledResult, sorts []Sort, dynamic bool, c *cursor, skip, limit int) ([]MarshaledResult, error) {
+	keys := make([]orderKey, len(values))
+	for i := range values {
+		k, err := resultOrderKey(sorts, values[i])
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = k
+	}
+	var sortErr error
+	sort.Sort(&resultSorter{values: values, keys: keys, less: func(a, b orderKey) bool {
+		r, err := compareKeys(sorts, a, b, dynamic)
+		if err != nil {
+			if sortErr == nil {
+				sortErr = fmt.Errorf("%w: %v", ErrInvalidSortingField, err)
+			}
+			return false
+		}
+		return r < 0
+	}})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	var start int
+	if c != nil {
+		start = sort.Search(len(keys), func(i int) bool {
+			r, err := compareKeys(sorts, c.key, keys[i], dynamic)
+			return err != nil || r < 0
+		})
+	}
+	if start += skip; start > len(values) {
+		start = len(values)
+	}
+	values = values[start:]
+	if limit > 0 && len(values) > limit {
+		values = values[:limit]
+	}
+	return values, nil
+}
+
+// resultSorter sorts results along with their order keys.
+type resultSorter struct {
+	values []MarshaledResult
+	keys   []orderKey
+	less   func(a, b orderKey) bool
+}
+
+func (s *resultSorter) Len() int { return len(s.values) }
+
+func (s *resultSorter) Less(i, j int) bool { return s.less(s.keys[i], s.keys[j]) }
+
+func (s *resultSorter) Swap(i, j int) {
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+}
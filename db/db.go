@@ -9,16 +9,19 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/alecthomas/jsonschema"
 	"github.com/dop251/goja"
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	format "github.com/ipfs/go-ipld-format"
 	logging "github.com/ipfs/go-log/v2"
 	ma "github.com/multiformats/go-multiaddr"
+	sym "github.com/textileio/crypto/symmetric"
 	threadcbor "github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
@@ -50,6 +53,18 @@ var (
 	ErrInvalidCollectionSchema = errors.New("the collection schema _id property must be a string")
 	// ErrCannotIndexIDField indicates a custom index was specified on the ID field.
 	ErrCannotIndexIDField = errors.New("cannot create custom index on " + idFieldName)
+	// ErrDatastoreNotFound indicates a collection is bound to a datastore that isn't registered.
+	ErrDatastoreNotFound = errors.New("datastore not found")
+	// ErrCannotChangeDatastore indicates an attempt to rebind an existing collection to another datastore.
+	ErrCannotChangeDatastore = errors.New("cannot change the datastore of an existing collection")
+	// ErrValueKeyRequired indicates a collection with encrypted values is used without a value key.
+	ErrValueKeyRequired = errors.New("a value key is required to encrypt collection values")
+	// ErrCannotChangeValueEncryption indicates an attempt to toggle value encryption of an existing collection.
+	ErrCannotChangeValueEncryption = errors.New("cannot change value encryption of an existing collection")
+	// ErrReadOnly indicates a write to a db opened with WithNewReadOnly.
+	ErrReadOnly = errors.New("db is read-only")
+	// ErrReadKeyNotFound indicates a collection is encrypted under a named read key the thread key doesn't hold.
+	ErrReadKeyNotFound = errors.New("read key not found")
 
 	nameRx *regexp.Regexp
 
@@ -59,6 +74,14 @@ var (
 	dsIndexes    = dsPrefix.ChildString("index")
 	dsValidators = dsPrefix.ChildString("validator")
 	dsFilters    = dsPrefix.ChildString("filter")
+	dsConflicts  = dsPrefix.ChildString("conflicts")
+	dsDatastores = dsPrefix.ChildString("datastore")
+	dsEncrypted  = dsPrefix.ChildString("encrypted")
+	dsLimits     = dsPrefix.ChildString("limits")
+	dsCodecs     = dsPrefix.ChildString("codec")
+	dsFormats    = dsPrefix.ChildString("ignoreformats")
+	dsDynamic    = dsPrefix.ChildString("dynamic")
+	dsReadKeys   = dsPrefix.ChildString("readkey")
 )
 
 func init() {
@@ -79,13 +102,36 @@ type DB struct {
 	connector *app.Connector
 
 	datastore  kt.TxnDatastoreExtended
+	datastores map[string]kt.TxnDatastoreExtended
+	valueKey   *sym.Key
+	schemaDocs map[string]json.RawMessage
+	acl        ACL
+	metrics    *metrics
+	queryCache *queryCache
+	quota      *quota
 	dispatcher *dispatcher
 	eventcodec core.EventCodec
+	codecs     map[string]core.EventCodec
+	readOnly   bool
+	// view is set for views replayed up to a past record, see ViewAt.
+	view       bool
+	syncWrites bool
+	middleware []Middleware
+
+	// actionLogSize is the number of recent actions kept for WithListenFrom.
+	// Actions aren't logged if it's zero, as for views.
+	actionLogSize int
+	position      Position
+
+	// author is the identity which wrote the events being dispatched. Guarded by txnlock.
+	author thread.PubKey
 
 	lock        sync.RWMutex
 	txnlock     sync.RWMutex
 	collections map[string]*Collection
 	closed      bool
+	// stopSweep stops the sweeper of expired instances. It's nil for views.
+	stopSweep chan struct{}
 
 	localEventsBus      *app.LocalEventsBus
 	stateChangedNotifee *stateChangedNotifee
@@ -111,7 +157,7 @@ func NewDB(
 		return nil, err
 	}
 
-	if args.Key.Defined() && !args.Key.CanRead() {
+	if args.Key.Defined() && !args.Key.CanReadAny() {
 		return nil, ErrThreadReadKeyRequired
 	}
 	if _, err := network.CreateThread(
@@ -148,7 +194,7 @@ func NewDBFromAddr(
 		return nil, err
 	}
 
-	if key.Defined() && !key.CanRead() {
+	if key.Defined() && !key.CanReadAny() {
 		return nil, ErrThreadReadKeyRequired
 	}
 	info, err := network.AddThread(
@@ -166,15 +212,21 @@ func NewDBFromAddr(
 		return nil, err
 	}
 
+	pull := func(ctx context.Context) error {
+		if args.BackfillDepth > 0 {
+			return network.PullThreadDepth(ctx, info.ID, args.BackfillDepth, net.WithThreadToken(args.Token))
+		}
+		return network.PullThread(ctx, info.ID, net.WithThreadToken(args.Token))
+	}
 	if args.Block {
-		if err = network.PullThread(ctx, info.ID, net.WithThreadToken(args.Token)); err != nil {
+		if err = pull(ctx); err != nil {
 			return nil, err
 		}
 	} else {
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), pullThreadBackgroundTimeout)
 			defer cancel()
-			if err := network.PullThread(ctx, info.ID, net.WithThreadToken(args.Token)); err != nil {
+			if err := pull(ctx); err != nil {
 				log.Errorf("error pulling thread %s", info.ID)
 			}
 		}()
@@ -188,14 +240,39 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 		opts.EventCodec = newDefaultEventCodec()
 	}
 
+	m, err := newMetrics(opts.Metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	events := kt.TxnDatastoreExtended(s)
+	if opts.ValueKey != nil {
+		events = newEncryptedStore(s, opts.ValueKey, dsDispatcherPrefix)
+	}
+	if opts.ExpirySweepInterval <= 0 {
+		opts.ExpirySweepInterval = defaultExpirySweepInterval
+	}
 	d := &DB{
 		datastore:           s,
-		dispatcher:          newDispatcher(s),
-		eventcodec:          opts.EventCodec,
+		datastores:          opts.Datastores,
+		valueKey:            opts.ValueKey,
+		schemaDocs:          opts.SchemaDocuments,
+		acl:                 opts.ACL,
+		metrics:             m,
+		queryCache:          newQueryCache(opts.QueryCacheSize),
+		quota:               newQuota(opts.MaxBytes),
+		dispatcher:          newDispatcher(events),
+		codecs:              newEventCodecs(opts.EventCodec, opts.EventCodecs),
+		readOnly:            opts.ReadOnly,
+		syncWrites:          opts.SyncWrites,
+		middleware:          opts.Middleware,
+		actionLogSize:       opts.ActionLogSize,
 		collections:         make(map[string]*Collection),
+		stopSweep:           make(chan struct{}),
 		localEventsBus:      app.NewLocalEventsBus(),
-		stateChangedNotifee: &stateChangedNotifee{},
+		stateChangedNotifee: newStateChangedNotifee(),
 	}
+	d.eventcodec = &eventCodecs{db: d}
 	if err := d.loadName(); err != nil {
 		return nil, err
 	}
@@ -211,6 +288,9 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 	if err := d.reCreateCollections(); err != nil {
 		return nil, err
 	}
+	if err := d.loadPosition(); err != nil {
+		return nil, err
+	}
 	d.dispatcher.Register(d)
 
 	connector, err := n.ConnectApp(d, id)
@@ -224,6 +304,9 @@ func newDB(s kt.TxnDatastoreExtended, n app.Net, id thread.ID, opts *NewOptions)
 			return nil, err
 		}
 	}
+	if !d.readOnly {
+		go d.sweepExpiredLoop(opts.ExpirySweepInterval)
+	}
 	return d, nil
 }
 
@@ -277,11 +360,80 @@ func (d *DB) reCreateCollections() error {
 		if err != nil && !errors.Is(err, ds.ErrNotFound) {
 			return err
 		}
+		rc, err := d.datastore.Has(dsConflicts.ChildString(name))
+		if err != nil {
+			return err
+		}
+		inf, err := d.datastore.Has(dsFormats.ChildString(name))
+		if err != nil {
+			return err
+		}
+		if dy, err := d.datastore.Has(dsDynamic.ChildString(name)); err != nil {
+			return err
+		} else if dy {
+			schema = nil
+		}
+		dn, err := d.datastore.Get(dsDatastores.ChildString(name))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		ev, err := d.datastore.Has(dsEncrypted.ChildString(name))
+		if err != nil {
+			return err
+		}
+		ec, err := d.datastore.Get(dsCodecs.ChildString(name))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		rk, err := d.datastore.Get(dsReadKeys.ChildString(name))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		var mi int
+		if l, err := d.datastore.Get(dsLimits.ChildString(name)); err == nil {
+			if mi, err = strconv.Atoi(string(l)); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		vf, err := d.datastore.Get(dsVersionFields.ChildString(name))
+		if err != nil && !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		var ex expiry
+		if e, err := d.datastore.Get(dsExpiry.ChildString(name)); err == nil {
+			if err := json.Unmarshal(e, &ex); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		var tr trash
+		if t, err := d.datastore.Get(dsTrash.ChildString(name)); err == nil {
+			if err := json.Unmarshal(t, &tr); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
 		c, err := newCollection(d, CollectionConfig{
-			Name:           name,
-			Schema:         schema,
-			WriteValidator: string(wv),
-			ReadFilter:     string(rf),
+			Name:            name,
+			Schema:          schema,
+			WriteValidator:  string(wv),
+			ReadFilter:      string(rf),
+			ReportConflicts: rc,
+			IgnoreFormats:   inf,
+			Datastore:       string(dn),
+			EncryptValues:   ev,
+			MaxInstances:    mi,
+			EventCodec:      string(ec),
+			TTLField:        ex.Field,
+			TTL:             ex.TTL,
+			VersionField:    string(vf),
+			TrashField:      tr.Field,
+			TrashRetention:  tr.Retention,
+			ReadKey:         string(rk),
 		})
 		if err != nil {
 			return err
@@ -294,8 +446,8 @@ func (d *DB) reCreateCollections() error {
 			}
 		}
 		for _, index := range indexes {
-			if index.Path != "" { // Catch bad indexes from an old bug
-				c.indexes[index.Path] = index
+			if name := index.Name(); name != "" { // Catch bad indexes from an old bug
+				c.indexes[name] = index
 			}
 		}
 		d.collections[c.name] = c
@@ -310,6 +462,13 @@ type Info struct {
 	Key   thread.Key
 }
 
+// IsPartial returns whether the db holds a partial view of its thread, because older records
+// were skipped when pulling it, see WithNewBackfillDepth. Queries of a partial db only cover
+// the instances created or updated by the pulled records.
+func (d *DB) IsPartial() (bool, error) {
+	return d.connector.Net.IsPartial(d.connector.ThreadID())
+}
+
 // GetDBInfo returns the addresses and key that can be used to join the DB thread.
 func (d *DB) GetDBInfo(opts ...Option) (info Info, err error) {
 	log.Debugf("getting db info in %s", d.name)
@@ -338,7 +497,18 @@ type CollectionConfig struct {
 	// Must only contain alphanumeric characters or non-consecutive hyphens, and cannot begin or end with a hyphen.
 	Name string
 	// Schema is JSON Schema used for instance validation.
+	// It may reference definitions of documents registered with WithNewSchemaDocument,
+	// e.g., {"$ref": "shared.json#/definitions/Address"}, but index paths aren't resolved
+	// through those references.
+	// Format keywords, e.g., "format": "email", are asserted with the checkers of gojsonschema,
+	// which support email, uri, date-time, date, time, uuid, ipv4, ipv6 and hostname, among
+	// others. Custom formats can be added to gojsonschema.FormatCheckers. Unknown formats pass.
+	// If Schema is nil, the collection is dynamic: it accepts any JSON object, which only
+	// needs a string _id, and indexes aren't checked against a schema.
 	Schema *jsonschema.Schema
+	// IgnoreFormats disables the assertion of format keywords of Schema and of the documents it
+	// references, so values which don't match their format are valid.
+	IgnoreFormats bool
 	// Indexes is a list of index configurations, which define how instances are indexed.
 	Indexes []Index
 	// An optional JavaScript (ECMAScript 5.1) function that is used to validate instances on write.
@@ -357,6 +527,84 @@ type CollectionConfig struct {
 	// Most implementation will modify and return the current instance.
 	// Note: Only the function body should be defined here.
 	ReadFilter string
+	// ReportConflicts enables conflict detection for saves.
+	// A save that overwrites fields changed by another writer since the save was made
+	// is reported to listeners as ActionConflict, following its ActionSave.
+	// Saves record the values they overwrite, so events are larger when enabled.
+	// See core.Conflict for detection limits.
+	ReportConflicts bool
+	// Datastore is the name of a datastore registered with WithNewDatastore.
+	// Instances and indexes of the collection are stored there instead of in the db's datastore,
+	// while events are still replicated through the db's thread.
+	// Collection metadata and the event log always stay in the db's datastore.
+	// Transactions are scoped to a single collection, so they never span datastores.
+	// The datastore of an existing collection can't be changed.
+	Datastore string
+	// EncryptValues enables encryption of stored instances with the key provided by WithNewValueKey.
+	// Indexes hold keyed tokens of field values instead of values, so only equality criteria can be
+	// used against single-field indexes, and the index-only counting of Count isn't available.
+	// Events sent to the network aren't affected. Value encryption of an existing collection
+	// can't be changed.
+	EncryptValues bool
+	// Computed maps paths of read-only fields to functions deriving their values from the stored instance.
+	// Computed fields are set on the results of Find, FindStream and FindByID, after the read filter,
+	// and are removed from instances on Create and Save, so they're never validated or stored.
+	// Computed fields can't be indexed. Since functions aren't persisted, they must be set again with
+	// UpdateCollection when a db is reopened.
+	Computed map[string]func(instance []byte) (interface{}, error)
+	// MaxInstances limits the number of instances in the collection.
+	// Creates which would exceed the limit fail with ErrQuotaExceeded.
+	// Instances created by remote peers aren't limited, so they may take the collection over it.
+	// Zero disables the limit.
+	MaxInstances int
+	// EventCodec is the name of the codec encoding the events of the collection, which is either
+	// a built-in codec, such as ReplaceEventCodec, or one registered with WithNewNamedEventCodec.
+	// The db's EventCodec is used by default. Records holding events of other codecs record the
+	// codec of each event, so they can only be read by peers which know those codecs.
+	// The codec of an existing collection can be changed, since events are always decoded and
+	// reduced by the codec which created them.
+	EventCodec string
+	// TTLField is the path of a field holding the time at which an instance expires, as an RFC 3339
+	// string or an integer number of unix nanoseconds. If TTL is set too, the field holds the time
+	// TTL starts from instead. Instances without a valid time in the field don't expire.
+	TTLField string
+	// TTL is how long instances live after the time in TTLField, or after they were created if
+	// TTLField isn't set. The creation time is taken from the instance ID, so instances whose ID
+	// isn't a generated one (a ULID) don't expire.
+	// Expired instances are never returned by reads, and are deleted by a background sweeper at the
+	// interval set with WithNewExpirySweepInterval. Deletes are sent to other peers as usual.
+	// Each peer decides expiry with its own clock, so with clock skew an instance may still be read
+	// on one peer after it expired on another, and several peers may delete it, which is harmless.
+	// Times in TTLField should be written with that skew in mind.
+	// Zero disables expiry unless TTLField is set.
+	TTL time.Duration
+	// VersionField is the name of a top-level field holding instance versions, e.g., "_version",
+	// which enables optimistic concurrency control. Create sets the version to 1, and Save fails
+	// with ErrVersionConflict unless the saved instance has the version of the stored one, which
+	// it then increments. Instances read with Find or FindByID hold their current version, so a
+	// conflicting save can be retried by reading the instance again. A missing version counts as 0.
+	// Versions are checked when the save is made, within its transaction, against the instances
+	// stored by this peer, so concurrent saves on other peers aren't detected (see ReportConflicts).
+	// Like _mod, the field is set after instances are validated, but the schema must allow it,
+	// since saved instances hold it.
+	VersionField string
+	// TrashField is the name of a top-level field holding the time an instance was moved to the
+	// trash, as an integer number of unix nanoseconds, which enables SoftDelete and Restore.
+	// Trashed instances are excluded from reads, unless queries include them with
+	// Query.IncludeTrashed. Since the field is saved like any other, soft-deletes and restores
+	// are sent to other peers. The schema must allow the field, but not require it.
+	TrashField string
+	// TrashRetention is how long trashed instances are kept before they're deleted, which is
+	// done at the interval set with WithNewExpirySweepInterval. Zero keeps them until they're
+	// deleted or restored. It requires TrashField.
+	TrashRetention time.Duration
+	// ReadKey is the name of a named read key of the thread key (see thread.Key.WithNamedRead)
+	// which encrypts the records holding events of the collection, instead of the thread read key.
+	// Peers holding only other read keys sync those records, but can't read them, so a thread can
+	// be shared with participants who may only read some of its collections.
+	// Since records are encrypted as a whole, a DBTxn can't span collections with different read keys.
+	// The key of an existing collection can be changed, which only affects new records.
+	ReadKey string
 }
 
 // NewCollection creates a new db collection with config.
@@ -374,6 +622,9 @@ func (d *DB) NewCollection(config CollectionConfig, opts ...Option) (*Collection
 	if _, ok := d.collections[config.Name]; ok {
 		return nil, ErrCollectionAlreadyRegistered
 	}
+	if err := d.checkReadKey(config.ReadKey, args.Token); err != nil {
+		return nil, err
+	}
 	c, err := newCollection(d, config)
 	if err != nil {
 		return nil, err
@@ -405,18 +656,45 @@ func (d *DB) UpdateCollection(config CollectionConfig, opts ...Option) (*Collect
 	if !ok {
 		return nil, ErrCollectionNotFound
 	}
+	if err := d.checkTokenRole(config.Name, args.Token, RoleAdmin); err != nil {
+		return nil, err
+	}
+	if xc.datastoreName != config.Datastore {
+		return nil, ErrCannotChangeDatastore
+	}
+	if (xc.indexKey != nil) != config.EncryptValues {
+		return nil, ErrCannotChangeValueEncryption
+	}
+	if err := d.checkReadKey(config.ReadKey, args.Token); err != nil {
+		return nil, err
+	}
 	c, err := newCollection(d, config)
 	if err != nil {
 		return nil, err
 	}
+	// Entries of indexes whose filter or multikey setting changed were added for the previous ones
+	var refiltered []Index
+	for _, index := range config.Indexes {
+		if x, ok := xc.indexes[index.Name()]; ok && (!index.sameFilter(x) || index.Multikey != x.Multikey) {
+			if err := c.clearIndex(index.Name()); err != nil {
+				return nil, err
+			}
+			refiltered = append(refiltered, x)
+		}
+	}
 	if err := d.addIndexes(c, config.Schema, config.Indexes, opts...); err != nil {
+		for _, x := range refiltered {
+			if err := xc.rebuildIndex(x); err != nil {
+				log.Errorf("error restoring index %s of %s: %v", x.Name(), c.name, err)
+			}
+		}
 		return nil, err
 	}
 
 	// Drop indexes that are no longer requested
 	for _, index := range xc.indexes {
-		if _, ok := c.indexes[index.Path]; !ok {
-			if err := c.dropIndex(index.Path); err != nil {
+		if _, ok := c.indexes[index.Name()]; !ok {
+			if err := c.dropIndex(index.Name()); err != nil {
 				return nil, err
 			}
 		}
@@ -425,6 +703,7 @@ func (d *DB) UpdateCollection(config CollectionConfig, opts ...Option) (*Collect
 	if err := d.saveCollection(c); err != nil {
 		return nil, err
 	}
+	d.queryCache.invalidateCollection(c.name)
 	return c, nil
 }
 
@@ -441,6 +720,21 @@ func (d *DB) addIndexes(c *Collection, schema *jsonschema.Schema, indexes []Inde
 	return c.addIndex(schema, Index{Path: idFieldName, Unique: true}, opts...)
 }
 
+// checkReadKey returns ErrReadKeyNotFound if the thread key doesn't hold the read key named name.
+func (d *DB) checkReadKey(name string, token thread.Token) error {
+	if name == "" {
+		return nil
+	}
+	info, err := d.connector.Net.GetThread(context.Background(), d.connector.ThreadID(), net.WithThreadToken(token))
+	if err != nil {
+		return err
+	}
+	if info.Key.NamedRead(name) == nil {
+		return fmt.Errorf("%w: %s", ErrReadKeyNotFound, name)
+	}
+	return nil
+}
+
 func (d *DB) saveCollection(c *Collection) error {
 	log.Debugf("saving collection %s in %s", c.name, d.name)
 	if err := d.datastore.Put(dsSchemas.ChildString(c.name), c.GetSchema()); err != nil {
@@ -456,6 +750,87 @@ func (d *DB) saveCollection(c *Collection) error {
 			return err
 		}
 	}
+	if c.reportConflicts {
+		if err := d.datastore.Put(dsConflicts.ChildString(c.name), []byte{}); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsConflicts.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.ignoreFormats {
+		if err := d.datastore.Put(dsFormats.ChildString(c.name), []byte{}); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsFormats.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.dynamic {
+		if err := d.datastore.Put(dsDynamic.ChildString(c.name), []byte{}); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsDynamic.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.datastoreName != "" {
+		if err := d.datastore.Put(dsDatastores.ChildString(c.name), []byte(c.datastoreName)); err != nil {
+			return err
+		}
+	}
+	if c.indexKey != nil {
+		if err := d.datastore.Put(dsEncrypted.ChildString(c.name), []byte{}); err != nil {
+			return err
+		}
+	}
+	if c.eventCodec != "" {
+		if err := d.datastore.Put(dsCodecs.ChildString(c.name), []byte(c.eventCodec)); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsCodecs.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.readKey != "" {
+		if err := d.datastore.Put(dsReadKeys.ChildString(c.name), []byte(c.readKey)); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsReadKeys.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.maxInstances > 0 {
+		if err := d.datastore.Put(dsLimits.ChildString(c.name), []byte(strconv.Itoa(c.maxInstances))); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsLimits.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.expiry != nil {
+		e, err := json.Marshal(c.expiry)
+		if err != nil {
+			return err
+		}
+		if err := d.datastore.Put(dsExpiry.ChildString(c.name), e); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsExpiry.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.versionField != "" {
+		if err := d.datastore.Put(dsVersionFields.ChildString(c.name), []byte(c.versionField)); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsVersionFields.ChildString(c.name)); err != nil {
+		return err
+	}
+	if c.trash != nil {
+		t, err := json.Marshal(c.trash)
+		if err != nil {
+			return err
+		}
+		if err := d.datastore.Put(dsTrash.ChildString(c.name), t); err != nil {
+			return err
+		}
+	} else if err := d.datastore.Delete(dsTrash.ChildString(c.name)); err != nil {
+		return err
+	}
 	d.collections[c.name] = c
 	return nil
 }
@@ -512,6 +887,9 @@ func (d *DB) DeleteCollection(name string, opts ...Option) error {
 	if !ok {
 		return ErrCollectionNotFound
 	}
+	if err := d.checkTokenRole(name, args.Token, RoleAdmin); err != nil {
+		return err
+	}
 	txn, err := d.datastore.NewTransaction(false)
 	if err != nil {
 		return err
@@ -528,10 +906,47 @@ func (d *DB) DeleteCollection(name string, opts ...Option) error {
 	if err := txn.Delete(dsFilters.ChildString(c.name)); err != nil {
 		return err
 	}
+	if err := txn.Delete(dsConflicts.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsFormats.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsDynamic.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsDatastores.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsMigrations.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsEncrypted.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsLimits.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsCodecs.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsReadKeys.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsExpiry.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsVersionFields.ChildString(c.name)); err != nil {
+		return err
+	}
+	if err := txn.Delete(dsTrash.ChildString(c.name)); err != nil {
+		return err
+	}
 	if err := txn.Commit(); err != nil {
 		return err
 	}
 	delete(d.collections, c.name)
+	d.queryCache.invalidateCollection(c.name)
 	return nil
 }
 
@@ -546,6 +961,9 @@ func (d *DB) Close() error {
 		return nil
 	}
 	d.closed = true
+	if d.stopSweep != nil {
+		close(d.stopSweep)
+	}
 	d.localEventsBus.Discard()
 	d.stateChangedNotifee.close()
 	return nil
@@ -553,11 +971,73 @@ func (d *DB) Close() error {
 
 func (d *DB) Reduce(events []core.Event) error {
 	log.Debugf("reducing events in %s", d.name)
-	codecActions, err := d.eventcodec.Reduce(events, d.datastore, baseKey, defaultIndexFunc(d))
+	var actions []Action
+	indexFunc := defaultIndexFunc(d)
+	for len(events) > 0 {
+		// Reduce runs of events stored in the same datastore together.
+		store := d.storeFor(events[0].Collection())
+		n := 1
+		for n < len(events) && d.storeFor(events[n].Collection()) == store {
+			n++
+		}
+		run, err := d.reduceRun(events[:n], store, indexFunc)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, run...)
+		events = events[n:]
+	}
+	d.notifyStateChanged(actions)
+	return nil
+}
+
+// reduceRun reduces events stored in store, and logs their actions in the same transaction,
+// so that positions can't miss applied actions. Callers must hold txnlock.
+func (d *DB) reduceRun(events []core.Event, store kt.TxnDatastoreExtended, indexFunc core.IndexFunc) ([]Action, error) {
+	txn, err := store.NewTransaction(false)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer txn.Discard()
+	// Record the state of each instance after its action, or before it for deletes,
+	// so listeners can filter actions by query.
+	var reduced [][]byte
+	ca, err := d.eventcodec.Reduce(events, reduceStore{TxnDatastore: store, txn: txn}, baseKey, func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
+		if err := indexFunc(collection, key, oldData, newData, txn); err != nil {
+			return err
+		}
+		d.queryCache.invalidate(collection, oldData, newData)
+		d.quota.update(collection, oldData, newData)
+		if newData != nil {
+			reduced = append(reduced, newData)
+		} else {
+			reduced = append(reduced, oldData)
+		}
+		return nil
+	}, defaultConflictFunc(d))
+	if err != nil {
+		return nil, err
+	}
+	if len(reduced) != len(ca) {
+		// The codec didn't index each action in order
+		reduced = make([][]byte, len(ca))
+	}
+	actions := d.reducedActions(ca, reduced)
+	p, err := d.logActions(txn, actions, d.position)
+	if err != nil {
+		return nil, err
+	}
+	if err := txn.Commit(); err != nil {
+		return nil, err
 	}
-	actions := make([]Action, len(codecActions))
+	d.position = p
+	return actions, nil
+}
+
+// reducedActions returns the actions of actions reduced by the event codec.
+// states holds the state of each instance after its action, or before it for deletes.
+func (d *DB) reducedActions(codecActions []core.ReduceAction, states [][]byte) []Action {
+	actions := make([]Action, 0, len(codecActions))
 	for i, ca := range codecActions {
 		var actionType ActionType
 		switch codecActions[i].Type {
@@ -570,12 +1050,64 @@ func (d *DB) Reduce(events []core.Event) error {
 		default:
 			panic("eventcodec action not recognized")
 		}
-		actions[i] = Action{Collection: ca.Collection, Type: actionType, ID: ca.InstanceID}
+		actions = append(actions, Action{
+			Collection: ca.Collection,
+			Type:       actionType,
+			ID:         ca.InstanceID,
+			Author:     d.author,
+			instance:   states[i],
+		})
+		if len(ca.Conflicts) > 0 {
+			actions = append(actions, Action{
+				Collection: ca.Collection,
+				Type:       ActionConflict,
+				ID:         ca.InstanceID,
+				Conflicts:  ca.Conflicts,
+				Author:     d.author,
+				instance:   states[i],
+			})
+		}
 	}
-	d.notifyStateChanged(actions)
+	return actions
+}
+
+// reduceStore is a datastore whose transactions are txn, so that the event codec
+// reduces events in the transaction of the db. The db commits or discards txn.
+type reduceStore struct {
+	ds.TxnDatastore
+	txn ds.Txn
+}
+
+func (s reduceStore) NewTransaction(bool) (ds.Txn, error) {
+	return reduceTxn{Txn: s.txn}, nil
+}
+
+// reduceTxn is a transaction which is committed or discarded by its owner.
+type reduceTxn struct {
+	ds.Txn
+}
+
+func (reduceTxn) Commit() error {
 	return nil
 }
 
+func (reduceTxn) Discard() {}
+
+// storeFor returns the datastore holding instances of a collection.
+func (d *DB) storeFor(collection string) kt.TxnDatastoreExtended {
+	if c := d.GetCollection(collection); c != nil {
+		return c.store
+	}
+	return d.datastore
+}
+
+func defaultConflictFunc(d *DB) func(collection string) bool {
+	return func(collection string) bool {
+		c := d.GetCollection(collection)
+		return c != nil && c.reportConflicts
+	}
+}
+
 func defaultIndexFunc(d *DB) func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
 	return func(collection string, key ds.Key, oldData, newData []byte, txn ds.Txn) error {
 		c := d.GetCollection(collection)
@@ -606,6 +1138,9 @@ func (d *DB) ValidateNetRecordBody(_ context.Context, body format.Node, identity
 		if !ok {
 			return ErrCollectionNotFound
 		}
+		if err := d.checkRole(c.name, identity, RoleWriter); err != nil {
+			return err
+		}
 		if err := c.validWrite(identity, e); err != nil {
 			return err
 		}
@@ -639,7 +1174,20 @@ func (d *DB) HandleNetRecord(ctx context.Context, rec net.ThreadRecord, key thre
 		return fmt.Errorf("error when unmarshaling event from bytes: %v", err)
 	}
 	log.Debugf("dispatching new record: %s/%s", rec.ThreadID(), rec.LogID())
-	return d.dispatch(events)
+	return d.dispatch(rec.Value().Cid(), recordAuthor(rec.Value()), events)
+}
+
+// recordAuthor returns the identity which authored rec, or nil if it's unknown.
+func recordAuthor(rec net.Record) thread.PubKey {
+	if len(rec.PubKey()) == 0 {
+		return nil
+	}
+	author := &thread.Libp2pPubKey{}
+	if err := author.UnmarshalBinary(rec.PubKey()); err != nil {
+		log.Warnf("decoding author of record %s: %v", rec.Cid(), err)
+		return nil
+	}
+	return author
 }
 
 // getBlockWithRetry gets a record block with exponential backoff.
@@ -658,29 +1206,71 @@ func (d *DB) getBlockWithRetry(ctx context.Context, rec net.Record) (format.Node
 	return nil, err
 }
 
-// dispatch applies external events to the db. This function guarantee
-// no interference with registered collection states, and viceversa.
-func (d *DB) dispatch(events []core.Event) error {
+// dispatch applies external events of record rec, written by author, to the db. This function
+// guarantee no interference with registered collection states, and viceversa.
+func (d *DB) dispatch(rec cid.Cid, author thread.PubKey, events []core.Event) error {
 	log.Debugf("dispatching events in %s", d.name)
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
-	if err := d.dispatcher.Dispatch(events); err != nil {
+	if err := d.dispatchRecord(rec, author, events); err != nil {
 		return err
 	}
 	log.Debugf("dispatched events in %s", d.name)
 	return nil
 }
 
+// dispatchRecord applies the events of record rec, written by author, to the db, and makes
+// rec its head. Callers must hold txnlock.
+func (d *DB) dispatchRecord(rec cid.Cid, author thread.PubKey, events []core.Event) error {
+	d.author = author
+	err := d.dispatcher.Dispatch(events)
+	d.author = nil
+	if err != nil {
+		return err
+	}
+	if err := d.setInstanceRecords(rec, events); err != nil {
+		return err
+	}
+	return d.setHead(rec)
+}
+
+// sync flushes the writes buffered by the db datastores. Callers must hold txnlock.
+func (d *DB) sync() error {
+	stores := []kt.TxnDatastoreExtended{d.datastore, d.dispatcher.Store()}
+	for _, s := range d.datastores {
+		stores = append(stores, s)
+	}
+	for _, s := range stores {
+		if err := s.Sync(ds.NewKey("")); err != nil {
+			return fmt.Errorf("syncing datastore: %w", err)
+		}
+	}
+	return nil
+}
+
 func (d *DB) readTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
 	log.Debugf("starting read txn in %s", d.name)
-	d.txnlock.RLock()
-	defer d.txnlock.RUnlock()
-
+	d.metrics.txn("read")
 	args := &TxnOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
-	txn := &Txn{collection: c, token: args.Token, readonly: true}
+	// Pulled records are applied under the txn lock, so pull before taking it.
+	// Views share the connector of their db, but pulled records never reach them.
+	var stale bool
+	if args.Consistency == Strong && !d.view {
+		stale = d.pullForRead(args.Token)
+	}
+	if args.Stale != nil {
+		*args.Stale = stale
+	}
+	d.txnlock.RLock()
+	defer d.txnlock.RUnlock()
+
+	if err := d.checkTokenRole(c.name, args.Token, RoleReader); err != nil {
+		return err
+	}
+	txn := &Txn{collection: c, token: args.Token, readonly: true, stale: stale}
 	defer txn.Discard()
 	if err := f(txn); err != nil {
 		return err
@@ -689,8 +1279,12 @@ func (d *DB) readTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) e
 	return nil
 }
 
-func (d *DB) writeTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
+func (d *DB) writeTxn(ctx context.Context, c *Collection, f func(txn *Txn) error, opts ...TxnOption) error {
+	if d.readOnly {
+		return ErrReadOnly
+	}
 	log.Debugf("starting write txn in %s", d.name)
+	d.metrics.txn("write")
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
 
@@ -698,13 +1292,19 @@ func (d *DB) writeTxn(c *Collection, f func(txn *Txn) error, opts ...TxnOption)
 	for _, opt := range opts {
 		opt(args)
 	}
+	if err := d.checkTokenRole(c.name, args.Token, RoleReader); err != nil {
+		return err
+	}
 	txn := &Txn{collection: c, token: args.Token}
 	defer txn.Discard()
 	if err := f(txn); err != nil {
 		return err
 	}
-	if err := txn.Commit(); err != nil {
-		return err
+	// f may have committed the txn already
+	if !txn.committed {
+		if err := txn.commit(ctx); err != nil {
+			return err
+		}
 	}
 	log.Debugf("ending write txn in %s", d.name)
 	return nil
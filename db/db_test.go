@@ -1,8 +1,12 @@
 package db
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"reflect"
@@ -12,12 +16,15 @@ import (
 	"time"
 
 	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/multiformats/go-multiaddr"
+	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/common"
 	"github.com/textileio/go-threads/core/app"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/replacer"
 	"github.com/textileio/go-threads/util"
 )
 
@@ -275,6 +282,7 @@ func TestListeners(t *testing.T) {
 			t.Fatalf("number of actions isn't correct, expected %d, got %d", len(expected), len(actions))
 		}
 		for i := range actions {
+			actions[i] = comparableAction(actions[i])
 			if !reflect.DeepEqual(actions[i], expected[i]) {
 				t.Fatalf("wrong action detect, expected %v, got %v", expected[i], actions[i])
 			}
@@ -392,6 +400,1087 @@ func TestListeners(t *testing.T) {
 	})
 }
 
+func TestConflicts(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	cc := CollectionConfig{
+		Name:            "Person",
+		Schema:          util.SchemaFromInstance(&Person{}, false),
+		ReportConflicts: true,
+	}
+	c, err := d.NewCollection(cc)
+	checkErr(t, err)
+
+	t.Run("Persisted", func(t *testing.T) {
+		checkErr(t, d.reCreateCollections())
+		if !d.GetCollection("Person").GetReportConflicts() {
+			t.Fatal("report conflicts should be persisted")
+		}
+		cc.ReportConflicts = false
+		_, err := d.UpdateCollection(cc)
+		checkErr(t, err)
+		checkErr(t, d.reCreateCollections())
+		if d.GetCollection("Person").GetReportConflicts() {
+			t.Fatal("report conflicts should be disabled")
+		}
+		cc.ReportConflicts = true
+		c, err = d.UpdateCollection(cc)
+		checkErr(t, err)
+	})
+	t.Run("Reduce", func(t *testing.T) {
+		id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+		checkErr(t, err)
+		original, err := c.FindByID(id)
+		checkErr(t, err)
+
+		// Craft a save made concurrently with the local saves below.
+		events, _, err := d.eventcodec.Create([]core.Action{{
+			Type:            core.Save,
+			InstanceID:      id,
+			CollectionName:  "Person",
+			Previous:        original,
+			Current:         util.SetJSONProperty("Name", "Bob", original),
+			DetectConflicts: true,
+		}})
+		checkErr(t, err)
+
+		checkErr(t, c.Save(util.SetJSONProperty("Name", "Carol", original)))
+		current, err := c.FindByID(id)
+		checkErr(t, err)
+		checkErr(t, c.Save(util.SetJSONProperty("Age", 31, current)))
+
+		saves, err := d.Listen(ListenOption{Type: ListenSave})
+		checkErr(t, err)
+		defer saves.Close()
+		conflicts, err := d.Listen(ListenOption{Type: ListenConflict})
+		checkErr(t, err)
+		defer conflicts.Close()
+		checkErr(t, d.Reduce(events))
+
+		expected := Action{Collection: "Person", Type: ActionSave, ID: id}
+		if a := <-saves.Channel(); !reflect.DeepEqual(comparableAction(a), expected) {
+			t.Fatalf("expected action %v, got %v", expected, a)
+		}
+		expected = Action{Collection: "Person", Type: ActionConflict, ID: id, Conflicts: []core.Conflict{
+			{Path: "Name", Winner: []byte(`"Bob"`), Loser: []byte(`"Carol"`)},
+		}}
+		if a := <-conflicts.Channel(); !reflect.DeepEqual(comparableAction(a), expected) {
+			t.Fatalf("expected action %v, got %v", expected, a)
+		}
+		instance, err := c.FindByID(id)
+		checkErr(t, err)
+		p := &Person{}
+		util.InstanceFromJSON(instance, p)
+		if p.Name != "Bob" || p.Age != 31 {
+			t.Fatalf("unexpected instance state %v", p)
+		}
+	})
+}
+
+func TestCollectionDatastore(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "")
+	checkErr(t, err)
+	defer os.RemoveAll(dir)
+	eu, err := util.NewBadgerDatastore(dir, "eu", false)
+	checkErr(t, err)
+	defer eu.Close()
+	d, clean := createTestDB(t, WithNewDatastore("eu", eu))
+	defer clean()
+
+	cc := CollectionConfig{
+		Name:      "Person",
+		Schema:    util.SchemaFromInstance(&Person{}, false),
+		Indexes:   []Index{{Path: "Name"}},
+		Datastore: "eu",
+	}
+	c, err := d.NewCollection(cc)
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+
+	key := baseKey.ChildString("Person").ChildString(id.String())
+	if ok, err := eu.Has(key); err != nil || !ok {
+		t.Fatalf("instance should be stored in the bound datastore: %v", err)
+	}
+	if ok, err := d.datastore.Has(key); err != nil || ok {
+		t.Fatalf("instance shouldn't be stored in the db datastore: %v", err)
+	}
+	res, err := c.Find(Where("Name").Eq("Alice"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(res))
+	}
+
+	t.Run("Persisted", func(t *testing.T) {
+		checkErr(t, d.reCreateCollections())
+		if d.GetCollection("Person").GetDatastore() != "eu" {
+			t.Fatal("datastore binding should be persisted")
+		}
+	})
+	t.Run("Fail/ChangeDatastore", func(t *testing.T) {
+		cc := cc
+		cc.Datastore = ""
+		if _, err := d.UpdateCollection(cc); !errors.Is(err, ErrCannotChangeDatastore) {
+			t.Fatalf("expected error %v, got %v", ErrCannotChangeDatastore, err)
+		}
+	})
+	t.Run("Fail/UnknownDatastore", func(t *testing.T) {
+		_, err := d.NewCollection(CollectionConfig{
+			Name:      "Dog",
+			Schema:    util.SchemaFromInstance(&Person{}, false),
+			Datastore: "us",
+		})
+		if !errors.Is(err, ErrDatastoreNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrDatastoreNotFound, err)
+		}
+	})
+}
+
+func TestEncryptValues(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t, WithNewValueKey(sym.New()))
+	defer clean()
+
+	cc := CollectionConfig{
+		Name:          "Person",
+		Schema:        util.SchemaFromInstance(&Person{}, false),
+		Indexes:       []Index{{Path: "Name", Unique: true}, {Paths: []string{"Name", "Age"}}},
+		EncryptValues: true,
+	}
+	c, err := d.NewCollection(cc)
+	checkErr(t, err)
+	alice, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 40}))
+	checkErr(t, err)
+
+	t.Run("Stored", func(t *testing.T) {
+		res, err := d.datastore.Query(query.Query{Prefix: "/"})
+		checkErr(t, err)
+		defer res.Close()
+		for r := range res.Next() {
+			checkErr(t, r.Error)
+			if strings.Contains(r.Key, "Alice") || bytes.Contains(r.Value, []byte("Alice")) {
+				t.Fatalf("plaintext value stored at %s", r.Key)
+			}
+		}
+	})
+	t.Run("Read", func(t *testing.T) {
+		instance, err := c.FindByID(alice)
+		checkErr(t, err)
+		p := &Person{}
+		util.InstanceFromJSON(instance, p)
+		if p.Name != "Alice" || p.Age != 30 {
+			t.Fatal("instance should be decrypted")
+		}
+		for _, q := range []*Query{
+			Where("Name").Eq("Alice").UseIndex("Name"),
+			Where("Name").Eq("Alice").And("Age").Eq(30.0),
+			Where("Age").Lt(35.0),
+		} {
+			res, err := c.Find(q)
+			checkErr(t, err)
+			if len(res) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(res))
+			}
+			n, err := c.Count(q)
+			checkErr(t, err)
+			if n != 1 {
+				t.Fatalf("expected count 1, got %d", n)
+			}
+		}
+	})
+	t.Run("Unique", func(t *testing.T) {
+		_, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 31}))
+		if !errors.Is(err, ErrUniqueExists) {
+			t.Fatalf("expected error %v, got %v", ErrUniqueExists, err)
+		}
+	})
+	t.Run("Fail/RangeOnIndex", func(t *testing.T) {
+		_, err := c.Find(Where("Name").Gt("A").UseIndex("Name"))
+		if !errors.Is(err, ErrEncryptedIndexOperation) {
+			t.Fatalf("expected error %v, got %v", ErrEncryptedIndexOperation, err)
+		}
+	})
+	t.Run("Fail/ChangeEncryption", func(t *testing.T) {
+		cc := cc
+		cc.EncryptValues = false
+		if _, err := d.UpdateCollection(cc); !errors.Is(err, ErrCannotChangeValueEncryption) {
+			t.Fatalf("expected error %v, got %v", ErrCannotChangeValueEncryption, err)
+		}
+	})
+	t.Run("Persisted", func(t *testing.T) {
+		checkErr(t, d.reCreateCollections())
+		if !d.GetCollection("Person").GetEncryptValues() {
+			t.Fatal("value encryption should be persisted")
+		}
+	})
+	t.Run("Fail/KeyRequired", func(t *testing.T) {
+		d, clean := createTestDB(t)
+		defer clean()
+		if _, err := d.NewCollection(cc); !errors.Is(err, ErrValueKeyRequired) {
+			t.Fatalf("expected error %v, got %v", ErrValueKeyRequired, err)
+		}
+	})
+}
+
+func TestMigrateCollection(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	alice, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 40}))
+	checkErr(t, err)
+
+	type personV2 struct {
+		ID       core.InstanceID `json:"_id"`
+		Mod      int64           `json:"_mod"`
+		FullName string
+		Age      int
+	}
+	cc := CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&personV2{}, false),
+		Indexes: []Index{{Path: "FullName"}},
+	}
+	var calls int
+	rename := func(old []byte) ([]byte, error) {
+		calls++
+		var p map[string]interface{}
+		if err := json.Unmarshal(old, &p); err != nil {
+			return nil, err
+		}
+		p["FullName"] = p["Name"]
+		delete(p, "Name")
+		return json.Marshal(p)
+	}
+	schema := c.GetSchema()
+	assertUnchanged := func(t *testing.T) {
+		if !reflect.DeepEqual(d.GetCollection("Person").GetSchema(), schema) {
+			t.Fatal("collection config shouldn't change")
+		}
+		instance, err := c.FindByID(alice)
+		checkErr(t, err)
+		p := &Person{}
+		util.InstanceFromJSON(instance, p)
+		if p.Name != "Alice" {
+			t.Fatal("instances shouldn't change")
+		}
+		if _, resuming, err := d.getMigration("Person"); err != nil || resuming {
+			t.Fatalf("migration marker shouldn't be written: %v", err)
+		}
+	}
+
+	t.Run("Fail/NameMismatch", func(t *testing.T) {
+		cc := cc
+		cc.Name = "Dog"
+		if err := d.MigrateCollection("Person", cc, rename); !errors.Is(err, ErrMigrationNameMismatch) {
+			t.Fatalf("expected error %v, got %v", ErrMigrationNameMismatch, err)
+		}
+	})
+	t.Run("Fail/Transform", func(t *testing.T) {
+		errTransform := errors.New("transform failed")
+		err := d.MigrateCollection("Person", cc, func(old []byte) ([]byte, error) {
+			return nil, errTransform
+		})
+		if !errors.Is(err, errTransform) {
+			t.Fatalf("expected error %v, got %v", errTransform, err)
+		}
+		assertUnchanged(t)
+	})
+	t.Run("Fail/InvalidInstance", func(t *testing.T) {
+		err := d.MigrateCollection("Person", cc, func(old []byte) ([]byte, error) {
+			return old, nil
+		})
+		if !errors.Is(err, ErrInvalidSchemaInstance) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSchemaInstance, err)
+		}
+		assertUnchanged(t)
+	})
+	t.Run("Fail/ChangedID", func(t *testing.T) {
+		err := d.MigrateCollection("Person", cc, func(old []byte) ([]byte, error) {
+			next, err := rename(old)
+			if err != nil {
+				return nil, err
+			}
+			return util.SetJSONID(core.NewInstanceID(), next), nil
+		})
+		if !errors.Is(err, errMigrationChangedID) {
+			t.Fatalf("expected error %v, got %v", errMigrationChangedID, err)
+		}
+		assertUnchanged(t)
+	})
+
+	// Simulate a migration that stopped after recording its instances.
+	m, err := newMigration(c)
+	checkErr(t, err)
+	checkErr(t, d.putMigration("Person", m))
+	calls = 0
+	checkErr(t, d.MigrateCollection("Person", cc, rename))
+	if calls != 4 {
+		t.Fatalf("expected each instance to be transformed twice, got %d calls", calls)
+	}
+	c = d.GetCollection("Person")
+	res, err := c.Find(Where("FullName").Eq("Alice"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(res))
+	}
+	p := &personV2{}
+	util.InstanceFromJSON(res[0], p)
+	if p.ID != alice || p.Age != 30 {
+		t.Fatal("migrated instance has wrong values")
+	}
+	if _, resuming, err := d.getMigration("Person"); err != nil || resuming {
+		t.Fatalf("migration marker should be removed: %v", err)
+	}
+
+	t.Run("ResumeFinished", func(t *testing.T) {
+		// Instances saved by the migration aren't transformed again.
+		checkErr(t, d.putMigration("Person", m))
+		calls = 0
+		checkErr(t, d.MigrateCollection("Person", cc, rename))
+		if calls != 0 {
+			t.Fatalf("expected no transforms, got %d calls", calls)
+		}
+	})
+}
+
+func TestImportCollection(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	config := CollectionConfig{
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name", Unique: true}},
+	}
+
+	t.Run("JSONLines", func(t *testing.T) {
+		data := `{"Name": "Alice", "Age": 42}
+
+{"Name": 42}
+not json
+{"Name": "Bob", "Age": 43}
+{"Name": "Alice", "Age": 44}
+{"Name": "Carl", "Age": 45}
+`
+		imported, failed, err := d.ImportCollection("Lines", config, strings.NewReader(data), ImportJSONLines, WithImportBatchSize(2))
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+		if imported != 3 || failed != 3 {
+			t.Fatalf("expected 3 imported and 3 failed records, got %d and %d", imported, failed)
+		}
+		expected := map[int]error{1: ErrInvalidSchemaInstance, 2: ErrInvalidImportRecord, 4: ErrUniqueExists}
+		for _, e := range verr.Errs {
+			if !errors.Is(e, expected[e.Index]) {
+				t.Fatalf("expected record %d to fail with %v, got %v", e.Index, expected[e.Index], e.Err)
+			}
+		}
+		n, err := d.GetCollection("Lines").Count(&Query{})
+		checkErr(t, err)
+		if n != 3 {
+			t.Fatalf("expected 3 instances, got %d", n)
+		}
+	})
+
+	t.Run("JSONArray", func(t *testing.T) {
+		data := `[{"Name": "Alice", "Age": 42}, {"Name": "Bob", "Age": 43}]`
+		imported, failed, err := d.ImportCollection("Array", config, strings.NewReader(data), ImportJSONArray)
+		checkErr(t, err)
+		if imported != 2 || failed != 0 {
+			t.Fatalf("expected 2 imported records, got %d and %d failed", imported, failed)
+		}
+	})
+
+	t.Run("Abort", func(t *testing.T) {
+		data := `[{"Name": "Alice", "Age": 42}, {"Name": 42}]`
+		_, _, err := d.ImportCollection("Abort", config, strings.NewReader(data), ImportJSONArray, WithImportAbortOnError())
+		var ierr *InstanceError
+		if !errors.As(err, &ierr) || ierr.Index != 1 {
+			t.Fatalf("expected record 1 to abort the import, got %v", err)
+		}
+		if d.GetCollection("Abort") != nil {
+			t.Fatal("expected collection of aborted import to be deleted")
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		data := `[{"Name": "Alice", "Age": 42}, {"Name"`
+		if _, _, err := d.ImportCollection("Malformed", config, strings.NewReader(data), ImportJSONArray); err == nil {
+			t.Fatal("expected malformed dataset to abort the import")
+		}
+		if d.GetCollection("Malformed") != nil {
+			t.Fatal("expected collection of aborted import to be deleted")
+		}
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "")
+	checkErr(t, err)
+	defer os.RemoveAll(dir)
+	eu, err := util.NewBadgerDatastore(dir, "eu", false)
+	checkErr(t, err)
+	defer eu.Close()
+	d, clean := createTestDB(t, WithNewDatastore("eu", eu))
+	defer clean()
+
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Age"}},
+	})
+	checkErr(t, err)
+	alice, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 30}))
+	checkErr(t, err)
+	dogs, err := d.NewCollection(CollectionConfig{
+		Name:      "Dog",
+		Schema:    util.SchemaFromInstance(&Person{}, false),
+		Indexes:   []Index{{Path: "Name"}},
+		Datastore: "eu",
+	})
+	checkErr(t, err)
+	fido, err := dogs.Create(util.JSONFromInstance(Person{Name: "Fido", Age: 3}))
+	checkErr(t, err)
+
+	r, err := d.Snapshot(context.Background())
+	checkErr(t, err)
+	// Writes made while the snapshot is open aren't included.
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Carol", Age: 30}))
+	checkErr(t, err)
+	snapshot, err := ioutil.ReadAll(r)
+	checkErr(t, err)
+	checkErr(t, r.Close())
+
+	restoreDir, err := ioutil.TempDir("", "")
+	checkErr(t, err)
+	defer os.RemoveAll(restoreDir)
+	store, err := util.NewBadgerDatastore(restoreDir, "eventstore", false)
+	checkErr(t, err)
+	defer store.Close()
+	eu2, err := util.NewBadgerDatastore(restoreDir, "eu", false)
+	checkErr(t, err)
+	defer eu2.Close()
+
+	t.Run("Fail/DatastoreNotFound", func(t *testing.T) {
+		err := RestoreSnapshot(store, bytes.NewReader(snapshot))
+		if !errors.Is(err, ErrDatastoreNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrDatastoreNotFound, err)
+		}
+	})
+	t.Run("Fail/Truncated", func(t *testing.T) {
+		err := RestoreSnapshot(store, bytes.NewReader(snapshot[:len(snapshot)-8]), WithNewDatastore("eu", eu2))
+		if !errors.Is(err, ErrInvalidSnapshot) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidSnapshot, err)
+		}
+	})
+	t.Run("Fail/NotEmpty", func(t *testing.T) {
+		err := RestoreSnapshot(d.datastore, bytes.NewReader(snapshot), WithNewDatastore("eu", eu2))
+		if !errors.Is(err, ErrSnapshotTargetNotEmpty) {
+			t.Fatalf("expected error %v, got %v", ErrSnapshotTargetNotEmpty, err)
+		}
+	})
+
+	checkErr(t, RestoreSnapshot(store, bytes.NewReader(snapshot), WithNewDatastore("eu", eu2)))
+
+	n, err := common.DefaultNetwork(
+		common.WithNetBadgerPersistence(restoreDir),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+	)
+	checkErr(t, err)
+	defer n.Close()
+	d2, err := NewDB(context.Background(), store, n, thread.NewIDV1(thread.Raw, 32), WithNewDatastore("eu", eu2))
+	checkErr(t, err)
+	defer d2.Close()
+
+	if d2.name != d.name {
+		t.Fatalf("expected name %s, got %s", d.name, d2.name)
+	}
+	c2 := d2.GetCollection("Person")
+	if c2 == nil {
+		t.Fatal("collection should be restored")
+	}
+	res, err := c2.Find(Where("Age").Eq(30).UseIndex("Age"))
+	checkErr(t, err)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(res))
+	}
+	instance, err := c2.FindByID(alice)
+	checkErr(t, err)
+	p := &Person{}
+	util.InstanceFromJSON(instance, p)
+	if p.Name != "Alice" {
+		t.Fatal("restored instance has wrong values")
+	}
+	dogs2 := d2.GetCollection("Dog")
+	if dogs2 == nil || dogs2.GetDatastore() != "eu" {
+		t.Fatal("bound collection should be restored")
+	}
+	res, err = dogs2.Find(Where("Name").Eq("Fido").UseIndex("Name"))
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(res))
+	}
+	if ok, err := eu2.Has(baseKey.ChildString("Dog").ChildString(fido.String())); err != nil || !ok {
+		t.Fatalf("bound instance should be restored to its datastore: %v", err)
+	}
+}
+
+func TestListenResume(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	l, err := d.Listen(ListenOption{Type: ListenCreate})
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Alice"}))
+	checkErr(t, err)
+	first := <-l.Channel()
+	l.Close()
+	if first.ResumeToken == "" {
+		t.Fatal("expected action to have a resume token")
+	}
+
+	// Actions created while nobody is listening are replayed on resume.
+	var ids []core.InstanceID
+	for _, n := range []string{"Bob", "Carol"} {
+		id, err := c.Create(util.JSONFromInstance(Person{Name: n}))
+		checkErr(t, err)
+		ids = append(ids, id)
+	}
+	l, err = d.ListenResume(first.ResumeToken, ListenOption{Type: ListenCreate})
+	checkErr(t, err)
+	defer l.Close()
+	for _, id := range ids {
+		if a := <-l.Channel(); a.ID != id || a.Type != ActionCreate {
+			t.Fatalf("expected create of %s, got %v", id, a)
+		}
+	}
+	select {
+	case a := <-l.Channel():
+		t.Fatalf("unexpected action %v", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	t.Run("Fail/Expired", func(t *testing.T) {
+		for _, rt := range []ResumeToken{"", "unknown.1", first.ResumeToken + "0"} {
+			if _, err := d.ListenResume(rt); !errors.Is(err, ErrResumeTokenExpired) {
+				t.Fatalf("expected error %v for token %q, got %v", ErrResumeTokenExpired, rt, err)
+			}
+		}
+	})
+}
+
+func TestListenBatch(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	t.Run("Wait", func(t *testing.T) {
+		l, err := d.ListenBatch(200*time.Millisecond, 100)
+		checkErr(t, err)
+		defer l.Close()
+		ids, err := c.CreateMany([][]byte{
+			util.JSONFromInstance(Person{Name: "Alice"}),
+			util.JSONFromInstance(Person{Name: "Bob"}),
+			util.JSONFromInstance(Person{Name: "Carol"}),
+		})
+		checkErr(t, err)
+		checkErr(t, c.Save(util.JSONFromInstance(Person{ID: ids[0], Name: "Alicia"})))
+		checkErr(t, c.Delete(ids[1]))
+		dave, err := c.Create(util.JSONFromInstance(Person{Name: "Dave"}))
+		checkErr(t, err)
+
+		batch := <-l.Channel()
+		expected := []core.InstanceID{ids[2], ids[0], dave}
+		if len(batch) != len(expected) {
+			t.Fatalf("expected %d actions, got %v", len(expected), batch)
+		}
+		for i, a := range batch {
+			if a.ID != expected[i] || a.Type != ActionCreate {
+				t.Fatalf("expected create of %s, got %v", expected[i], a)
+			}
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		l, err := d.ListenBatch(time.Hour, 2, ListenOption{Type: ListenCreate})
+		checkErr(t, err)
+		defer l.Close()
+		_, err = c.CreateMany([][]byte{
+			util.JSONFromInstance(Person{Name: "Eve"}),
+			util.JSONFromInstance(Person{Name: "Frank"}),
+		})
+		checkErr(t, err)
+		select {
+		case batch := <-l.Channel():
+			if len(batch) != 2 {
+				t.Fatalf("expected 2 actions, got %v", batch)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected full batch to be delivered")
+		}
+	})
+
+	t.Run("Collapse", func(t *testing.T) {
+		actions := collapseActions([]Action{
+			{Collection: "Person", Type: ActionDelete, ID: "a"},
+			{Collection: "Person", Type: ActionCreate, ID: "a"},
+			{Collection: "Person", Type: ActionCreate, ID: "b"},
+			{Collection: "Person", Type: ActionDelete, ID: "b"},
+			{Collection: "Person", Type: ActionCreate, ID: "b"},
+			{Collection: "Person", Type: ActionConflict, ID: "c"},
+			{Collection: "Person", Type: ActionSave, ID: "c"},
+		})
+		expected := []Action{
+			{Collection: "Person", Type: ActionSave, ID: "a"},
+			{Collection: "Person", Type: ActionCreate, ID: "b"},
+			{Collection: "Person", Type: ActionConflict, ID: "c"},
+			{Collection: "Person", Type: ActionSave, ID: "c"},
+		}
+		if !reflect.DeepEqual(actions, expected) {
+			t.Fatalf("expected %v, got %v", expected, actions)
+		}
+	})
+
+	t.Run("Fail/Invalid", func(t *testing.T) {
+		if _, err := d.ListenBatch(0, 1); !errors.Is(err, ErrInvalidListenBatch) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidListenBatch, err)
+		}
+	})
+}
+
+func TestListenBuffer(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	t.Run("Invalid", func(t *testing.T) {
+		if _, err := d.Listen(WithListenBuffer(0, Block)); !errors.Is(err, ErrInvalidListenBuffer) {
+			t.Fatalf("expected invalid buffer error, got %v", err)
+		}
+		if _, err := d.Listen(WithListenBuffer(1, OverflowPolicy(42))); !errors.Is(err, ErrInvalidListenBuffer) {
+			t.Fatalf("expected invalid buffer error, got %v", err)
+		}
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		l, err := d.Listen(WithListenBuffer(1, Block), ListenOption{Type: ListenCreate})
+		checkErr(t, err)
+		defer l.Close()
+		// Writes are held up until the receiver makes room, so they're made concurrently
+		errs := make(chan error, 1)
+		go func() {
+			defer close(errs)
+			for _, name := range []string{"Alice", "Bob", "Carol"} {
+				if _, err := c.Create(util.JSONFromInstance(Person{Name: name})); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+		for i := 0; i < 3; i++ {
+			select {
+			case a := <-l.Channel():
+				if a.Type != ActionCreate {
+					t.Fatalf("expected create, got %v", a)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected create %d to be delivered", i)
+			}
+		}
+		checkErr(t, <-errs)
+	})
+
+	t.Run("DropOldest", func(t *testing.T) {
+		l, err := d.Listen(WithListenBuffer(2, DropOldest), ListenOption{Type: ListenCreate})
+		checkErr(t, err)
+		defer l.Close()
+		var ids []core.InstanceID
+		for _, name := range []string{"Dave", "Eve", "Frank"} {
+			id, err := c.Create(util.JSONFromInstance(Person{Name: name}))
+			checkErr(t, err)
+			ids = append(ids, id)
+		}
+		time.Sleep(100 * time.Millisecond)
+		for _, id := range ids[1:] {
+			if a := <-l.Channel(); a.ID != id {
+				t.Fatalf("expected create of %s, got %v", id, a)
+			}
+		}
+	})
+
+	t.Run("DropNewestWithSignal", func(t *testing.T) {
+		l, err := d.Listen(WithListenBuffer(1, DropNewestWithSignal), ListenOption{Type: ListenCreate})
+		checkErr(t, err)
+		defer l.Close()
+		var ids []core.InstanceID
+		for _, name := range []string{"Grace", "Heidi", "Ivan"} {
+			id, err := c.Create(util.JSONFromInstance(Person{Name: name}))
+			checkErr(t, err)
+			ids = append(ids, id)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if a := <-l.Channel(); a.ID != ids[0] {
+			t.Fatalf("expected create of %s, got %v", ids[0], a)
+		}
+		// The gap is delivered before the next action, which is dropped since the buffer is full again
+		_, err = c.Create(util.JSONFromInstance(Person{Name: "Judy"}))
+		checkErr(t, err)
+		time.Sleep(100 * time.Millisecond)
+		if a := <-l.Channel(); a.Type != ActionGap || a.Dropped != 2 {
+			t.Fatalf("expected gap of 2 actions, got %v", a)
+		}
+	})
+}
+
+func TestListenFrom(t *testing.T) {
+	t.Parallel()
+	tmpDir, err := ioutil.TempDir("", "")
+	checkErr(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	n, err := common.DefaultNetwork(
+		common.WithNetBadgerPersistence(tmpDir),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+		common.WithNetDebug(true),
+	)
+	checkErr(t, err)
+	store, err := util.NewBadgerDatastore(tmpDir, "eventstore", false)
+	checkErr(t, err)
+	defer store.Close()
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	d, err := NewDB(context.Background(), store, n, id, WithNewActionLogSize(3))
+	checkErr(t, err)
+	if head, err := d.Head(); err != nil || head.Defined() {
+		t.Fatalf("expected undefined head, got %s (%v)", head, err)
+	}
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	var ids []core.InstanceID
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		id, err := c.Create(util.JSONFromInstance(Person{Name: name}))
+		checkErr(t, err)
+		ids = append(ids, id)
+	}
+	if head, err := d.Head(); err != nil || !head.Defined() {
+		t.Fatalf("expected defined head, got %s (%v)", head, err)
+	}
+	if p := d.Position(); p != 3 {
+		t.Fatalf("expected position 3, got %d", p)
+	}
+	info, err := d.GetDBInfo()
+	checkErr(t, err)
+
+	// Positions survive restarts.
+	checkErr(t, n.Close())
+	checkErr(t, d.Close())
+	time.Sleep(time.Second * 3)
+	n, err = common.DefaultNetwork(
+		common.WithNetBadgerPersistence(tmpDir),
+		common.WithNetHostAddr(util.FreeLocalAddr()),
+		common.WithNetDebug(true),
+	)
+	checkErr(t, err)
+	defer n.Close()
+	d, err = NewDB(context.Background(), store, n, id, WithNewKey(info.Key), WithNewActionLogSize(3))
+	checkErr(t, err)
+	defer d.Close()
+	c = d.GetCollection("Person")
+	if v, err := c.Version(); err != nil || v != 3 {
+		t.Fatalf("expected version 3, got %d (%v)", v, err)
+	}
+
+	l, err := d.Listen(WithListenFrom(1), ListenOption{Type: ListenCreate})
+	checkErr(t, err)
+	defer l.Close()
+	dan, err := c.Create(util.JSONFromInstance(Person{Name: "Dan"}))
+	checkErr(t, err)
+	for i, id := range append(ids[1:], dan) {
+		a := <-l.Channel()
+		if a.ID != id || a.Position != Position(i+2) {
+			t.Fatalf("expected create of %s at %d, got %v", id, i+2, a)
+		}
+	}
+	select {
+	case a := <-l.Channel():
+		t.Fatalf("unexpected action %v", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	t.Run("Fail/Expired", func(t *testing.T) {
+		// The log holds positions 2 through 4.
+		for _, p := range []Position{0, 5} {
+			if _, err := d.Listen(WithListenFrom(p)); !errors.Is(err, ErrPositionExpired) {
+				t.Fatalf("expected error %v for position %d, got %v", ErrPositionExpired, p, err)
+			}
+		}
+	})
+
+	t.Run("Fail/Unsupported", func(t *testing.T) {
+		if _, err := d.ListenBatch(time.Second, 10, WithListenFrom(4)); !errors.Is(err, ErrListenFromUnsupported) {
+			t.Fatalf("expected error %v, got %v", ErrListenFromUnsupported, err)
+		}
+	})
+}
+
+func TestListenFromDisabled(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Alice"}))
+	checkErr(t, err)
+
+	// Positions are tracked, but actions aren't logged by default.
+	if p := d.Position(); p != 1 {
+		t.Fatalf("expected position 1, got %d", p)
+	}
+	if _, err := d.Listen(WithListenFrom(0)); !errors.Is(err, ErrPositionExpired) {
+		t.Fatalf("expected error %v, got %v", ErrPositionExpired, err)
+	}
+	l, err := d.Listen(WithListenFrom(1))
+	checkErr(t, err)
+	l.Close()
+}
+
+func TestListenQuery(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	l, err := d.Listen(ListenOption{Collection: "Person", Query: Where("Name").Eq("Alice")})
+	checkErr(t, err)
+	defer l.Close()
+
+	expect := func(expected Action) {
+		t.Helper()
+		if a := <-l.Channel(); !reflect.DeepEqual(comparableAction(a), expected) {
+			t.Fatalf("expected action %v, got %v", expected, a)
+		}
+	}
+	alice, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+	expect(Action{Collection: "Person", Type: ActionCreate, ID: alice})
+	bob, err := c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 30}))
+	checkErr(t, err)
+	checkErr(t, c.ModifyByID(bob, []byte(`{"Age": 31}`)))
+	checkErr(t, c.ModifyByID(alice, []byte(`{"Age": 31}`)))
+	expect(Action{Collection: "Person", Type: ActionSave, ID: alice})
+	checkErr(t, c.Delete(bob))
+	// Deletes match the instance before it was deleted.
+	checkErr(t, c.Delete(alice))
+	expect(Action{Collection: "Person", Type: ActionDelete, ID: alice})
+	select {
+	case a := <-l.Channel():
+		t.Fatalf("unexpected action %v", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	t.Run("Fail/InvalidQuery", func(t *testing.T) {
+		if _, err := d.Listen(ListenOption{Query: &Query{Ands: []*Criterion{{FieldPath: "Name"}}}}); err == nil {
+			t.Fatal("expected invalid query to be rejected")
+		}
+	})
+}
+
+func TestListenAuthors(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	me, myTok := createACLIdentity(t, d)
+	other, otherTok := createACLIdentity(t, d)
+
+	others, err := d.Listen(ListenOption{ExcludeAuthors: []thread.PubKey{me}})
+	checkErr(t, err)
+	defer others.Close()
+	mine, err := d.Listen(ListenOption{Authors: []thread.PubKey{me}})
+	checkErr(t, err)
+	defer mine.Close()
+
+	expect := func(l Listener, id core.InstanceID, author thread.PubKey) {
+		t.Helper()
+		select {
+		case a := <-l.Channel():
+			if a.ID != id {
+				t.Fatalf("expected action on %s, got %v", id, a)
+			}
+			if a.Author == nil || !a.Author.Equals(author) {
+				t.Fatalf("expected author %s, got %v", author, a.Author)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected an action")
+		}
+	}
+	expectNone := func(l Listener) {
+		t.Helper()
+		select {
+		case a := <-l.Channel():
+			t.Fatalf("unexpected action %v", a)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	myID, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}), WithTxnToken(myTok))
+	checkErr(t, err)
+	expect(mine, myID, me)
+	expectNone(others)
+
+	otherID, err := c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 30}), WithTxnToken(otherTok))
+	checkErr(t, err)
+	expect(others, otherID, other)
+	expectNone(mine)
+}
+
+func TestResetNetRecords(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	ids, err := c.CreateMany([][]byte{
+		util.JSONFromInstance(Person{Name: "Alice", Age: 30}),
+		util.JSONFromInstance(Person{Name: "Bob", Age: 40}),
+	})
+	checkErr(t, err)
+
+	checkErr(t, d.ResetNetRecords(context.Background()))
+	for _, id := range ids {
+		if _, err := c.FindByID(id); !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+	}
+	res, err := c.Find(Where("Name").Eq("Alice").UseIndex("Name"))
+	checkErr(t, err)
+	if len(res) != 0 {
+		t.Fatalf("expected no indexed results, got %d", len(res))
+	}
+	head, err := d.Head()
+	checkErr(t, err)
+	if head.Defined() {
+		t.Fatalf("expected no head, got %s", head)
+	}
+
+	// Collections are kept, and can be written again
+	if d.GetCollection("Person") == nil {
+		t.Fatal("expected collection to be kept")
+	}
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+}
+
+func TestCollectionWatch(t *testing.T) {
+	t.Parallel()
+	d, clean := createTestDB(t)
+	defer clean()
+	c, err := d.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	alice, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 30}))
+	checkErr(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := c.Watch(ctx, Where("Name").Eq("Alice"))
+	checkErr(t, err)
+
+	// Writes made before receiving are buffered, not dropped.
+	const saves = 5
+	for i := 0; i < saves; i++ {
+		checkErr(t, c.ModifyByID(alice, []byte(fmt.Sprintf(`{"Age": %d}`, 31+i))))
+	}
+	expect := func(expected Action) {
+		t.Helper()
+		if a := <-ch; !reflect.DeepEqual(comparableAction(a), expected) {
+			t.Fatalf("expected action %v, got %v", expected, a)
+		}
+	}
+	expect(Action{Collection: "Person", Type: ActionCreate, ID: alice})
+	for i := 0; i < saves; i++ {
+		expect(Action{Collection: "Person", Type: ActionSave, ID: alice})
+	}
+	select {
+	case a := <-ch:
+		t.Fatalf("unexpected action %v", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Fatal("expected channel to be closed")
+	}
+
+	t.Run("Fail/InvalidQuery", func(t *testing.T) {
+		if _, err := c.Watch(context.Background(), &Query{Ands: []*Criterion{{FieldPath: "Name"}}}); err == nil {
+			t.Fatal("expected invalid query to be rejected")
+		}
+	})
+}
+
+// comparableAction clears the fields of a that depend on when and how it was produced.
+func comparableAction(a Action) Action {
+	a.ResumeToken = ""
+	a.Position = 0
+	a.Author = nil
+	a.instance = nil
+	return a
+}
+
 // runListenersComplexUseCase runs a complex db use-case, and returns
 // Actions received with the ...ListenOption provided.
 func runListenersComplexUseCase(t *testing.T, los ...ListenOption) []Action {
@@ -500,6 +1589,7 @@ func (dec *mockEventCodec) Reduce(
 	ds.TxnDatastore,
 	ds.Key,
 	core.IndexFunc,
+	core.ConflictFunc,
 ) ([]core.ReduceAction, error) {
 	dec.called = true
 	return nil, nil
@@ -512,3 +1602,355 @@ func (dec *mockEventCodec) EventsFromBytes([]byte) ([]core.Event, error) {
 	dec.called = true
 	return nil, nil
 }
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+	nets, done, err := common.NewMemoryNetwork(2, common.WithNetPubSub(true))
+	checkErr(t, err)
+	defer done()
+	ctx := context.Background()
+	cc := CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	}
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	d1, err := NewDB(ctx, NewTxMapDatastore(), nets[0], id, WithNewCollections(cc))
+	checkErr(t, err)
+	defer d1.Close()
+	info, err := nets[0].GetThread(ctx, id)
+	checkErr(t, err)
+	addr, err := multiaddr.NewMultiaddr("/p2p/" + nets[0].Host().ID().String() + "/thread/" + id.String())
+	checkErr(t, err)
+	d2, err := NewDBFromAddr(ctx, NewTxMapDatastore(), nets[1], addr, info.Key, WithNewCollections(cc), WithNewReadOnly())
+	checkErr(t, err)
+	defer d2.Close()
+	c2 := d2.GetCollection("dummy")
+
+	t.Run("Write", func(t *testing.T) {
+		if _, err := c2.Create(util.JSONFromInstance(dummy{Name: "Textile"})); !errors.Is(err, ErrReadOnly) {
+			t.Fatalf("expected error %v, got %v", ErrReadOnly, err)
+		}
+		if _, err := d2.WriteTxn(ctx); !errors.Is(err, ErrReadOnly) {
+			t.Fatalf("expected error %v, got %v", ErrReadOnly, err)
+		}
+		if n, err := c2.Count(nil); err != nil || n != 0 {
+			t.Fatalf("expected no instances, got %d (%v)", n, err)
+		}
+	})
+	t.Run("Replicate", func(t *testing.T) {
+		iid, err := d1.GetCollection("dummy").Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		for i := 0; ; i++ {
+			if _, err := c2.FindByID(iid); err == nil {
+				break
+			} else if i == 50 {
+				t.Fatalf("expected instance to be replicated: %v", err)
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	})
+}
+
+func TestBackfillDepth(t *testing.T) {
+	t.Parallel()
+	nets, done, err := common.NewMemoryNetwork(2)
+	checkErr(t, err)
+	defer done()
+	ctx := context.Background()
+	cc := CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	}
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	d1, err := NewDB(ctx, NewTxMapDatastore(), nets[0], id, WithNewCollections(cc))
+	checkErr(t, err)
+	defer d1.Close()
+	c1 := d1.GetCollection("dummy")
+	var ids []core.InstanceID
+	for _, name := range []string{"one", "two", "three"} {
+		iid, err := c1.Create(util.JSONFromInstance(dummy{Name: name}))
+		checkErr(t, err)
+		ids = append(ids, iid)
+	}
+	if partial, err := d1.IsPartial(); err != nil || partial {
+		t.Fatalf("expected db not to be partial, got %v (%v)", partial, err)
+	}
+
+	info, err := nets[0].GetThread(ctx, id)
+	checkErr(t, err)
+	addr, err := multiaddr.NewMultiaddr("/p2p/" + nets[0].Host().ID().String() + "/thread/" + id.String())
+	checkErr(t, err)
+	d2, err := NewDBFromAddr(
+		ctx,
+		NewTxMapDatastore(),
+		nets[1],
+		addr,
+		info.Key,
+		WithNewCollections(cc),
+		WithNewBackfillBlock(true),
+		WithNewBackfillDepth(2),
+	)
+	checkErr(t, err)
+	defer d2.Close()
+	c2 := d2.GetCollection("dummy")
+
+	if partial, err := d2.IsPartial(); err != nil || !partial {
+		t.Fatalf("expected db to be partial, got %v (%v)", partial, err)
+	}
+	if n, err := c2.Count(nil); err != nil || n != 2 {
+		t.Fatalf("expected 2 instances, got %d (%v)", n, err)
+	}
+	if ok, err := c2.Has(ids[0]); err != nil || ok {
+		t.Fatalf("expected instance created before the pulled records to be missing, got %v (%v)", ok, err)
+	}
+
+	// Deletes of missing instances are applied
+	checkErr(t, c1.Delete(ids[0]))
+	checkErr(t, c1.Delete(ids[1]))
+	checkErr(t, nets[1].PullThread(ctx, id))
+	if n, err := c2.Count(nil); err != nil || n != 1 {
+		t.Fatalf("expected 1 instance, got %d (%v)", n, err)
+	}
+}
+
+// syncCountingStore counts the syncs of a datastore.
+type syncCountingStore struct {
+	*TxnMapDatastore
+	lk    sync.Mutex
+	syncs int
+}
+
+func (s *syncCountingStore) Sync(prefix ds.Key) error {
+	s.lk.Lock()
+	s.syncs++
+	s.lk.Unlock()
+	return s.TxnMapDatastore.Sync(prefix)
+}
+
+func (s *syncCountingStore) count() int {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	return s.syncs
+}
+
+func TestSyncWrites(t *testing.T) {
+	t.Parallel()
+	nets, done, err := common.NewMemoryNetwork(1)
+	checkErr(t, err)
+	defer done()
+	ctx := context.Background()
+	cc := CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	}
+	newDB := func(t *testing.T, opts ...NewOption) (*Collection, *syncCountingStore) {
+		store := &syncCountingStore{TxnMapDatastore: NewTxMapDatastore()}
+		d, err := NewDB(ctx, store, nets[0], thread.NewIDV1(thread.Raw, 32), append(opts, WithNewCollections(cc))...)
+		checkErr(t, err)
+		t.Cleanup(func() { _ = d.Close() })
+		return d.GetCollection("dummy"), store
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		c, store := newDB(t)
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		if n := store.count(); n != 0 {
+			t.Fatalf("expected no syncs, got %d", n)
+		}
+		checkErr(t, c.WriteTxn(func(txn *Txn) error {
+			txn.Sync()
+			_, err := txn.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+			return err
+		}))
+		if n := store.count(); n == 0 {
+			t.Fatal("expected synced txn to sync the datastore")
+		}
+	})
+	t.Run("SyncWrites", func(t *testing.T) {
+		c, store := newDB(t, WithNewSyncWrites(true))
+		_, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		n := store.count()
+		if n == 0 {
+			t.Fatal("expected create to sync the datastore")
+		}
+		// Transactions without changes aren't synced.
+		checkErr(t, c.WriteTxn(func(txn *Txn) error { return nil }))
+		if store.count() != n {
+			t.Fatal("expected empty txn not to sync the datastore")
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	nets, done, err := common.NewMemoryNetwork(1)
+	checkErr(t, err)
+	defer done()
+	ctx := context.Background()
+	cc := CollectionConfig{
+		Name:   "dummy",
+		Schema: util.SchemaFromInstance(&dummy{}, false),
+	}
+
+	type ctxKey struct{}
+	errDenied := errors.New("denied")
+	var calls []string
+	trace := func(next Handler) Handler {
+		return func(ctx context.Context, op OperationInfo) error {
+			calls = append(calls, "trace "+string(op.Type))
+			return next(context.WithValue(ctx, ctxKey{}, op.Collection), op)
+		}
+	}
+	authorize := func(next Handler) Handler {
+		return func(ctx context.Context, op OperationInfo) error {
+			calls = append(calls, fmt.Sprintf("authorize %v", ctx.Value(ctxKey{})))
+			if op.Write {
+				return errDenied
+			}
+			return next(ctx, op)
+		}
+	}
+	d, err := NewDB(
+		ctx,
+		NewTxMapDatastore(),
+		nets[0],
+		thread.NewIDV1(thread.Raw, 32),
+		WithNewCollections(cc),
+		WithNewMiddleware(trace),
+		WithNewMiddleware(authorize),
+	)
+	checkErr(t, err)
+	defer d.Close()
+	c := d.GetCollection("dummy")
+
+	t.Run("Order", func(t *testing.T) {
+		calls = nil
+		n, err := c.Count(nil)
+		checkErr(t, err)
+		if n != 0 {
+			t.Fatalf("expected no instances, got %d", n)
+		}
+		expected := []string{"trace count", "authorize dummy"}
+		if !reflect.DeepEqual(calls, expected) {
+			t.Fatalf("expected calls %v, got %v", expected, calls)
+		}
+	})
+	t.Run("ShortCircuit", func(t *testing.T) {
+		calls = nil
+		if _, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"})); !errors.Is(err, errDenied) {
+			t.Fatalf("expected error %v, got %v", errDenied, err)
+		}
+		if calls[0] != "trace create" {
+			t.Fatalf("expected create to be traced, got %v", calls)
+		}
+		if n, err := c.Count(nil); err != nil || n != 0 {
+			t.Fatalf("expected denied create not to be applied, got %d (%v)", n, err)
+		}
+	})
+}
+
+func TestCodecEventGob(t *testing.T) {
+	t.Parallel()
+	evs, _, err := replacer.New().Create([]core.Action{{
+		Type:           core.Create,
+		InstanceID:     core.NewInstanceID(),
+		CollectionName: "replaced",
+		Current:        util.JSONFromInstance(dummy{Name: "Textile"}),
+	}})
+	checkErr(t, err)
+	e := codecEvent{Event: evs[0], codec: ReplaceEventCodec}
+
+	var b bytes.Buffer
+	checkErr(t, gob.NewEncoder(&b).Encode(e))
+	var got codecEvent
+	checkErr(t, gob.NewDecoder(&b).Decode(&got))
+	if name := eventCodecName(got); name != ReplaceEventCodec {
+		t.Fatalf("expected codec %s, got %s", ReplaceEventCodec, name)
+	}
+	if got.InstanceID() != e.InstanceID() || got.Collection() != e.Collection() {
+		t.Fatalf("expected event %v, got %v", e.Event, got.Event)
+	}
+	want, err := e.Marshal()
+	checkErr(t, err)
+	data, err := got.Marshal()
+	checkErr(t, err)
+	if !bytes.Equal(data, want) {
+		t.Fatalf("expected event %s, got %s", want, data)
+	}
+}
+
+func TestCollectionEventCodec(t *testing.T) {
+	t.Parallel()
+	nets, done, err := common.NewMemoryNetwork(2, common.WithNetPubSub(true))
+	checkErr(t, err)
+	defer done()
+	ctx := context.Background()
+	ccs := []CollectionConfig{
+		{Name: "patched", Schema: util.SchemaFromInstance(&dummy{}, false)},
+		{Name: "replaced", Schema: util.SchemaFromInstance(&dummy{}, false), EventCodec: ReplaceEventCodec},
+	}
+
+	id := thread.NewIDV1(thread.Raw, 32)
+	d1, err := NewDB(ctx, NewTxMapDatastore(), nets[0], id, WithNewCollections(ccs...))
+	checkErr(t, err)
+	defer d1.Close()
+	info, err := nets[0].GetThread(ctx, id)
+	checkErr(t, err)
+	addr, err := multiaddr.NewMultiaddr("/p2p/" + nets[0].Host().ID().String() + "/thread/" + id.String())
+	checkErr(t, err)
+	d2, err := NewDBFromAddr(ctx, NewTxMapDatastore(), nets[1], addr, info.Key, WithNewCollections(ccs...))
+	checkErr(t, err)
+	defer d2.Close()
+
+	if codec := d1.GetCollection("replaced").GetEventCodec(); codec != ReplaceEventCodec {
+		t.Fatalf("expected codec %s, got %s", ReplaceEventCodec, codec)
+	}
+	_, err = d1.NewCollection(CollectionConfig{
+		Name:       "unknown",
+		Schema:     util.SchemaFromInstance(&dummy{}, false),
+		EventCodec: "unknown",
+	})
+	if !errors.Is(err, ErrEventCodecNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrEventCodecNotFound, err)
+	}
+
+	// Write both collections in one record, so it holds events of both codecs.
+	txn, err := d1.WriteTxn(ctx)
+	checkErr(t, err)
+	var ids []core.InstanceID
+	for _, cc := range ccs {
+		c, err := txn.Collection(cc.Name)
+		checkErr(t, err)
+		res, err := c.Create(util.JSONFromInstance(dummy{Name: "Textile"}))
+		checkErr(t, err)
+		ids = append(ids, res[0])
+	}
+	checkErr(t, txn.Commit())
+
+	replaced := d1.GetCollection("replaced")
+	instance, err := replaced.FindByID(ids[1])
+	checkErr(t, err)
+	checkErr(t, replaced.Save(util.SetJSONProperty("Counter", 42, instance)))
+
+	for i, cc := range ccs {
+		c := d2.GetCollection(cc.Name)
+		for j := 0; ; j++ {
+			instance, err := c.FindByID(ids[i])
+			if err == nil {
+				var d dummy
+				util.InstanceFromJSON(instance, &d)
+				if cc.EventCodec == "" || d.Counter == 42 {
+					break
+				}
+			}
+			if j == 50 {
+				t.Fatalf("expected instance of %s to be replicated: %v", cc.Name, err)
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
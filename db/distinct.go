@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+	"github.com/tidwall/gjson"
+)
+
+// Distinct returns the distinct values at path of the instances of the collection, sorted by
+// type, nulls, booleans, numbers, strings, and then objects and arrays, and by value within a type.
+// Elements of array values are flattened, so each element is a value. Instances without a value at
+// path are skipped.
+// If a single-field index exists on path, a single instance is read per index entry, and
+// otherwise all instances are read. Instances of collections with a read filter, a TTL or
+// a trash field, or with encrypted values, are always all read, see Count.
+func (t *Txn) Distinct(path string) ([]interface{}, error) {
+	t.collection.db.metrics.query("distinct")
+	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
+		return nil, err
+	}
+	values := make(distinctValues)
+	if index, ok := t.collection.distinctIndex(path); ok {
+		txn, err := t.collection.store.NewTransactionExtended(true)
+		if err != nil {
+			return nil, err
+		}
+		defer txn.Discard()
+		if err := t.distinctEntries(txn, index, values); err != nil {
+			return nil, err
+		}
+	} else {
+		err := t.Iterate(context.Background(), &Query{}, func(instance []byte) error {
+			values.add(gjson.GetBytes(instance, path))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values.sorted(), nil
+}
+
+// distinctIndex returns the index whose entries hold the distinct values at path.
+// Entries of partial indexes, of encrypted values, and of instances that may not be
+// readable don't hold them.
+func (c *Collection) distinctIndex(path string) (Index, bool) {
+	if c.hasReadFilter() || c.indexKey != nil {
+		return Index{}, false
+	}
+	index, ok := c.indexes[path]
+	if !ok || index.isCompound() || index.Filter != nil {
+		return Index{}, false
+	}
+	return index, true
+}
+
+// distinctEntries adds the values at the path of index of an instance of each of its entries.
+// Entry keys can't be decoded to their values in general, e.g., when values contain slashes.
+func (t *Txn) distinctEntries(txn dse.TxnExt, index Index, values distinctValues) error {
+	prefix := indexPrefix.Child(t.collection.baseKey()).ChildString(index.Name())
+	res, err := txn.Query(query.Query{Prefix: prefix.String()})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	entryPrefix := prefix.String() + "/"
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if !strings.HasPrefix(r.Key, entryPrefix) {
+			continue
+		}
+		keys := make(keyList, 0)
+		if err := DefaultDecode(r.Value, &keys); err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		instance, err := txn.Get(ds.RawKey(string(keys[0])))
+		if err != nil {
+			return err
+		}
+		values.add(gjson.GetBytes(instance, index.Path))
+	}
+	return nil
+}
+
+// distinctValues is a set of values keyed by their type and normalized JSON encoding.
+type distinctValues map[string]gjson.Result
+
+// add adds res to the set, or its elements if it's an array.
+func (v distinctValues) add(res gjson.Result) {
+	if !res.Exists() {
+		return
+	}
+	if res.IsArray() {
+		for _, e := range res.Array() {
+			v[distinctKey(e)] = e
+		}
+		return
+	}
+	v[distinctKey(res)] = res
+}
+
+// distinctKey returns the key of res in a set of distinct values, which is equal for
+// equal values regardless of their formatting.
+func distinctKey(res gjson.Result) string {
+	switch res.Type {
+	case gjson.String:
+		return "s" + res.Str
+	case gjson.Number:
+		return "n" + strconv.FormatFloat(res.Num, 'g', -1, 64)
+	case gjson.JSON:
+		// Objects are marshaled with sorted keys
+		if b, err := json.Marshal(res.Value()); err == nil {
+			return "j" + string(b)
+		}
+	}
+	return "r" + res.Raw
+}
+
+// sorted returns the values in sorted order.
+func (v distinctValues) sorted() []interface{} {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := v[keys[i]], v[keys[j]]
+		if ra, rb := distinctRank(a), distinctRank(b); ra != rb {
+			return ra < rb
+		}
+		switch a.Type {
+		case gjson.False, gjson.True:
+			return a.Type == gjson.False && b.Type == gjson.True
+		case gjson.Number:
+			return a.Num < b.Num
+		case gjson.String:
+			return a.Str < b.Str
+		}
+		return keys[i] < keys[j]
+	})
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		values[i] = v[k].Value()
+	}
+	return values
+}
+
+// distinctRank returns the rank of the type of res in the order of distinct values.
+func distinctRank(res gjson.Result) int {
+	switch res.Type {
+	case gjson.Null:
+		return 0
+	case gjson.False, gjson.True:
+		return 1
+	case gjson.Number:
+		return 2
+	case gjson.String:
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	sym "github.com/textileio/crypto/symmetric"
+	dse "github.com/textileio/go-datastore-extensions"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+// indexKeyInfo is mixed into a value key to derive the key of index tokens.
+const indexKeyInfo = "index"
+
+// encryptedStore encrypts the values of keys under prefix with key before they're written
+// to the underlying datastore, and decrypts them on read.
+// Other values are passed through, so it can wrap a datastore shared with other collections.
+// Values can't be filtered or ordered by the underlying datastore.
+type encryptedStore struct {
+	kt.TxnDatastoreExtended
+	key    *sym.Key
+	prefix string
+}
+
+var _ kt.TxnDatastoreExtended = (*encryptedStore)(nil)
+
+// newEncryptedStore wraps store, encrypting the values of keys under prefix.
+func newEncryptedStore(store kt.TxnDatastoreExtended, key *sym.Key, prefix ds.Key) *encryptedStore {
+	return &encryptedStore{
+		TxnDatastoreExtended: store,
+		key:                  key,
+		prefix:               prefix.String() + "/",
+	}
+}
+
+func (s *encryptedStore) encrypts(key ds.Key) bool {
+	return strings.HasPrefix(key.String(), s.prefix)
+}
+
+func (s *encryptedStore) encrypt(key ds.Key, value []byte) ([]byte, error) {
+	if !s.encrypts(key) {
+		return value, nil
+	}
+	return s.key.Encrypt(value)
+}
+
+func (s *encryptedStore) decrypt(key ds.Key, value []byte) ([]byte, error) {
+	if !s.encrypts(key) {
+		return value, nil
+	}
+	plain, err := s.key.Decrypt(value)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting value of %s: %w", key, err)
+	}
+	return plain, nil
+}
+
+// decryptResults decrypts the values of res.
+func (s *encryptedStore) decryptResults(q query.Query, res query.Results) query.Results {
+	return query.ResultsFromIterator(q, query.Iterator{
+		Next: func() (query.Result, bool) {
+			r, ok := res.NextSync()
+			if !ok {
+				return r, false
+			}
+			if r.Error == nil && !q.KeysOnly {
+				r.Value, r.Error = s.decrypt(ds.RawKey(r.Key), r.Value)
+			}
+			return r, true
+		},
+		Close: res.Close,
+	})
+}
+
+func (s *encryptedStore) Get(key ds.Key) ([]byte, error) {
+	value, err := s.TxnDatastoreExtended.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.decrypt(key, value)
+}
+
+func (s *encryptedStore) Put(key ds.Key, value []byte) error {
+	value, err := s.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return s.TxnDatastoreExtended.Put(key, value)
+}
+
+func (s *encryptedStore) Query(q query.Query) (query.Results, error) {
+	res, err := s.TxnDatastoreExtended.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	return s.decryptResults(q, res), nil
+}
+
+func (s *encryptedStore) QueryExtended(q dse.QueryExt) (query.Results, error) {
+	res, err := s.TxnDatastoreExtended.QueryExtended(q)
+	if err != nil {
+		return nil, err
+	}
+	return s.decryptResults(q.Query, res), nil
+}
+
+func (s *encryptedStore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return s.NewTransactionExtended(readOnly)
+}
+
+func (s *encryptedStore) NewTransactionExtended(readOnly bool) (dse.TxnExt, error) {
+	txn, err := s.TxnDatastoreExtended.NewTransactionExtended(readOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedTxn{TxnExt: txn, store: s}, nil
+}
+
+// encryptedTxn is a transaction of an encryptedStore.
+type encryptedTxn struct {
+	dse.TxnExt
+	store *encryptedStore
+}
+
+var _ dse.TxnExt = (*encryptedTxn)(nil)
+
+func (t *encryptedTxn) Get(key ds.Key) ([]byte, error) {
+	value, err := t.TxnExt.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return t.store.decrypt(key, value)
+}
+
+func (t *encryptedTxn) Put(key ds.Key, value []byte) error {
+	value, err := t.store.encrypt(key, value)
+	if err != nil {
+		return err
+	}
+	return t.TxnExt.Put(key, value)
+}
+
+func (t *encryptedTxn) Query(q query.Query) (query.Results, error) {
+	res, err := t.TxnExt.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	return t.store.decryptResults(q, res), nil
+}
+
+func (t *encryptedTxn) QueryExtended(q dse.QueryExt) (query.Results, error) {
+	res, err := t.TxnExt.QueryExtended(q)
+	if err != nil {
+		return nil, err
+	}
+	return t.store.decryptResults(q.Query, res), nil
+}
+
+// newIndexKey derives the key of index tokens from a value key.
+func newIndexKey(key *sym.Key) SearchKey {
+	mac := hmac.New(sha256.New, key.Bytes())
+	mac.Write([]byte(indexKeyInfo))
+	return mac.Sum(nil)
+}
+
+// indexComponent returns the index key component of the value v at path.
+// Collections with encrypted values index keyed tokens of values instead of values.
+func (c *Collection) indexComponent(path, v string) string {
+	if c.indexKey == nil {
+		return v
+	}
+	tok, err := c.indexKey.Token(path, v)
+	if err != nil {
+		panic(fmt.Sprintf("creating index token: %v", err))
+	}
+	return tok
+}
+
+// indexQuery returns q with the values of criteria on the single-field index named by q.Index
+// replaced with their index tokens, so it can be matched against index entries.
+func (c *Collection) indexQuery(q *Query) *Query {
+	if c.indexKey == nil || q.Index == "" {
+		return q
+	}
+	if index, ok := c.indexes[q.Index]; !ok || index.isCompound() {
+		return q
+	}
+	return c.tokenizeCriteria(q, q.Index)
+}
+
+// tokenizeCriteria returns a copy of q with the values of criteria on path replaced with
+// their index tokens.
+func (c *Collection) tokenizeCriteria(q *Query, path string) *Query {
+	tq := *q
+	tq.Ands = make([]*Criterion, len(q.Ands))
+	for i, a := range q.Ands {
+		ta := *a
+		if v, ok := a.Value.indexValue(); ok && a.FieldPath == path {
+			tok := c.indexComponent(path, v)
+			ta.Value = Value{String: &tok}
+		}
+		tq.Ands[i] = &ta
+	}
+	tq.Ors = make([]*Query, len(q.Ors))
+	for i, o := range q.Ors {
+		tq.Ors[i] = c.tokenizeCriteria(o, path)
+	}
+	return &tq
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	ulid "github.com/oklog/ulid/v2"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+// defaultExpirySweepInterval is the default interval at which expired instances are deleted.
+const defaultExpirySweepInterval = time.Minute
+
+var (
+	// ErrInvalidTTL indicates a negative collection TTL.
+	ErrInvalidTTL = errors.New("collection ttl must not be negative")
+
+	dsExpiry = dsPrefix.ChildString("expiry")
+)
+
+// expiry describes when the instances of a collection expire.
+type expiry struct {
+	// Field is the path of the field holding the expiry time, or the time TTL starts from.
+	Field string `json:"field,omitempty"`
+	// TTL is added to the time of Field, or to the creation time if Field is empty.
+	TTL time.Duration `json:"ttl,omitempty"`
+}
+
+// newExpiry returns the expiry of a collection, or nil if its instances don't expire.
+func newExpiry(field string, ttl time.Duration) (*expiry, error) {
+	if ttl < 0 {
+		return nil, ErrInvalidTTL
+	}
+	if field == "" && ttl == 0 {
+		return nil, nil
+	}
+	return &expiry{Field: field, TTL: ttl}, nil
+}
+
+// expiresAt returns the time instance expires, or false if it doesn't.
+// Instances without a valid time in the field, or whose ID isn't a ULID when the creation
+// time is used, never expire.
+func (e *expiry) expiresAt(instance []byte) (time.Time, bool) {
+	var start time.Time
+	if e.Field != "" {
+		res := gjson.GetBytes(instance, e.Field)
+		switch res.Type {
+		case gjson.String:
+			t, err := time.Parse(time.RFC3339Nano, res.Str)
+			if err != nil {
+				return time.Time{}, false
+			}
+			start = t
+		case gjson.Number:
+			start = time.Unix(0, res.Int())
+		default:
+			return time.Time{}, false
+		}
+	} else {
+		id, err := ulid.ParseStrict(strings.ToUpper(gjson.GetBytes(instance, idFieldName).Str))
+		if err != nil {
+			return time.Time{}, false
+		}
+		start = ulid.Time(id.Time())
+	}
+	return start.Add(e.TTL), true
+}
+
+// expired returns whether instance has expired at now. It's false for a nil expiry.
+func (e *expiry) expired(instance []byte, now time.Time) bool {
+	if e == nil {
+		return false
+	}
+	at, ok := e.expiresAt(instance)
+	return ok && !now.Before(at)
+}
+
+// expiredIDs returns the IDs of the instances of c which have expired at now, or which have
+// been in the trash for longer than its retention.
+func (c *Collection) expiredIDs(now time.Time) ([]core.InstanceID, error) {
+	prefix := c.baseKey().String()
+	res, err := c.store.Query(query.Query{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+	var ids []core.InstanceID
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		if !strings.HasPrefix(r.Key, prefix+"/") {
+			continue
+		}
+		if c.expiry.expired(r.Value, now) || c.trash.purgeable(r.Value, now) {
+			ids = append(ids, core.InstanceID(ds.RawKey(r.Key).Name()))
+		}
+	}
+	return ids, nil
+}
+
+// sweepExpiredLoop deletes expired instances every interval until the db is closed.
+func (d *DB) sweepExpiredLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.sweepExpired()
+		case <-d.stopSweep:
+			return
+		}
+	}
+}
+
+// sweepExpired deletes the expired instances of all collections, along with the instances
+// which have been in the trash for longer than its retention.
+func (d *DB) sweepExpired() {
+	d.lock.RLock()
+	var cs []*Collection
+	for _, c := range d.collections {
+		if c.expiry != nil || (c.trash != nil && c.trash.Retention != 0) {
+			cs = append(cs, c)
+		}
+	}
+	d.lock.RUnlock()
+	for _, c := range cs {
+		n, err := d.sweepCollection(c, time.Now())
+		if err != nil {
+			log.Errorf("error deleting expired instances of %s: %v", c.name, err)
+		} else if n > 0 {
+			log.Debugf("deleted %d expired instances of %s", n, c.name)
+		}
+	}
+}
+
+// sweepCollection deletes the instances of c which have expired at now in a single transaction,
+// so the deletes are sent to other peers like any other. The transaction isn't authorized
+// with a token, since expiry is configured by the collection, not requested by a writer.
+func (d *DB) sweepCollection(c *Collection, now time.Time) (int, error) {
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	if d.closed || d.readOnly {
+		return 0, nil
+	}
+	ids, err := c.expiredIDs(now)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	txn := &Txn{collection: c}
+	defer txn.Discard()
+	for _, id := range ids {
+		txn.actions = append(txn.actions, core.Action{
+			Type:           core.Delete,
+			InstanceID:     id,
+			CollectionName: c.name,
+		})
+	}
+	if err := txn.Commit(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/textileio/go-threads/util"
+)
+
+type session struct {
+	ID        string `json:"_id"`
+	User      string `json:"user"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+func TestCollectionTTLField(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:     "Session",
+		Schema:   util.SchemaFromInstance(&session{}, false),
+		TTLField: "expiresAt",
+	})
+	checkErr(t, err)
+	if field, ttl := c.GetTTL(); field != "expiresAt" || ttl != 0 {
+		t.Fatalf("expected ttl field expiresAt, got %s and %v", field, ttl)
+	}
+
+	now := time.Now()
+	expired, err := c.Create(util.JSONFromInstance(session{
+		User:      "alice",
+		ExpiresAt: now.Add(-time.Minute).Format(time.RFC3339Nano),
+	}))
+	checkErr(t, err)
+	live, err := c.Create(util.JSONFromInstance(session{
+		User:      "bob",
+		ExpiresAt: now.Add(time.Hour).Format(time.RFC3339Nano),
+	}))
+	checkErr(t, err)
+
+	t.Run("FilteredOnRead", func(t *testing.T) {
+		if _, err := c.FindByID(expired); !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+		if ok, err := c.Has(expired); err != nil || ok {
+			t.Fatalf("expected expired instance not to exist, got %v (%v)", ok, err)
+		}
+		if ok, err := c.Has(live); err != nil || !ok {
+			t.Fatalf("expected live instance to exist, got %v (%v)", ok, err)
+		}
+		res, err := c.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance, got %d", len(res))
+		}
+		if n, err := c.Count(nil); err != nil || n != 1 {
+			t.Fatalf("expected count of 1, got %d (%v)", n, err)
+		}
+	})
+
+	t.Run("Sweep", func(t *testing.T) {
+		n, err := db.sweepCollection(c, time.Now())
+		checkErr(t, err)
+		if n != 1 {
+			t.Fatalf("expected 1 expired instance to be deleted, got %d", n)
+		}
+		if ok, err := c.store.Has(c.baseKey().ChildString(expired.String())); err != nil || ok {
+			t.Fatalf("expected expired instance to be deleted, got %v (%v)", ok, err)
+		}
+		if n, err = db.sweepCollection(c, time.Now().Add(2*time.Hour)); err != nil || n != 1 {
+			t.Fatalf("expected live instance to be deleted once expired, got %d (%v)", n, err)
+		}
+	})
+}
+
+func TestCollectionTTL(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Session",
+		Schema: util.SchemaFromInstance(&session{}, false),
+		TTL:    time.Hour,
+	})
+	checkErr(t, err)
+	id, err := c.Create(util.JSONFromInstance(session{User: "alice"}))
+	checkErr(t, err)
+	_, err = c.FindByID(id)
+	checkErr(t, err)
+	if n, err := db.sweepCollection(c, time.Now()); err != nil || n != 0 {
+		t.Fatalf("expected no expired instances, got %d (%v)", n, err)
+	}
+	if n, err := db.sweepCollection(c, time.Now().Add(2*time.Hour)); err != nil || n != 1 {
+		t.Fatalf("expected instance to expire after its ttl, got %d (%v)", n, err)
+	}
+
+	_, err = db.NewCollection(CollectionConfig{
+		Name:   "Invalid",
+		Schema: util.SchemaFromInstance(&session{}, false),
+		TTL:    -time.Hour,
+	})
+	if !errors.Is(err, ErrInvalidTTL) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidTTL, err)
+	}
+}
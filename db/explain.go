@@ -0,0 +1,114 @@
+package db
+
+import (
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// QueryPlan describes how a query reads instances, without reading them.
+type QueryPlan struct {
+	// Index is the name of the index the query reads instance IDs from.
+	// It's empty when the query scans all instances of the collection.
+	Index string
+	// Compound indicates Index is a compound index.
+	Compound bool
+	// Partial indicates Index is a partial index, see Index.Filter.
+	Partial bool
+	// Multikey indicates Index is a multikey index.
+	Multikey bool
+	// Prefix is the datastore key prefix of the scanned range. For compound indexes,
+	// it includes the leading index values the query is restricted to.
+	Prefix string
+	// Seek is the key the scan starts at, if the query seeks to an instance.
+	Seek string
+	// OrderedScan indicates the datastore returns the scanned range in key order,
+	// which is instance ID order for scans of the collection.
+	OrderedScan bool
+	// InMemorySort indicates all matching instances are read and sorted in memory
+	// before Skip and Limit apply, since they're sorted by a field other than the ID.
+	InMemorySort bool
+}
+
+// scanPlan holds the decisions made to read the instances matching a query.
+type scanPlan struct {
+	// index is the compound index to read, if compound is set.
+	index    Index
+	compound bool
+	// values are the leading values of index, as they're stored.
+	values []string
+	// query is the query used to read a single-field index or the collection.
+	query    *Query
+	multikey bool
+}
+
+// planScan returns how the instances matching q are read.
+func (c *Collection) planScan(q *Query) scanPlan {
+	if index, values, ok := c.planIndex(q); ok {
+		for i, v := range values {
+			values[i] = c.indexComponent(index.Paths[i], v)
+		}
+		return scanPlan{index: index, compound: true, values: values, query: q}
+	}
+	iq := c.indexQuery(q)
+	return scanPlan{
+		query:    iq,
+		multikey: iq.Index != "" && c.indexes[iq.Index].Multikey,
+	}
+}
+
+// Explain returns how the instances matching q would be read by Find, without reading them.
+// An error is returned if q names an index that doesn't exist.
+func (t *Txn) Explain(q *Query) (QueryPlan, error) {
+	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
+		return QueryPlan{}, err
+	}
+	if q == nil {
+		q = &Query{}
+	}
+	if err := q.Validate(); err != nil {
+		return QueryPlan{}, fmt.Errorf("invalid query: %w", err)
+	}
+	if err := t.collection.validEncryptedQuery(q); err != nil {
+		return QueryPlan{}, err
+	}
+	if q.Index != "" {
+		if _, ok := t.collection.indexes[q.Index]; !ok {
+			return QueryPlan{}, fmt.Errorf("%w: %s", ErrIndexNotFound, q.Index)
+		}
+	}
+
+	var (
+		p        = t.collection.planScan(q)
+		baseKey  = t.collection.baseKey()
+		plan     QueryPlan
+		prefix   ds.Key
+		sortByID = q.Sort.FieldPath == idFieldName || (q.Sort.FieldPath == "" && q.AfterCursor != "")
+	)
+	if p.compound {
+		plan.Index = p.index.Name()
+		plan.Compound = true
+		plan.Partial = p.index.Filter != nil
+		prefix = indexPrefix.Child(baseKey).ChildString(plan.Index)
+		for _, v := range p.values {
+			prefix = prefix.ChildString(encodeIndexComponent(v))
+		}
+	} else {
+		if p.query.Index == "" {
+			prefix = baseKey
+		} else {
+			index := t.collection.indexes[p.query.Index]
+			plan.Index = p.query.Index
+			plan.Partial = index.Filter != nil
+			plan.Multikey = p.multikey
+			prefix = indexPrefix.Child(baseKey).ChildString(p.query.Index)
+		}
+		if q.Seek != "" {
+			plan.Seek = prefix.Child(ds.NewKey(string(q.Seek))).String()
+		}
+		plan.OrderedScan = sortByID
+	}
+	plan.Prefix = prefix.String()
+	plan.InMemorySort = q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName
+	return plan, nil
+}
@@ -0,0 +1,269 @@
+package db
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrImportNameMismatch indicates the config passed to ImportCollection names another collection.
+	ErrImportNameMismatch = errors.New("import config name doesn't match collection")
+	// ErrInvalidImportFormat indicates an unknown import format.
+	ErrInvalidImportFormat = errors.New("invalid import format")
+	// ErrInvalidImportRecord indicates an imported record isn't a JSON object.
+	ErrInvalidImportRecord = errors.New("import record isn't a JSON object")
+)
+
+const (
+	// defaultImportBatchSize is the number of records created per transaction by default.
+	defaultImportBatchSize = 1000
+	// maxImportLineSize is the maximum size of a record in a JSON-lines dataset.
+	maxImportLineSize = 16 << 20
+)
+
+// ImportFormat is the encoding of the records of an imported dataset.
+type ImportFormat int
+
+const (
+	// ImportJSONLines is a dataset with a JSON object per line. Blank lines are skipped.
+	ImportJSONLines ImportFormat = iota
+	// ImportJSONArray is a dataset holding a JSON array of objects.
+	ImportJSONArray
+)
+
+// ImportCollection creates a collection with config, and creates an instance for each record of
+// the dataset read from r. Records are validated against the collection schema and unique indexes,
+// and created in transactions of up to the batch size records, so each batch is sent to the network
+// in a single record.
+// Invalid records are skipped, and the returned error is a *ValidationError holding an *InstanceError
+// for each of them, whose Index is the position of the record in the dataset. The count of created
+// and skipped records is returned along with it.
+// With WithImportAbortOnError, the import is aborted instead, and the collection is deleted. A dataset
+// which can't be read or decoded, e.g., a JSON array with a syntax error, always aborts the import.
+func (d *DB) ImportCollection(
+	name string,
+	config CollectionConfig,
+	r io.Reader,
+	format ImportFormat,
+	opts ...ImportOption,
+) (imported, failed int, err error) {
+	args := &ImportOptions{BatchSize: defaultImportBatchSize}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if config.Name == "" {
+		config.Name = name
+	} else if config.Name != name {
+		return 0, 0, ErrImportNameMismatch
+	}
+	var next func() ([]byte, error)
+	switch format {
+	case ImportJSONLines:
+		next = jsonLinesReader(r)
+	case ImportJSONArray:
+		if next, err = jsonArrayReader(r); err != nil {
+			return 0, 0, err
+		}
+	default:
+		return 0, 0, ErrInvalidImportFormat
+	}
+	if args.BatchSize <= 0 {
+		args.BatchSize = defaultImportBatchSize
+	}
+	c, err := d.NewCollection(config, WithToken(args.Token))
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		if err != nil && (args.AbortOnError || !isImportValidationError(err)) {
+			if derr := d.DeleteCollection(name, WithToken(args.Token)); derr != nil {
+				log.Errorf("deleting collection %s of aborted import: %v", name, derr)
+			}
+			imported = 0
+		}
+	}()
+
+	imp := &importer{c: c, args: args}
+	for {
+		rec, err := next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return imp.imported, len(imp.errs), fmt.Errorf("reading record %d: %w", imp.index, err)
+		}
+		if err := imp.add(rec); err != nil {
+			return imp.imported, len(imp.errs), err
+		}
+	}
+	if err := imp.flush(); err != nil {
+		return imp.imported, len(imp.errs), err
+	}
+	log.Debugf("imported %d records into collection %s, skipping %d", imp.imported, name, len(imp.errs))
+	if len(imp.errs) > 0 {
+		return imp.imported, len(imp.errs), &ValidationError{Errs: imp.errs}
+	}
+	return imp.imported, 0, nil
+}
+
+// isImportValidationError returns whether err only reports the records skipped by an import.
+func isImportValidationError(err error) bool {
+	var verr *ValidationError
+	return errors.As(err, &verr)
+}
+
+// importer batches the records of an import.
+type importer struct {
+	c    *Collection
+	args *ImportOptions
+	// index is the position of the next record in the dataset.
+	index    int
+	batch    [][]byte
+	indexes  []int
+	imported int
+	errs     []*InstanceError
+}
+
+// add queues a record, creating the queued records once a batch is full.
+func (imp *importer) add(rec []byte) error {
+	i := imp.index
+	imp.index++
+	if !json.Valid(rec) || !bytes.HasPrefix(bytes.TrimSpace(rec), []byte("{")) {
+		return imp.fail(i, ErrInvalidImportRecord)
+	}
+	imp.batch = append(imp.batch, rec)
+	imp.indexes = append(imp.indexes, i)
+	if len(imp.batch) < imp.args.BatchSize {
+		return nil
+	}
+	return imp.flush()
+}
+
+// fail records the failure of the record at index i, returning an error if the import
+// must be aborted.
+func (imp *importer) fail(i int, err error) error {
+	ierr := &InstanceError{Index: i, Err: err}
+	imp.errs = append(imp.errs, ierr)
+	if imp.args.AbortOnError {
+		return ierr
+	}
+	return nil
+}
+
+// flush validates the queued records, and creates the valid ones in a transaction.
+func (imp *importer) flush() error {
+	batch, indexes := imp.batch, imp.indexes
+	imp.batch, imp.indexes = nil, nil
+	for len(batch) > 0 {
+		err := imp.c.ValidateMany(batch, WithTxnToken(imp.args.Token))
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			invalid := make(map[int]error, len(verr.Errs))
+			for _, e := range verr.Errs {
+				invalid[e.Index] = e.Err
+			}
+			batch, indexes, err = imp.skip(batch, indexes, invalid)
+			if err != nil {
+				return err
+			}
+			continue
+		} else if err != nil && len(batch) == 1 && isInstanceRejection(err) {
+			// A single record is validated without an *InstanceError
+			_, _, err = imp.skip(batch, indexes, map[int]error{0: err})
+			return err
+		} else if err != nil {
+			return err
+		}
+		_, err = imp.c.CreateMany(batch, WithTxnToken(imp.args.Token))
+		var ierr *InstanceError
+		if errors.As(err, &ierr) {
+			// Rejected by something other than validation, e.g., a write validator
+			batch, indexes, err = imp.skip(batch, indexes, map[int]error{ierr.Index: ierr.Err})
+			if err != nil {
+				return err
+			}
+			continue
+		} else if err != nil && len(batch) == 1 && isInstanceRejection(err) {
+			_, _, err = imp.skip(batch, indexes, map[int]error{0: err})
+			return err
+		} else if err != nil {
+			return err
+		}
+		imp.imported += len(batch)
+		return nil
+	}
+	return nil
+}
+
+// skip removes the invalid records from a batch, recording their failures.
+func (imp *importer) skip(batch [][]byte, indexes []int, invalid map[int]error) ([][]byte, []int, error) {
+	kept, keptIndexes := batch[:0], indexes[:0]
+	for i := range batch {
+		if err, ok := invalid[i]; ok {
+			if ferr := imp.fail(indexes[i], err); ferr != nil {
+				return nil, nil, ferr
+			}
+			continue
+		}
+		kept = append(kept, batch[i])
+		keptIndexes = append(keptIndexes, indexes[i])
+	}
+	return kept, keptIndexes, nil
+}
+
+// isInstanceRejection returns whether err rejects an instance, rather than the write.
+func isInstanceRejection(err error) bool {
+	return errors.Is(err, ErrInvalidSchemaInstance) ||
+		errors.Is(err, ErrUniqueExists) ||
+		errors.Is(err, ErrInstanceExists) ||
+		errors.Is(err, ErrInvalidImportRecord)
+}
+
+// jsonLinesReader returns a function reading the records of a JSON-lines dataset,
+// which returns io.EOF once all are read.
+func jsonLinesReader(r io.Reader) func() ([]byte, error) {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), maxImportLineSize)
+	return func() ([]byte, error) {
+		for s.Scan() {
+			line := bytes.TrimSpace(s.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			return append([]byte(nil), line...), nil
+		}
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
+
+// jsonArrayReader returns a function reading the elements of a dataset holding a JSON array,
+// which returns io.EOF once all are read.
+func jsonArrayReader(r io.Reader) (func() ([]byte, error), error) {
+	dec := json.NewDecoder(r)
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("reading dataset: %w", err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("reading dataset: expected a JSON array")
+	}
+	return func() ([]byte, error) {
+		if !dec.More() {
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		var rec json.RawMessage
+		if err := dec.Decode(&rec); err != nil {
+			return nil, err
+		}
+		return rec, nil
+	}, nil
+}
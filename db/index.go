@@ -6,10 +6,13 @@ package db
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"sort"
+	"strings"
 
 	"github.com/alecthomas/jsonschema"
 	ds "github.com/ipfs/go-datastore"
@@ -33,17 +36,102 @@ var (
 	ErrCantCreateUniqueIndex = errors.New("can't create unique index (duplicate instances exist)")
 	// ErrIndexNotFound indicates a requested index was not found.
 	ErrIndexNotFound = errors.New("index not found")
+	// ErrInvalidCompoundIndex indicates a compound index doesn't list at least two distinct
+	// paths, or also sets Path or Encrypted.
+	ErrInvalidCompoundIndex = errors.New("invalid compound index")
+	// ErrInvalidIndexFilter indicates an index filter isn't a valid query, or is set on the ID index.
+	ErrInvalidIndexFilter = errors.New("invalid index filter")
+	// ErrInvalidMultikeyIndex indicates a multikey index isn't on an array of indexable values,
+	// or is also compound, unique, or encrypted.
+	ErrInvalidMultikeyIndex = errors.New("invalid multikey index")
 
 	indexPrefix = ds.NewKey("_index")
 	indexTypes  = []string{"string", "number", "integer", "boolean"}
 )
 
+const (
+	// compoundIndexSep separates the paths of a compound index in its name.
+	compoundIndexSep = ","
+	// missingIndexValue marks a compound index component whose field doesn't exist.
+	missingIndexValue = "~"
+)
+
 // Index defines an index.
 type Index struct {
 	// Path to the field to index in dot syntax, e.g., "name.last" or "age".
 	Path string `json:"path"`
 	// Unique indicates that only one instance should exist per field value.
 	Unique bool `json:"unique,omitempty"`
+	// Encrypted indicates that the field at path holds searchable encryption tokens
+	// created with a SearchKey. Only equality criteria can be used against encrypted paths.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Paths to the fields of a compound index, in order, e.g., []string{"status", "createdAt"}.
+	// Path must be empty when Paths is set. The index is named by joining paths with commas,
+	// e.g., "status,createdAt", which can be passed to Query.UseIndex.
+	// Queries with equality criteria on a leading subset of paths use the index automatically.
+	// Unique indicates that only one instance should exist per tuple of field values.
+	Paths []string `json:"paths,omitempty"`
+	// Filter restricts the index to instances matching its criteria, e.g., an email index
+	// which is unique only among instances that aren't deleted.
+	// Instances are added to or removed from the index as they start or stop matching.
+	// Such partial indexes are only used by queries naming them with Query.UseIndex,
+	// which only return instances matching the filter.
+	Filter *Query `json:"filter,omitempty"`
+	// Multikey indexes each element of the array at Path, which must be an array of strings,
+	// numbers, integers, or booleans. Instances whose field isn't an array aren't indexed.
+	// Queries naming the index with Query.UseIndex read matching instances from the index
+	// if their only criteria are Contains, AnyGt, AnyLt, AnyGe, or AnyLe on Path.
+	// Other queries using array operators scan the collection.
+	// Multikey indexes can't be compound, unique, or encrypted.
+	Multikey bool `json:"multikey,omitempty"`
+}
+
+// Name returns the name of the index, which is its path, or its comma-joined paths
+// for compound indexes.
+func (i Index) Name() string {
+	if len(i.Paths) != 0 {
+		return strings.Join(i.Paths, compoundIndexSep)
+	}
+	return i.Path
+}
+
+func (i Index) isCompound() bool {
+	return len(i.Paths) != 0
+}
+
+func (i Index) equal(o Index) bool {
+	if i.Path != o.Path || i.Unique != o.Unique || i.Encrypted != o.Encrypted || i.Multikey != o.Multikey ||
+		len(i.Paths) != len(o.Paths) {
+		return false
+	}
+	for j := range i.Paths {
+		if i.Paths[j] != o.Paths[j] {
+			return false
+		}
+	}
+	return i.sameFilter(o)
+}
+
+// sameFilter returns whether i and o have the same filter.
+func (i Index) sameFilter(o Index) bool {
+	if i.Filter == nil || o.Filter == nil {
+		return i.Filter == o.Filter
+	}
+	fi, erri := json.Marshal(i.Filter)
+	fo, erro := json.Marshal(o.Filter)
+	return erri == nil && erro == nil && bytes.Equal(fi, fo)
+}
+
+// matchFilter returns whether the instance data belongs to the index.
+func (i Index) matchFilter(data []byte) (bool, error) {
+	if i.Filter == nil {
+		return true, nil
+	}
+	val := make(map[string]interface{})
+	if err := json.Unmarshal(data, &val); err != nil {
+		return false, err
+	}
+	return i.Filter.match(val)
 }
 
 // GetIndexes returns the current indexes.
@@ -67,8 +155,8 @@ func (c *Collection) GetIndexes() []Index {
 // The field at path must be one of the supported JSON Schema types: string, number, integer, or boolean
 // Set unique to true if you want a unique constraint on path.
 // Adding an index will override any overlapping index values if they already exist.
-// @note: This does NOT currently build the index. If items have been added prior to adding
-// a new index, they will NOT be indexed a posteriori.
+// @note: This does NOT currently build single-field indexes. If items have been added prior to adding
+// a new single-field index, they will NOT be indexed a posteriori. Compound indexes are built.
 func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Option) error {
 	args := &Options{}
 	for _, opt := range opts {
@@ -82,35 +170,87 @@ func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Op
 		}
 	}
 
-	// Validate path and type.
-	jt, err := getSchemaTypeAtPath(schema, index.Path)
-	if err != nil {
-		return err
-	}
-	var valid bool
-	for _, t := range indexTypes {
-		if jt.Type == t {
-			valid = true
-			break
+	// Validate paths and types. The fields of dynamic collections aren't known, so instances
+	// are only indexed on the paths they hold values of an indexable type at.
+	if index.Multikey {
+		if index.isCompound() || index.Unique || index.Encrypted {
+			return ErrInvalidMultikeyIndex
+		}
+		if !c.dynamic {
+			if err := validMultikeyType(schema, index.Path); err != nil {
+				return err
+			}
+		}
+	} else if index.isCompound() {
+		if err := validCompoundIndex(index); err != nil {
+			return err
+		}
+		if !c.dynamic {
+			for _, pth := range index.Paths {
+				if _, err := getIndexableType(schema, pth); err != nil {
+					return err
+				}
+			}
+		}
+	} else if !c.dynamic {
+		jt, err := getIndexableType(schema, index.Path)
+		if err != nil {
+			return err
+		}
+		if index.Encrypted && jt != "string" {
+			return ErrEncryptedIndexType
 		}
 	}
-	if !valid {
-		return ErrNotIndexable
+	if index.Filter != nil {
+		if index.Path == idFieldName {
+			return ErrInvalidIndexFilter
+		}
+		if err := index.Filter.Validate(); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidIndexFilter, err)
+		}
 	}
 
 	// Skip if nothing to do
-	if x, ok := c.indexes[index.Path]; ok && index.Unique == x.Unique {
+	name := index.Name()
+	x, exists := c.indexes[name]
+	if exists && index.equal(x) {
 		return nil
 	}
 
 	// Ensure collection does not contain multiple instances with the same value at path
-	if index.Unique && index.Path != idFieldName {
+	if index.Unique && index.isCompound() {
+		vals := make(map[string]struct{})
+		all, err := c.Find(&Query{}, WithTxnToken(args.Token))
+		if err != nil {
+			return err
+		}
+		for _, i := range all {
+			if ok, err := index.matchFilter(i); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+			tuple, complete := c.compoundIndexValue(index.Paths, i)
+			if !complete {
+				continue
+			}
+			if _, ok := vals[tuple]; ok {
+				return ErrCantCreateUniqueIndex
+			}
+			vals[tuple] = struct{}{}
+		}
+	} else if index.Unique && index.Path != idFieldName {
 		vals := make(map[interface{}]struct{})
 		all, err := c.Find(&Query{}, WithTxnToken(args.Token))
 		if err != nil {
 			return err
 		}
 		for _, i := range all {
+			if ok, err := index.matchFilter(i); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
 			res := gjson.GetBytes(i, index.Path)
 			if !res.Exists() {
 				continue
@@ -123,10 +263,131 @@ func (c *Collection) addIndex(schema *jsonschema.Schema, index Index, opts ...Op
 		}
 	}
 
-	c.indexes[index.Path] = index
+	// Compound indexes may be picked automatically, so they must cover existing instances.
+	// Partial indexes are built so that uniqueness holds among existing instances.
+	// Multikey indexes are built, since queries on arrays may only now be possible.
+	if !exists && (index.isCompound() || index.Filter != nil || index.Multikey) {
+		if err := c.buildIndex(index); err != nil {
+			return err
+		}
+	}
+
+	c.indexes[name] = index
 	return c.saveIndexes()
 }
 
+// buildIndex adds existing instances to index.
+// Uniqueness isn't enforced, so it must be checked beforehand.
+func (c *Collection) buildIndex(index Index) error {
+	index.Unique = false
+	txn, err := c.store.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	res, err := txn.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	prefix := c.baseKey().String() + "/"
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if !strings.HasPrefix(r.Key, prefix) {
+			continue
+		}
+		if err := c.indexUpdate(index, txn, ds.RawKey(r.Key), r.Value, false); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// clearIndex removes the entries of the index with name.
+func (c *Collection) clearIndex(name string) error {
+	txn, err := c.store.NewTransaction(false)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	prefix := indexPrefix.Child(c.baseKey()).ChildString(name).String()
+	res, err := txn.Query(query.Query{Prefix: prefix, KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if !strings.HasPrefix(r.Key, prefix+"/") {
+			continue
+		}
+		if err := txn.Delete(ds.RawKey(r.Key)); err != nil {
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// rebuildIndex replaces the entries of index with those of existing instances.
+func (c *Collection) rebuildIndex(index Index) error {
+	if err := c.clearIndex(index.Name()); err != nil {
+		return err
+	}
+	return c.buildIndex(index)
+}
+
+// validCompoundIndex returns an error if index lists less than two distinct paths,
+// or also sets Path or Encrypted.
+func validCompoundIndex(index Index) error {
+	if index.Path != "" || index.Encrypted || len(index.Paths) < 2 {
+		return ErrInvalidCompoundIndex
+	}
+	seen := make(map[string]struct{}, len(index.Paths))
+	for _, pth := range index.Paths {
+		if _, ok := seen[pth]; ok || pth == "" {
+			return ErrInvalidCompoundIndex
+		}
+		seen[pth] = struct{}{}
+	}
+	return nil
+}
+
+// validMultikeyType returns an error if the JSON Schema type at path isn't an array
+// of values that can be indexed.
+func validMultikeyType(schema *jsonschema.Schema, pth string) error {
+	jt, err := getSchemaTypeAtPath(schema, pth)
+	if err != nil {
+		return err
+	}
+	if jt.Type != "array" || jt.Items == nil {
+		return ErrInvalidMultikeyIndex
+	}
+	for _, t := range indexTypes {
+		if jt.Items.Type == t {
+			return nil
+		}
+	}
+	return ErrInvalidMultikeyIndex
+}
+
+// getIndexableType returns the JSON Schema type at path if it can be indexed.
+func getIndexableType(schema *jsonschema.Schema, pth string) (string, error) {
+	jt, err := getSchemaTypeAtPath(schema, pth)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range indexTypes {
+		if jt.Type == t {
+			return jt.Type, nil
+		}
+	}
+	return "", ErrNotIndexable
+}
+
 // dropIndex drops the index at path.
 func (c *Collection) dropIndex(pth string) error {
 	// Don't allow the default index to be dropped
@@ -148,8 +409,8 @@ func (c *Collection) saveIndexes() error {
 
 // indexAdd adds an item to the index.
 func (c *Collection) indexAdd(tx ds.Txn, key ds.Key, data []byte) error {
-	for path, index := range c.indexes {
-		err := c.indexUpdate(path, index, tx, key, data, false)
+	for _, index := range c.indexes {
+		err := c.indexUpdate(index, tx, key, data, false)
 		if err != nil {
 			return err
 		}
@@ -160,8 +421,8 @@ func (c *Collection) indexAdd(tx ds.Txn, key ds.Key, data []byte) error {
 // indexDelete removes an item from the index.
 // Be sure to pass the data from the old record, not the new one.
 func (c *Collection) indexDelete(tx ds.Txn, key ds.Key, originalData []byte) error {
-	for path, index := range c.indexes {
-		err := c.indexUpdate(path, index, tx, key, originalData, true)
+	for _, index := range c.indexes {
+		err := c.indexUpdate(index, tx, key, originalData, true)
 		if err != nil {
 			return err
 		}
@@ -170,22 +431,53 @@ func (c *Collection) indexDelete(tx ds.Txn, key ds.Key, originalData []byte) err
 }
 
 // indexUpdate adds or removes a specific index on an item.
-func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Key, input []byte, delete bool) error {
-	valueKey, err := getIndexValue(field, input)
-	if err != nil {
-		if errors.Is(err, ErrNotIndexable) {
-			return nil
-		}
+func (c *Collection) indexUpdate(index Index, tx ds.Txn, key ds.Key, input []byte, delete bool) error {
+	if index.Multikey {
+		return c.multikeyIndexUpdate(index, tx, key, input, delete)
+	}
+	indexKey, unique, ok, err := c.indexEntryKey(index, input)
+	if err != nil || !ok {
+		return err
+	}
+	return updateIndexEntry(tx, indexKey, key, unique, delete)
+}
+
+// multikeyIndexUpdate adds or removes an item in the entries of each element of its array
+// in a multikey index.
+func (c *Collection) multikeyIndexUpdate(index Index, tx ds.Txn, key ds.Key, input []byte, delete bool) error {
+	if ok, err := index.matchFilter(input); err != nil || !ok {
 		return err
 	}
+	res := gjson.GetBytes(input, index.Path)
+	if !res.IsArray() {
+		return nil
+	}
+	prefix := indexPrefix.Child(c.baseKey()).ChildString(index.Path)
+	seen := make(map[string]struct{})
+	for _, e := range res.Array() {
+		if e.IsArray() || e.IsObject() {
+			continue
+		}
+		v := e.String()
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		if err := updateIndexEntry(tx, prefix.ChildString(ds.NewKey(v).String()[1:]), key, false, delete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	indexKey := indexPrefix.Child(c.baseKey()).ChildString(field).ChildString(valueKey.String()[1:])
+// updateIndexEntry adds or removes key in the index entry at indexKey.
+func updateIndexEntry(tx ds.Txn, indexKey, key ds.Key, unique, delete bool) error {
 	data, err := tx.Get(indexKey)
 	if err != nil && err != ds.ErrNotFound {
 		return err
 	}
 	if err != ds.ErrNotFound {
-		if index.Unique && !delete {
+		if unique && !delete {
 			return ErrUniqueExists
 		}
 	}
@@ -212,6 +504,43 @@ func (c *Collection) indexUpdate(field string, index Index, tx ds.Txn, key ds.Ke
 	return tx.Put(indexKey, val)
 }
 
+// indexEntryKey returns the key of the entry of index which holds input, and whether the entry
+// is unique. It returns false if input isn't added to the index.
+func (c *Collection) indexEntryKey(index Index, input []byte) (indexKey ds.Key, unique bool, ok bool, err error) {
+	// Multikey indexes have an entry per element. See multikeyIndexUpdate.
+	if index.Multikey {
+		return ds.Key{}, false, false, nil
+	}
+	// Instances outside of a partial index were never added to it
+	if ok, err := index.matchFilter(input); err != nil || !ok {
+		return ds.Key{}, false, false, err
+	}
+	unique = index.Unique
+	if index.isCompound() {
+		// Instances are always added to compound indexes so that queries on a prefix of
+		// paths don't miss those without values at the remaining paths.
+		tuple, complete := c.compoundIndexValue(index.Paths, input)
+		indexKey = indexPrefix.Child(c.baseKey()).ChildString(index.Name()).Child(ds.RawKey(tuple))
+		unique = unique && complete
+	} else if c.indexKey != nil {
+		res := gjson.GetBytes(input, index.Path)
+		if !res.Exists() {
+			return ds.Key{}, false, false, nil
+		}
+		indexKey = indexPrefix.Child(c.baseKey()).ChildString(index.Path).ChildString(c.indexComponent(index.Path, res.String()))
+	} else {
+		valueKey, err := getIndexValue(index.Path, input)
+		if err != nil {
+			if errors.Is(err, ErrNotIndexable) {
+				return ds.Key{}, false, false, nil
+			}
+			return ds.Key{}, false, false, err
+		}
+		indexKey = indexPrefix.Child(c.baseKey()).ChildString(index.Path).ChildString(valueKey.String()[1:])
+	}
+	return indexKey, unique, true, nil
+}
+
 // getIndexValue returns the result of a field search on input.
 func getIndexValue(field string, input []byte) (ds.Key, error) {
 	result := gjson.GetBytes(input, field)
@@ -221,6 +550,28 @@ func getIndexValue(field string, input []byte) (ds.Key, error) {
 	return ds.NewKey(result.String()), nil
 }
 
+// compoundIndexValue returns the key path of the values at paths in input, and whether
+// all of them exist.
+func (c *Collection) compoundIndexValue(paths []string, input []byte) (string, bool) {
+	var b strings.Builder
+	complete := true
+	for i, res := range gjson.GetManyBytes(input, paths...) {
+		b.WriteString("/")
+		if res.Exists() {
+			b.WriteString(encodeIndexComponent(c.indexComponent(paths[i], res.String())))
+		} else {
+			b.WriteString(missingIndexValue)
+			complete = false
+		}
+	}
+	return b.String(), complete
+}
+
+// encodeIndexComponent encodes a compound index value as a single, non-empty key namespace.
+func encodeIndexComponent(v string) string {
+	return "=" + url.PathEscape(v)
+}
+
 // keyList is a slice of unique, sorted keys([]byte) such as what an index points to
 type keyList [][]byte
 
@@ -267,15 +618,24 @@ type MarshaledResult struct {
 }
 
 type iterator struct {
+	// ctx aborts iteration when done.
+	ctx      context.Context
 	nextKeys func() ([]ds.Key, error)
 	txn      ds.Txn
 	query    *Query
 	keyCache []ds.Key
 	iter     query.Results
+	// compound indicates keys come from a compound index and values must be matched.
+	compound bool
+	// dynamic indicates instances are of a dynamic collection, see matchInstance.
+	dynamic bool
 }
 
-func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
+// newIterator returns an iterator over the instances matching q. If q names a multikey index,
+// multikey must be set, so that instances matching several of its entries are returned once.
+func newIterator(ctx context.Context, txn dse.TxnExt, baseKey ds.Key, q *Query, multikey bool) (*iterator, error) {
 	i := &iterator{
+		ctx:   ctx,
 		txn:   txn,
 		query: q,
 	}
@@ -295,7 +655,7 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 			// Offset: q.Skip,
 		},
 	}
-	if q.Sort.FieldPath == idFieldName {
+	if q.Sort.FieldPath == idFieldName || (q.Sort.FieldPath == "" && q.AfterCursor != "") {
 		if q.Sort.Desc {
 			dsq.Orders = []query.Order{query.OrderByKeyDescending{}}
 		} else {
@@ -321,6 +681,10 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 
 	// indexed field, get keys from index
 	first := true
+	var seen map[string]struct{}
+	if multikey {
+		seen = make(map[string]struct{})
+	}
 	i.nextKeys = func() ([]ds.Key, error) {
 		var nKeys []ds.Key
 		for len(nKeys) < iteratorKeyMinCacheSize {
@@ -332,32 +696,22 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 				return nKeys, result.Error
 			}
 			first = false
-			// result.Key contains the indexed value, extract here first
-			key := ds.RawKey(result.Key)
-			base := prefix.Name()
-			name := key.Name()
-			val := gjson.Parse(name).Value()
-			if val == nil {
-				val = name
-			}
-			doc, err := sjson.Set("", base, val)
+			ok, err := matchIndexEntry(q, prefix.Name(), multikey, i.dynamic, ds.RawKey(result.Key))
 			if err != nil {
 				return nil, err
 			}
-			value := make(map[string]interface{})
-			if err := json.Unmarshal([]byte(doc), &value); err != nil {
-				return nil, fmt.Errorf("error when unmarshaling query result: %v", err)
-			}
-			ok, err = q.match(value)
-			if err != nil {
-				return nil, fmt.Errorf("error when matching entry with query: %v", err)
-			}
 			if ok {
 				indexValue := make(keyList, 0)
 				if err := DefaultDecode(result.Value, &indexValue); err != nil {
 					return nil, err
 				}
 				for _, v := range indexValue {
+					if seen != nil {
+						if _, ok := seen[string(v)]; ok {
+							continue
+						}
+						seen[string(v)] = struct{}{}
+					}
 					nKeys = append(nKeys, ds.RawKey(string(v)))
 				}
 			}
@@ -367,18 +721,97 @@ func newIterator(txn dse.TxnExt, baseKey ds.Key, q *Query) (*iterator, error) {
 	return i, nil
 }
 
+// matchIndexEntry returns whether the entry at key of the index on path matches q.
+// Entries of multikey indexes are matched as arrays holding their element. Entries of
+// indexes of dynamic collections may hold values of any type, see matchInstance.
+func matchIndexEntry(q *Query, path string, multikey, dynamic bool, key ds.Key) (bool, error) {
+	// key contains the indexed value, extract here first
+	name := key.Name()
+	val := gjson.Parse(name).Value()
+	if val == nil {
+		val = name
+	}
+	if multikey {
+		val = []interface{}{val}
+	}
+	doc, err := sjson.Set("", path, val)
+	if err != nil {
+		return false, err
+	}
+	value := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(doc), &value); err != nil {
+		return false, fmt.Errorf("error when unmarshaling query result: %v", err)
+	}
+	ok, err := matchInstance(q, value, dynamic)
+	if err != nil {
+		return false, fmt.Errorf("error when matching entry with query: %v", err)
+	}
+	return ok, nil
+}
+
+// newCompoundIterator returns an iterator over the instances in a compound index entry
+// that starts with values. Since entries may hold instances that don't match the whole
+// query, instances are matched as they're read.
+func newCompoundIterator(ctx context.Context, txn dse.TxnExt, baseKey ds.Key, q *Query, index Index, values []string) (*iterator, error) {
+	prefix := indexPrefix.Child(baseKey).ChildString(index.Name())
+	for _, v := range values {
+		prefix = prefix.ChildString(encodeIndexComponent(v))
+	}
+	iter, err := txn.QueryExtended(dse.QueryExt{
+		Query: query.Query{Prefix: prefix.String()},
+	})
+	if err != nil {
+		return nil, err
+	}
+	i := &iterator{
+		ctx:      ctx,
+		txn:      txn,
+		query:    q,
+		iter:     iter,
+		compound: true,
+	}
+	entryPrefix := prefix.String() + "/"
+	i.nextKeys = func() ([]ds.Key, error) {
+		var nKeys []ds.Key
+		for len(nKeys) < iteratorKeyMinCacheSize {
+			result, ok := i.iter.NextSync()
+			if !ok {
+				return nKeys, result.Error
+			}
+			if !strings.HasPrefix(result.Key, entryPrefix) {
+				continue
+			}
+			indexValue := make(keyList, 0)
+			if err := DefaultDecode(result.Value, &indexValue); err != nil {
+				return nil, err
+			}
+			for _, v := range indexValue {
+				nKeys = append(nKeys, ds.RawKey(string(v)))
+			}
+		}
+		return nKeys, nil
+	}
+	return i, nil
+}
+
 // NextSync returns the next key value that matches the iterators criteria
 // If there is an error, ok is false and result.Error() will return the error
 func (i *iterator) NextSync() (MarshaledResult, bool) {
-	if i.query.Index == "" {
+	if i.query.Index == "" && !i.compound {
 		value := MarshaledResult{}
 		var ok bool
 		for res := range i.iter.Next() {
+			if value.Error = res.Error; value.Error != nil {
+				break
+			}
+			if value.Error = i.ctx.Err(); value.Error != nil {
+				break
+			}
 			val := make(map[string]interface{})
 			if value.Error = json.Unmarshal(res.Value, &val); value.Error != nil {
 				break
 			}
-			ok, value.Error = i.query.match(val)
+			ok, value.Error = matchInstance(i.query, val, i.dynamic)
 			if value.Error != nil {
 				break
 			}
@@ -391,47 +824,72 @@ func (i *iterator) NextSync() (MarshaledResult, bool) {
 		}
 		return value, ok
 	}
-	if len(i.keyCache) == 0 {
-		newKeys, err := i.nextKeys()
-		if err != nil {
+	for {
+		if len(i.keyCache) == 0 {
+			newKeys, err := i.nextKeys()
+			if err != nil {
+				return MarshaledResult{
+					Result: query.Result{
+						Entry: query.Entry{},
+						Error: err,
+					},
+				}, false
+			}
+
+			if len(newKeys) == 0 {
+				return MarshaledResult{
+					Result: query.Result{
+						Entry: query.Entry{},
+						Error: nil,
+					},
+				}, false
+			}
+			i.keyCache = append(i.keyCache, newKeys...)
+		}
+
+		if err := i.ctx.Err(); err != nil {
 			return MarshaledResult{
 				Result: query.Result{
 					Entry: query.Entry{},
 					Error: err,
-				},
-			}, false
+				}}, false
 		}
+		key := i.keyCache[0]
+		i.keyCache = i.keyCache[1:]
 
-		if len(newKeys) == 0 {
+		value, err := i.txn.Get(key)
+		if err != nil {
 			return MarshaledResult{
 				Result: query.Result{
 					Entry: query.Entry{},
-					Error: nil,
+					Error: err,
+				}}, false
+		}
+		res := MarshaledResult{
+			Result: query.Result{
+				Entry: query.Entry{
+					Key:   key.String(),
+					Value: value,
 				},
-			}, false
+				Error: nil,
+			}}
+		if !i.compound {
+			return res, true
+		}
+		val := make(map[string]interface{})
+		if res.Error = json.Unmarshal(value, &val); res.Error != nil {
+			return res, false
+		}
+		ok, err := matchInstance(i.query, val, i.dynamic)
+		if err != nil {
+			res.Error = err
+			return res, false
+		}
+		if ok {
+			res.MarshaledValue = val
+			return res, true
 		}
-		i.keyCache = append(i.keyCache, newKeys...)
 	}
-
-	key := i.keyCache[0]
-	i.keyCache = i.keyCache[1:]
-
-	value, err := i.txn.Get(key)
-	if err != nil {
-		return MarshaledResult{
-			Result: query.Result{
-				Entry: query.Entry{},
-				Error: err,
-			}}, false
-	}
-	return MarshaledResult{
-		Result: query.Result{
-			Entry: query.Entry{
-				Key:   key.String(),
-				Value: value,
-			},
-			Error: nil,
-		}}, true
 }
 
 func (i *iterator) Close() {
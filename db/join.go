@@ -0,0 +1,115 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// ErrInvalidJoinSpec indicates a join spec is missing a field or collection.
+var ErrInvalidJoinSpec = errors.New("invalid join spec")
+
+// JoinSpec describes how instances of a collection are matched with instances of another.
+type JoinSpec struct {
+	// LocalField is the path of the field of base instances holding the foreign key.
+	LocalField string
+	// ForeignCollection is the name of the collection holding the instances to embed.
+	ForeignCollection string
+	// ForeignField is the path of the field of foreign instances matched with LocalField.
+	ForeignField string
+	// As is the path at which the matched foreign instance is embedded in base instances.
+	// Defaults to the name of the foreign collection.
+	As string
+}
+
+// Join returns the instances of baseCollection matching q, each with the instance of the
+// foreign collection whose ForeignField equals its LocalField embedded at As.
+// Join is read-only and best-effort. Instances are matched with a nested-loop join, which
+// looks up each distinct key in the foreign collection, using its index on ForeignField if
+// there is one. If no instance matches a key, or the key isn't a string, number, or bool,
+// null is embedded. If several instances match, the first one found is embedded.
+// Foreign instances are read in a single transaction, but not in the one reading base
+// instances, so they may reflect writes made after base instances were read.
+// If q selects fields, it must select LocalField.
+func (d *DB) Join(baseCollection string, q *Query, on JoinSpec, opts ...TxnOption) ([][]byte, error) {
+	if on.LocalField == "" || on.ForeignCollection == "" || on.ForeignField == "" {
+		return nil, ErrInvalidJoinSpec
+	}
+	as := on.As
+	if as == "" {
+		as = on.ForeignCollection
+	}
+	d.lock.Lock()
+	base, foreign := d.collections[baseCollection], d.collections[on.ForeignCollection]
+	d.lock.Unlock()
+	if base == nil || foreign == nil {
+		return nil, ErrCollectionNotFound
+	}
+
+	instances, err := base.Find(q, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := foreign.ReadTxn(func(txn *Txn) error {
+		matches := make(map[string][]byte)
+		for i, instance := range instances {
+			key := gjson.GetBytes(instance, on.LocalField)
+			match, ok := matches[key.Raw]
+			if !ok {
+				if match, err = txn.findJoined(on.ForeignField, key); err != nil {
+					return err
+				}
+				matches[key.Raw] = match
+			}
+			if match == nil {
+				match = []byte("null")
+			}
+			if instances[i], err = sjson.SetRawBytes(instance, as, match); err != nil {
+				return fmt.Errorf("embedding joined instance: %w", err)
+			}
+		}
+		return nil
+	}, opts...); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// findJoined returns the first instance whose field at path equals key, or nil if none do.
+func (t *Txn) findJoined(path string, key gjson.Result) ([]byte, error) {
+	var value interface{}
+	switch key.Type {
+	case gjson.String, gjson.Number, gjson.True, gjson.False:
+		value = key.Value()
+	default:
+		return nil, nil
+	}
+	if path == idFieldName {
+		id, ok := value.(string)
+		if !ok {
+			return nil, nil
+		}
+		instance, err := t.FindByID(core.InstanceID(id))
+		if errors.Is(err, ErrInstanceNotFound) {
+			return nil, nil
+		}
+		return instance, err
+	}
+	q := Where(path).Eq(value)
+	q.Limit = 1
+	if index, ok := t.collection.indexes[path]; ok && !index.isCompound() {
+		q.UseIndex(path)
+	}
+	res, err := t.Find(q)
+	if errors.Is(err, ErrIndexNotFound) {
+		// The index has no entries.
+		return nil, nil
+	}
+	if err != nil || len(res) == 0 {
+		return nil, err
+	}
+	return res[0], nil
+}
@@ -0,0 +1,114 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+type order struct {
+	ID       core.InstanceID `json:"_id"`
+	Item     string
+	Customer string
+}
+
+type joinedOrder struct {
+	ID       core.InstanceID `json:"_id"`
+	Item     string
+	Customer string
+	Person   *Person
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	persons, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Name"}},
+	})
+	checkErr(t, err)
+	orders, err := db.NewCollection(CollectionConfig{
+		Name:   "Order",
+		Schema: util.SchemaFromInstance(&order{}, false),
+	})
+	checkErr(t, err)
+
+	aliceID, err := persons.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+	_, err = orders.CreateMany([][]byte{
+		util.JSONFromInstance(order{Item: "Book", Customer: "Alice"}),
+		util.JSONFromInstance(order{Item: "Pen", Customer: "Bob"}),
+		util.JSONFromInstance(order{Item: "Cup", Customer: "Alice"}),
+	})
+	checkErr(t, err)
+
+	join := func(t *testing.T, on JoinSpec) map[string]*Person {
+		res, err := db.Join("Order", nil, on)
+		checkErr(t, err)
+		if len(res) != 3 {
+			t.Fatalf("expected 3 orders, got %d", len(res))
+		}
+		joined := make(map[string]*Person)
+		for _, r := range res {
+			var o joinedOrder
+			checkErr(t, json.Unmarshal(r, &o))
+			joined[o.Item] = o.Person
+		}
+		return joined
+	}
+
+	t.Run("Index", func(t *testing.T) {
+		joined := join(t, JoinSpec{
+			LocalField:        "Customer",
+			ForeignCollection: "Person",
+			ForeignField:      "Name",
+		})
+		for _, item := range []string{"Book", "Cup"} {
+			if joined[item] == nil || joined[item].ID != aliceID {
+				t.Fatalf("expected order %s to embed Alice, got %v", item, joined[item])
+			}
+		}
+		if joined["Pen"] != nil {
+			t.Fatalf("expected unmatched order to embed null, got %v", joined["Pen"])
+		}
+	})
+	t.Run("ID", func(t *testing.T) {
+		o, err := orders.Find(Where("Item").Eq("Book"))
+		checkErr(t, err)
+		var book order
+		checkErr(t, json.Unmarshal(o[0], &book))
+		book.Customer = aliceID.String()
+		checkErr(t, orders.Save(util.JSONFromInstance(book)))
+
+		joined := join(t, JoinSpec{
+			LocalField:        "Customer",
+			ForeignCollection: "Person",
+			ForeignField:      "_id",
+		})
+		if joined["Book"] == nil || joined["Book"].Name != "Alice" {
+			t.Fatalf("expected order Book to embed Alice, got %v", joined["Book"])
+		}
+		if joined["Cup"] != nil {
+			t.Fatalf("expected unmatched order to embed null, got %v", joined["Cup"])
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := db.Join("Order", nil, JoinSpec{LocalField: "Customer", ForeignCollection: "Person"})
+		if !errors.Is(err, ErrInvalidJoinSpec) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidJoinSpec, err)
+		}
+		_, err = db.Join("Order", nil, JoinSpec{
+			LocalField:        "Customer",
+			ForeignCollection: "Missing",
+			ForeignField:      "Name",
+		})
+		if !errors.Is(err, ErrCollectionNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrCollectionNotFound, err)
+		}
+	})
+}
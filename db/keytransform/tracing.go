@@ -0,0 +1,249 @@
+package keytransform
+
+import (
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dse "github.com/textileio/go-datastore-extensions"
+)
+
+// Datastore operations reported to a Tracer.
+const (
+	OpGet     = "get"
+	OpHas     = "has"
+	OpGetSize = "getSize"
+	OpPut     = "put"
+	OpDelete  = "delete"
+	OpQuery   = "query"
+	OpSync    = "sync"
+	OpCommit  = "commit"
+	OpDiscard = "discard"
+)
+
+// Span describes a completed datastore operation.
+type Span struct {
+	// Op is the operation, e.g., OpGet.
+	Op string
+	// Key is the key of the operation, the prefix of a query, or empty for commits and discards.
+	Key ds.Key
+	// Txn is whether the operation was part of a transaction.
+	Txn bool
+	// Start is the time the operation started.
+	Start time.Time
+	// Duration is the time the operation took. Queries last until their results are closed.
+	Duration time.Duration
+	// Err is the error returned by the operation, if any.
+	Err error
+}
+
+// Tracer receives the spans of datastore operations.
+type Tracer interface {
+	// Trace is called once an operation completes. It's called concurrently by
+	// concurrent operations, and shouldn't block.
+	Trace(span Span)
+}
+
+// TracerFunc is a function implementing Tracer.
+type TracerFunc func(span Span)
+
+// Trace calls f.
+func (f TracerFunc) Trace(span Span) {
+	f(span)
+}
+
+// WithTracing wraps a datastore, reporting a span to tracer for each operation of the datastore
+// and of its transactions. Operations are passed to store unchanged, so transactions keep
+// the semantics of store.
+func WithTracing(store TxnDatastoreExtended, tracer Tracer) TxnDatastoreExtended {
+	return &tracingDatastore{child: store, tracer: tracer}
+}
+
+type tracingDatastore struct {
+	child  TxnDatastoreExtended
+	tracer Tracer
+}
+
+var _ TxnDatastoreExtended = (*tracingDatastore)(nil)
+
+// trace reports the span of an operation started at start.
+func (d *tracingDatastore) trace(op string, key ds.Key, txn bool, start time.Time, err error) {
+	d.tracer.Trace(Span{
+		Op:       op,
+		Key:      key,
+		Txn:      txn,
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// traceResults returns results which report the span of a query when they're closed.
+// Iterators may be closed more than once, but the span is only reported the first time.
+func (d *tracingDatastore) traceResults(q dsq.Query, res dsq.Results, txn bool, start time.Time) dsq.Results {
+	var once sync.Once
+	return dsq.ResultsFromIterator(q, dsq.Iterator{
+		Next: res.NextSync,
+		Close: func() error {
+			err := res.Close()
+			once.Do(func() {
+				d.trace(OpQuery, ds.NewKey(q.Prefix), txn, start, err)
+			})
+			return err
+		},
+	})
+}
+
+func (d *tracingDatastore) Get(key ds.Key) ([]byte, error) {
+	start := time.Now()
+	value, err := d.child.Get(key)
+	d.trace(OpGet, key, false, start, err)
+	return value, err
+}
+
+func (d *tracingDatastore) Has(key ds.Key) (bool, error) {
+	start := time.Now()
+	exists, err := d.child.Has(key)
+	d.trace(OpHas, key, false, start, err)
+	return exists, err
+}
+
+func (d *tracingDatastore) GetSize(key ds.Key) (int, error) {
+	start := time.Now()
+	size, err := d.child.GetSize(key)
+	d.trace(OpGetSize, key, false, start, err)
+	return size, err
+}
+
+func (d *tracingDatastore) Put(key ds.Key, value []byte) error {
+	start := time.Now()
+	err := d.child.Put(key, value)
+	d.trace(OpPut, key, false, start, err)
+	return err
+}
+
+func (d *tracingDatastore) Delete(key ds.Key) error {
+	start := time.Now()
+	err := d.child.Delete(key)
+	d.trace(OpDelete, key, false, start, err)
+	return err
+}
+
+func (d *tracingDatastore) Query(q dsq.Query) (dsq.Results, error) {
+	start := time.Now()
+	res, err := d.child.Query(q)
+	if err != nil {
+		d.trace(OpQuery, ds.NewKey(q.Prefix), false, start, err)
+		return nil, err
+	}
+	return d.traceResults(q, res, false, start), nil
+}
+
+func (d *tracingDatastore) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	start := time.Now()
+	res, err := d.child.QueryExtended(q)
+	if err != nil {
+		d.trace(OpQuery, ds.NewKey(q.Prefix), false, start, err)
+		return nil, err
+	}
+	return d.traceResults(q.Query, res, false, start), nil
+}
+
+func (d *tracingDatastore) Sync(prefix ds.Key) error {
+	start := time.Now()
+	err := d.child.Sync(prefix)
+	d.trace(OpSync, prefix, false, start, err)
+	return err
+}
+
+func (d *tracingDatastore) Close() error {
+	return d.child.Close()
+}
+
+func (d *tracingDatastore) NewTransaction(readOnly bool) (ds.Txn, error) {
+	return d.NewTransactionExtended(readOnly)
+}
+
+func (d *tracingDatastore) NewTransactionExtended(readOnly bool) (dse.TxnExt, error) {
+	t, err := d.child.NewTransactionExtended(readOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &tracingTxn{child: t, ds: d}, nil
+}
+
+type tracingTxn struct {
+	child dse.TxnExt
+	ds    *tracingDatastore
+}
+
+var _ dse.TxnExt = (*tracingTxn)(nil)
+
+func (t *tracingTxn) Get(key ds.Key) ([]byte, error) {
+	start := time.Now()
+	value, err := t.child.Get(key)
+	t.ds.trace(OpGet, key, true, start, err)
+	return value, err
+}
+
+func (t *tracingTxn) Has(key ds.Key) (bool, error) {
+	start := time.Now()
+	exists, err := t.child.Has(key)
+	t.ds.trace(OpHas, key, true, start, err)
+	return exists, err
+}
+
+func (t *tracingTxn) GetSize(key ds.Key) (int, error) {
+	start := time.Now()
+	size, err := t.child.GetSize(key)
+	t.ds.trace(OpGetSize, key, true, start, err)
+	return size, err
+}
+
+func (t *tracingTxn) Put(key ds.Key, value []byte) error {
+	start := time.Now()
+	err := t.child.Put(key, value)
+	t.ds.trace(OpPut, key, true, start, err)
+	return err
+}
+
+func (t *tracingTxn) Delete(key ds.Key) error {
+	start := time.Now()
+	err := t.child.Delete(key)
+	t.ds.trace(OpDelete, key, true, start, err)
+	return err
+}
+
+func (t *tracingTxn) Query(q dsq.Query) (dsq.Results, error) {
+	start := time.Now()
+	res, err := t.child.Query(q)
+	if err != nil {
+		t.ds.trace(OpQuery, ds.NewKey(q.Prefix), true, start, err)
+		return nil, err
+	}
+	return t.ds.traceResults(q, res, true, start), nil
+}
+
+func (t *tracingTxn) QueryExtended(q dse.QueryExt) (dsq.Results, error) {
+	start := time.Now()
+	res, err := t.child.QueryExtended(q)
+	if err != nil {
+		t.ds.trace(OpQuery, ds.NewKey(q.Prefix), true, start, err)
+		return nil, err
+	}
+	return t.ds.traceResults(q.Query, res, true, start), nil
+}
+
+func (t *tracingTxn) Commit() error {
+	start := time.Now()
+	err := t.child.Commit()
+	t.ds.trace(OpCommit, ds.Key{}, true, start, err)
+	return err
+}
+
+func (t *tracingTxn) Discard() {
+	start := time.Now()
+	t.child.Discard()
+	t.ds.trace(OpDiscard, ds.Key{}, true, start, nil)
+}
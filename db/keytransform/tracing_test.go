@@ -0,0 +1,97 @@
+package keytransform
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	badger "github.com/textileio/go-ds-badger"
+)
+
+type spanRecorder struct {
+	lk    sync.Mutex
+	spans []Span
+}
+
+func (r *spanRecorder) Trace(span Span) {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	r.spans = append(r.spans, span)
+}
+
+func (r *spanRecorder) ops() []string {
+	r.lk.Lock()
+	defer r.lk.Unlock()
+	ops := make([]string, len(r.spans))
+	for i, s := range r.spans {
+		ops[i] = s.Op
+	}
+	return ops
+}
+
+func TestWithTracing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	opts := badger.DefaultOptions
+	child, err := badger.NewDatastore(dir, &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := &spanRecorder{}
+	store := WithTracing(child, rec)
+	defer store.Close()
+
+	key := ds.NewKey("/collection/foo/1")
+	txn, err := store.NewTransactionExtended(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Put(key, []byte("bar")); err != nil {
+		t.Fatal(err)
+	}
+	if err := txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ds.NewKey("/collection/foo/2")); !errors.Is(err, ds.ErrNotFound) {
+		t.Fatalf("expected error %v, got %v", ds.ErrNotFound, err)
+	}
+	res, err := store.Query(dsq.Query{Prefix: "/collection/foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := res.Rest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Key != key.String() {
+		t.Fatalf("expected entry %s, got %v", key, entries)
+	}
+
+	expected := []string{OpPut, OpCommit, OpGet, OpQuery}
+	ops := rec.ops()
+	if len(ops) != len(expected) {
+		t.Fatalf("expected ops %v, got %v", expected, ops)
+	}
+	for i, op := range expected {
+		if ops[i] != op {
+			t.Fatalf("expected ops %v, got %v", expected, ops)
+		}
+	}
+	spans := rec.spans
+	if !spans[0].Txn || !spans[0].Key.Equal(key) {
+		t.Fatalf("expected put of %s in a transaction, got %+v", key, spans[0])
+	}
+	if spans[2].Txn || !errors.Is(spans[2].Err, ds.ErrNotFound) {
+		t.Fatalf("expected failed get outside of a transaction, got %+v", spans[2])
+	}
+	if spans[3].Key.String() != "/collection/foo" {
+		t.Fatalf("expected query of /collection/foo, got %s", spans[3].Key)
+	}
+}
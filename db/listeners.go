@@ -1,8 +1,14 @@
 package db
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	format "github.com/ipfs/go-ipld-format"
 	"github.com/textileio/go-threads/core/app"
@@ -11,22 +17,163 @@ import (
 )
 
 // Listen returns a Listener which notifies about actions applying the
-// defined filters. The DB *won't* wait for slow receivers, so if the
-// channel is full, the action will be dropped.
+// defined filters. By default, the DB *won't* wait for slow receivers, so if the
+// channel is full, the action will be dropped. WithListenBuffer sets another policy.
+// WithListenFrom first replays the actions that followed a position.
 func (d *DB) Listen(los ...ListenOption) (Listener, error) {
+	if err := validListenOptions(los); err != nil {
+		return nil, err
+	}
 	d.txnlock.Lock()
 	defer d.txnlock.Unlock()
 	if d.closed {
 		return nil, fmt.Errorf("can't listen on closed DB")
 	}
 
+	if from := listenFrom(los); from != nil {
+		actions, err := d.loggedActions(*from)
+		if err != nil {
+			return nil, err
+		}
+		sl := d.stateChangedNotifee.replay(actions, los)
+		d.stateChangedNotifee.addListener(sl)
+		return sl, nil
+	}
+	sl := d.stateChangedNotifee.newListener(los, 1)
+	d.stateChangedNotifee.addListener(sl)
+	return sl, nil
+}
+
+// ListenResume is like Listen, but first replays the actions that followed the
+// action with resume token rt, so a listener can pick up where another left off
+// without missing or repeating actions.
+// Only the most recent actions are kept for replay, and tokens don't survive
+// restarts. ErrResumeTokenExpired is returned when rt can no longer be resumed.
+func (d *DB) ListenResume(rt ResumeToken, los ...ListenOption) (Listener, error) {
+	if err := validListenOptions(los); err != nil {
+		return nil, err
+	}
+	if listenFrom(los) != nil {
+		return nil, ErrListenFromUnsupported
+	}
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	if d.closed {
+		return nil, fmt.Errorf("can't listen on closed DB")
+	}
+	return d.stateChangedNotifee.resume(rt, los)
+}
+
+// Watch returns a channel that first receives an ActionCreate for each instance of the
+// collection currently matching q, then the actions on instances matching q as they're applied.
+// The current instances are read and the listener is registered while no writes are made, so no
+// action is missed or repeated at the boundary. Unlike Listen, actions are buffered rather than
+// dropped for slow receivers. Sort, Limit and Skip of q only apply to the current instances.
+// The channel is closed when ctx is done or the db is closed.
+func (c *Collection) Watch(ctx context.Context, q *Query, opts ...TxnOption) (<-chan Action, error) {
+	if q == nil {
+		q = &Query{}
+	}
+	if err := q.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	d := c.db
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	if d.closed {
+		return nil, fmt.Errorf("can't watch on closed DB")
+	}
+	if err := d.checkTokenRole(c.name, args.Token, RoleReader); err != nil {
+		return nil, err
+	}
+	txn := &Txn{collection: c, token: args.Token, readonly: true}
+	instances, err := txn.FindContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]Action, len(instances))
+	for i, instance := range instances {
+		id, err := getInstanceID(instance)
+		if err != nil {
+			return nil, err
+		}
+		pending[i] = Action{Collection: c.name, Type: ActionCreate, ID: id, instance: instance}
+	}
 	sl := &listener{
 		scn:     d.stateChangedNotifee,
-		filters: los,
+		filters: []ListenOption{{Collection: c.name, Query: q}},
 		c:       make(chan Action, 1),
+		done:    make(chan struct{}),
 	}
 	d.stateChangedNotifee.addListener(sl)
-	return sl, nil
+
+	out := make(chan Action)
+	go func() {
+		defer close(out)
+		defer sl.Close()
+		defer close(sl.done)
+		in := sl.Channel()
+		for in != nil || len(pending) > 0 {
+			var (
+				next Action
+				send chan<- Action
+			)
+			if len(pending) > 0 {
+				next, send = pending[0], out
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case a, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				pending = append(pending, a)
+			case send <- next:
+				pending = pending[1:]
+			}
+		}
+	}()
+	return out, nil
+}
+
+// ListenBatch is like Listen, but coalesces actions into batches. A batch is delivered
+// maxWait after its first action, or as soon as it holds maxCount actions. Actions on the
+// same instance within a batch are collapsed into their net effect, e.g., a create followed
+// by saves is delivered as a single create with the last state, and a create followed by a
+// delete isn't delivered at all. Conflicts are delivered as they are.
+// Like Listen, the DB won't wait for slow receivers, so actions are dropped once more than
+// maxCount of them are pending, unless WithListenBuffer sets another buffer and policy.
+func (d *DB) ListenBatch(maxWait time.Duration, maxCount int, los ...ListenOption) (BatchListener, error) {
+	if maxWait <= 0 || maxCount <= 0 {
+		return nil, ErrInvalidListenBatch
+	}
+	if err := validListenOptions(los); err != nil {
+		return nil, err
+	}
+	if listenFrom(los) != nil {
+		return nil, ErrListenFromUnsupported
+	}
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	if d.closed {
+		return nil, fmt.Errorf("can't listen on closed DB")
+	}
+
+	sl := d.stateChangedNotifee.newListener(los, maxCount)
+	d.stateChangedNotifee.addListener(sl)
+	bl := &batchListener{
+		l:    sl,
+		c:    make(chan []Action),
+		done: make(chan struct{}),
+	}
+	go bl.run(maxWait, maxCount)
+	return bl, nil
 }
 
 func (d *DB) notifyStateChanged(actions []Action) {
@@ -47,6 +194,12 @@ const (
 	ActionCreate ActionType = iota + 1
 	ActionSave
 	ActionDelete
+	// ActionConflict indicates a save overwrote concurrent changes.
+	// Only reported for collections with CollectionConfig.ReportConflicts enabled.
+	ActionConflict
+	// ActionGap indicates actions were dropped for a slow receiver, which should resync.
+	// Only reported to listeners with the DropNewestWithSignal policy. It has no collection or ID.
+	ActionGap
 )
 
 const (
@@ -54,18 +207,129 @@ const (
 	ListenCreate
 	ListenSave
 	ListenDelete
+	ListenConflict
 )
 
 type Action struct {
 	Collection string
 	Type       ActionType
 	ID         core.InstanceID
+	// Conflicts holds the competing values of an ActionConflict.
+	Conflicts []core.Conflict
+	// ResumeToken identifies the action for ListenResume.
+	// It's empty for actions replayed by WithListenFrom.
+	ResumeToken ResumeToken
+	// Position is the position of the action in the db, for WithListenFrom.
+	Position Position
+	// Author is the identity which wrote the action. It's nil for actions of views,
+	// and of records without an author.
+	Author thread.PubKey
+	// Dropped is the number of actions dropped before an ActionGap.
+	Dropped int
+
+	// instance is the state of the instance after the action,
+	// or before it for deletes. It's used to match ListenOption queries.
+	instance []byte
 }
 
+// ResumeToken is an opaque position in the stream of db actions.
+type ResumeToken string
+
+// ErrResumeTokenExpired indicates a resume token is unknown or its actions are no longer available.
+var ErrResumeTokenExpired = errors.New("resume token expired")
+
+// journalSize is the number of recent actions kept for ListenResume.
+const journalSize = 1024
+
 type ListenOption struct {
 	Type       ListenActionType
 	Collection string
 	ID         core.InstanceID
+	// Query restricts actions to those whose instance matches the query.
+	// Deletes are matched against the instance before it was deleted.
+	// Sort, Seek, Limit, Skip, Index, and AfterCursor are ignored.
+	Query *Query
+	// Authors restricts actions to those written by one of the given identities.
+	Authors []thread.PubKey
+	// ExcludeAuthors drops actions written by any of the given identities, e.g., to ignore
+	// one's own writes.
+	ExcludeAuthors []thread.PubKey
+
+	// buffer is set for options returned by WithListenBuffer, which aren't filters.
+	buffer *listenBuffer
+	// from is set for options returned by WithListenFrom, which aren't filters.
+	from *Position
+}
+
+// OverflowPolicy is what a listener does with an action when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// DropNewest drops the action. It's the policy of listeners without WithListenBuffer.
+	DropNewest OverflowPolicy = iota
+	// Block waits for the receiver to make room, so no action is missed, but writes and remote
+	// records are held up meanwhile. Receivers mustn't write to the db while they hold up the
+	// listener, and must close it once they stop receiving.
+	Block
+	// DropOldest drops the oldest buffered action to make room, so receivers see the most
+	// recent actions.
+	DropOldest
+	// DropNewestWithSignal drops the action like DropNewest, and once there's room again, delivers
+	// an ActionGap holding the number of dropped actions first, so receivers know to resync.
+	DropNewestWithSignal
+)
+
+// ErrInvalidListenBuffer indicates a non-positive listen buffer size or an unknown overflow policy.
+var ErrInvalidListenBuffer = errors.New("listen buffer size must be positive, with a known overflow policy")
+
+// listenBuffer is the buffer size and overflow policy of a listener.
+type listenBuffer struct {
+	size   int
+	policy OverflowPolicy
+}
+
+// WithListenBuffer returns a ListenOption which sets the number of actions buffered for a slow
+// receiver, and what's done with an action when the buffer is full. Unlike other options,
+// it doesn't filter actions, so it's combined with the filters of other options, if any.
+func WithListenBuffer(size int, policy OverflowPolicy) ListenOption {
+	return ListenOption{buffer: &listenBuffer{size: size, policy: policy}}
+}
+
+// ErrListenFromUnsupported indicates WithListenFrom was passed to a method other than Listen.
+var ErrListenFromUnsupported = errors.New("listen from a position is only supported by Listen")
+
+// WithListenFrom returns a ListenOption which first replays the actions that followed
+// position from, so a listener can resume from the position of the last action it
+// processed, even after a restart, without missing or repeating actions. Position 0
+// precedes the first action. Like WithListenBuffer, it's combined with other options.
+// Only the most recent actions are kept for replay, and none by default, see
+// WithNewActionLogSize. Listen returns ErrPositionExpired when from can no longer be resumed.
+func WithListenFrom(from Position) ListenOption {
+	return ListenOption{from: &from}
+}
+
+// listenFrom returns the position set by the last WithListenFrom option of los, if any.
+func listenFrom(los []ListenOption) *Position {
+	var from *Position
+	for _, lo := range los {
+		if lo.from != nil {
+			from = lo.from
+		}
+	}
+	return from
+}
+
+// validListenOptions returns an error if a listen option has an invalid query or buffer.
+func validListenOptions(los []ListenOption) error {
+	for _, lo := range los {
+		if err := lo.Query.Validate(); err != nil {
+			return fmt.Errorf("invalid query: %w", err)
+		}
+		if b := lo.buffer; b != nil && (b.size <= 0 || b.policy < DropNewest || b.policy > DropNewestWithSignal) {
+			return ErrInvalidListenBuffer
+		}
+	}
+	return nil
 }
 
 type Listener interface {
@@ -73,35 +337,189 @@ type Listener interface {
 	Close()
 }
 
+// BatchListener receives batches of actions, see DB.ListenBatch.
+type BatchListener interface {
+	Channel() <-chan []Action
+	Close()
+}
+
+// ErrInvalidListenBatch indicates a non-positive batch wait or count.
+var ErrInvalidListenBatch = errors.New("listen batch wait and count must be positive")
+
 type stateChangedNotifee struct {
 	lock      sync.RWMutex
 	listeners []*listener
+
+	// epoch distinguishes tokens issued by different notifees, i.e., across restarts.
+	epoch   string
+	seq     uint64
+	journal []Action
+}
+
+func newStateChangedNotifee() *stateChangedNotifee {
+	return &stateChangedNotifee{
+		epoch: strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
 }
 
 type listener struct {
 	scn     *stateChangedNotifee
 	filters []ListenOption
 	c       chan Action
+	// done is closed when a watcher stops receiving. Actions are never dropped for
+	// listeners with a done channel.
+	done chan struct{}
+	// policy is what's done with actions when c is full.
+	policy OverflowPolicy
+	// stop is closed when a listener with the Block policy is closed, so it's no longer waited for.
+	stop     chan struct{}
+	stopOnce sync.Once
+	// dropped is the number of actions dropped since the last ActionGap.
+	dropped int
 }
 
 var _ Listener = (*listener)(nil)
 
 func (scn *stateChangedNotifee) notify(actions []Action) {
-	scn.lock.RLock()
-	defer scn.lock.RUnlock()
+	scn.lock.Lock()
+	defer scn.lock.Unlock()
 	for _, a := range actions {
+		scn.seq++
+		a.ResumeToken = ResumeToken(scn.epoch + "." + strconv.FormatUint(scn.seq, 10))
+		if len(scn.journal) == journalSize {
+			copy(scn.journal, scn.journal[1:])
+			scn.journal = scn.journal[:journalSize-1]
+		}
+		scn.journal = append(scn.journal, a)
 		for _, l := range scn.listeners {
 			if l.evaluate(a) {
-				select {
-				case l.c <- a:
-				default:
-					log.Warnf("dropped action %v for reducer with filters %v", a, l.filters)
-				}
+				l.deliver(a)
 			}
 		}
 	}
 }
 
+// deliver sends a to the listener, applying its overflow policy if its channel is full.
+func (l *listener) deliver(a Action) {
+	if l.done != nil {
+		// Watchers buffer actions themselves, so wait for them instead of dropping
+		select {
+		case l.c <- a:
+		case <-l.done:
+		}
+		return
+	}
+	switch l.policy {
+	case Block:
+		select {
+		case l.c <- a:
+		case <-l.stop:
+		}
+	case DropOldest:
+		for {
+			select {
+			case l.c <- a:
+				return
+			default:
+			}
+			select {
+			case old := <-l.c:
+				log.Warnf("dropped action %v for reducer with filters %v", old, l.filters)
+			default:
+			}
+		}
+	case DropNewestWithSignal:
+		if l.dropped > 0 {
+			select {
+			case l.c <- Action{Type: ActionGap, Dropped: l.dropped}:
+				l.dropped = 0
+			default:
+				l.dropped++
+				return
+			}
+		}
+		select {
+		case l.c <- a:
+		default:
+			l.dropped++
+		}
+	default:
+		select {
+		case l.c <- a:
+		default:
+			log.Warnf("dropped action %v for reducer with filters %v", a, l.filters)
+		}
+	}
+}
+
+// resume registers a listener that first receives the journaled actions following rt.
+func (scn *stateChangedNotifee) resume(rt ResumeToken, filters []ListenOption) (*listener, error) {
+	scn.lock.Lock()
+	defer scn.lock.Unlock()
+	parts := strings.SplitN(string(rt), ".", 2)
+	if len(parts) != 2 || parts[0] != scn.epoch {
+		return nil, ErrResumeTokenExpired
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || seq > scn.seq {
+		return nil, ErrResumeTokenExpired
+	}
+	// The journal holds actions scn.seq-len(journal)+1 through scn.seq.
+	first := scn.seq - uint64(len(scn.journal)) + 1
+	if seq+1 < first {
+		return nil, ErrResumeTokenExpired
+	}
+	sl := scn.replay(scn.journal[seq+1-first:], filters)
+	scn.listeners = append(scn.listeners, sl)
+	return sl, nil
+}
+
+// newListener returns a listener with the filters and buffer of los, whose buffer holds
+// size actions unless they set another size. The listener isn't registered.
+func (scn *stateChangedNotifee) newListener(los []ListenOption, size int) *listener {
+	sl := &listener{scn: scn}
+	size = sl.configure(los, size)
+	sl.c = make(chan Action, size)
+	return sl
+}
+
+// configure sets the filters and overflow policy of the listener from los, and returns
+// the size of its buffer, which is size unless they set another size.
+func (sl *listener) configure(los []ListenOption, size int) int {
+	for _, lo := range los {
+		if lo.buffer != nil {
+			size, sl.policy = lo.buffer.size, lo.buffer.policy
+			continue
+		}
+		if lo.from != nil {
+			continue
+		}
+		sl.filters = append(sl.filters, lo)
+	}
+	if sl.policy == Block {
+		sl.stop = make(chan struct{})
+	}
+	return size
+}
+
+// replay returns a listener that first receives the actions matching filters.
+// The listener isn't registered.
+func (scn *stateChangedNotifee) replay(actions []Action, filters []ListenOption) *listener {
+	sl := &listener{scn: scn}
+	size := sl.configure(filters, 1)
+	var replay []Action
+	for _, a := range actions {
+		if sl.evaluate(a) {
+			replay = append(replay, a)
+		}
+	}
+	sl.c = make(chan Action, len(replay)+size)
+	for _, a := range replay {
+		sl.c <- a
+	}
+	return sl
+}
+
 func (scn *stateChangedNotifee) addListener(sl *listener) {
 	scn.lock.Lock()
 	defer scn.lock.Unlock()
@@ -141,6 +559,10 @@ func (sl *listener) Channel() <-chan Action {
 // Close indicates that no further notifications will be received
 // and ready for being garbage collected
 func (sl *listener) Close() {
+	if sl.stop != nil {
+		// Stop waiting for the listener first, since actions are sent to it holding the lock
+		sl.stopOnce.Do(func() { close(sl.stop) })
+	}
 	if ok := sl.scn.remove(sl); ok {
 		close(sl.c)
 	}
@@ -165,6 +587,10 @@ func (sl *listener) evaluate(a Action) bool {
 			if a.Type != ActionDelete {
 				continue
 			}
+		case ListenConflict:
+			if a.Type != ActionConflict {
+				continue
+			}
 		default:
 			panic("unknown action type")
 		}
@@ -176,7 +602,167 @@ func (sl *listener) evaluate(a Action) bool {
 		if f.ID != core.EmptyInstanceID && f.ID != a.ID {
 			continue
 		}
+
+		if f.Query != nil && !a.matches(f.Query) {
+			continue
+		}
+
+		if !a.writtenBy(f.Authors, f.ExcludeAuthors) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// writtenBy returns whether a wasn't written by an identity of exclude, and, if include isn't
+// empty, whether it was written by one of its identities.
+func (a Action) writtenBy(include, exclude []thread.PubKey) bool {
+	for _, k := range exclude {
+		if a.Author != nil && k.Equals(a.Author) {
+			return false
+		}
+	}
+	if len(include) == 0 {
 		return true
 	}
+	for _, k := range include {
+		if a.Author != nil && k.Equals(a.Author) {
+			return true
+		}
+	}
 	return false
 }
+
+// matches returns whether the instance of a matches q.
+func (a Action) matches(q *Query) bool {
+	if a.instance == nil {
+		return false
+	}
+	v := make(map[string]interface{})
+	if err := json.Unmarshal(a.instance, &v); err != nil {
+		return false
+	}
+	ok, err := q.match(v)
+	return err == nil && ok
+}
+
+type batchListener struct {
+	l    *listener
+	c    chan []Action
+	done chan struct{}
+	once sync.Once
+}
+
+var _ BatchListener = (*batchListener)(nil)
+
+// Channel returns an unbuffered channel to receive batches of
+// db change notifications
+func (bl *batchListener) Channel() <-chan []Action {
+	return bl.c
+}
+
+// Close indicates that no further notifications will be received.
+// The pending batch is discarded.
+func (bl *batchListener) Close() {
+	bl.once.Do(func() {
+		close(bl.done)
+		bl.l.Close()
+	})
+}
+
+// run batches the actions of the underlying listener until it's closed.
+func (bl *batchListener) run(maxWait time.Duration, maxCount int) {
+	defer close(bl.c)
+	var (
+		batch   []Action
+		timer   *time.Timer
+		timeout <-chan time.Time
+	)
+	flush := func() bool {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		actions := collapseActions(batch)
+		batch = nil
+		if len(actions) == 0 {
+			return true
+		}
+		select {
+		case bl.c <- actions:
+			return true
+		case <-bl.done:
+			return false
+		}
+	}
+	in := bl.l.Channel()
+	for {
+		select {
+		case a, ok := <-in:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, a)
+			if timer == nil {
+				timer = time.NewTimer(maxWait)
+				timeout = timer.C
+			}
+			if len(batch) >= maxCount && !flush() {
+				return
+			}
+		case <-timeout:
+			if !flush() {
+				return
+			}
+		case <-bl.done:
+			return
+		}
+	}
+}
+
+// collapseActions returns actions with the actions on each instance replaced by their net
+// effect, which takes the place of the last of them. Conflicts are kept as they are.
+func collapseActions(actions []Action) []Action {
+	type key struct {
+		collection string
+		id         core.InstanceID
+	}
+	type collapsed struct {
+		Action
+		// none indicates actions which cancelled out.
+		none       bool
+		superseded bool
+	}
+	last := make(map[key]int)
+	entries := make([]collapsed, 0, len(actions))
+	for _, a := range actions {
+		e := collapsed{Action: a}
+		if a.Type != ActionConflict && a.Type != ActionGap {
+			k := key{collection: a.Collection, id: a.ID}
+			if i, ok := last[k]; ok {
+				prev := &entries[i]
+				prev.superseded = true
+				switch {
+				case prev.none:
+				case prev.Type == ActionCreate && a.Type == ActionDelete:
+					e.none = true
+				case prev.Type == ActionCreate:
+					e.Type = ActionCreate
+				case prev.Type == ActionDelete && a.Type == ActionCreate:
+					e.Type = ActionSave
+				}
+			}
+			last[k] = len(entries)
+		}
+		entries = append(entries, e)
+	}
+	res := make([]Action, 0, len(entries))
+	for _, e := range entries {
+		if !e.none && !e.superseded {
+			res = append(res, e.Action)
+		}
+	}
+	return res
+}
@@ -26,6 +26,8 @@ var (
 	ErrDBNotFound = errors.New("db not found")
 	// ErrDBExists indicates that the specified db alrady exists in the manager.
 	ErrDBExists = errors.New("db already exists")
+	// ErrInvalidStorePrefix indicates the store prefix of a manager isn't valid.
+	ErrInvalidStorePrefix = errors.New("store prefix may only contain alphanumeric characters or non-consecutive hyphens")
 
 	// MaxLoadConcurrency is the max number of dbs that will be concurrently loaded when the manager starts.
 	MaxLoadConcurrency = 100
@@ -46,11 +48,27 @@ type Manager struct {
 }
 
 // NewManager hydrates and starts dbs from prefixes.
+// Managers sharing a store are isolated by WithNewStorePrefix, in which case only the dbs
+// created with the same prefix are loaded.
 func NewManager(store kt.TxnDatastoreExtended, network app.Net, opts ...NewOption) (*Manager, error) {
 	args := &NewOptions{}
 	for _, opt := range opts {
 		opt(args)
 	}
+	if args.StorePrefix != "" {
+		if !nameRx.MatchString(args.StorePrefix) {
+			return nil, ErrInvalidStorePrefix
+		}
+		prefix := keytransform.PrefixTransform{Prefix: ds.NewKey(args.StorePrefix)}
+		store = kt.WrapTxnDatastore(store, prefix)
+		if len(args.Datastores) > 0 {
+			stores := make(map[string]kt.TxnDatastoreExtended, len(args.Datastores))
+			for n, s := range args.Datastores {
+				stores[n] = kt.WrapTxnDatastore(s, prefix)
+			}
+			args.Datastores = stores
+		}
+	}
 	if err := util.SetLogLevels(map[string]logging.LogLevel{
 		"db": util.LevelFromDebugFlag(args.Debug),
 	}); err != nil {
@@ -162,7 +180,7 @@ func (m *Manager) NewDB(ctx context.Context, id thread.ID, opts ...NewManagedOpt
 		return nil, ErrInvalidName
 	}
 
-	if args.Key.Defined() && !args.Key.CanRead() {
+	if args.Key.Defined() && !args.Key.CanReadAny() {
 		return nil, ErrThreadReadKeyRequired
 	}
 	log.Debugf("manager: creating thread with net %s", id)
@@ -223,7 +241,7 @@ func (m *Manager) NewDBFromAddr(
 		return nil, ErrInvalidName
 	}
 
-	if key.Defined() && !key.CanRead() {
+	if key.Defined() && !key.CanReadAny() {
 		return nil, ErrThreadReadKeyRequired
 	}
 	log.Debugf("manager: adding thread to net %s", id)
@@ -417,14 +435,28 @@ func wrapDB(
 	if err := id.Validate(); err != nil {
 		return nil, nil, err
 	}
-	store = kt.WrapTxnDatastore(store, keytransform.PrefixTransform{
+	prefix := keytransform.PrefixTransform{
 		Prefix: dsManagerBaseKey.ChildString(id.String()),
-	})
+	}
+	store = kt.WrapTxnDatastore(store, prefix)
+	var stores map[string]kt.TxnDatastoreExtended
+	if len(base.Datastores) > 0 {
+		stores = make(map[string]kt.TxnDatastoreExtended, len(base.Datastores))
+		for n, s := range base.Datastores {
+			stores[n] = kt.WrapTxnDatastore(s, prefix)
+		}
+	}
 	opts := &NewOptions{
 		Name:        name,
 		Collections: append(base.Collections, collections...),
 		EventCodec:  base.EventCodec,
+		EventCodecs: base.EventCodecs,
 		Debug:       base.Debug,
+		Datastores:  stores,
+		ValueKey:    base.ValueKey,
+		ReadOnly:    base.ReadOnly,
+		SyncWrites:  base.SyncWrites,
+		Middleware:  base.Middleware,
 	}
 	return store, opts, nil
 }
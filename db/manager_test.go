@@ -146,6 +146,55 @@ func TestManager_ReloadDBs(t *testing.T) {
 	checkErr(t, err)
 }
 
+func TestManager_StorePrefix(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	newNetwork := func() common.NetBoostrapper {
+		n, err := common.DefaultNetwork(
+			common.WithNetBadgerPersistence(dir),
+			common.WithNetHostAddr(util.FreeLocalAddr()),
+			common.WithNetDebug(true),
+		)
+		checkErr(t, err)
+		return n
+	}
+	n := newNetwork()
+	store, err := util.NewBadgerDatastore(dir, "eventstore", false)
+	checkErr(t, err)
+	defer store.Close()
+
+	if _, err := NewManager(store, n, WithNewStorePrefix("a/b")); !errors.Is(err, ErrInvalidStorePrefix) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidStorePrefix, err)
+	}
+	man, err := NewManager(store, n, WithNewStorePrefix("app-a"))
+	checkErr(t, err)
+	d := createDBAndAddData(t, ctx, man)
+	id := d.connector.ThreadID()
+	checkErr(t, man.Close())
+	checkErr(t, n.Close())
+
+	n = newNetwork()
+	defer n.Close()
+	for _, opts := range [][]NewOption{{WithNewStorePrefix("app-b")}, nil} {
+		other, err := NewManager(store, n, opts...)
+		checkErr(t, err)
+		dbs, err := other.ListDBs(ctx)
+		checkErr(t, err)
+		if len(dbs) != 0 {
+			t.Fatalf("expected no dbs of other managers, got %d", len(dbs))
+		}
+		checkErr(t, other.Close())
+	}
+	man, err = NewManager(store, n, WithNewStorePrefix("app-a"))
+	checkErr(t, err)
+	defer man.Close()
+	if _, err := man.GetDB(ctx, id); err != nil {
+		t.Fatalf("expected db to be reloaded with its prefix: %v", err)
+	}
+}
+
 func TestManager_GetDB(t *testing.T) {
 	t.Parallel()
 	ctx := context.Background()
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/textileio/go-threads/util"
+)
+
+// metrics counts queries and transactions.
+// A nil *metrics is valid and counts nothing.
+type metrics struct {
+	queries *prometheus.CounterVec
+	txns    *prometheus.CounterVec
+}
+
+// newMetrics registers db metrics with reg. It returns nil if reg is nil.
+func newMetrics(reg prometheus.Registerer) (*metrics, error) {
+	if reg == nil {
+		return nil, nil
+	}
+	queries, err := util.RegisterCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "threads",
+		Subsystem: "db",
+		Name:      "queries_total",
+		Help:      "Number of collection queries by kind.",
+	}, []string{"kind"}))
+	if err != nil {
+		return nil, err
+	}
+	txns, err := util.RegisterCollector(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "threads",
+		Subsystem: "db",
+		Name:      "txns_total",
+		Help:      "Number of transactions by kind.",
+	}, []string{"kind"}))
+	if err != nil {
+		return nil, err
+	}
+	return &metrics{
+		queries: queries.(*prometheus.CounterVec),
+		txns:    txns.(*prometheus.CounterVec),
+	}, nil
+}
+
+// query counts a query of kind, e.g., "find" or "count".
+func (m *metrics) query(kind string) {
+	if m == nil {
+		return
+	}
+	m.queries.WithLabelValues(kind).Inc()
+}
+
+// txn counts a transaction of kind, e.g., "read" or "write".
+func (m *metrics) txn(kind string) {
+	if m == nil {
+		return
+	}
+	m.txns.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	reg := prometheus.NewRegistry()
+	db, clean := createTestDB(t, WithNewMetrics(reg))
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30}))
+	checkErr(t, err)
+	_, err = c.Find(&Query{})
+	checkErr(t, err)
+	_, err = c.FindByID(id)
+	checkErr(t, err)
+	_, err = c.Count(&Query{})
+	checkErr(t, err)
+
+	m := db.metrics
+	for kind, count := range map[string]float64{"find": 1, "find_by_id": 1, "count": 1} {
+		if got := testutil.ToFloat64(m.queries.WithLabelValues(kind)); got != count {
+			t.Fatalf("expected %v %s queries, got %v", count, kind, got)
+		}
+	}
+	if got := testutil.ToFloat64(m.txns.WithLabelValues("write")); got != 1 {
+		t.Fatalf("expected 1 write txn, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.txns.WithLabelValues("read")); got != 3 {
+		t.Fatalf("expected 3 read txns, got %v", got)
+	}
+
+	t.Run("SharedRegistry", func(t *testing.T) {
+		other, clean := createTestDB(t, WithNewMetrics(reg))
+		defer clean()
+		if other.metrics.queries != m.queries {
+			t.Fatal("expected dbs sharing a registry to share metrics")
+		}
+	})
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// OperationType is the kind of a collection operation passed to middleware.
+type OperationType string
+
+// Operation types of the collection methods. ReadTxn and WriteTxn have their own types,
+// whatever is done in the transaction.
+const (
+	OpReadTxn       OperationType = "read_txn"
+	OpWriteTxn      OperationType = "write_txn"
+	OpFindByID      OperationType = "find_by_id"
+	OpCreate        OperationType = "create"
+	OpUpsert        OperationType = "upsert"
+	OpDelete        OperationType = "delete"
+	OpSoftDelete    OperationType = "soft_delete"
+	OpRestore       OperationType = "restore"
+	OpSave          OperationType = "save"
+	OpModify        OperationType = "modify"
+	OpVerify        OperationType = "verify"
+	OpValidate      OperationType = "validate"
+	OpHas           OperationType = "has"
+	OpFind          OperationType = "find"
+	OpCount         OperationType = "count"
+	OpExplain       OperationType = "explain"
+	OpFindStream    OperationType = "find_stream"
+	OpIterate       OperationType = "iterate"
+	OpReduce        OperationType = "reduce"
+	OpDistinct      OperationType = "distinct"
+	OpTimeSeries    OperationType = "time_series"
+	OpModifiedSince OperationType = "modified_since"
+)
+
+// OperationInfo describes a collection operation passed to middleware.
+type OperationInfo struct {
+	// Type is the kind of operation. Operations on many instances, e.g., CreateMany, have
+	// the type of their single instance counterpart.
+	Type OperationType
+	// Collection is the name of the collection.
+	Collection string
+	// Write is set for operations run in a write transaction.
+	Write bool
+	// Token is the token authorizing the operation, if any.
+	Token thread.Token
+}
+
+// Handler runs a collection operation with ctx.
+type Handler func(ctx context.Context, op OperationInfo) error
+
+// Middleware wraps the Handler of collection operations, e.g., to trace operations, or to
+// authorize them by returning an error without calling next. The context passed to next is
+// used by the operation, so middleware may annotate it or add a deadline.
+//
+// Middleware runs before the transaction of the operation starts, so before the db lock is
+// taken, the token is validated, and instances are validated against the collection schema
+// and write validator. The error returned by next is the error of the operation, including
+// validation errors.
+type Middleware func(next Handler) Handler
+
+// handle runs f as operation typ of collection c through the middleware of the db.
+// The first registered middleware is the outermost, so it's called first and returns last.
+func (d *DB) handle(
+	ctx context.Context,
+	c *Collection,
+	typ OperationType,
+	write bool,
+	f func(ctx context.Context) error,
+	opts ...TxnOption,
+) error {
+	if len(d.middleware) == 0 {
+		return f(ctx)
+	}
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	h := Handler(func(ctx context.Context, _ OperationInfo) error {
+		return f(ctx)
+	})
+	for i := len(d.middleware) - 1; i >= 0; i-- {
+		h = d.middleware[i](h)
+	}
+	return h(ctx, OperationInfo{
+		Type:       typ,
+		Collection: c.name,
+		Write:      write,
+		Token:      args.Token,
+	})
+}
+
+// read runs f in a read transaction of the collection, as operation typ.
+func (c *Collection) read(
+	ctx context.Context,
+	typ OperationType,
+	f func(ctx context.Context, txn *Txn) error,
+	opts ...TxnOption,
+) error {
+	return c.db.handle(ctx, c, typ, false, func(ctx context.Context) error {
+		return c.db.readTxn(c, func(txn *Txn) error {
+			return f(ctx, txn)
+		}, opts...)
+	}, opts...)
+}
+
+// write runs f in a write transaction of the collection, as operation typ.
+// The transaction is committed with the context returned by the middleware.
+func (c *Collection) write(
+	ctx context.Context,
+	typ OperationType,
+	f func(ctx context.Context, txn *Txn) error,
+	opts ...TxnOption,
+) error {
+	return c.db.handle(ctx, c, typ, true, func(ctx context.Context) error {
+		return c.db.writeTxn(ctx, c, func(txn *Txn) error {
+			return f(ctx, txn)
+		}, opts...)
+	}, opts...)
+}
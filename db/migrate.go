@@ -0,0 +1,194 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrMigrationNameMismatch indicates the config passed to MigrateCollection names another collection.
+	ErrMigrationNameMismatch = errors.New("migration config name doesn't match collection")
+
+	errMigrationChangedID = errors.New("transform changed the instance ID")
+
+	dsMigrations = dsPrefix.ChildString("migration")
+)
+
+// MigrationTransform rewrites an instance for a new collection config.
+// It must not change the instance ID, and should be deterministic,
+// since it may be called more than once for the same instance.
+type MigrationTransform func(old []byte) (new []byte, err error)
+
+// migration records the instances being rewritten by a migration, so it can be resumed.
+type migration struct {
+	// Mods maps the IDs of instances to migrate to their _mod tags before the migration.
+	Mods map[core.InstanceID]int64 `json:"mods"`
+}
+
+// MigrateCollection updates a collection with a new config, and rewrites every instance with transform.
+// First, all instances are transformed and validated against the new config. If transform fails, or
+// an instance doesn't satisfy the new schema or changes its ID, the migration is aborted before
+// anything is written. Then, the collection config is updated, and the transformed instances are saved
+// in a single transaction. Their events are dispatched and sent to the network in one record, so replicas
+// converge on the migrated instances.
+// If the process stops before the migration finishes, calling MigrateCollection again with the same
+// arguments resumes it. Instances that were already rewritten aren't transformed again.
+// Writes to the collection shouldn't be made while it's being migrated.
+func (d *DB) MigrateCollection(name string, config CollectionConfig, transform MigrationTransform, opts ...Option) error {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if config.Name != name {
+		return ErrMigrationNameMismatch
+	}
+	if d.readOnly {
+		return ErrReadOnly
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return err
+	}
+	c := d.GetCollection(name)
+	if c == nil {
+		return ErrCollectionNotFound
+	}
+	if c.datastoreName != config.Datastore {
+		return ErrCannotChangeDatastore
+	}
+	// Build the new collection to validate instances against its schema.
+	nc, err := newCollection(d, config)
+	if err != nil {
+		return err
+	}
+
+	m, resuming, err := d.getMigration(name)
+	if err != nil {
+		return err
+	}
+	if !resuming {
+		if m, err = newMigration(c); err != nil {
+			return err
+		}
+	}
+	if _, err := migrateInstances(c, nc, m, transform); err != nil {
+		return err
+	}
+	if !resuming {
+		if err := d.putMigration(name, m); err != nil {
+			return err
+		}
+	}
+
+	c, err = d.UpdateCollection(config, opts...)
+	if err != nil {
+		return err
+	}
+	if err := c.WriteTxn(func(txn *Txn) error {
+		updated, err := migrateInstances(c, c, m, transform)
+		if err != nil {
+			return err
+		}
+		if len(updated) == 0 {
+			return nil
+		}
+		return txn.Save(updated...)
+	}, WithTxnToken(args.Token)); err != nil {
+		return err
+	}
+	return d.datastore.Delete(dsMigrations.ChildString(name))
+}
+
+// newMigration records the instances of c to migrate.
+func newMigration(c *Collection) (*migration, error) {
+	m := &migration{Mods: make(map[core.InstanceID]int64)}
+	err := forEachInstance(c, func(id core.InstanceID, instance []byte) error {
+		m.Mods[id] = gjson.GetBytes(instance, modFieldName).Int()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// migrateInstances returns the transformed instances of c recorded by m, validated against nc.
+// Instances whose _mod tag changed since m was recorded were already migrated, and are skipped.
+func migrateInstances(c, nc *Collection, m *migration, transform MigrationTransform) ([][]byte, error) {
+	var updated [][]byte
+	err := forEachInstance(c, func(id core.InstanceID, instance []byte) error {
+		mod, ok := m.Mods[id]
+		if !ok || gjson.GetBytes(instance, modFieldName).Int() != mod {
+			return nil
+		}
+		next, err := transform(instance)
+		if err != nil {
+			return fmt.Errorf("migrating instance %s: %w", id, err)
+		}
+		if nextID, err := getInstanceID(next); err != nil || nextID != id {
+			return fmt.Errorf("migrating instance %s: %w", id, errMigrationChangedID)
+		}
+		if err := nc.validInstance(next); err != nil {
+			return fmt.Errorf("migrating instance %s: %w", id, err)
+		}
+		updated = append(updated, next)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// forEachInstance calls fn with each instance of c, as stored.
+func forEachInstance(c *Collection, fn func(id core.InstanceID, instance []byte) error) error {
+	prefix := c.baseKey().String() + "/"
+	res, err := c.store.Query(query.Query{Prefix: c.baseKey().String()})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if !strings.HasPrefix(r.Key, prefix) {
+			continue
+		}
+		if err := fn(core.InstanceID(ds.RawKey(r.Key).Name()), r.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getMigration returns the unfinished migration of a collection, if any.
+func (d *DB) getMigration(name string) (*migration, bool, error) {
+	b, err := d.datastore.Get(dsMigrations.ChildString(name))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	m := &migration{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, false, err
+	}
+	return m, true, nil
+}
+
+// putMigration persists the migration of a collection until it finishes.
+func (d *DB) putMigration(name string, m *migration) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return d.datastore.Put(dsMigrations.ChildString(name), b)
+}
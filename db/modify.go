@@ -0,0 +1,96 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	core "github.com/textileio/go-threads/core/db"
+)
+
+var (
+	// ErrInvalidPatch indicates a patch passed to ModifyByID is malformed or can't be applied.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrCantPatchID indicates a patch passed to ModifyByID changes the instance ID.
+	ErrCantPatchID = errors.New("patch can't change instance ID")
+)
+
+// ModifyByID applies a patch to an instance in a single write transaction.
+// See Txn.ModifyByID for details.
+func (c *Collection) ModifyByID(id core.InstanceID, patch []byte, opts ...TxnOption) error {
+	return c.write(context.Background(), OpModify, func(_ context.Context, txn *Txn) error {
+		return txn.ModifyByID(id, patch)
+	}, opts...)
+}
+
+// ModifyByID applies a patch to an instance when the current transaction commits.
+// The patch format is detected from its JSON type: an array is a JSON Patch (RFC 6902),
+// and an object is a JSON Merge Patch (RFC 7396).
+// The patch is applied to the instance as it stands in the transaction, including earlier
+// writes. Like Save, the patched instance must satisfy the collection schema, and only its
+// differences from the current instance are written to the event log.
+// Malformed patches, or JSON Patches with failing operations, are rejected with ErrInvalidPatch.
+func (t *Txn) ModifyByID(id core.InstanceID, patch []byte) error {
+	if t.readonly {
+		return ErrReadonlyTx
+	}
+	current, err := t.pendingInstance(id)
+	if err != nil {
+		return err
+	}
+	next, err := applyPatch(current, patch)
+	if err != nil {
+		return err
+	}
+	nextID, err := getInstanceID(next)
+	if err != nil || nextID != id {
+		return ErrCantPatchID
+	}
+	return t.Save(next)
+}
+
+// pendingInstance returns an instance as it stands in the transaction.
+func (t *Txn) pendingInstance(id core.InstanceID) ([]byte, error) {
+	for i := len(t.actions) - 1; i >= 0; i-- {
+		a := t.actions[i]
+		if a.InstanceID != id {
+			continue
+		}
+		if a.Type == core.Delete {
+			return nil, ErrInstanceNotFound
+		}
+		return a.Current, nil
+	}
+	return t.FindByID(id)
+}
+
+// applyPatch applies a JSON Patch or JSON Merge Patch to doc.
+func applyPatch(doc, patch []byte) ([]byte, error) {
+	patch = bytes.TrimSpace(patch)
+	if !json.Valid(patch) {
+		return nil, fmt.Errorf("%w: malformed JSON", ErrInvalidPatch)
+	}
+	switch {
+	case len(patch) > 0 && patch[0] == '[':
+		p, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		next, err := p.Apply(doc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		return next, nil
+	case len(patch) > 0 && patch[0] == '{':
+		next, err := jsonpatch.MergePatch(doc, patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		return next, nil
+	default:
+		return nil, fmt.Errorf("%w: patch must be a JSON array or object", ErrInvalidPatch)
+	}
+}
@@ -1,9 +1,15 @@
 package db
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/prometheus/client_golang/prometheus"
+	sym "github.com/textileio/crypto/symmetric"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
+	kt "github.com/textileio/go-threads/db/keytransform"
 	"github.com/textileio/go-threads/jsonpatcher"
 )
 
@@ -13,14 +19,33 @@ func newDefaultEventCodec() core.EventCodec {
 
 // NewOptions defines options for creating a new db.
 type NewOptions struct {
-	Name        string
-	Key         thread.Key
-	LogKey      crypto.Key
-	Collections []CollectionConfig
-	Block       bool
-	EventCodec  core.EventCodec
-	Token       thread.Token
-	Debug       bool
+	Name            string
+	Key             thread.Key
+	LogKey          crypto.Key
+	Collections     []CollectionConfig
+	Block           bool
+	EventCodec      core.EventCodec
+	EventCodecs     map[string]core.EventCodec
+	Token           thread.Token
+	Debug           bool
+	Datastores      map[string]kt.TxnDatastoreExtended
+	ValueKey        *sym.Key
+	ACL             ACL
+	SchemaDocuments map[string]json.RawMessage
+	Metrics         prometheus.Registerer
+	QueryCacheSize  int
+	MaxBytes        int64
+	ActionLogSize   int
+	ReadOnly        bool
+	SyncWrites      bool
+	// ExpirySweepInterval is the interval at which expired instances are deleted.
+	ExpirySweepInterval time.Duration
+	// BackfillDepth is the maximum number of records of each log pulled by NewDBFromAddr.
+	BackfillDepth int
+	// StorePrefix namespaces the datastore keys of the dbs of a manager.
+	StorePrefix string
+	// Middleware wraps the collection operations of the db, in order of registration.
+	Middleware []Middleware
 }
 
 // NewOption specifies a new db option.
@@ -68,6 +93,16 @@ func WithNewBackfillBlock(block bool) NewOption {
 	}
 }
 
+// WithNewBackfillDepth makes NewDBFromAddr pull at most depth of the newest records of each log
+// of the thread, instead of its entire history. The db then holds a partial view of the thread,
+// see DB.IsPartial. Instances created before the pulled records are missing, and those updated
+// by them only hold the updated fields.
+func WithNewBackfillDepth(depth int) NewOption {
+	return func(o *NewOptions) {
+		o.BackfillDepth = depth
+	}
+}
+
 // WithNewEventCodec configure to use ec as the EventCodec
 // for transforming actions in events, and viceversa.
 func WithNewEventCodec(ec core.EventCodec) NewOption {
@@ -76,6 +111,19 @@ func WithNewEventCodec(ec core.EventCodec) NewOption {
 	}
 }
 
+// WithNewNamedEventCodec registers ec under name.
+// Collections use a registered codec instead of the db's EventCodec with CollectionConfig.EventCodec.
+// Codecs must be registered under the same names by every peer of the db.
+// The built-in codecs, such as ReplaceEventCodec, are always registered.
+func WithNewNamedEventCodec(name string, ec core.EventCodec) NewOption {
+	return func(o *NewOptions) {
+		if o.EventCodecs == nil {
+			o.EventCodecs = make(map[string]core.EventCodec)
+		}
+		o.EventCodecs[name] = ec
+	}
+}
+
 // WithNewToken provides authorization for interacting with a db.
 func WithNewToken(t thread.Token) NewOption {
 	return func(o *NewOptions) {
@@ -83,6 +131,132 @@ func WithNewToken(t thread.Token) NewOption {
 	}
 }
 
+// WithNewDatastore registers store under name.
+// Collections are bound to a registered store with CollectionConfig.Datastore.
+// The store is not closed with the db.
+func WithNewDatastore(name string, store kt.TxnDatastoreExtended) NewOption {
+	return func(o *NewOptions) {
+		if o.Datastores == nil {
+			o.Datastores = make(map[string]kt.TxnDatastoreExtended)
+		}
+		o.Datastores[name] = store
+	}
+}
+
+// WithNewSchemaDocument registers a JSON Schema document under name.
+// Collection schemas can reference its definitions, e.g., {"$ref": "name#/definitions/Type"}.
+// References are resolved when collections are created or loaded, so the documents
+// referenced by existing collections must be registered each time the db is opened.
+func WithNewSchemaDocument(name string, doc json.RawMessage) NewOption {
+	return func(o *NewOptions) {
+		if o.SchemaDocuments == nil {
+			o.SchemaDocuments = make(map[string]json.RawMessage)
+		}
+		o.SchemaDocuments[name] = doc
+	}
+}
+
+// WithNewValueKey provides the key used to encrypt the stored values of collections
+// with CollectionConfig.EncryptValues set. The key never leaves the local peer.
+// Dispatched events are also encrypted, since they hold instance values.
+// Collections with encrypted values can't be loaded without the key they were created with.
+func WithNewValueKey(key *sym.Key) NewOption {
+	return func(o *NewOptions) {
+		o.ValueKey = key
+	}
+}
+
+// WithNewACL enforces acl on collection reads and writes.
+// Writes are checked before events are created, and records from other peers are
+// validated against it, so an identity lacking a role can't mutate a collection.
+func WithNewACL(acl ACL) NewOption {
+	return func(o *NewOptions) {
+		o.ACL = acl
+	}
+}
+
+// WithNewMetrics registers query and transaction counts with reg.
+// Dbs opened with the same registry report to the same metrics.
+func WithNewMetrics(reg prometheus.Registerer) NewOption {
+	return func(o *NewOptions) {
+		o.Metrics = reg
+	}
+}
+
+// WithNewQueryCache caches the results of up to maxEntries Find queries.
+// Cached results are invalidated by writes to instances which match their query,
+// so a result is never returned after a write which affects it.
+func WithNewQueryCache(maxEntries int) NewOption {
+	return func(o *NewOptions) {
+		o.QueryCacheSize = maxEntries
+	}
+}
+
+// WithNewMaxBytes limits the total size of stored instances to n bytes.
+// Creates which would exceed the limit fail with ErrQuotaExceeded. The size is the sum of the
+// lengths of stored instances, so keys, indexes and events aren't counted.
+// A non-positive n disables the limit.
+func WithNewMaxBytes(n int64) NewOption {
+	return func(o *NewOptions) {
+		o.MaxBytes = n
+	}
+}
+
+// WithNewActionLogSize keeps the last n actions for WithListenFrom. Actions aren't kept by default.
+// Older positions can't be resumed from.
+func WithNewActionLogSize(n int) NewOption {
+	return func(o *NewOptions) {
+		o.ActionLogSize = n
+	}
+}
+
+// WithNewReadOnly opens the db in read-only mode, for nodes which only replicate and serve a thread.
+// Local writes fail with ErrReadOnly, while records received from other peers are still applied.
+// Collections can still be managed, since they must exist locally for records to be applied.
+func WithNewReadOnly() NewOption {
+	return func(o *NewOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// WithNewSyncWrites makes writes durable before they return, by flushing the db datastores
+// to disk after each transaction is committed. See Txn.Sync to make a single transaction durable.
+// Writes are asynchronous by default: a datastore may buffer committed writes, which can be lost
+// on a crash. Syncing costs a flush per transaction, which greatly reduces write throughput.
+// Only the db datastores are synced, not the log and block stores of the network.
+func WithNewSyncWrites(enable bool) NewOption {
+	return func(o *NewOptions) {
+		o.SyncWrites = enable
+	}
+}
+
+// WithNewExpirySweepInterval sets the interval at which instances of collections with a TTL
+// are checked, and expired ones deleted. Defaults to one minute. Expired instances aren't returned
+// by reads between sweeps, so the interval only bounds how long they're kept in the datastore.
+func WithNewExpirySweepInterval(interval time.Duration) NewOption {
+	return func(o *NewOptions) {
+		o.ExpirySweepInterval = interval
+	}
+}
+
+// WithNewStorePrefix namespaces the keys of a manager's dbs in its datastores under prefix,
+// so managers sharing a datastore with different prefixes, or none, don't see each other's dbs.
+// The prefix must be the same each time the manager is started. It's only used by NewManager.
+func WithNewStorePrefix(prefix string) NewOption {
+	return func(o *NewOptions) {
+		o.StorePrefix = prefix
+	}
+}
+
+// WithNewMiddleware adds middleware wrapping the collection operations of a db.
+// Middleware is called in the order it's added, so the first added is the outermost.
+// See Middleware for when it runs relative to the validation of the operation.
+func WithNewMiddleware(m Middleware) NewOption {
+	return func(o *NewOptions) {
+		o.Middleware = append(o.Middleware, m)
+	}
+}
+
 // WithNewDebug indicate to output debug information.
 func WithNewDebug(enable bool) NewOption {
 	return func(o *NewOptions) {
@@ -107,7 +281,9 @@ func WithToken(t thread.Token) Option {
 
 // TxnOptions defines options for a transaction.
 type TxnOptions struct {
-	Token thread.Token
+	Token       thread.Token
+	Consistency ReadConsistency
+	Stale       *bool
 }
 
 // TxnOption specifies a transaction option.
@@ -120,6 +296,54 @@ func WithTxnToken(t thread.Token) TxnOption {
 	}
 }
 
+// WithReadConsistency sets the consistency of a read transaction. Strong reads pull the thread
+// from its peers first, which makes them slower. It's ignored by write transactions.
+func WithReadConsistency(level ReadConsistency) TxnOption {
+	return func(o *TxnOptions) {
+		o.Consistency = level
+	}
+}
+
+// WithReadStaleness sets stale to whether a read transaction read the local state in place of
+// a strong read, because the thread couldn't be pulled.
+func WithReadStaleness(stale *bool) TxnOption {
+	return func(o *TxnOptions) {
+		o.Stale = stale
+	}
+}
+
+// ImportOptions defines options for importing a dataset into a collection.
+type ImportOptions struct {
+	Token        thread.Token
+	BatchSize    int
+	AbortOnError bool
+}
+
+// ImportOption specifies an import option.
+type ImportOption func(*ImportOptions)
+
+// WithImportToken provides authorization for the import.
+func WithImportToken(t thread.Token) ImportOption {
+	return func(o *ImportOptions) {
+		o.Token = t
+	}
+}
+
+// WithImportBatchSize sets the number of records created per transaction. Defaults to 1000.
+func WithImportBatchSize(n int) ImportOption {
+	return func(o *ImportOptions) {
+		o.BatchSize = n
+	}
+}
+
+// WithImportAbortOnError aborts the import at the first invalid record, deleting the
+// collection, instead of skipping invalid records.
+func WithImportAbortOnError() ImportOption {
+	return func(o *ImportOptions) {
+		o.AbortOnError = true
+	}
+}
+
 // NewManagedOptions defines options for creating a new managed db.
 type NewManagedOptions struct {
 	Name        string
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+// ErrPositionExpired indicates a position is unknown, or its actions are no longer logged.
+var ErrPositionExpired = errors.New("position expired")
+
+var (
+	dsHead     = dsPrefix.ChildString("head")
+	dsPosition = dsPrefix.ChildString("position")
+	dsActions  = dsPrefix.ChildString("actions")
+	dsVersions = dsPrefix.ChildString("versions")
+)
+
+// Position is the position of an action in the order actions were applied to the db.
+// Positions start at 1 and increase with each action, and are persisted with the db,
+// so they can be stored to resume listening from with WithListenFrom, even after a restart.
+type Position uint64
+
+// loggedAction is an action kept in the datastore for WithListenFrom.
+type loggedAction struct {
+	Collection string
+	Type       ActionType
+	ID         core.InstanceID
+	Conflicts  []core.Conflict `json:",omitempty"`
+	Instance   []byte
+	Author     []byte `json:",omitempty"`
+}
+
+func actionKey(p Position) ds.Key {
+	// Zero-padded, so that keys are ordered by position.
+	return dsActions.ChildString(fmt.Sprintf("%020d", uint64(p)))
+}
+
+// logStores returns the datastores which collections are stored in. Positions and logged
+// actions are written to the datastore of the reduced instances, see logActions.
+func (d *DB) logStores() []kt.TxnDatastoreExtended {
+	stores := []kt.TxnDatastoreExtended{d.datastore}
+	for _, s := range d.datastores {
+		stores = append(stores, s)
+	}
+	return stores
+}
+
+// loadPosition loads the position of the last applied action.
+func (d *DB) loadPosition() error {
+	for _, s := range d.logStores() {
+		v, err := s.Get(dsPosition)
+		if errors.Is(err, ds.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		p, err := strconv.ParseUint(string(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing action position: %w", err)
+		}
+		if Position(p) > d.position {
+			d.position = Position(p)
+		}
+	}
+	return nil
+}
+
+// logActions sets the positions of actions, which follow position p, and writes the
+// position of the last one, and the versions of their collections, with txn.
+// If the action log is enabled, actions are also added to it, and actions that fall out
+// of it are removed. Since txn is the transaction in which the actions were reduced, the
+// log can't miss actions which were applied. It returns the position of the last action.
+// Callers must hold txnlock.
+func (d *DB) logActions(txn ds.Txn, actions []Action, p Position) (Position, error) {
+	if len(actions) == 0 {
+		return p, nil
+	}
+	versions := make(map[string]Position)
+	for i, a := range actions {
+		p++
+		actions[i].Position = p
+		versions[a.Collection] = p
+		if d.actionLogSize <= 0 {
+			continue
+		}
+		if err := d.logAction(txn, actions[i]); err != nil {
+			return p, err
+		}
+		if p > Position(d.actionLogSize) {
+			if err := d.pruneAction(txn, p-Position(d.actionLogSize)); err != nil {
+				return p, err
+			}
+		}
+	}
+	for collection, v := range versions {
+		pos := strconv.FormatUint(uint64(v), 10)
+		if err := txn.Put(dsVersions.ChildString(collection), []byte(pos)); err != nil {
+			return p, err
+		}
+	}
+	pos := strconv.FormatUint(uint64(p), 10)
+	if err := txn.Put(dsPosition, []byte(pos)); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// logAction adds action a to the action log with txn.
+func (d *DB) logAction(txn ds.Txn, a Action) error {
+	la := loggedAction{
+		Collection: a.Collection,
+		Type:       a.Type,
+		ID:         a.ID,
+		Conflicts:  a.Conflicts,
+		Instance:   a.instance,
+	}
+	var err error
+	if a.Author != nil {
+		if la.Author, err = a.Author.MarshalBinary(); err != nil {
+			return err
+		}
+	}
+	v, err := json.Marshal(la)
+	if err != nil {
+		return err
+	}
+	if d.valueKey != nil {
+		if v, err = d.valueKey.Encrypt(v); err != nil {
+			return err
+		}
+	}
+	return txn.Put(actionKey(a.Position), v)
+}
+
+// pruneAction removes the action at position p from the action log. Actions logged in
+// the datastore of another collection are removed outside of txn, so a crash may leave
+// them behind, out of the range read by loggedActions.
+func (d *DB) pruneAction(txn ds.Txn, p Position) error {
+	if err := txn.Delete(actionKey(p)); err != nil {
+		return err
+	}
+	stores := d.logStores()
+	if len(stores) == 1 {
+		return nil
+	}
+	for _, s := range stores {
+		if err := s.Delete(actionKey(p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loggedActions returns the logged actions following position from.
+// Callers must hold txnlock.
+func (d *DB) loggedActions(from Position) ([]Action, error) {
+	if from > d.position {
+		return nil, ErrPositionExpired
+	}
+	// The log holds actions first through d.position.
+	first := d.position + 1
+	if d.actionLogSize > 0 {
+		first = Position(1)
+		if d.position > Position(d.actionLogSize) {
+			first = d.position - Position(d.actionLogSize) + 1
+		}
+	}
+	if from+1 < first {
+		return nil, ErrPositionExpired
+	}
+	actions := make([]Action, 0, int(d.position-from))
+	for p := from + 1; p <= d.position; p++ {
+		la, err := d.getLoggedAction(p)
+		if err != nil {
+			return nil, err
+		}
+		a := Action{
+			Collection: la.Collection,
+			Type:       la.Type,
+			ID:         la.ID,
+			Conflicts:  la.Conflicts,
+			Position:   p,
+			instance:   la.Instance,
+		}
+		if len(la.Author) > 0 {
+			author := &thread.Libp2pPubKey{}
+			if err := author.UnmarshalBinary(la.Author); err != nil {
+				return nil, fmt.Errorf("decoding author of action %d: %w", p, err)
+			}
+			a.Author = author
+		}
+		actions = append(actions, a)
+	}
+	return actions, nil
+}
+
+// getLoggedAction returns the logged action at position p.
+func (d *DB) getLoggedAction(p Position) (la loggedAction, err error) {
+	var v []byte
+	for _, s := range d.logStores() {
+		if v, err = s.Get(actionKey(p)); !errors.Is(err, ds.ErrNotFound) {
+			break
+		}
+	}
+	if err != nil {
+		return la, fmt.Errorf("getting action %d: %w", p, err)
+	}
+	if d.valueKey != nil {
+		if v, err = d.valueKey.Decrypt(v); err != nil {
+			return la, fmt.Errorf("decrypting action %d: %w", p, err)
+		}
+	}
+	if err := json.Unmarshal(v, &la); err != nil {
+		return la, fmt.Errorf("decoding action %d: %w", p, err)
+	}
+	return la, nil
+}
+
+// setHead records rec as the last record whose events were applied.
+func (d *DB) setHead(rec cid.Cid) error {
+	return d.datastore.Put(dsHead, rec.Bytes())
+}
+
+// Head returns the last record whose events were applied to the db, or cid.Undef if
+// no record was applied. Records are applied as they're received, so the head is not
+// necessarily the most recent record of the thread.
+// See Position for a cursor to resume listening from.
+func (d *DB) Head() (cid.Cid, error) {
+	d.txnlock.RLock()
+	defer d.txnlock.RUnlock()
+	v, err := d.datastore.Get(dsHead)
+	if errors.Is(err, ds.ErrNotFound) {
+		return cid.Undef, nil
+	}
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.Cast(v)
+}
+
+// Position returns the position of the last action applied to the db, or 0 if none was.
+func (d *DB) Position() Position {
+	d.txnlock.RLock()
+	defer d.txnlock.RUnlock()
+	return d.position
+}
+
+// Version returns the position of the last action applied to the collection, or 0 if none was.
+// Versions of different collections are comparable, since positions are shared by the db.
+func (c *Collection) Version() (Position, error) {
+	c.db.txnlock.RLock()
+	defer c.db.txnlock.RUnlock()
+	v, err := c.store.Get(dsVersions.ChildString(c.name))
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	p, err := strconv.ParseUint(string(v), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing version of %s: %w", c.name, err)
+	}
+	return Position(p), nil
+}
@@ -0,0 +1,253 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	bserv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
+	ds "github.com/ipfs/go-datastore"
+	syncds "github.com/ipfs/go-datastore/sync"
+	bstore "github.com/ipfs/go-ipfs-blockstore"
+	offline "github.com/ipfs/go-ipfs-exchange-offline"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/ipfs/go-merkledag"
+	"github.com/libp2p/go-libp2p-core/crypto"
+	mh "github.com/multiformats/go-multihash"
+	threadcbor "github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	netpb "github.com/textileio/go-threads/net/pb"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrInstanceRecordNotFound indicates the record which last wrote an instance isn't known,
+	// e.g., because the instance was written before records were tracked.
+	ErrInstanceRecordNotFound = errors.New("instance record not found")
+	// ErrInvalidProof indicates an instance proof doesn't verify an instance.
+	ErrInvalidProof = errors.New("invalid instance proof")
+
+	dsRecords = dsPrefix.ChildString("record")
+)
+
+// InstanceProof holds the encoded nodes of the record whose events last wrote an instance.
+// The nodes are encrypted as they're stored, so verifying a proof requires the thread key.
+type InstanceProof struct {
+	RecordNode []byte
+	EventNode  []byte
+	HeaderNode []byte
+	BodyNode   []byte
+}
+
+// recordKey returns the key of the record which last wrote instance id of collection.
+func recordKey(collection string, id core.InstanceID) ds.Key {
+	return dsRecords.ChildString(collection).ChildString(id.String())
+}
+
+// setInstanceRecords tracks rec as the record which last wrote the instances of events.
+// Records of deleted instances are removed. Callers must hold txnlock.
+func (d *DB) setInstanceRecords(rec cid.Cid, events []core.Event) error {
+	for _, e := range events {
+		d.lock.RLock()
+		c, ok := d.collections[e.Collection()]
+		d.lock.RUnlock()
+		if !ok {
+			continue
+		}
+		key := recordKey(e.Collection(), e.InstanceID())
+		exists, err := c.store.Has(c.baseKey().ChildString(e.InstanceID().String()))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			err = d.datastore.Delete(key)
+		} else {
+			err = d.datastore.Put(key, rec.Bytes())
+		}
+		if err != nil {
+			return fmt.Errorf("tracking instance record: %w", err)
+		}
+	}
+	return nil
+}
+
+// InstanceRecord returns the record whose events last wrote an instance.
+func (c *Collection) InstanceRecord(id core.InstanceID) (cid.Cid, error) {
+	v, err := c.db.datastore.Get(recordKey(c.name, id))
+	if errors.Is(err, ds.ErrNotFound) {
+		return cid.Undef, ErrInstanceRecordNotFound
+	} else if err != nil {
+		return cid.Undef, err
+	}
+	return cid.Cast(v)
+}
+
+// Prove returns a proof that an instance was last written by a record of the thread.
+// Proofs let readers which hold the thread key check instances returned by an untrusted
+// peer, see VerifyInstance.
+func (c *Collection) Prove(ctx context.Context, id core.InstanceID, opts ...TxnOption) (*InstanceProof, error) {
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := c.db.checkTokenRole(c.name, args.Token, RoleReader); err != nil {
+		return nil, err
+	}
+	rid, err := c.InstanceRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := c.db.connector.Net.GetRecord(ctx, c.db.connector.ThreadID(), rid, net.WithThreadToken(args.Token))
+	if err != nil {
+		return nil, fmt.Errorf("getting record %s: %w", rid, err)
+	}
+	p, err := threadcbor.RecordToProto(ctx, c.db.connector.Net, rec)
+	if err != nil {
+		return nil, fmt.Errorf("encoding record %s: %w", rid, err)
+	}
+	return &InstanceProof{
+		RecordNode: p.RecordNode,
+		EventNode:  p.EventNode,
+		HeaderNode: p.HeaderNode,
+		BodyNode:   p.BodyNode,
+	}, nil
+}
+
+// VerifyInstance checks that proof holds a record signed by one of logKeys, the keys of the
+// logs of the thread, whose events write instance to collection. The events are decoded with
+// codec, or the default codec if nil. It returns an error wrapping ErrInvalidProof if they don't.
+// Applying the events to instance must leave it unchanged, so instances must be returned whole:
+// projected instances, and instances with computed fields, don't verify.
+// A proof shows that an instance was written by the thread, not that it's the current version
+// of the instance, nor that a query returned every instance it matched.
+func VerifyInstance(
+	collection string,
+	instance []byte,
+	proof *InstanceProof,
+	key thread.Key,
+	logKeys []crypto.PubKey,
+	codec core.EventCodec,
+) error {
+	if !key.CanRead() {
+		return ErrThreadReadKeyRequired
+	}
+	if codec == nil {
+		codec = newDefaultEventCodec()
+	}
+	id := core.InstanceID(gjson.GetBytes(instance, idFieldName).String())
+	if id == core.EmptyInstanceID {
+		return fmt.Errorf("%w: instance has no id", ErrInvalidProof)
+	}
+
+	rec, err := threadcbor.RecordFromProto(&netpb.Log_Record{
+		RecordNode: proof.RecordNode,
+		EventNode:  proof.EventNode,
+		HeaderNode: proof.HeaderNode,
+		BodyNode:   proof.BodyNode,
+	}, key.Service())
+	if err != nil {
+		return fmt.Errorf("%w: decoding record: %v", ErrInvalidProof, err)
+	}
+	ctx := context.Background()
+	dag := offlineDAG()
+	event, err := threadcbor.EventFromRecord(ctx, dag, rec)
+	if err != nil {
+		return fmt.Errorf("%w: decoding event: %v", ErrInvalidProof, err)
+	}
+	if !rec.BlockID().Equals(event.Cid()) {
+		return fmt.Errorf("%w: event doesn't belong to record", ErrInvalidProof)
+	}
+	for _, n := range []struct {
+		data []byte
+		id   cid.Cid
+	}{{proof.HeaderNode, event.HeaderID()}, {proof.BodyNode, event.BodyID()}} {
+		node, err := cbornode.Decode(n.data, mh.SHA2_256, -1)
+		if err != nil {
+			return fmt.Errorf("%w: decoding event node: %v", ErrInvalidProof, err)
+		}
+		if !node.Cid().Equals(n.id) {
+			return fmt.Errorf("%w: node %s doesn't belong to event", ErrInvalidProof, node.Cid())
+		}
+	}
+	signed := false
+	for _, k := range logKeys {
+		if rec.Verify(k) == nil {
+			signed = true
+			break
+		}
+	}
+	if !signed {
+		return fmt.Errorf("%w: record isn't signed by a log of the thread", ErrInvalidProof)
+	}
+
+	body, err := event.GetBody(ctx, dag, key.Read())
+	if err != nil {
+		return fmt.Errorf("%w: decrypting body: %v", ErrInvalidProof, err)
+	}
+	all, err := codec.EventsFromBytes(body.RawData())
+	if err != nil {
+		return fmt.Errorf("%w: decoding events: %v", ErrInvalidProof, err)
+	}
+	var events []core.Event
+	for _, e := range all {
+		if e.Collection() == collection && e.InstanceID() == id {
+			events = append(events, e)
+		}
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("%w: record doesn't write instance %s", ErrInvalidProof, id)
+	}
+
+	// The events either write the whole instance, or update the instance they were applied to
+	for _, base := range [][]byte{nil, instance} {
+		if ok, err := reducesTo(codec, collection, id, base, events, instance); err != nil {
+			return err
+		} else if ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: record doesn't write instance %s as returned", ErrInvalidProof, id)
+}
+
+// offlineDAG returns an empty DAG which doesn't fetch missing nodes, e.g., the chunks of
+// a chunked body, which aren't part of proofs.
+func offlineDAG() format.DAGService {
+	bs := bstore.NewBlockstore(syncds.MutexWrap(ds.NewMapDatastore()))
+	return merkledag.NewDAGService(bserv.New(bs, offline.Exchange(bs)))
+}
+
+// reducesTo returns whether reducing events on base, or on no instance if base is nil,
+// results in instance.
+func reducesTo(codec core.EventCodec, collection string, id core.InstanceID, base []byte, events []core.Event, instance []byte) (bool, error) {
+	store := NewTxMapDatastore()
+	key := baseKey.ChildString(collection).ChildString(id.String())
+	if base != nil {
+		if err := store.Put(key, base); err != nil {
+			return false, err
+		}
+	}
+	noIndex := func(string, ds.Key, []byte, []byte, ds.Txn) error { return nil }
+	if _, err := codec.Reduce(events, store, baseKey, noIndex, nil); err != nil {
+		return false, nil
+	}
+	reduced, err := store.Get(key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	var a, b interface{}
+	if err := json.Unmarshal(reduced, &a); err != nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(instance, &b); err != nil {
+		return false, fmt.Errorf("%w: invalid instance: %v", ErrInvalidProof, err)
+	}
+	return reflect.DeepEqual(a, b), nil
+}
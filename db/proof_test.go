@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/textileio/go-threads/core/thread"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestProveInstance(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	p := &Person{Name: "Alice", Age: 42}
+	ids, err := c.CreateMany([][]byte{util.JSONFromInstance(p)})
+	checkErr(t, err)
+	p.ID = ids[0]
+	p.Age = 43
+	checkErr(t, c.Save(util.JSONFromInstance(p)))
+
+	ctx := context.Background()
+	info, err := db.connector.Net.GetThread(ctx, db.connector.ThreadID())
+	checkErr(t, err)
+	logKeys := make([]crypto.PubKey, len(info.Logs))
+	for i, l := range info.Logs {
+		logKeys[i] = l.PubKey
+	}
+	instance, err := c.FindByID(p.ID)
+	checkErr(t, err)
+	proof, err := c.Prove(ctx, p.ID)
+	checkErr(t, err)
+	checkErr(t, VerifyInstance("Person", instance, proof, info.Key, logKeys, nil))
+
+	t.Run("Tampered", func(t *testing.T) {
+		tampered := util.JSONFromInstance(&Person{ID: p.ID, Name: "Alice", Age: 18})
+		if err := VerifyInstance("Person", tampered, proof, info.Key, logKeys, nil); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidProof, err)
+		}
+	})
+	t.Run("WrongLogKey", func(t *testing.T) {
+		_, pk, err := crypto.GenerateEd25519Key(nil)
+		checkErr(t, err)
+		if err := VerifyInstance("Person", instance, proof, info.Key, []crypto.PubKey{pk}, nil); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidProof, err)
+		}
+	})
+	t.Run("WrongThreadKey", func(t *testing.T) {
+		if err := VerifyInstance("Person", instance, proof, thread.NewRandomKey(), logKeys, nil); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidProof, err)
+		}
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		checkErr(t, c.Delete(p.ID))
+		if _, err := c.Prove(ctx, p.ID); !errors.Is(err, ErrInstanceRecordNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceRecordNotFound, err)
+		}
+	})
+}
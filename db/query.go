@@ -1,6 +1,8 @@
 package db
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -16,13 +18,24 @@ import (
 
 // Query is a json-seriable query representation.
 type Query struct {
-	Ands  []*Criterion
-	Ors   []*Query
-	Sort  Sort
+	Ands []*Criterion
+	Ors  []*Query
+	Sort Sort
+	// Then lists the orders of results which are equal in Sort. See ThenBy.
+	Then  []Sort
 	Seek  core.InstanceID
 	Limit int
 	Skip  int
 	Index string
+	// AfterCursor is a continuation token returned by Cursor. See After.
+	AfterCursor string
+	// Projection lists the field paths included in results. See Select.
+	Projection []string
+	// Trashed includes trashed instances in results. See IncludeTrashed.
+	Trashed bool
+
+	// err is set when building the query fails.
+	err error
 }
 
 // Criterion represents a restriction on a field.
@@ -45,6 +58,12 @@ func (q *Query) Validate() error {
 	if q == nil {
 		return nil
 	}
+	if q.err != nil {
+		return q.err
+	}
+	if _, err := q.cursor(); err != nil {
+		return err
+	}
 	for _, a := range q.Ands {
 		if err := a.Validate(); err != nil {
 			return err
@@ -80,6 +99,8 @@ func (c *Criterion) Validate() error {
 }
 
 // Sort represents a sort order on a field.
+// Nested fields are sorted by with dot-separated paths, such as "Address.Zip".
+// Instances which are missing the field, or where it's null, come last in both directions.
 type Sort struct {
 	FieldPath string
 	Desc      bool
@@ -101,14 +122,33 @@ const (
 	Ge = Operation(ge)
 	// Le is "less than or equal to"
 	Le = Operation(le)
+	// Contains is "is an array with an element equal to"
+	Contains = Operation(contains)
+	// AnyGt is "is an array with an element greater than"
+	AnyGt = Operation(anyGt)
+	// AnyLt is "is an array with an element less than"
+	AnyLt = Operation(anyLt)
+	// AnyGe is "is an array with an element greater than or equal to"
+	AnyGe = Operation(anyGe)
+	// AnyLe is "is an array with an element less than or equal to"
+	AnyLe = Operation(anyLe)
 )
 
 var (
 	// ErrInvalidSortingField is returned when a query sorts a result by a
 	// non-existent field in the collection schema.
 	ErrInvalidSortingField = errors.New("sorting field doesn't correspond to instance type")
+	// ErrCantStreamSortedQuery is returned when a streamed query sorts results by
+	// a field other than ID.
+	ErrCantStreamSortedQuery = errors.New("can't stream results sorted by a field other than ID")
 )
 
+// Result is an instance or an error sent by FindStream.
+type Result struct {
+	Instance []byte
+	Err      error
+}
+
 // Where starts to create a query condition for a field.
 func Where(field string) *Criterion {
 	return &Criterion{
@@ -162,6 +202,15 @@ func (q *Query) UseIndex(path string) *Query {
 	return q
 }
 
+// Select restricts results to the given field paths, plus the instance ID.
+// Nested fields are selected with dot-separated paths, such as "Address.City".
+// Paths which don't exist in an instance are absent from its result.
+// Selection applies to results only, so criteria and sorting may use any field.
+func (q *Query) Select(paths ...string) *Query {
+	q.Projection = append(q.Projection, paths...)
+	return q
+}
+
 // Or concatenates a new condition that is sufficient
 // for an instance to satisfy, independant of the current Query.
 // Has left-associativity as: (a And b) Or c
@@ -171,37 +220,70 @@ func (q *Query) Or(orQuery *Query) *Query {
 }
 
 // OrderBy specifies ascending order for the query results.
-// On multiple calls, only the last one is considered.
+// On multiple calls, only the last one is considered, and secondary orders are cleared.
 func (q *Query) OrderBy(field string) *Query {
+	q.Then = nil
 	q.Sort.FieldPath = field
 	q.Sort.Desc = false
 	return q
 }
 
 // OrderByDesc specifies descending order for the query results.
-// On multiple calls, only the last one is considered.
+// On multiple calls, only the last one is considered, and secondary orders are cleared.
 func (q *Query) OrderByDesc(field string) *Query {
+	q.Then = nil
 	q.Sort.FieldPath = field
 	q.Sort.Desc = true
 	return q
 }
 
 // OrderByID specifies ascending ID order for the query results.
-// On multiple calls, only the last one is considered.
+// On multiple calls, only the last one is considered, and secondary orders are cleared.
 func (q *Query) OrderByID() *Query {
+	q.Then = nil
 	q.Sort.FieldPath = idFieldName
 	q.Sort.Desc = false
 	return q
 }
 
 // OrderByIDDesc specifies descending ID order for the query results.
-// On multiple calls, only the last one is considered.
+// On multiple calls, only the last one is considered, and secondary orders are cleared.
 func (q *Query) OrderByIDDesc() *Query {
+	q.Then = nil
 	q.Sort.FieldPath = idFieldName
 	q.Sort.Desc = true
 	return q
 }
 
+// ThenBy specifies ascending order for results which are equal in the previous orders.
+// If the query isn't ordered yet, it's the same as OrderBy. Secondary orders are ignored
+// when ordering by ID, since IDs are unique.
+func (q *Query) ThenBy(field string) *Query {
+	return q.thenBy(Sort{FieldPath: field})
+}
+
+// ThenByDesc specifies descending order for results which are equal in the previous orders.
+// If the query isn't ordered yet, it's the same as OrderByDesc.
+func (q *Query) ThenByDesc(field string) *Query {
+	return q.thenBy(Sort{FieldPath: field, Desc: true})
+}
+
+func (q *Query) thenBy(s Sort) *Query {
+	if q.Sort.FieldPath == "" {
+		q.Sort = s
+	} else {
+		q.Then = append(q.Then, s)
+	}
+	return q
+}
+
+// IncludeTrashed includes instances which were moved to the trash with SoftDelete in the
+// results of the query.
+func (q *Query) IncludeTrashed() *Query {
+	q.Trashed = true
+	return q
+}
+
 // SeekID seeks to the given ID before returning query results.
 func (q *Query) SeekID(id core.InstanceID) *Query {
 	q.Seek = id
@@ -252,6 +334,34 @@ func (c *Criterion) Le(value interface{}) *Query {
 	return c.createcriterion(Le, value)
 }
 
+// Contains matches instances whose field is an array with an element equal to value.
+// Array operators never match fields which aren't arrays, and skip elements of another
+// type than value. Criteria with array operators can use a multikey index on the field
+// if it's named with Query.UseIndex. See Index.Multikey.
+func (c *Criterion) Contains(value interface{}) *Query {
+	return c.createcriterion(Contains, value)
+}
+
+// AnyGt matches instances whose field is an array with an element greater than value.
+func (c *Criterion) AnyGt(value interface{}) *Query {
+	return c.createcriterion(AnyGt, value)
+}
+
+// AnyLt matches instances whose field is an array with an element less than value.
+func (c *Criterion) AnyLt(value interface{}) *Query {
+	return c.createcriterion(AnyLt, value)
+}
+
+// AnyGe matches instances whose field is an array with an element greater than or equal to value.
+func (c *Criterion) AnyGe(value interface{}) *Query {
+	return c.createcriterion(AnyGe, value)
+}
+
+// AnyLe matches instances whose field is an array with an element less than or equal to value.
+func (c *Criterion) AnyLe(value interface{}) *Query {
+	return c.createcriterion(AnyLe, value)
+}
+
 func createValue(value interface{}) Value {
 	s, ok := value.(string)
 	if ok {
@@ -292,30 +402,36 @@ func (c *Criterion) createcriterion(op Operation, value interface{}) *Query {
 
 // Find queries for instances by Query.
 func (t *Txn) Find(q *Query) ([][]byte, error) {
-	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
-		return nil, err
-	}
+	return t.FindContext(context.Background(), q)
+}
+
+// FindContext queries for instances by Query, and stops reading them when ctx is done.
+// Since datastore reads don't take a context, a read that's in progress isn't interrupted,
+// but iteration is aborted before the next instance is read.
+func (t *Txn) FindContext(ctx context.Context, q *Query) ([][]byte, error) {
+	t.collection.db.metrics.query("find")
 	if q == nil {
 		q = &Query{}
 	}
-	if err := q.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid query: %s", err)
-	}
-	txn, err := t.collection.db.datastore.NewTransactionExtended(true)
-	if err != nil {
-		return nil, fmt.Errorf("error building internal query: %v", err)
+	cache := t.collection.db.queryCache
+	if res, ok := cache.get(t.collection, q); ok {
+		return res, nil
 	}
-	defer txn.Discard()
-	iter, err := newIterator(txn, t.collection.baseKey(), q)
+	iter, discard, err := t.iterate(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	defer iter.Close()
+	defer discard()
 
 	pk, err := t.token.PubKey()
 	if err != nil {
 		return nil, err
 	}
+	after, err := q.cursor()
+	if err != nil {
+		return nil, err
+	}
+	byField := q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName
 	var values []MarshaledResult
 	// Use count to track real count of returned values taking into account
 	// read filter and any indexes etc in the query
@@ -323,75 +439,288 @@ func (t *Txn) Find(q *Query) ([][]byte, error) {
 	for {
 		res, ok := iter.NextSync()
 		if !ok {
+			if res.Error != nil {
+				return nil, res.Error
+			}
 			break
 		}
-		res.Value, err = t.collection.filterRead(pk, res.Value)
+		if res.MarshaledValue == nil && (byField || after != nil) {
+			// Index iterators don't unmarshal values
+			if err := json.Unmarshal(res.Value, &res.MarshaledValue); err != nil {
+				return nil, err
+			}
+		}
+		res.Value, err = t.collection.filterQueryRead(pk, res.Value, q)
 		if err != nil {
 			return nil, err
 		}
-		if res.Value != nil {
-			// Only count valid values that aren't filtered by the read filter
-			count++
-			if count > q.Skip {
-				values = append(values, res)
+		if res.Value == nil {
+			continue
+		}
+		if byField {
+			// Skip, limit, and the cursor apply to sorted values
+			values = append(values, res)
+			continue
+		}
+		if after != nil {
+			if ok, err := after.precedes(res, t.collection.dynamic); err != nil {
+				return nil, err
+			} else if !ok {
+				continue
 			}
 		}
-		if len(values) == q.Limit {
+		// Only count valid values that aren't filtered by the read filter
+		count++
+		if count > q.Skip {
+			values = append(values, res)
+		}
+		if q.Limit > 0 && len(values) == q.Limit {
 			break
 		}
 	}
 
+	if byField {
+		values, err = sortResults(values, q.orderings(), t.collection.dynamic, after, q.Skip, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([][]byte, len(values))
+	for i := range values {
+		res[i], err = t.collection.computed.add(values[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		res[i], err = project(res[i], q.Projection)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cache.put(t.collection, q, res)
+
+	return res, nil
+}
+
+// FindStream queries for instances by Query, sending them on the returned channel as they're
+// read from the datastore, so that memory use doesn't grow with the number of results.
+// Results are read from a snapshot of the collection taken when FindStream is called.
+// Sorting by a field other than ID requires reading all results, so it's not supported.
+// If iterating fails, a final Result holding the error is sent.
+// The channel is closed when all results are sent, or when ctx is done.
+func (t *Txn) FindStream(ctx context.Context, q *Query) (<-chan Result, error) {
+	t.collection.db.metrics.query("find_stream")
+	if q == nil {
+		q = &Query{}
+	}
 	if q.Sort.FieldPath != "" && q.Sort.FieldPath != idFieldName {
-		var wrongField, cantCompare bool
-		sort.Slice(values, func(i, j int) bool {
-			fieldI, err := traverseFieldPathMap(values[i].MarshaledValue, q.Sort.FieldPath)
+		return nil, ErrCantStreamSortedQuery
+	}
+	pk, err := t.token.PubKey()
+	if err != nil {
+		return nil, err
+	}
+	iter, discard, err := t.iterate(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	after, err := q.cursor()
+	if err != nil {
+		discard()
+		return nil, err
+	}
+
+	results := make(chan Result)
+	send := func(r Result) bool {
+		select {
+		case results <- r:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(results)
+		defer discard()
+		var count, sent int
+		for ctx.Err() == nil {
+			res, ok := iter.NextSync()
+			if !ok {
+				if res.Error != nil {
+					send(Result{Err: res.Error})
+				}
+				return
+			}
+			value, err := t.collection.filterQueryRead(pk, res.Value, q)
 			if err != nil {
-				wrongField = true
-				return false
+				send(Result{Err: err})
+				return
 			}
-			fieldJ, err := traverseFieldPathMap(values[j].MarshaledValue, q.Sort.FieldPath)
+			if value == nil {
+				continue
+			}
+			if after != nil {
+				if ok, err := after.precedes(res, t.collection.dynamic); err != nil {
+					send(Result{Err: err})
+					return
+				} else if !ok {
+					continue
+				}
+			}
+			// Only count valid values that aren't filtered by the read filter
+			count++
+			if count <= q.Skip {
+				continue
+			}
+			value, err = t.collection.computed.add(value)
 			if err != nil {
-				wrongField = true
-				return false
+				send(Result{Err: err})
+				return
 			}
-			res, err := compare(fieldI.Interface(), fieldJ.Interface())
+			value, err = project(value, q.Projection)
 			if err != nil {
-				cantCompare = true
-				return false
+				send(Result{Err: err})
+				return
+			}
+			if !send(Result{Instance: value}) {
+				return
 			}
-			if q.Sort.Desc {
-				res *= -1
+			sent++
+			if sent == q.Limit {
+				return
 			}
-			return res < 0
-		})
-		if wrongField {
-			return nil, ErrInvalidSortingField
 		}
-		if cantCompare {
-			panic("can't compare while sorting")
+	}()
+	return results, nil
+}
+
+// iterate validates q and returns an iterator over matching instances,
+// along with a function that releases it.
+func (t *Txn) iterate(ctx context.Context, q *Query) (*iterator, func(), error) {
+	if err := t.collection.db.connector.Validate(t.token, true); err != nil {
+		return nil, nil, err
+	}
+	if err := q.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid query: %w", err)
+	}
+	if err := t.collection.validEncryptedQuery(q); err != nil {
+		return nil, nil, err
+	}
+	txn, err := t.collection.store.NewTransactionExtended(true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error building internal query: %v", err)
+	}
+	var iter *iterator
+	if p := t.collection.planScan(q); p.compound {
+		iter, err = newCompoundIterator(ctx, txn, t.collection.baseKey(), q, p.index, p.values)
+	} else {
+		iter, err = newIterator(ctx, txn, t.collection.baseKey(), p.query, p.multikey)
+	}
+	if err != nil {
+		txn.Discard()
+		return nil, nil, err
+	}
+	iter.dynamic = t.collection.dynamic
+	return iter, func() {
+		iter.Close()
+		txn.Discard()
+	}, nil
+}
+
+// planIndex returns the compound index to use for q, along with the leading index values
+// to which the query is restricted.
+// A compound index named by q.Index is always used. Otherwise, the compound index without a
+// filter with the longest prefix of paths having equality criteria in q is used, unless q has Or clauses,
+// or seeks, sorts, or continues a cursor by ID, which require instances in ID order.
+func (c *Collection) planIndex(q *Query) (Index, []string, bool) {
+	if q.Index != "" {
+		index, ok := c.indexes[q.Index]
+		if !ok || !index.isCompound() {
+			return Index{}, nil, false
+		}
+		if len(q.Ors) != 0 {
+			return index, nil, true
 		}
+		return index, q.eqPrefix(index.Paths), true
+	}
+	if len(q.Ors) != 0 || q.Seek != "" || q.AfterCursor != "" || q.Sort.FieldPath == idFieldName {
+		return Index{}, nil, false
 	}
+	names := make([]string, 0, len(c.indexes))
+	for name, index := range c.indexes {
+		if index.isCompound() && index.Filter == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var (
+		best   Index
+		values []string
+	)
+	for _, name := range names {
+		index := c.indexes[name]
+		if vals := q.eqPrefix(index.Paths); len(vals) > len(values) {
+			best, values = index, vals
+		}
+	}
+	return best, values, len(values) != 0
+}
 
-	res := make([][]byte, len(values))
-	for i := range values {
-		res[i] = values[i].Value
+// eqPrefix returns the values of the equality criteria on the longest prefix of paths.
+func (q *Query) eqPrefix(paths []string) []string {
+	var values []string
+	for _, pth := range paths {
+		var found bool
+		for _, c := range q.Ands {
+			if c.FieldPath != pth || c.Operation != Eq {
+				continue
+			}
+			if v, ok := c.Value.indexValue(); ok {
+				values = append(values, v)
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
 	}
+	return values
+}
 
-	return res, nil
+// indexValue returns v formatted as it's stored in indexes.
+func (v Value) indexValue() (string, bool) {
+	switch {
+	case v.String != nil:
+		return *v.String, true
+	case v.Bool != nil:
+		return strconv.FormatBool(*v.Bool), true
+	case v.Float != nil:
+		return strconv.FormatFloat(*v.Float, 'f', -1, 64), true
+	default:
+		return "", false
+	}
 }
 
 func (q *Query) match(v map[string]interface{}) (bool, error) {
+	return q.matchFields(v, false)
+}
+
+// matchInstance returns whether v matches q. If dynamic is set, v is an instance of a
+// dynamic collection: since such instances don't share a schema, criteria on fields which v
+// lacks, or holds values of another type at, don't match instead of failing.
+func matchInstance(q *Query, v map[string]interface{}, dynamic bool) (bool, error) {
+	return q.matchFields(v, dynamic)
+}
+
+func (q *Query) matchFields(v map[string]interface{}, dynamic bool) (bool, error) {
 	if q == nil {
 		panic("query can't be nil")
 	}
 
 	andOk := true
 	for _, c := range q.Ands {
-		fieldRes, err := traverseFieldPathMap(v, c.FieldPath)
-		if err != nil {
-			return false, err
-		}
-		ok, err := c.match(fieldRes)
+		ok, err := c.matchField(v, dynamic)
 		if err != nil {
 			return false, err
 		}
@@ -405,7 +734,7 @@ func (q *Query) match(v map[string]interface{}) (bool, error) {
 	}
 
 	for _, q := range q.Ors {
-		ok, err := q.match(v)
+		ok, err := q.matchFields(v, dynamic)
 		if err != nil {
 			return false, err
 		}
@@ -452,8 +781,33 @@ func compareValue(value interface{}, critVal Value) (int, error) {
 	return 0, nil
 }
 
+// matchField returns whether the field of v at c.FieldPath matches c. If dynamic is set,
+// missing fields and values of another type than the criterion value don't match.
+func (c *Criterion) matchField(v map[string]interface{}, dynamic bool) (bool, error) {
+	fieldRes, err := traverseFieldPathMap(v, c.FieldPath)
+	if err != nil {
+		if dynamic {
+			return false, nil
+		}
+		return false, err
+	}
+	ok, err := c.match(fieldRes)
+	var mismatch *errTypeMismatch
+	if dynamic && errors.As(err, &mismatch) {
+		return false, nil
+	}
+	return ok, err
+}
+
 func (c *Criterion) match(value reflect.Value) (bool, error) {
-	valueInterface := value.Interface()
+	// Null fields hold no value
+	var valueInterface interface{}
+	if value.IsValid() {
+		valueInterface = value.Interface()
+	}
+	if c.isArrayOperation() {
+		return c.matchElements(valueInterface)
+	}
 	result, err := compareValue(valueInterface, c.Value)
 	if err != nil {
 		return false, err
@@ -477,6 +831,100 @@ func (c *Criterion) match(value reflect.Value) (bool, error) {
 
 }
 
+// isArrayOperation returns whether c matches the elements of an array.
+func (c *Criterion) isArrayOperation() bool {
+	switch c.Operation {
+	case Contains, AnyGt, AnyLt, AnyGe, AnyLe:
+		return true
+	default:
+		return false
+	}
+}
+
+// matchElements returns whether value is an array with an element matching c.
+func (c *Criterion) matchElements(value interface{}) (bool, error) {
+	elems, ok := value.([]interface{})
+	if !ok {
+		return false, nil
+	}
+	for _, e := range elems {
+		result, err := compareValue(e, c.Value)
+		if err != nil {
+			var mismatch *errTypeMismatch
+			if errors.As(err, &mismatch) {
+				continue
+			}
+			return false, err
+		}
+		var ok bool
+		switch c.Operation {
+		case Contains:
+			ok = result == 0
+		case AnyGt:
+			ok = result > 0
+		case AnyLt:
+			ok = result < 0
+		case AnyGe:
+			ok = result >= 0
+		case AnyLe:
+			ok = result <= 0
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// project returns instance with only the fields at paths and its ID.
+// If paths is empty, instance is returned as is.
+func project(instance []byte, paths []string) ([]byte, error) {
+	if len(paths) == 0 {
+		return instance, nil
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(instance, &v); err != nil {
+		return nil, err
+	}
+	p := make(map[string]interface{})
+	if id, ok := v[idFieldName]; ok {
+		p[idFieldName] = id
+	}
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		value, ok := lookupFieldPath(v, fields)
+		if !ok {
+			continue
+		}
+		dst := p
+		for _, f := range fields[:len(fields)-1] {
+			next, ok := dst[f].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				dst[f] = next
+			}
+			dst = next
+		}
+		dst[fields[len(fields)-1]] = value
+	}
+	return json.Marshal(p)
+}
+
+// lookupFieldPath returns the value at fields in v, and whether it exists.
+func lookupFieldPath(v map[string]interface{}, fields []string) (interface{}, bool) {
+	var value interface{} = v
+	for _, f := range fields {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[f]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
 func traverseFieldPathMap(value map[string]interface{}, fieldPath string) (reflect.Value, error) {
 	fields := strings.Split(fieldPath, ".")
 
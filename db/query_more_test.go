@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"sort"
@@ -90,6 +91,9 @@ var (
 		{name: "SortAllAscFloat", query: OrderBy("Meta.Rating"), resIdx: []int{0, 1, 2, 3, 4}, ordered: true},
 		{name: "SortAllDescFloat", query: OrderByDesc("Meta.Rating"), resIdx: []int{4, 3, 2, 1, 0}, ordered: true},
 
+		{name: "SortThenDesc", query: OrderBy("Author").ThenByDesc("Meta.Rating"), resIdx: []int{2, 1, 0, 3, 4}, ordered: true},
+		{name: "SortDescThen", query: OrderByDesc("Author").ThenBy("Title"), resIdx: []int{4, 3, 0, 1, 2}, ordered: true},
+
 		{name: "LimitTotalReadsOutside", query: Where("Meta.TotalReads").Gt(float64(100)).LimitTo(2), resIdx: []int{3, 4}},
 		{name: "LimitTotalReadsInside", query: Where("Meta.TotalReads").Lt(float64(100)).LimitTo(2), resIdx: []int{0, 1}},
 
@@ -139,17 +143,434 @@ func TestCollectionQuery(t *testing.T) {
 	}
 }
 
+func TestCollectionQueryStream(t *testing.T) {
+	t.Parallel()
+	c, _, clean := createCollectionWithData(t)
+	defer clean()
+	for _, q := range queries {
+		if q.ordered {
+			continue
+		}
+		q := q
+		t.Run(q.name, func(t *testing.T) {
+			expected, err := c.Find(q.query)
+			checkErr(t, err)
+			results, err := c.FindStream(context.Background(), q.query)
+			checkErr(t, err)
+			var ret [][]byte
+			for r := range results {
+				checkErr(t, r.Err)
+				ret = append(ret, r.Instance)
+			}
+			if !reflect.DeepEqual(expected, ret) {
+				t.Fatalf("streamed results don't match, expected: %s, got: %s", expected, ret)
+			}
+		})
+	}
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		results, err := c.FindStream(ctx, &Query{})
+		checkErr(t, err)
+		r := <-results
+		checkErr(t, r.Err)
+		cancel()
+		for range results {
+		}
+	})
+	t.Run("Fail/SortByField", func(t *testing.T) {
+		_, err := c.FindStream(context.Background(), OrderBy("Title"))
+		if !errors.Is(err, ErrCantStreamSortedQuery) {
+			t.Fatalf("expected error %v, got %v", ErrCantStreamSortedQuery, err)
+		}
+	})
+}
+
+func TestCollectionReduce(t *testing.T) {
+	t.Parallel()
+	c, data, clean := createCollectionWithData(t)
+	defer clean()
+
+	var expected int
+	for _, b := range data {
+		if b.Author == "Author1" {
+			expected += b.Meta.TotalReads
+		}
+	}
+	sum := func(acc interface{}, instance []byte) (interface{}, error) {
+		var b book
+		util.InstanceFromJSON(instance, &b)
+		return acc.(int) + b.Meta.TotalReads, nil
+	}
+	res, err := c.Reduce(context.Background(), Where("Author").Eq("Author1"), 0, sum)
+	checkErr(t, err)
+	if res.(int) != expected {
+		t.Fatalf("expected total reads of %d, got %d", expected, res)
+	}
+
+	t.Run("Fail/Func", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var n int
+		err := c.Iterate(context.Background(), &Query{}, func([]byte) error {
+			n++
+			return errStop
+		})
+		if !errors.Is(err, errStop) || n != 1 {
+			t.Fatalf("expected iteration to stop with error %v after 1 instance, got %v after %d", errStop, err, n)
+		}
+	})
+	t.Run("Fail/Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := c.Reduce(ctx, &Query{}, 0, func(acc interface{}, _ []byte) (interface{}, error) {
+			cancel()
+			return acc, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestDistinct(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	for _, config := range []CollectionConfig{
+		{Name: "Plain"},
+		{Name: "Indexed", Indexes: []Index{{Path: "category"}, {Path: "tags", Multikey: true}}},
+	} {
+		c, err := db.NewCollection(config)
+		checkErr(t, err)
+		_, err = c.CreateMany([][]byte{
+			[]byte(`{"category": "b", "tags": ["x", "y"]}`),
+			[]byte(`{"category": "a/1", "tags": ["y"]}`),
+			[]byte(`{"category": "b"}`),
+			[]byte(`{"category": 3}`),
+			[]byte(`{}`),
+		})
+		checkErr(t, err)
+		t.Run(config.Name, func(t *testing.T) {
+			values, err := c.Distinct("category")
+			checkErr(t, err)
+			if expected := []interface{}{float64(3), "a/1", "b"}; !reflect.DeepEqual(values, expected) {
+				t.Fatalf("expected categories %v, got %v", expected, values)
+			}
+			values, err = c.Distinct("tags")
+			checkErr(t, err)
+			if expected := []interface{}{"x", "y"}; !reflect.DeepEqual(values, expected) {
+				t.Fatalf("expected tags %v, got %v", expected, values)
+			}
+			values, err = c.Distinct("missing")
+			checkErr(t, err)
+			if len(values) != 0 {
+				t.Fatalf("expected no values, got %v", values)
+			}
+		})
+	}
+}
+
+func TestQueryCursor(t *testing.T) {
+	t.Parallel()
+	t.Run("ByField", func(t *testing.T) {
+		t.Parallel()
+		c, data, clean := createCollectionWithData(t)
+		defer clean()
+		var pages []book
+		q := OrderByDesc("Meta.TotalReads").LimitTo(2)
+		for {
+			ret, err := c.Find(q)
+			checkErr(t, err)
+			if len(ret) == 0 {
+				break
+			}
+			for _, r := range ret {
+				var b book
+				util.InstanceFromJSON(r, &b)
+				pages = append(pages, b)
+			}
+			token, err := q.Cursor(ret[len(ret)-1])
+			checkErr(t, err)
+			q = OrderByDesc("Meta.TotalReads").LimitTo(2).After(token)
+
+			// Instances created before the cursor position don't shift pages
+			_, err = c.Create(util.JSONFromInstance(book{Title: "New", Meta: bookStats{TotalReads: 1000}}))
+			checkErr(t, err)
+		}
+		expected := []book{data[4], data[3], data[2], data[1], data[0]}
+		if !reflect.DeepEqual(expected, pages) {
+			t.Fatalf("wrong pages, expected: %v, got: %v", expected, pages)
+		}
+	})
+	t.Run("ByID", func(t *testing.T) {
+		t.Parallel()
+		c, _, clean := createCollectionWithData(t)
+		defer clean()
+		all, err := c.Find(OrderByID())
+		checkErr(t, err)
+		token, err := OrderByID().Cursor(all[1])
+		checkErr(t, err)
+		ret, err := c.Find(Where("Author").Ne("Author2").After(token))
+		checkErr(t, err)
+		var expected [][]byte
+		for _, r := range all[2:] {
+			var b book
+			util.InstanceFromJSON(r, &b)
+			if b.Author != "Author2" {
+				expected = append(expected, r)
+			}
+		}
+		if !reflect.DeepEqual(expected, ret) {
+			t.Fatalf("wrong results, expected: %s, got: %s", expected, ret)
+		}
+		results, err := c.FindStream(context.Background(), (&Query{}).After(token))
+		checkErr(t, err)
+		var streamed [][]byte
+		for r := range results {
+			checkErr(t, r.Err)
+			streamed = append(streamed, r.Instance)
+		}
+		if !reflect.DeepEqual(all[2:], streamed) {
+			t.Fatalf("streamed results don't match, expected: %s, got: %s", all[2:], streamed)
+		}
+	})
+	t.Run("Fail/Mismatch", func(t *testing.T) {
+		t.Parallel()
+		c, _, clean := createCollectionWithData(t)
+		defer clean()
+		all, err := c.Find(OrderBy("Title"))
+		checkErr(t, err)
+		token, err := OrderBy("Title").Cursor(all[0])
+		checkErr(t, err)
+		for _, q := range []*Query{OrderByDesc("Title"), OrderBy("Author"), {}} {
+			if _, err := c.Find(q.After(token)); !errors.Is(err, ErrCursorMismatch) {
+				t.Fatalf("expected error %v, got %v", ErrCursorMismatch, err)
+			}
+		}
+		if _, err := c.Find(OrderBy("Title").After("not a cursor")); !errors.Is(err, ErrInvalidCursor) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidCursor, err)
+		}
+	})
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Book",
+		Schema: util.SchemaFromInstance(&book{}, false),
+		Indexes: []Index{
+			{Path: "Author"},
+			{Paths: []string{"Author", "Title"}},
+		},
+	})
+	checkErr(t, err)
+	for i := range sampleData {
+		_, err := c.Create(util.JSONFromInstance(sampleData[i]))
+		checkErr(t, err)
+	}
+	for _, q := range queries {
+		q := q
+		t.Run(q.name, func(t *testing.T) {
+			n, err := c.Count(q.query)
+			checkErr(t, err)
+			if n != len(q.resIdx) {
+				t.Fatalf("expected count %d, got %d", len(q.resIdx), n)
+			}
+		})
+	}
+	t.Run("CoveredByIndex", func(t *testing.T) {
+		for _, q := range []*Query{
+			Where("Author").Eq("Author1").And("Title").Eq("Title2"),
+			Where("Author").Eq("Author1").UseIndex("Author"),
+			Where("Author").Gt("Author1").UseIndex("Author"),
+		} {
+			if _, _, ok := c.coveringIndex(q); !ok {
+				t.Fatalf("expected query %v to be covered by an index", q)
+			}
+		}
+		n, err := c.Count(Where("Author").Ge("Author2").UseIndex("Author"))
+		checkErr(t, err)
+		if n != 2 {
+			t.Fatalf("expected count %d, got %d", 2, n)
+		}
+	})
+	t.Run("ReadFilter", func(t *testing.T) {
+		filtered, err := db.UpdateCollection(CollectionConfig{
+			Name:   "Book",
+			Schema: util.SchemaFromInstance(&book{}, false),
+			ReadFilter: `
+				if (instance.Author === "Author1") {
+					return null
+				}
+				return instance
+			`,
+		})
+		checkErr(t, err)
+		n, err := filtered.Count(&Query{})
+		checkErr(t, err)
+		if n != 2 {
+			t.Fatalf("expected count %d, got %d", 2, n)
+		}
+	})
+}
+
 func TestInvalidSortField(t *testing.T) {
 	t.Parallel()
 
 	c, _, clean := createCollectionWithData(t)
 	defer clean()
-	_, err := c.Find(OrderBy("WrongFieldName"))
+	_, err := c.Find(OrderBy("Title.WrongFieldName"))
 	if !errors.Is(err, ErrInvalidSortingField) {
 		t.Fatal("query should fail using an invalid field")
 	}
 }
 
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person2{}, false),
+		Indexes: []Index{
+			{Paths: []string{"Name", "Age"}},
+			{Path: "Toys.Favorite"},
+		},
+	})
+	checkErr(t, err)
+
+	tests := []struct {
+		name  string
+		query *Query
+		plan  QueryPlan
+	}{
+		{
+			name:  "Compound",
+			query: Where("Name").Eq("Alice").And("Age").Eq(30.0),
+			plan:  QueryPlan{Index: "Name,Age", Compound: true},
+		},
+		{
+			name:  "Index",
+			query: Where("Toys.Favorite").Eq("Ball").UseIndex("Toys.Favorite"),
+			plan:  QueryPlan{Index: "Toys.Favorite"},
+		},
+		{
+			name:  "Scan",
+			query: Where("Age").Gt(30.0),
+			plan:  QueryPlan{Prefix: c.baseKey().String()},
+		},
+		{
+			name:  "ScanByID",
+			query: Where("Age").Gt(30.0).OrderByID(),
+			plan:  QueryPlan{Prefix: c.baseKey().String(), OrderedScan: true},
+		},
+		{
+			name:  "SortInMemory",
+			query: Where("Name").Eq("Alice").And("Age").Eq(30.0).OrderBy("Toys.Favorite"),
+			plan:  QueryPlan{Index: "Name,Age", Compound: true, InMemorySort: true},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := c.Explain(tc.query)
+			checkErr(t, err)
+			if tc.plan.Prefix == "" {
+				// Index prefixes are checked by their index
+				tc.plan.Prefix = plan.Prefix
+				if !strings.Contains(plan.Prefix, tc.plan.Index) {
+					t.Fatalf("expected prefix of index %s, got %s", tc.plan.Index, plan.Prefix)
+				}
+			}
+			if !reflect.DeepEqual(plan, tc.plan) {
+				t.Fatalf("expected plan %+v, got %+v", tc.plan, plan)
+			}
+		})
+	}
+
+	t.Run("Fail/IndexNotFound", func(t *testing.T) {
+		if _, err := c.Explain(Where("Age").Eq(30.0).UseIndex("Age")); !errors.Is(err, ErrIndexNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrIndexNotFound, err)
+		}
+	})
+}
+
+type contact struct {
+	ID      core.InstanceID `json:"_id"`
+	Name    string
+	Address *contactAddress `json:",omitempty"`
+}
+
+type contactAddress struct {
+	Zip string
+}
+
+func TestSortMissingField(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Contact",
+		Schema: util.SchemaFromInstance(&contact{}, false),
+	})
+	checkErr(t, err)
+	for _, v := range []contact{
+		{Name: "A", Address: &contactAddress{Zip: "2"}},
+		{Name: "B", Address: &contactAddress{Zip: "1"}},
+		{Name: "C"},
+		{Name: "D", Address: &contactAddress{Zip: "1"}},
+	} {
+		_, err := c.Create(util.JSONFromInstance(v))
+		checkErr(t, err)
+	}
+	names := func(res [][]byte) string {
+		var ns string
+		for _, r := range res {
+			var v contact
+			util.InstanceFromJSON(r, &v)
+			ns += v.Name
+		}
+		return ns
+	}
+
+	for _, tc := range []struct {
+		query    func() *Query
+		expected string
+	}{
+		{query: func() *Query { return OrderBy("Address.Zip").ThenBy("Name") }, expected: "BDAC"},
+		{query: func() *Query { return OrderByDesc("Address.Zip").ThenByDesc("Name") }, expected: "ADBC"},
+	} {
+		res, err := c.Find(tc.query())
+		checkErr(t, err)
+		if got := names(res); got != tc.expected {
+			t.Fatalf("expected order %s, got %s", tc.expected, got)
+		}
+
+		// Pages follow the same order, including instances which are missing the field
+		var paged string
+		q := tc.query().LimitTo(1)
+		for {
+			res, err := c.Find(q)
+			checkErr(t, err)
+			if len(res) == 0 {
+				break
+			}
+			paged += names(res)
+			token, err := q.Cursor(res[0])
+			checkErr(t, err)
+			q = tc.query().LimitTo(1).After(token)
+		}
+		if paged != tc.expected {
+			t.Fatalf("expected paged order %s, got %s", tc.expected, paged)
+		}
+	}
+
+	token, err := OrderBy("Address.Zip").ThenBy("Name").Cursor(util.JSONFromInstance(contact{ID: "x", Name: "A"}))
+	checkErr(t, err)
+	if _, err := c.Find(OrderBy("Address.Zip").After(token)); !errors.Is(err, ErrCursorMismatch) {
+		t.Fatalf("expected error %v, got %v", ErrCursorMismatch, err)
+	}
+}
+
 func createCollectionWithData(t *testing.T) (*Collection, []book, func()) {
 	db, clean := createTestDB(t)
 	c, err := db.NewCollection(CollectionConfig{
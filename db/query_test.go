@@ -1,6 +1,9 @@
 package db
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"reflect"
 	"sort"
 	"strings"
@@ -305,6 +308,54 @@ func TestQuery(t *testing.T) {
 	}
 }
 
+func TestQuerySelect(t *testing.T) {
+	t.Parallel()
+	c, d, clean := createCollectionWithJSONData(t)
+	defer clean()
+
+	q := Where("Author").Eq("Author2").Select("Title", "Meta.Rating", "Missing", "Meta.Missing")
+	res, err := c.Find(q)
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(res))
+	}
+	var got map[string]interface{}
+	checkErr(t, json.Unmarshal(res[0], &got))
+	expected := map[string]interface{}{
+		"_id":   d[2].ID.String(),
+		"Title": d[2].Title,
+		"Meta":  map[string]interface{}{"Rating": d[2].Meta.Rating},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	// Sorting may use fields which aren't selected
+	res, err = c.Find(OrderByDesc("Meta.TotalReads").Select("Title").LimitTo(2))
+	checkErr(t, err)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(res))
+	}
+	for i, idx := range []int{3, 1} {
+		var book Book
+		util.InstanceFromJSON(res[i], &book)
+		if book.ID != d[idx].ID || book.Title != d[idx].Title || book.Author != "" {
+			t.Fatalf("expected selected fields of %v, got %v", d[idx], book)
+		}
+	}
+
+	stream, err := c.FindStream(context.Background(), Where("Author").Eq("Author2").Select("Title"))
+	checkErr(t, err)
+	for r := range stream {
+		checkErr(t, r.Err)
+		var book Book
+		util.InstanceFromJSON(r.Instance, &book)
+		if book.Title != d[2].Title || book.Author != "" {
+			t.Fatalf("expected streamed result with selected fields only, got %v", book)
+		}
+	}
+}
+
 func createCollectionWithJSONData(t *testing.T) (*Collection, []Book, func()) {
 	s, clean := createTestDB(t)
 	c, err := s.NewCollection(CollectionConfig{
@@ -330,3 +381,86 @@ func createCollectionWithJSONData(t *testing.T) (*Collection, []Book, func()) {
 	}
 	return c, dataCopy, clean
 }
+
+type task struct {
+	ID     db.InstanceID `json:"_id"`
+	Title  string
+	Tags   []string
+	Scores []float64
+}
+
+func TestQueryArrays(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Task",
+		Schema: util.SchemaFromInstance(&task{}, false),
+		Indexes: []Index{
+			{Path: "Tags", Multikey: true},
+			{Path: "Scores", Multikey: true},
+		},
+	})
+	checkErr(t, err)
+	tasks := []task{
+		{Title: "a", Tags: []string{"urgent", "home"}, Scores: []float64{91, 95}},
+		{Title: "b", Tags: []string{"work"}, Scores: []float64{50}},
+		{Title: "c", Tags: []string{"urgent", "urgent"}},
+	}
+	for _, tk := range tasks {
+		_, err := c.Create(util.JSONFromInstance(tk))
+		checkErr(t, err)
+	}
+
+	cases := []struct {
+		name   string
+		query  *Query
+		titles []string
+	}{
+		{name: "Contains", query: Where("Tags").Contains("urgent"), titles: []string{"a", "c"}},
+		{name: "ContainsNone", query: Where("Tags").Contains("play"), titles: nil},
+		{name: "ContainsScalar", query: Where("Title").Contains("a"), titles: nil},
+		{name: "ContainsOtherType", query: Where("Tags").Contains(float64(1)), titles: nil},
+		{name: "AnyGt", query: Where("Scores").AnyGt(float64(90)), titles: []string{"a"}},
+		{name: "AnyLe", query: Where("Scores").AnyLe(float64(91)), titles: []string{"a", "b"}},
+		{name: "AnyGeAnyLt", query: Where("Scores").AnyGe(float64(50)).And("Scores").AnyLt(float64(60)), titles: []string{"b"}},
+		{name: "IndexContains", query: Where("Tags").Contains("urgent").UseIndex("Tags"), titles: []string{"a", "c"}},
+		{name: "IndexAnyGt", query: Where("Scores").AnyGt(float64(90)).UseIndex("Scores"), titles: []string{"a"}},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := c.Find(tc.query)
+			checkErr(t, err)
+			var titles []string
+			for _, r := range res {
+				var tk task
+				util.InstanceFromJSON(r, &tk)
+				titles = append(titles, tk.Title)
+			}
+			sort.Strings(titles)
+			if !reflect.DeepEqual(titles, tc.titles) {
+				t.Fatalf("expected %v, got %v", tc.titles, titles)
+			}
+			if n, err := c.Count(tc.query); err != nil || n != len(tc.titles) {
+				t.Fatalf("expected count %d, got %d (%v)", len(tc.titles), n, err)
+			}
+		})
+	}
+
+	t.Run("InvalidIndex", func(t *testing.T) {
+		for _, index := range []Index{
+			{Path: "Title", Multikey: true},
+			{Path: "Tags", Multikey: true, Unique: true},
+		} {
+			_, err := db.UpdateCollection(CollectionConfig{
+				Name:    "Task",
+				Schema:  util.SchemaFromInstance(&task{}, false),
+				Indexes: []Index{index},
+			})
+			if !errors.Is(err, ErrInvalidMultikeyIndex) {
+				t.Fatalf("expected error %v for %v, got %v", ErrInvalidMultikeyIndex, index, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+)
+
+// queryCache memoizes the results of Find queries, evicting the least recently used results
+// once it holds max entries.
+// A result is invalidated by a change to an instance which matches its query before or after
+// the change. Changes to instances which match neither can't affect the result, so they keep it.
+// Queries of collections with a read filter, computed fields or a TTL aren't cached, since their
+// results depend on the reader, or may change without a write.
+type queryCache struct {
+	lock  sync.Mutex
+	max   int
+	order *list.List // of *queryCacheEntry, most recently used first
+	// entries maps a collection name and a query key to its element in order.
+	entries map[string]map[string]*list.Element
+}
+
+type queryCacheEntry struct {
+	collection *Collection
+	key        string
+	query      *Query
+	results    [][]byte
+}
+
+// newQueryCache returns a cache holding up to max results, or nil if max isn't positive.
+func newQueryCache(max int) *queryCache {
+	if max <= 0 {
+		return nil
+	}
+	return &queryCache{
+		max:     max,
+		order:   list.New(),
+		entries: make(map[string]map[string]*list.Element),
+	}
+}
+
+// cacheable returns whether results of q on c can be cached, and the key of q.
+func (qc *queryCache) cacheable(c *Collection, q *Query) (string, bool) {
+	if qc == nil || c.rawReadFilter != nil || c.computed != nil || c.expiry != nil || q.err != nil {
+		return "", false
+	}
+	key, err := json.Marshal(q)
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// get returns a copy of the cached results of q on c.
+func (qc *queryCache) get(c *Collection, q *Query) ([][]byte, bool) {
+	key, ok := qc.cacheable(c, q)
+	if !ok {
+		return nil, false
+	}
+	qc.lock.Lock()
+	defer qc.lock.Unlock()
+	el, ok := qc.entries[c.name][key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*queryCacheEntry)
+	if e.collection != c {
+		// The collection was updated since the results were cached
+		qc.remove(el)
+		return nil, false
+	}
+	qc.order.MoveToFront(el)
+	return copyResults(e.results), true
+}
+
+// put caches a copy of results of q on c.
+func (qc *queryCache) put(c *Collection, q *Query, results [][]byte) {
+	key, ok := qc.cacheable(c, q)
+	if !ok {
+		return
+	}
+	// Keep a copy of q, since the caller may go on building it
+	cq := &Query{}
+	if err := json.Unmarshal([]byte(key), cq); err != nil {
+		return
+	}
+	qc.lock.Lock()
+	defer qc.lock.Unlock()
+	if el, ok := qc.entries[c.name][key]; ok {
+		qc.remove(el)
+	}
+	el := qc.order.PushFront(&queryCacheEntry{
+		collection: c,
+		key:        key,
+		query:      cq,
+		results:    copyResults(results),
+	})
+	if qc.entries[c.name] == nil {
+		qc.entries[c.name] = make(map[string]*list.Element)
+	}
+	qc.entries[c.name][key] = el
+	for qc.order.Len() > qc.max {
+		qc.remove(qc.order.Back())
+	}
+}
+
+// invalidate removes the results of queries on collection which match an instance before
+// or after a change, given as previous and current, which are nil if it didn't or doesn't exist.
+func (qc *queryCache) invalidate(collection string, previous, current []byte) {
+	if qc == nil {
+		return
+	}
+	qc.lock.Lock()
+	defer qc.lock.Unlock()
+	if len(qc.entries[collection]) == 0 {
+		return
+	}
+	var states []map[string]interface{}
+	for _, instance := range [][]byte{previous, current} {
+		if instance == nil {
+			continue
+		}
+		v := make(map[string]interface{})
+		if err := json.Unmarshal(instance, &v); err != nil {
+			qc.clear(collection)
+			return
+		}
+		states = append(states, v)
+	}
+	for _, el := range qc.entries[collection] {
+		q := el.Value.(*queryCacheEntry).query
+		for _, v := range states {
+			// An instance which fails to match makes the query fail, so it affects the results too
+			if ok, err := q.match(v); err != nil || ok {
+				qc.remove(el)
+				break
+			}
+		}
+	}
+}
+
+// invalidateCollection removes the results of all queries on collection.
+func (qc *queryCache) invalidateCollection(collection string) {
+	if qc == nil {
+		return
+	}
+	qc.lock.Lock()
+	defer qc.lock.Unlock()
+	qc.clear(collection)
+}
+
+func (qc *queryCache) clear(collection string) {
+	for _, el := range qc.entries[collection] {
+		qc.remove(el)
+	}
+}
+
+func (qc *queryCache) remove(el *list.Element) {
+	e := qc.order.Remove(el).(*queryCacheEntry)
+	delete(qc.entries[e.collection.name], e.key)
+	if len(qc.entries[e.collection.name]) == 0 {
+		delete(qc.entries, e.collection.name)
+	}
+}
+
+func copyResults(results [][]byte) [][]byte {
+	cp := make([][]byte, len(results))
+	for i, r := range results {
+		cp[i] = append([]byte(nil), r...)
+	}
+	return cp
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestQueryCache(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t, WithNewQueryCache(10))
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Age"}},
+	})
+	checkErr(t, err)
+
+	create := func(name string, age int) core.InstanceID {
+		id, err := c.Create(util.JSONFromInstance(Person{Name: name, Age: age}))
+		checkErr(t, err)
+		return id
+	}
+	save := func(id core.InstanceID, name string, age int) {
+		checkErr(t, c.Save(util.JSONFromInstance(Person{ID: id, Name: name, Age: age})))
+	}
+	q := Where("Age").Ge(40.0)
+	find := func(expected ...string) {
+		t.Helper()
+		res, err := c.Find(q)
+		checkErr(t, err)
+		var names []string
+		for _, r := range res {
+			var p Person
+			checkErr(t, json.Unmarshal(r, &p))
+			names = append(names, p.Name)
+		}
+		if len(names) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, names)
+		}
+		for i := range names {
+			if names[i] != expected[i] {
+				t.Fatalf("expected %v, got %v", expected, names)
+			}
+		}
+	}
+	assertCached := func(cached bool) {
+		t.Helper()
+		if _, ok := db.queryCache.get(c, q); ok != cached {
+			t.Fatalf("expected query cached to be %v", cached)
+		}
+	}
+
+	alice := create("Alice", 42)
+	bob := create("Bob", 30)
+	assertCached(false)
+	find("Alice")
+	assertCached(true)
+
+	t.Run("CreateNonMatching", func(t *testing.T) {
+		create("Carl", 20)
+		assertCached(true)
+		find("Alice")
+	})
+	t.Run("CreateMatching", func(t *testing.T) {
+		dan := create("Dan", 50)
+		assertCached(false)
+		find("Alice", "Dan")
+		checkErr(t, c.Delete(dan))
+		assertCached(false)
+		find("Alice")
+	})
+	t.Run("UpdateNonMatching", func(t *testing.T) {
+		save(bob, "Bob", 31)
+		assertCached(true)
+		find("Alice")
+	})
+	t.Run("UpdateIntoMatching", func(t *testing.T) {
+		save(bob, "Bob", 45)
+		assertCached(false)
+		find("Alice", "Bob")
+	})
+	t.Run("UpdateMatching", func(t *testing.T) {
+		save(alice, "Alicia", 42)
+		assertCached(false)
+		find("Alicia", "Bob")
+	})
+	t.Run("UpdateOutOfMatching", func(t *testing.T) {
+		save(bob, "Bob", 30)
+		assertCached(false)
+		find("Alicia")
+	})
+	t.Run("DeleteNonMatching", func(t *testing.T) {
+		checkErr(t, c.Delete(bob))
+		assertCached(true)
+		find("Alicia")
+	})
+	t.Run("DeleteMatching", func(t *testing.T) {
+		checkErr(t, c.Delete(alice))
+		assertCached(false)
+		find()
+	})
+	t.Run("ResultsAreCopies", func(t *testing.T) {
+		create("Eve", 60)
+		res, err := c.Find(q)
+		checkErr(t, err)
+		res[0][0] = 'x'
+		find("Eve")
+	})
+	t.Run("UpdateCollection", func(t *testing.T) {
+		find("Eve")
+		c, err = db.UpdateCollection(CollectionConfig{
+			Name:   "Person",
+			Schema: util.SchemaFromInstance(&Person{}, false),
+		})
+		checkErr(t, err)
+		assertCached(false)
+		find("Eve")
+	})
+}
+
+func TestQueryCacheEviction(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t, WithNewQueryCache(2))
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+
+	queries := []*Query{Where("Age").Eq(42.0), Where("Age").Eq(43.0), Where("Age").Eq(44.0)}
+	for _, q := range queries {
+		_, err := c.Find(q)
+		checkErr(t, err)
+	}
+	if _, ok := db.queryCache.get(c, queries[0]); ok {
+		t.Fatal("expected least recently used query to be evicted")
+	}
+	for _, q := range queries[1:] {
+		if _, ok := db.queryCache.get(c, q); !ok {
+			t.Fatal("expected recently used query to be cached")
+		}
+	}
+}
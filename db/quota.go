@@ -0,0 +1,122 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ipfs/go-datastore/query"
+)
+
+// ErrQuotaExceeded indicates that a write would exceed the instance limit of a collection,
+// or the size limit of a db.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// quota enforces CollectionConfig.MaxInstances and the db size limit set by WithNewMaxBytes.
+// Usage is read from the datastore when a limit is first checked, and then kept up to date
+// as events are reduced, so writes don't scan the datastore.
+// The size of a db is the sum of the lengths of its stored instances, which is an approximation
+// of the space it takes, since it ignores keys, indexes, events and encryption overhead.
+type quota struct {
+	maxBytes int64
+
+	lock      sync.Mutex
+	loaded    bool
+	bytes     int64
+	instances map[string]int
+}
+
+func newQuota(maxBytes int64) *quota {
+	return &quota{maxBytes: maxBytes}
+}
+
+// check returns ErrQuotaExceeded if adding instances, which are bytes long in total,
+// to c would exceed a limit.
+func (q *quota) check(c *Collection, instances int, bytes int64) error {
+	if q == nil || (c.maxInstances <= 0 && q.maxBytes <= 0) {
+		return nil
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if err := q.load(c.db); err != nil {
+		return err
+	}
+	if c.maxInstances > 0 && q.instances[c.name]+instances > c.maxInstances {
+		return fmt.Errorf("%w: collection %s is limited to %d instances", ErrQuotaExceeded, c.name, c.maxInstances)
+	}
+	if q.maxBytes > 0 && q.bytes+bytes > q.maxBytes {
+		return fmt.Errorf("%w: db is limited to %d bytes", ErrQuotaExceeded, q.maxBytes)
+	}
+	return nil
+}
+
+// update accounts for an instance of collection changing from previous to current,
+// which are nil if it didn't or doesn't exist.
+func (q *quota) update(collection string, previous, current []byte) {
+	if q == nil {
+		return
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if !q.loaded {
+		// Usage is read from the datastore once it's needed
+		return
+	}
+	q.bytes += int64(len(current) - len(previous))
+	if previous == nil && current != nil {
+		q.instances[collection]++
+	} else if previous != nil && current == nil {
+		q.instances[collection]--
+	}
+}
+
+// reset discards the usage, so that it's read from the datastore again when it's needed.
+func (q *quota) reset() {
+	if q == nil {
+		return
+	}
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.loaded = false
+}
+
+// load reads the usage of d from its datastores if it wasn't read yet.
+// This method is internal and *not* thread-safe. It assumes we currently own the quota lock.
+func (q *quota) load(d *DB) error {
+	if q.loaded {
+		return nil
+	}
+	d.lock.RLock()
+	collections := make([]*Collection, 0, len(d.collections))
+	for _, c := range d.collections {
+		collections = append(collections, c)
+	}
+	d.lock.RUnlock()
+
+	var bytes int64
+	instances := make(map[string]int, len(collections))
+	for _, c := range collections {
+		res, err := c.store.Query(query.Query{Prefix: c.baseKey().String()})
+		if err != nil {
+			return err
+		}
+		entryPrefix := c.baseKey().String() + "/"
+		for r := range res.Next() {
+			if r.Error != nil {
+				res.Close()
+				return r.Error
+			}
+			if !strings.HasPrefix(r.Key, entryPrefix) {
+				continue
+			}
+			instances[c.name]++
+			bytes += int64(len(r.Value))
+		}
+		res.Close()
+	}
+	q.bytes = bytes
+	q.instances = instances
+	q.loaded = true
+	return nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestQuotaMaxInstances(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:         "Person",
+		Schema:       util.SchemaFromInstance(&Person{}, false),
+		MaxInstances: 2,
+	})
+	checkErr(t, err)
+	if c.GetMaxInstances() != 2 {
+		t.Fatalf("expected instance limit of 2, got %d", c.GetMaxInstances())
+	}
+
+	id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+	_, err = c.CreateMany([][]byte{
+		util.JSONFromInstance(Person{Name: "Bob", Age: 42}),
+		util.JSONFromInstance(Person{Name: "Carl", Age: 42}),
+	})
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected error %v, got %v", ErrQuotaExceeded, err)
+	}
+	if n, err := c.Count(nil); err != nil || n != 1 {
+		t.Fatalf("expected failed create not to be applied, got %d instances (%v)", n, err)
+	}
+
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 42}))
+	checkErr(t, err)
+	checkErr(t, c.Delete(id))
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Carl", Age: 42}))
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Dan", Age: 42}))
+	if !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("expected error %v, got %v", ErrQuotaExceeded, err)
+	}
+
+	c, err = db.UpdateCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Dan", Age: 42}))
+	checkErr(t, err)
+}
+
+func TestQuotaMaxBytes(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t, WithNewMaxBytes(200))
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	var ids []core.InstanceID
+	for {
+		id, err := c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+		if errors.Is(err, ErrQuotaExceeded) {
+			break
+		}
+		checkErr(t, err)
+		ids = append(ids, id)
+		if len(ids) > 10 {
+			t.Fatal("expected size limit to be reached")
+		}
+	}
+	if len(ids) == 0 {
+		t.Fatal("expected instances to be created before reaching the size limit")
+	}
+	checkErr(t, c.Delete(ids[0]))
+	_, err = c.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+}
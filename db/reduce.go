@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+)
+
+// ReduceFunc folds an instance into the accumulated value acc, and returns the new value.
+type ReduceFunc func(acc interface{}, instance []byte) (interface{}, error)
+
+// Iterate calls fn with each instance matching q, as they're read from the datastore, so that
+// memory use doesn't grow with the number of instances. Like FindStream, sorting by a field other
+// than ID isn't supported. Iteration stops with the error of fn if it fails, or with the error
+// of ctx if it's done.
+func (t *Txn) Iterate(ctx context.Context, q *Query, fn func(instance []byte) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results, err := t.FindStream(ctx, q)
+	if err != nil {
+		return err
+	}
+	for r := range results {
+		if r.Err != nil {
+			return r.Err
+		}
+		if err := fn(r.Instance); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Reduce folds the instances matching q into a single value with fn, starting from init, and
+// returns it. Instances are read as they're folded, see Iterate.
+func (t *Txn) Reduce(ctx context.Context, q *Query, init interface{}, fn ReduceFunc) (interface{}, error) {
+	acc := init
+	err := t.Iterate(ctx, q, func(instance []byte) (err error) {
+		acc, err = fn(acc, instance)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
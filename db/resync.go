@@ -0,0 +1,64 @@
+package db
+
+import (
+	"context"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+// ResetNetRecords drops the instances and indexes of all collections, and the events
+// dispatched to the db, keeping the collections and their configuration. It's called by the
+// net when the thread of the db is resynced, before its records are handled again from the
+// start of the thread, which creates the instances again.
+// Listeners aren't notified of dropped instances, and positions keep increasing from the
+// last action, so actions of the rebuilt instances follow the actions logged before.
+func (d *DB) ResetNetRecords(_ context.Context) error {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	d.txnlock.Lock()
+	defer d.txnlock.Unlock()
+	log.Debugf("resetting records of %s", d.name)
+
+	for _, c := range d.collections {
+		if err := deletePrefix(c.store, c.baseKey()); err != nil {
+			return err
+		}
+		if err := deletePrefix(c.store, indexPrefix.Child(c.baseKey())); err != nil {
+			return err
+		}
+		d.queryCache.invalidateCollection(c.name)
+	}
+	if err := deletePrefix(d.dispatcher.Store(), dsDispatcherPrefix); err != nil {
+		return err
+	}
+	if err := d.datastore.Delete(dsHead); err != nil {
+		return err
+	}
+	d.quota.reset()
+	return d.sync()
+}
+
+// deletePrefix deletes the entries of store below prefix.
+func deletePrefix(store kt.TxnDatastoreExtended, prefix ds.Key) error {
+	results, err := store.Query(query.Query{Prefix: prefix.String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+	entryPrefix := prefix.String() + "/"
+	for res := range results.Next() {
+		if res.Error != nil {
+			return res.Error
+		}
+		if !strings.HasPrefix(res.Key, entryPrefix) {
+			continue
+		}
+		if err := store.Delete(ds.NewKey(res.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
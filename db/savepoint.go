@@ -0,0 +1,41 @@
+package db
+
+import (
+	"errors"
+)
+
+// ErrInvalidSavepoint indicates a savepoint wasn't set in a transaction, or was released by
+// rolling back to an earlier savepoint.
+var ErrInvalidSavepoint = errors.New("invalid savepoint")
+
+// SavepointID identifies a savepoint of a transaction.
+type SavepointID int
+
+// Savepoint marks the changes made in the transaction so far, so that the changes made after
+// it can be dropped with RollbackTo, while the transaction stays open.
+func (t *Txn) Savepoint() (SavepointID, error) {
+	if err := t.checkOpen(); err != nil {
+		return 0, err
+	}
+	if t.readonly {
+		return 0, ErrReadonlyTx
+	}
+	t.savepoints = append(t.savepoints, len(t.actions))
+	return SavepointID(len(t.savepoints) - 1), nil
+}
+
+// RollbackTo drops the changes made in the transaction after savepoint id. Changes are only
+// buffered until commit, so nothing dropped is written or dispatched, and later operations of
+// the transaction don't see the dropped changes. The savepoint can be rolled back to again,
+// but savepoints set after it are released.
+func (t *Txn) RollbackTo(id SavepointID) error {
+	if err := t.checkOpen(); err != nil {
+		return err
+	}
+	if id < 0 || int(id) >= len(t.savepoints) {
+		return ErrInvalidSavepoint
+	}
+	t.actions = t.actions[:t.savepoints[id]]
+	t.savepoints = t.savepoints[:id+1]
+	return nil
+}
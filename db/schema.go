@@ -0,0 +1,140 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ErrUnresolvedSchemaRef indicates a collection schema references a definition that can't be resolved.
+var ErrUnresolvedSchemaRef = errors.New("schema reference can't be resolved")
+
+// compileSchema compiles a collection schema, resolving its references against the named
+// schema documents of docs. References to other documents aren't loaded.
+// Format keywords are asserted, unless ignoreFormats is set.
+func compileSchema(schema []byte, docs map[string]json.RawMessage, ignoreFormats bool) (*gojsonschema.Schema, error) {
+	if err := checkSchemaRefs(schema, docs); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(docs))
+	for name, doc := range docs {
+		if err := checkSchemaRefs(doc, docs); err != nil {
+			return nil, fmt.Errorf("document %s: %w", name, err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	load := func(b []byte) (gojsonschema.JSONLoader, error) {
+		if !ignoreFormats {
+			return gojsonschema.NewBytesLoader(b), nil
+		}
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		stripSchemaFormats(v)
+		return gojsonschema.NewGoLoader(v), nil
+	}
+	sl := gojsonschema.NewSchemaLoader()
+	for _, name := range names {
+		doc, err := load(docs[name])
+		if err != nil {
+			return nil, fmt.Errorf("document %s: %w", name, err)
+		}
+		if err := sl.AddSchema(name, doc); err != nil {
+			return nil, fmt.Errorf("%w: document %s: %v", ErrUnresolvedSchemaRef, name, err)
+		}
+	}
+	root, err := load(schema)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := sl.Compile(root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnresolvedSchemaRef, err)
+	}
+	return compiled, nil
+}
+
+// stripSchemaFormats removes the format keywords of a decoded schema and its subschemas.
+// Properties named "format" are kept, since they're not keywords.
+func stripSchemaFormats(v interface{}) {
+	schema, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if _, ok := schema["format"].(string); ok {
+		delete(schema, "format")
+	}
+	for k, child := range schema {
+		switch k {
+		case "properties", "patternProperties", "definitions", "$defs", "dependencies":
+			if m, ok := child.(map[string]interface{}); ok {
+				for _, sub := range m {
+					stripSchemaFormats(sub)
+				}
+			}
+		case "items", "allOf", "anyOf", "oneOf":
+			if l, ok := child.([]interface{}); ok {
+				for _, sub := range l {
+					stripSchemaFormats(sub)
+				}
+			} else {
+				stripSchemaFormats(child)
+			}
+		case "additionalItems", "additionalProperties", "contains", "propertyNames", "not", "if", "then", "else":
+			stripSchemaFormats(child)
+		}
+	}
+}
+
+// checkSchemaRefs returns ErrUnresolvedSchemaRef if schema references a document
+// which isn't in docs.
+func checkSchemaRefs(schema []byte, docs map[string]json.RawMessage) error {
+	var v interface{}
+	if err := json.Unmarshal(schema, &v); err != nil {
+		return err
+	}
+	return walkSchemaRefs(v, func(ref string) error {
+		name := ref
+		if i := strings.Index(ref, "#"); i >= 0 {
+			name = ref[:i]
+		}
+		if name == "" {
+			return nil
+		}
+		if _, ok := docs[name]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnresolvedSchemaRef, ref)
+		}
+		return nil
+	})
+}
+
+// walkSchemaRefs calls fn with each $ref of a decoded schema.
+func walkSchemaRefs(v interface{}, fn func(ref string) error) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if ref, ok := child.(string); ok && k == "$ref" {
+				if err := fn(ref); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := walkSchemaRefs(child, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if err := walkSchemaRefs(child, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package db
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	mbase "github.com/multiformats/go-multibase"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	// SearchKeyBytes is the length of a SearchKey in bytes.
+	SearchKeyBytes = 32
+)
+
+var (
+	// ErrInvalidSearchKey indicates an invalid byte slice was given to SearchKeyFromBytes.
+	ErrInvalidSearchKey = errors.New("invalid search key")
+	// ErrEncryptedIndexOperation indicates a query used an operation that an encrypted index can't answer.
+	ErrEncryptedIndexOperation = errors.New("only equality criteria are supported on encrypted index paths")
+	// ErrEncryptedIndexType indicates an encrypted index was specified on a non-string path.
+	ErrEncryptedIndexType = errors.New("encrypted index path must be of type string")
+	// ErrInvalidSearchToken indicates an instance holds a value that isn't a search token at an encrypted index path.
+	ErrInvalidSearchToken = errors.New("encrypted index path must hold a search token")
+)
+
+// SearchKey is a secret used to derive searchable encryption tokens for encrypted indexes.
+// See the package README for the security tradeoffs of encrypted indexes.
+type SearchKey []byte
+
+// NewSearchKey returns a random search key.
+func NewSearchKey() SearchKey {
+	k := make([]byte, SearchKeyBytes)
+	if _, err := rand.Read(k); err != nil {
+		panic(fmt.Sprintf("reading random bytes: %v", err))
+	}
+	return k
+}
+
+// SearchKeyFromBytes returns a search key by wrapping b.
+func SearchKeyFromBytes(b []byte) (SearchKey, error) {
+	if len(b) != SearchKeyBytes {
+		return nil, ErrInvalidSearchKey
+	}
+	k := make([]byte, SearchKeyBytes)
+	copy(k, b)
+	return k, nil
+}
+
+// SearchKeyFromString returns a search key by decoding a base32-encoded string.
+func SearchKeyFromString(s string) (SearchKey, error) {
+	_, b, err := mbase.Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	return SearchKeyFromBytes(b)
+}
+
+// String returns the base32-encoded string representation of raw key bytes.
+func (k SearchKey) String() string {
+	str, err := mbase.Encode(mbase.Base32, k)
+	if err != nil {
+		panic("should not error with hardcoded mbase: " + err.Error())
+	}
+	return str
+}
+
+// Token returns the deterministic token for value at path.
+// value can be any JSON-encodable value. Numbers are encoded as float64 in JSON,
+// so 42 and 42.0 produce the same token.
+func (k SearchKey) Token(path string, value interface{}) (string, error) {
+	if len(k) != SearchKeyBytes {
+		return "", ErrInvalidSearchKey
+	}
+	v, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("marshaling token value: %v", err)
+	}
+	mac := hmac.New(sha256.New, k)
+	mac.Write([]byte(path))
+	mac.Write([]byte{0})
+	mac.Write(v)
+	return mbase.Encode(mbase.Base32, mac.Sum(nil))
+}
+
+// EncryptFields replaces the values at paths in instance with their tokens.
+// Paths that don't exist in instance are skipped.
+func (k SearchKey) EncryptFields(instance []byte, paths ...string) ([]byte, error) {
+	if len(k) != SearchKeyBytes {
+		return nil, ErrInvalidSearchKey
+	}
+	res := instance
+	for _, p := range paths {
+		val := gjson.GetBytes(res, p)
+		if !val.Exists() {
+			continue
+		}
+		tok, err := k.Token(p, val.Value())
+		if err != nil {
+			return nil, err
+		}
+		res, err = sjson.SetBytes(res, p, tok)
+		if err != nil {
+			return nil, fmt.Errorf("setting token at %s: %v", p, err)
+		}
+	}
+	return res, nil
+}
+
+// EqEncrypted is an equality operator against a field holding searchable encryption tokens.
+func (c *Criterion) EqEncrypted(key SearchKey, value interface{}) *Query {
+	return c.createEncryptedCriterion(Eq, key, value)
+}
+
+// NeEncrypted is a not equal operator against a field holding searchable encryption tokens.
+func (c *Criterion) NeEncrypted(key SearchKey, value interface{}) *Query {
+	return c.createEncryptedCriterion(Ne, key, value)
+}
+
+func (c *Criterion) createEncryptedCriterion(op Operation, key SearchKey, value interface{}) *Query {
+	tok, err := key.Token(c.FieldPath, value)
+	q := c.createcriterion(op, tok)
+	if err != nil && q.err == nil {
+		q.err = fmt.Errorf("creating search token for %s: %w", c.FieldPath, err)
+	}
+	return q
+}
+
+// validEncryptedInstance ensures instance only holds search tokens at encrypted index paths.
+func (c *Collection) validEncryptedInstance(instance []byte) error {
+	for _, index := range c.indexes {
+		if !index.Encrypted {
+			continue
+		}
+		val := gjson.GetBytes(instance, index.Path)
+		if !val.Exists() {
+			continue
+		}
+		if !isSearchToken(val) {
+			return fmt.Errorf("%w: %s", ErrInvalidSearchToken, index.Path)
+		}
+	}
+	return nil
+}
+
+// isSearchToken returns whether val is a base32 multibase encoded HMAC-SHA256 sum.
+func isSearchToken(val gjson.Result) bool {
+	if val.Type != gjson.String {
+		return false
+	}
+	enc, b, err := mbase.Decode(val.String())
+	return err == nil && enc == mbase.Base32 && len(b) == sha256.Size
+}
+
+// validEncryptedQuery ensures q only uses equality criteria on encrypted index paths.
+func (c *Collection) validEncryptedQuery(q *Query) error {
+	if q == nil {
+		return nil
+	}
+	for _, a := range q.Ands {
+		index, ok := c.indexes[a.FieldPath]
+		if !ok || !index.Encrypted {
+			continue
+		}
+		if a.Operation != Eq && a.Operation != Ne {
+			return ErrEncryptedIndexOperation
+		}
+	}
+	for _, o := range q.Ors {
+		if err := c.validEncryptedQuery(o); err != nil {
+			return err
+		}
+	}
+	for _, s := range append([]Sort{q.Sort}, q.Then...) {
+		if index, ok := c.indexes[s.FieldPath]; ok && index.Encrypted {
+			return ErrEncryptedIndexOperation
+		}
+	}
+	// Indexes of collections with encrypted values hold tokens, which can only be compared for equality.
+	if index, ok := c.indexes[q.Index]; ok && c.indexKey != nil && !index.isCompound() && !onlyEqualityOn(q, index.Path) {
+		return ErrEncryptedIndexOperation
+	}
+	return nil
+}
+
+// onlyEqualityOn returns whether criteria on path in q are all equality criteria.
+func onlyEqualityOn(q *Query, path string) bool {
+	for _, a := range q.Ands {
+		if a.FieldPath == path && a.Operation != Eq && a.Operation != Ne {
+			return false
+		}
+	}
+	for _, o := range q.Ors {
+		if !onlyEqualityOn(o, path) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,316 @@
+package db
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	kt "github.com/textileio/go-threads/db/keytransform"
+)
+
+const (
+	snapshotVersion = 1
+	// restoreBatchSize is the number of entries written per transaction when restoring.
+	restoreBatchSize = 1000
+)
+
+var (
+	// ErrInvalidSnapshot indicates a snapshot stream is malformed or truncated.
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+	// ErrSnapshotTargetNotEmpty indicates a snapshot is restored over existing data.
+	ErrSnapshotTargetNotEmpty = errors.New("snapshot target datastore isn't empty")
+)
+
+// snapshotHeader starts a snapshot stream.
+type snapshotHeader struct {
+	Version int
+	// Name is the db name.
+	Name string
+	// Head is the key of the last event dispatched to the db, if any.
+	Head string
+	// Ranges are the key ranges copied from each datastore.
+	Ranges []snapshotRange
+}
+
+// snapshotRange is a key range copied from a datastore. Store is empty for the db datastore.
+type snapshotRange struct {
+	Store  string
+	Prefix string
+}
+
+// snapshotEntry is a key-value pair of a snapshot range.
+// The last entry of a stream has End set, and no key.
+type snapshotEntry struct {
+	Range int
+	Key   string
+	Value []byte
+	End   bool
+}
+
+// snapshotSource is a range of a datastore, read in a transaction.
+type snapshotSource struct {
+	snapshotRange
+	txn ds.Txn
+}
+
+// Snapshot returns a serialized copy of the db, including collections, indexes, and
+// dispatched events, which can be restored with RestoreSnapshot.
+// The copy is read from datastore transactions opened together, so it reflects a single
+// moment even if the db is written while it's being read. This relies on the isolation of
+// the datastore transactions, which Badger provides.
+// The stream must be closed to release the transactions. It fails with the error of ctx
+// if ctx is done before it's read.
+func (d *DB) Snapshot(ctx context.Context, opts ...Option) (io.ReadCloser, error) {
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		return nil, err
+	}
+	sources, header, err := d.openSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			for _, s := range sources {
+				s.txn.Discard()
+			}
+		}()
+		_ = w.CloseWithError(writeSnapshot(ctx, w, header, sources))
+	}()
+	go func() {
+		// Unblock the writer if ctx is done while the stream isn't read.
+		select {
+		case <-ctx.Done():
+			_ = w.CloseWithError(ctx.Err())
+		case <-done:
+		}
+	}()
+	return r, nil
+}
+
+// openSnapshot opens transactions on the db datastore and the ranges of bound datastores
+// holding collections, while no writes are made.
+func (d *DB) openSnapshot() ([]snapshotSource, snapshotHeader, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	d.txnlock.RLock()
+	defer d.txnlock.RUnlock()
+
+	header := snapshotHeader{Version: snapshotVersion, Name: d.name}
+	ranges := []snapshotRange{{Prefix: "/"}}
+	stores := map[string]kt.TxnDatastoreExtended{"": d.datastore}
+	for _, c := range d.collections {
+		if c.datastoreName == "" {
+			continue
+		}
+		stores[c.datastoreName] = c.store
+		ranges = append(ranges,
+			snapshotRange{Store: c.datastoreName, Prefix: c.baseKey().String()},
+			snapshotRange{Store: c.datastoreName, Prefix: indexPrefix.Child(c.baseKey()).String()})
+	}
+
+	txns := make(map[string]ds.Txn, len(stores))
+	discard := func() {
+		for _, txn := range txns {
+			txn.Discard()
+		}
+	}
+	for name, store := range stores {
+		txn, err := store.NewTransaction(true)
+		if err != nil {
+			discard()
+			return nil, snapshotHeader{}, err
+		}
+		txns[name] = txn
+	}
+	head, err := dispatcherHead(txns[""])
+	if err != nil {
+		discard()
+		return nil, snapshotHeader{}, err
+	}
+	header.Head = head
+
+	header.Ranges = ranges
+	sources := make([]snapshotSource, len(ranges))
+	for i, rng := range ranges {
+		sources[i] = snapshotSource{snapshotRange: rng, txn: txns[rng.Store]}
+	}
+	return sources, header, nil
+}
+
+// dispatcherHead returns the key of the last event in the dispatcher store.
+func dispatcherHead(txn ds.Txn) (string, error) {
+	res, err := txn.Query(query.Query{Prefix: dsDispatcherPrefix.String(), KeysOnly: true})
+	if err != nil {
+		return "", err
+	}
+	defer res.Close()
+	var (
+		head     string
+		headTime int64
+	)
+	for r := range res.Next() {
+		if r.Error != nil {
+			return "", r.Error
+		}
+		k := ds.RawKey(r.Key)
+		if !dsDispatcherPrefix.IsAncestorOf(k) {
+			continue
+		}
+		t, err := strconv.ParseInt(k.List()[len(dsDispatcherPrefix.List())], 10, 64)
+		if err != nil {
+			continue
+		}
+		if head == "" || t > headTime || (t == headTime && r.Key > head) {
+			head, headTime = r.Key, t
+		}
+	}
+	return head, nil
+}
+
+// writeSnapshot encodes the header and the entries of sources to w.
+func writeSnapshot(ctx context.Context, w io.Writer, header snapshotHeader, sources []snapshotSource) error {
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+	for i, s := range sources {
+		if err := forEachEntry(s.txn, s.Prefix, func(e query.Entry) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return enc.Encode(snapshotEntry{Range: i, Key: e.Key, Value: e.Value})
+		}); err != nil {
+			return err
+		}
+	}
+	return enc.Encode(snapshotEntry{End: true})
+}
+
+// forEachEntry calls fn with each entry under prefix.
+func forEachEntry(txn ds.Txn, prefix string, fn func(e query.Entry) error) error {
+	res, err := txn.Query(query.Query{Prefix: prefix})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if prefix != "/" && !strings.HasPrefix(r.Key, prefix+"/") {
+			continue
+		}
+		if err := fn(r.Entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreSnapshot writes a snapshot returned by DB.Snapshot to store, which can then be opened
+// with NewDB to get a db with the same collections, instances, and dispatched events.
+// Collections bound to other datastores are restored to the datastores registered with
+// WithNewDatastore under the same names.
+// The restored key ranges must be empty, so a snapshot can't be restored over an existing db.
+// Entries are written in batches, so a failed restore may leave some of them behind.
+func RestoreSnapshot(store kt.TxnDatastoreExtended, r io.Reader, opts ...NewOption) error {
+	args := &NewOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	dec := gob.NewDecoder(r)
+	var header snapshotHeader
+	if err := dec.Decode(&header); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+	}
+	if header.Version != snapshotVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, header.Version)
+	}
+	stores := make([]kt.TxnDatastoreExtended, len(header.Ranges))
+	for i, rng := range header.Ranges {
+		if rng.Store == "" {
+			stores[i] = store
+		} else if s, ok := args.Datastores[rng.Store]; ok {
+			stores[i] = s
+		} else {
+			return fmt.Errorf("%w: %s", ErrDatastoreNotFound, rng.Store)
+		}
+		if err := checkEmptyRange(stores[i], rng.Prefix); err != nil {
+			return err
+		}
+	}
+
+	txns := make(map[kt.TxnDatastoreExtended]ds.Txn)
+	discard := func() {
+		for _, txn := range txns {
+			txn.Discard()
+		}
+	}
+	defer discard()
+	commit := func() error {
+		for s, txn := range txns {
+			if err := txn.Commit(); err != nil {
+				return err
+			}
+			delete(txns, s)
+		}
+		return nil
+	}
+	var pending int
+	for {
+		var e snapshotEntry
+		if err := dec.Decode(&e); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidSnapshot, err)
+		}
+		if e.End {
+			return commit()
+		}
+		if e.Range < 0 || e.Range >= len(stores) {
+			return fmt.Errorf("%w: unknown range %d", ErrInvalidSnapshot, e.Range)
+		}
+		s := stores[e.Range]
+		txn, ok := txns[s]
+		if !ok {
+			var err error
+			if txn, err = s.NewTransaction(false); err != nil {
+				return err
+			}
+			txns[s] = txn
+		}
+		if err := txn.Put(ds.NewKey(e.Key), e.Value); err != nil {
+			return err
+		}
+		if pending++; pending == restoreBatchSize {
+			if err := commit(); err != nil {
+				return err
+			}
+			pending = 0
+		}
+	}
+}
+
+// checkEmptyRange returns ErrSnapshotTargetNotEmpty if store has entries under prefix.
+func checkEmptyRange(store kt.TxnDatastoreExtended, prefix string) error {
+	txn, err := store.NewTransaction(true)
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	return forEachEntry(txn, prefix, func(query.Entry) error {
+		return ErrSnapshotTargetNotEmpty
+	})
+}
@@ -0,0 +1,303 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// AggOp is an aggregation operation computed over the instances in a time bucket.
+type AggOp int
+
+const (
+	// AggCount counts instances in a bucket.
+	AggCount AggOp = iota
+	// AggSum sums a numeric field.
+	AggSum
+	// AggAvg averages a numeric field.
+	AggAvg
+	// AggMin finds the minimum of a numeric field.
+	AggMin
+	// AggMax finds the maximum of a numeric field.
+	AggMax
+)
+
+func (op AggOp) String() (s string) {
+	switch op {
+	case AggCount:
+		s = "count"
+	case AggSum:
+		s = "sum"
+	case AggAvg:
+		s = "avg"
+	case AggMin:
+		s = "min"
+	case AggMax:
+		s = "max"
+	}
+	return s
+}
+
+var (
+	// ErrInvalidTimeSeriesInterval indicates a non-positive time series interval.
+	ErrInvalidTimeSeriesInterval = errors.New("time series interval must be positive")
+	// ErrInvalidAggSpec indicates an invalid aggregation spec.
+	ErrInvalidAggSpec = errors.New("invalid aggregation spec")
+	// ErrAggFieldNotNumeric indicates an aggregation over a non-numeric field.
+	ErrAggFieldNotNumeric = errors.New("aggregated field must be a number")
+)
+
+// AggSpec describes an aggregate computed for each time bucket.
+type AggSpec struct {
+	// Op is the aggregation operation.
+	Op AggOp
+	// FieldPath is the path of the aggregated field. Not used by AggCount.
+	FieldPath string
+	// Name is the key of the aggregate in TimeBucket.Values.
+	// Defaults to "<op>" for AggCount and "<op>(<path>)" for other operations.
+	Name string
+}
+
+func (s AggSpec) name() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	if s.Op == AggCount {
+		return s.Op.String()
+	}
+	return fmt.Sprintf("%s(%s)", s.Op, s.FieldPath)
+}
+
+func (s AggSpec) validate() error {
+	switch s.Op {
+	case AggCount:
+		return nil
+	case AggSum, AggAvg, AggMin, AggMax:
+		if s.FieldPath == "" {
+			return fmt.Errorf("%w: %s requires a field path", ErrInvalidAggSpec, s.Op)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown operation %d", ErrInvalidAggSpec, s.Op)
+	}
+}
+
+// TimeAlignment controls where time series buckets start.
+// The zero value aligns buckets in UTC.
+type TimeAlignment struct {
+	// Location is the time zone used to align buckets and report bucket starts.
+	// Defaults to UTC.
+	Location *time.Location
+	// Origin aligns buckets to start at Origin plus a multiple of the interval.
+	// By default, buckets of whole days start at midnight, and other buckets are
+	// aligned to the unix epoch.
+	Origin time.Time
+}
+
+// TimeBucket holds the aggregates of instances falling in a time interval.
+type TimeBucket struct {
+	// Start is the inclusive start of the bucket.
+	Start time.Time
+	// Count is the number of instances in the bucket.
+	Count int
+	// Values maps aggregate names to their values.
+	// Aggregates over fields missing from every instance in the bucket are omitted.
+	Values map[string]float64
+}
+
+type aggState struct {
+	n   int
+	sum float64
+	min float64
+	max float64
+}
+
+// TimeSeries buckets instances matching q by timeField into intervals, and computes
+// aggs for each bucket. Buckets without instances are omitted and the series is
+// ordered by bucket start.
+// The time field must hold an RFC 3339 string, or an integer number of unix nanoseconds
+// like _mod. Instances where the time field is missing or holds anything else are skipped.
+// If the collection has an index on timeField and q only restricts timeField,
+// the index is used to find matching instances.
+func (t *Txn) TimeSeries(
+	timeField string,
+	interval time.Duration,
+	aggs []AggSpec,
+	q *Query,
+	align TimeAlignment,
+) ([]TimeBucket, error) {
+	if align.Location == nil {
+		align.Location = time.UTC
+	}
+	if interval <= 0 {
+		return nil, ErrInvalidTimeSeriesInterval
+	}
+	for _, a := range aggs {
+		if err := a.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	var sq Query
+	if q != nil {
+		sq = *q
+	}
+	if _, ok := t.collection.indexes[timeField]; ok && sq.Index == "" && queryOnlyOn(&sq, timeField) {
+		sq.Index = timeField
+	}
+	res, err := t.Find(&sq)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int)
+	states := make(map[int64][]aggState)
+	for _, data := range res {
+		instance := make(map[string]interface{})
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&instance); err != nil {
+			return nil, err
+		}
+		tv, err := traverseFieldPathMap(instance, timeField)
+		if err != nil || !tv.IsValid() {
+			continue
+		}
+		ts, ok := parseTimeField(tv.Interface())
+		if !ok {
+			continue
+		}
+		start := bucketStart(ts, interval, align.Location, align.Origin).UnixNano()
+		counts[start]++
+		s, ok := states[start]
+		if !ok {
+			s = make([]aggState, len(aggs))
+			states[start] = s
+		}
+		for i, a := range aggs {
+			if a.Op == AggCount {
+				continue
+			}
+			fv, err := traverseFieldPathMap(instance, a.FieldPath)
+			if err != nil || !fv.IsValid() {
+				continue
+			}
+			n, ok := fv.Interface().(json.Number)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrAggFieldNotNumeric, a.FieldPath)
+			}
+			f, err := n.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrAggFieldNotNumeric, a.FieldPath)
+			}
+			if s[i].n == 0 {
+				s[i].min, s[i].max = f, f
+			} else {
+				s[i].min, s[i].max = math.Min(s[i].min, f), math.Max(s[i].max, f)
+			}
+			s[i].n++
+			s[i].sum += f
+		}
+	}
+
+	starts := make([]int64, 0, len(counts))
+	for start := range counts {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	series := make([]TimeBucket, len(starts))
+	for i, start := range starts {
+		b := TimeBucket{
+			Start:  time.Unix(0, start).In(align.Location),
+			Count:  counts[start],
+			Values: make(map[string]float64),
+		}
+		for j, a := range aggs {
+			s := states[start][j]
+			switch {
+			case a.Op == AggCount:
+				b.Values[a.name()] = float64(b.Count)
+			case s.n == 0:
+				continue
+			case a.Op == AggSum:
+				b.Values[a.name()] = s.sum
+			case a.Op == AggAvg:
+				b.Values[a.name()] = s.sum / float64(s.n)
+			case a.Op == AggMin:
+				b.Values[a.name()] = s.min
+			case a.Op == AggMax:
+				b.Values[a.name()] = s.max
+			}
+		}
+		series[i] = b
+	}
+	return series, nil
+}
+
+// bucketStart returns the start of the interval containing t.
+// Without an origin, intervals that are a whole number of days are aligned to
+// calendar days in loc, so daily buckets start at local midnight regardless of
+// daylight saving changes. Other intervals are aligned to origin, which defaults
+// to the unix epoch in loc.
+func bucketStart(t time.Time, interval time.Duration, loc *time.Location, origin time.Time) time.Time {
+	t = t.In(loc)
+	const day = 24 * time.Hour
+	if origin.IsZero() && interval%day == 0 {
+		days := int64(interval / day)
+		n := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / int64(day/time.Second)
+		n = floorDiv(n, days) * days
+		return time.Date(1970, 1, 1+int(n), 0, 0, 0, 0, loc)
+	}
+	if origin.IsZero() {
+		origin = time.Date(1970, 1, 1, 0, 0, 0, 0, loc)
+	}
+	k := floorDiv(int64(t.Sub(origin)), int64(interval))
+	return origin.Add(time.Duration(k) * interval).In(loc)
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// parseTimeField parses an RFC 3339 string or an integer number of unix nanoseconds.
+func parseTimeField(v interface{}) (time.Time, bool) {
+	switch tv := v.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, tv)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	case json.Number:
+		n, err := tv.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(0, n), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// queryOnlyOn returns whether q only has criteria on field.
+func queryOnlyOn(q *Query, field string) bool {
+	for _, a := range q.Ands {
+		if a.FieldPath != field {
+			return false
+		}
+	}
+	for _, o := range q.Ors {
+		if !queryOnlyOn(o, field) {
+			return false
+		}
+	}
+	return q.Sort.FieldPath == ""
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+type reading struct {
+	ID    core.InstanceID `json:"_id"`
+	Time  string
+	Value float64
+}
+
+// eventSchema leaves Time untyped so instances can hold any kind of time value.
+const eventSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"type": "object",
+	"required": ["_id", "Value"],
+	"properties": {
+		"_id": {"type": "string"},
+		"Value": {"type": "number"}
+	}
+}`
+
+func TestTimeSeries(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Reading",
+		Schema:  util.SchemaFromInstance(&reading{}, false),
+		Indexes: []Index{{Path: "Time"}},
+	})
+	checkErr(t, err)
+	for _, r := range []reading{
+		{Time: "2020-01-01T00:10:00Z", Value: 1},
+		{Time: "2020-01-01T00:50:00Z", Value: 3},
+		{Time: "2020-01-01T02:30:00Z", Value: 5},
+		{Time: "2020-01-02T01:00:00Z", Value: 7},
+	} {
+		_, err := c.Create(util.JSONFromInstance(r))
+		checkErr(t, err)
+	}
+	aggs := []AggSpec{{Op: AggCount}, {Op: AggSum, FieldPath: "Value"}, {Op: AggMax, FieldPath: "Value", Name: "peak"}}
+
+	t.Run("Hourly", func(t *testing.T) {
+		series, err := c.TimeSeries("Time", time.Hour, aggs, nil, TimeAlignment{})
+		checkErr(t, err)
+		if len(series) != 3 {
+			t.Fatalf("expected %d buckets, got %d", 3, len(series))
+		}
+		first := series[0]
+		if !first.Start.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("unexpected first bucket start %v", first.Start)
+		}
+		if first.Count != 2 || first.Values["count"] != 2 || first.Values["sum(Value)"] != 4 || first.Values["peak"] != 3 {
+			t.Fatalf("unexpected first bucket %v", first)
+		}
+	})
+	t.Run("DailyWithQuery", func(t *testing.T) {
+		series, err := c.TimeSeries("Time", 24*time.Hour, aggs, Where("Time").Ge("2020-01-01T00:30:00Z"), TimeAlignment{})
+		checkErr(t, err)
+		if len(series) != 2 {
+			t.Fatalf("expected %d buckets, got %d", 2, len(series))
+		}
+		if series[0].Count != 2 || series[1].Count != 1 {
+			t.Fatalf("unexpected series %v", series)
+		}
+	})
+	t.Run("DailyInLocation", func(t *testing.T) {
+		loc := time.FixedZone("UTC-2", -2*60*60)
+		series, err := c.TimeSeries("Time", 24*time.Hour, aggs, nil, TimeAlignment{Location: loc})
+		checkErr(t, err)
+		if len(series) != 2 {
+			t.Fatalf("expected %d buckets, got %d", 2, len(series))
+		}
+		if !series[0].Start.Equal(time.Date(2019, 12, 31, 0, 0, 0, 0, loc)) || series[0].Count != 2 {
+			t.Fatalf("unexpected first bucket %v", series[0])
+		}
+	})
+	t.Run("Fail/InvalidInterval", func(t *testing.T) {
+		if _, err := c.TimeSeries("Time", 0, aggs, nil, TimeAlignment{}); !errors.Is(err, ErrInvalidTimeSeriesInterval) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidTimeSeriesInterval, err)
+		}
+	})
+	t.Run("Fail/InvalidAggSpec", func(t *testing.T) {
+		if _, err := c.TimeSeries("Time", time.Hour, []AggSpec{{Op: AggSum}}, nil, TimeAlignment{}); !errors.Is(err, ErrInvalidAggSpec) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidAggSpec, err)
+		}
+	})
+}
+
+func TestTimeSeries_TimeFieldTypes(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Event",
+		Schema: util.SchemaFromSchemaString(eventSchema),
+	})
+	checkErr(t, err)
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, e := range []string{
+		`{"_id": "", "Time": "2020-01-01T00:10:00Z", "Value": 1}`,
+		// Nanosecond times beyond float64 precision must land in the right bucket.
+		fmt.Sprintf(`{"_id": "", "Time": %d, "Value": 2}`, base.Add(time.Hour-time.Nanosecond).UnixNano()),
+		fmt.Sprintf(`{"_id": "", "Time": %d, "Value": 4}`, base.Add(time.Hour).UnixNano()),
+		`{"_id": "", "Value": 8}`,
+		`{"_id": "", "Time": null, "Value": 16}`,
+		`{"_id": "", "Time": true, "Value": 32}`,
+		`{"_id": "", "Time": "yesterday", "Value": 64}`,
+		`{"_id": "", "Time": 1.5, "Value": 128}`,
+	} {
+		_, err := c.Create([]byte(e))
+		checkErr(t, err)
+	}
+	series, err := c.TimeSeries("Time", time.Hour, []AggSpec{{Op: AggSum, FieldPath: "Value"}}, nil, TimeAlignment{})
+	checkErr(t, err)
+	if len(series) != 2 {
+		t.Fatalf("expected %d buckets, got %d", 2, len(series))
+	}
+	if series[0].Values["sum(Value)"] != 3 || series[1].Values["sum(Value)"] != 4 {
+		t.Fatalf("unexpected series %v", series)
+	}
+}
+
+func TestBucketStart(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2020, 3, 10, 15, 45, 0, 0, time.UTC)
+	if s := bucketStart(ts, 15*time.Minute, time.UTC, time.Time{}); !s.Equal(ts) {
+		t.Fatalf("expected %v, got %v", ts, s)
+	}
+	if s := bucketStart(ts, 7*24*time.Hour, time.UTC, time.Time{}); !s.Equal(time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected weekly bucket start %v", s)
+	}
+	origin := time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC)
+	if s := bucketStart(ts, time.Hour, time.UTC, origin); !s.Equal(time.Date(2020, 3, 10, 15, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected aligned bucket start %v", s)
+	}
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	ds "github.com/ipfs/go-datastore"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrTrashDisabled indicates a soft-delete or restore in a collection without a trash field.
+	ErrTrashDisabled = errors.New("collection has no trash field")
+	// ErrInvalidTrashField indicates a trash field which isn't a top-level field, or is reserved.
+	ErrInvalidTrashField = errors.New("invalid trash field")
+	// ErrInvalidTrashRetention indicates a negative trash retention.
+	ErrInvalidTrashRetention = errors.New("trash retention must not be negative")
+
+	dsTrash = dsPrefix.ChildString("trash")
+)
+
+// trash describes how the instances of a collection are soft-deleted.
+type trash struct {
+	// Field is the top-level field holding the time an instance was trashed.
+	Field string `json:"field"`
+	// Retention is how long trashed instances are kept before they're deleted.
+	// Zero keeps them until they're deleted or restored.
+	Retention time.Duration `json:"retention,omitempty"`
+}
+
+// newTrash returns the trash of a collection, or nil if its instances can't be soft-deleted.
+func newTrash(field string, retention time.Duration) (*trash, error) {
+	if retention < 0 {
+		return nil, ErrInvalidTrashRetention
+	}
+	if field == "" {
+		if retention != 0 {
+			return nil, fmt.Errorf("%w: retention requires a field", ErrInvalidTrashField)
+		}
+		return nil, nil
+	}
+	if field == idFieldName || field == modFieldName || strings.ContainsAny(field, ".*?") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTrashField, field)
+	}
+	return &trash{Field: field, Retention: retention}, nil
+}
+
+// trashedAt returns the time instance was trashed, or false if it isn't trashed.
+func (t *trash) trashedAt(instance []byte) (time.Time, bool) {
+	if t == nil {
+		return time.Time{}, false
+	}
+	at := gjson.GetBytes(instance, t.Field).Int()
+	if at <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, at), true
+}
+
+// trashed returns whether instance is trashed. It's false for a nil trash.
+func (t *trash) trashed(instance []byte) bool {
+	_, ok := t.trashedAt(instance)
+	return ok
+}
+
+// purgeable returns whether instance was trashed more than the retention before now.
+// It's false for a nil trash, or if trashed instances are retained indefinitely.
+func (t *trash) purgeable(instance []byte, now time.Time) bool {
+	if t == nil || t.Retention == 0 {
+		return false
+	}
+	at, ok := t.trashedAt(instance)
+	return ok && !now.Before(at.Add(t.Retention))
+}
+
+// SoftDelete moves the instances with the given IDs to the trash by setting the trash field
+// of the collection to the current time. Trashed instances are excluded from reads unless
+// queries include them with Query.IncludeTrashed, and can be restored with Restore.
+// The change is saved like any other, so it's sent to other peers.
+// Instances which are already trashed are left as they are.
+func (t *Txn) SoftDelete(ids ...core.InstanceID) error {
+	return t.setTrashed(ids, time.Now().UnixNano())
+}
+
+// Restore moves the instances with the given IDs out of the trash.
+// Instances which aren't trashed are left as they are.
+func (t *Txn) Restore(ids ...core.InstanceID) error {
+	return t.setTrashed(ids, 0)
+}
+
+// setTrashed saves the instances with the given IDs with the trash field set to at, or
+// without it if at is zero.
+func (t *Txn) setTrashed(ids []core.InstanceID, at int64) error {
+	tr := t.collection.trash
+	if tr == nil {
+		return ErrTrashDisabled
+	}
+	var (
+		updated [][]byte
+		patch   = []byte(fmt.Sprintf(`{"%s": null}`, tr.Field))
+	)
+	if at != 0 {
+		patch = []byte(fmt.Sprintf(`{"%s": %d}`, tr.Field, at))
+	}
+	for _, id := range ids {
+		key := baseKey.ChildString(t.collection.name).ChildString(id.String())
+		v, err := t.collection.store.Get(key)
+		if errors.Is(err, ds.ErrNotFound) {
+			return ErrInstanceNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if tr.trashed(v) == (at != 0) {
+			continue
+		}
+		if v, err = jsonpatch.MergePatch(v, patch); err != nil {
+			return err
+		}
+		updated = append(updated, v)
+	}
+	if len(updated) == 0 {
+		return nil
+	}
+	return t.Save(updated...)
+}
@@ -0,0 +1,108 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+type note struct {
+	ID      core.InstanceID `json:"_id"`
+	Mod     int64           `json:"_mod"`
+	Trashed int64           `json:"_trashed,omitempty"`
+	Text    string
+}
+
+func TestSoftDelete(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:           "Note",
+		Schema:         util.SchemaFromInstance(&note{}, false),
+		TrashField:     "_trashed",
+		TrashRetention: time.Hour,
+	})
+	checkErr(t, err)
+	if field, retention := c.GetTrash(); field != "_trashed" || retention != time.Hour {
+		t.Fatalf("expected trash field _trashed with retention 1h, got %s and %v", field, retention)
+	}
+	id, err := c.Create(util.JSONFromInstance(note{Text: "foo"}))
+	checkErr(t, err)
+	_, err = c.Create(util.JSONFromInstance(note{Text: "bar"}))
+	checkErr(t, err)
+
+	checkErr(t, c.SoftDelete(id))
+	if _, err := c.FindByID(id); !errors.Is(err, ErrInstanceNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+	}
+	if ok, err := c.Has(id); err != nil || ok {
+		t.Fatalf("expected trashed instance not to exist, got %v (%v)", ok, err)
+	}
+	res, err := c.Find(&Query{})
+	checkErr(t, err)
+	if len(res) != 1 {
+		t.Fatalf("expected 1 instance, got %d", len(res))
+	}
+	res, err = c.Find((&Query{}).IncludeTrashed())
+	checkErr(t, err)
+	if len(res) != 2 {
+		t.Fatalf("expected 2 instances including the trash, got %d", len(res))
+	}
+	if n, err := c.Count(nil); err != nil || n != 1 {
+		t.Fatalf("expected count of 1, got %d (%v)", n, err)
+	}
+	if n, err := c.Count((&Query{}).IncludeTrashed()); err != nil || n != 2 {
+		t.Fatalf("expected count of 2 including the trash, got %d (%v)", n, err)
+	}
+
+	t.Run("Restore", func(t *testing.T) {
+		checkErr(t, c.Restore(id))
+		v, err := c.FindByID(id)
+		checkErr(t, err)
+		var n note
+		util.InstanceFromJSON(v, &n)
+		if n.Trashed != 0 || n.Text != "foo" {
+			t.Fatalf("expected restored instance, got %+v", n)
+		}
+		// Restoring an instance that isn't trashed does nothing
+		checkErr(t, c.Restore(id))
+	})
+
+	t.Run("Sweep", func(t *testing.T) {
+		checkErr(t, c.SoftDelete(id))
+		if n, err := db.sweepCollection(c, time.Now()); err != nil || n != 0 {
+			t.Fatalf("expected trashed instance to be retained, got %d (%v)", n, err)
+		}
+		if n, err := db.sweepCollection(c, time.Now().Add(2*time.Hour)); err != nil || n != 1 {
+			t.Fatalf("expected trashed instance to be deleted after its retention, got %d (%v)", n, err)
+		}
+		if err := c.Restore(id); !errors.Is(err, ErrInstanceNotFound) {
+			t.Fatalf("expected error %v, got %v", ErrInstanceNotFound, err)
+		}
+	})
+
+	t.Run("Fail/Disabled", func(t *testing.T) {
+		other, err := db.NewCollection(CollectionConfig{
+			Name:   "Other",
+			Schema: util.SchemaFromInstance(&note{}, false),
+		})
+		checkErr(t, err)
+		oid, err := other.Create(util.JSONFromInstance(note{Text: "baz"}))
+		checkErr(t, err)
+		if err := other.SoftDelete(oid); !errors.Is(err, ErrTrashDisabled) {
+			t.Fatalf("expected error %v, got %v", ErrTrashDisabled, err)
+		}
+		_, err = db.NewCollection(CollectionConfig{
+			Name:       "Invalid",
+			Schema:     util.SchemaFromInstance(&note{}, false),
+			TrashField: "_id",
+		})
+		if !errors.Is(err, ErrInvalidTrashField) {
+			t.Fatalf("expected error %v, got %v", ErrInvalidTrashField, err)
+		}
+	})
+}
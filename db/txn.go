@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrDBTxnDone indicates a db transaction was already committed or discarded.
+var ErrDBTxnDone = errors.New("db transaction already committed or discarded")
+
+// ErrReadKeyMismatch indicates a db transaction spans collections encrypted under different read keys.
+var ErrReadKeyMismatch = errors.New("db transaction can't span collections with different read keys")
+
+// ErrDatastoreMismatch indicates a db transaction spans collections bound to different datastores.
+var ErrDatastoreMismatch = errors.New("db transaction can't span collections bound to different datastores")
+
+// DBTxn is a write transaction spanning multiple collections of a db.
+// Changes made through the transactions returned by Collection are committed
+// together as a single net record and dispatcher batch, or not at all.
+// A DBTxn excludes all other write transactions of the db until it's committed or discarded.
+type DBTxn struct {
+	ctx   context.Context
+	db    *DB
+	token thread.Token
+
+	lk    sync.Mutex
+	txns  map[string]*Txn
+	order []*Txn
+	done  bool
+}
+
+// WriteTxn starts a write transaction spanning multiple collections.
+// The transaction must be committed or discarded to release the db.
+func (d *DB) WriteTxn(ctx context.Context, opts ...TxnOption) (*DBTxn, error) {
+	if d.readOnly {
+		return nil, ErrReadOnly
+	}
+	log.Debugf("starting db write txn in %s", d.name)
+	d.metrics.txn("db_write")
+	args := &TxnOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, false); err != nil {
+		return nil, err
+	}
+	d.txnlock.Lock()
+	return &DBTxn{
+		ctx:   ctx,
+		db:    d,
+		token: args.Token,
+		txns:  make(map[string]*Txn),
+	}, nil
+}
+
+// Collection returns the transaction of the collection with name.
+// Its changes are committed with the db transaction, so it can't be committed itself.
+// Collections encrypted under different read keys, or bound to different datastores,
+// can't be used in the same transaction, since their changes couldn't be applied atomically.
+func (t *DBTxn) Collection(name string) (*Txn, error) {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if t.done {
+		return nil, ErrDBTxnDone
+	}
+	if txn, ok := t.txns[name]; ok {
+		return txn, nil
+	}
+	c := t.db.GetCollection(name, WithToken(t.token))
+	if c == nil {
+		return nil, ErrCollectionNotFound
+	}
+	if err := t.db.checkTokenRole(name, t.token, RoleReader); err != nil {
+		return nil, err
+	}
+	if len(t.order) > 0 && t.order[0].collection.readKey != c.readKey {
+		return nil, ErrReadKeyMismatch
+	}
+	if len(t.order) > 0 && t.order[0].collection.datastoreName != c.datastoreName {
+		return nil, ErrDatastoreMismatch
+	}
+	txn := &Txn{collection: c, token: t.token, batched: true}
+	t.txns[name] = txn
+	t.order = append(t.order, txn)
+	return txn, nil
+}
+
+// Commit applies the changes made in all collections.
+// Nothing is applied if the changes of any collection are invalid.
+func (t *DBTxn) Commit() error {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if t.done {
+		return ErrDBTxnDone
+	}
+	defer t.release()
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	var actions []core.Action
+	var sync bool
+	for _, txn := range t.order {
+		if err := txn.checkOpen(); err != nil {
+			return err
+		}
+		actions = append(actions, txn.actions...)
+		sync = sync || txn.sync
+	}
+	for _, txn := range t.order {
+		txn.committed = true
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	batch := &Txn{collection: t.order[0].collection, token: t.token, actions: actions, sync: sync}
+	if err := batch.commit(t.ctx); err != nil {
+		return err
+	}
+	log.Debugf("ending db write txn in %s", t.db.name)
+	return nil
+}
+
+// Discard drops the changes made in all collections.
+// It's a no-op if the transaction was already committed or discarded.
+func (t *DBTxn) Discard() {
+	t.lk.Lock()
+	defer t.lk.Unlock()
+	if !t.done {
+		t.release()
+	}
+}
+
+// release marks the transaction as done and unlocks the db.
+func (t *DBTxn) release() {
+	for _, txn := range t.order {
+		txn.Discard()
+	}
+	t.done = true
+	t.db.txnlock.Unlock()
+}
@@ -0,0 +1,320 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestDBTxn(t *testing.T) {
+	t.Parallel()
+	t.Run("Commit", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		ca, cb := createLedgers(t, db)
+
+		txn, err := db.WriteTxn(context.Background())
+		checkErr(t, err)
+		ta, err := txn.Collection("A")
+		checkErr(t, err)
+		tb, err := txn.Collection("B")
+		checkErr(t, err)
+		ids, err := ta.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 10}))
+		checkErr(t, err)
+		_, err = tb.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 20}))
+		checkErr(t, err)
+		if err := ta.Commit(); !errors.Is(err, errCommitBatchedTxn) {
+			t.Fatalf("expected collection txn commit to fail, got %v", err)
+		}
+		checkErr(t, txn.Commit())
+		if err := txn.Commit(); !errors.Is(err, ErrDBTxnDone) {
+			t.Fatalf("expected second commit to fail, got %v", err)
+		}
+
+		exists, err := ca.Has(ids[0])
+		checkErr(t, err)
+		if !exists {
+			t.Fatal("instance in collection A should exist")
+		}
+		res, err := cb.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 instance in collection B, got %d", len(res))
+		}
+	})
+	t.Run("Rollback", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		ca, cb := createLedgers(t, db)
+
+		txn, err := db.WriteTxn(context.Background())
+		checkErr(t, err)
+		ta, err := txn.Collection("A")
+		checkErr(t, err)
+		tb, err := txn.Collection("B")
+		checkErr(t, err)
+		ids, err := ta.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 10}))
+		checkErr(t, err)
+		if _, err := tb.Create([]byte(`{"Name": 42}`)); err == nil {
+			t.Fatal("invalid instance should fail")
+		}
+		txn.Discard()
+
+		exists, err := ca.Has(ids[0])
+		checkErr(t, err)
+		if exists {
+			t.Fatal("instance in collection A should have been rolled back")
+		}
+		res, err := cb.Find(&Query{})
+		checkErr(t, err)
+		if len(res) != 0 {
+			t.Fatalf("expected no instances in collection B, got %d", len(res))
+		}
+		if _, err := txn.Collection("A"); !errors.Is(err, ErrDBTxnDone) {
+			t.Fatalf("expected discarded txn to fail, got %v", err)
+		}
+	})
+	t.Run("Fail/DatastoreMismatch", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t, WithNewDatastore("eu", NewTxMapDatastore()))
+		defer clean()
+		createLedgers(t, db)
+		_, err := db.NewCollection(CollectionConfig{
+			Name:      "C",
+			Schema:    util.SchemaFromInstance(&Person{}, false),
+			Datastore: "eu",
+		})
+		checkErr(t, err)
+
+		txn, err := db.WriteTxn(context.Background())
+		checkErr(t, err)
+		defer txn.Discard()
+		_, err = txn.Collection("A")
+		checkErr(t, err)
+		if _, err := txn.Collection("C"); !errors.Is(err, ErrDatastoreMismatch) {
+			t.Fatalf("expected error %v, got %v", ErrDatastoreMismatch, err)
+		}
+		_, err = txn.Collection("B")
+		checkErr(t, err)
+	})
+	t.Run("Serializable", func(t *testing.T) {
+		t.Parallel()
+		db, clean := createTestDB(t)
+		defer clean()
+		ca, cb := createLedgers(t, db)
+		ida, err := ca.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 100}))
+		checkErr(t, err)
+		idb, err := cb.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 0}))
+		checkErr(t, err)
+
+		const transfers = 10
+		var wg sync.WaitGroup
+		errs := make(chan error, transfers)
+		for i := 0; i < transfers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- transfer(db, ida, idb, 1)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			checkErr(t, err)
+		}
+
+		a := &Person{}
+		res, err := ca.FindByID(ida)
+		checkErr(t, err)
+		util.InstanceFromJSON(res, a)
+		b := &Person{}
+		res, err = cb.FindByID(idb)
+		checkErr(t, err)
+		util.InstanceFromJSON(res, b)
+		if a.Age != 100-transfers || b.Age != transfers {
+			t.Fatalf("expected balances %d and %d, got %d and %d", 100-transfers, transfers, a.Age, b.Age)
+		}
+	})
+}
+
+func TestTxn_Discard(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	t.Run("CommitAfterDiscard", func(t *testing.T) {
+		head := threadHead(t, db)
+		var ids []core.InstanceID
+		err := c.WriteTxn(func(txn *Txn) error {
+			var err error
+			if ids, err = txn.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30})); err != nil {
+				return err
+			}
+			txn.Discard()
+			if err := txn.Commit(); !errors.Is(err, ErrTxnDiscarded) {
+				t.Fatalf("expected discarded txn error, got %v", err)
+			}
+			return nil
+		})
+		if !errors.Is(err, ErrTxnDiscarded) {
+			t.Fatalf("expected discarded txn error, got %v", err)
+		}
+		exists, err := c.Has(ids[0])
+		checkErr(t, err)
+		if exists {
+			t.Fatal("discarded instance shouldn't exist")
+		}
+		if !threadHead(t, db).Equals(head) {
+			t.Fatal("discarded txn shouldn't create a record")
+		}
+	})
+	t.Run("DiscardAfterCommit", func(t *testing.T) {
+		var ids []core.InstanceID
+		err := c.WriteTxn(func(txn *Txn) error {
+			var err error
+			if ids, err = txn.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 40})); err != nil {
+				return err
+			}
+			if err := txn.Commit(); err != nil {
+				return err
+			}
+			txn.Discard()
+			if err := txn.Commit(); !errors.Is(err, ErrTxnCommitted) {
+				t.Fatalf("expected committed txn error, got %v", err)
+			}
+			return nil
+		})
+		checkErr(t, err)
+		exists, err := c.Has(ids[0])
+		checkErr(t, err)
+		if !exists {
+			t.Fatal("committed instance should exist")
+		}
+	})
+}
+
+func TestTxn_Savepoint(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:   "Person",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+
+	var alice, bob, carol []core.InstanceID
+	err = c.WriteTxn(func(txn *Txn) error {
+		var err error
+		if alice, err = txn.Create(util.JSONFromInstance(Person{Name: "Alice", Age: 30})); err != nil {
+			return err
+		}
+		sp, err := txn.Savepoint()
+		if err != nil {
+			return err
+		}
+		if bob, err = txn.Create(util.JSONFromInstance(Person{Name: "Bob", Age: 40})); err != nil {
+			return err
+		}
+		later, err := txn.Savepoint()
+		if err != nil {
+			return err
+		}
+		if err := txn.RollbackTo(sp); err != nil {
+			return err
+		}
+		if err := txn.RollbackTo(later); !errors.Is(err, ErrInvalidSavepoint) {
+			t.Fatalf("expected released savepoint error, got %v", err)
+		}
+		// Rolled back instances can be created again
+		if carol, err = txn.Create(util.JSONFromInstance(Person{ID: bob[0], Name: "Carol", Age: 50})); err != nil {
+			return err
+		}
+		return nil
+	})
+	checkErr(t, err)
+
+	exists, err := c.Has(alice[0])
+	checkErr(t, err)
+	if !exists {
+		t.Fatal("instance created before the savepoint should exist")
+	}
+	res, err := c.FindByID(carol[0])
+	checkErr(t, err)
+	p := &Person{}
+	util.InstanceFromJSON(res, p)
+	if p.Name != "Carol" {
+		t.Fatalf("expected rolled back instance to be replaced, got %s", p.Name)
+	}
+
+	err = c.ReadTxn(func(txn *Txn) error {
+		_, err := txn.Savepoint()
+		return err
+	})
+	if !errors.Is(err, ErrReadonlyTx) {
+		t.Fatalf("expected read only txn error, got %v", err)
+	}
+}
+
+func createLedgers(t *testing.T, db *DB) (*Collection, *Collection) {
+	ca, err := db.NewCollection(CollectionConfig{
+		Name:   "A",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	cb, err := db.NewCollection(CollectionConfig{
+		Name:   "B",
+		Schema: util.SchemaFromInstance(&Person{}, false),
+	})
+	checkErr(t, err)
+	return ca, cb
+}
+
+// transfer moves amount from the age of instance from in collection A
+// to the age of instance to in collection B.
+func transfer(db *DB, from, to core.InstanceID, amount int) error {
+	txn, err := db.WriteTxn(context.Background())
+	if err != nil {
+		return err
+	}
+	defer txn.Discard()
+	ta, err := txn.Collection("A")
+	if err != nil {
+		return err
+	}
+	tb, err := txn.Collection("B")
+	if err != nil {
+		return err
+	}
+	a, b := &Person{}, &Person{}
+	res, err := ta.FindByID(from)
+	if err != nil {
+		return err
+	}
+	util.InstanceFromJSON(res, a)
+	res, err = tb.FindByID(to)
+	if err != nil {
+		return err
+	}
+	util.InstanceFromJSON(res, b)
+	a.Age -= amount
+	b.Age += amount
+	if err := ta.Save(util.JSONFromInstance(a)); err != nil {
+		return err
+	}
+	if err := tb.Save(util.JSONFromInstance(b)); err != nil {
+		return err
+	}
+	return txn.Commit()
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/tidwall/gjson"
+)
+
+var (
+	// ErrVersionConflict indicates a save of an instance whose version differs from the stored one.
+	ErrVersionConflict = errors.New("instance version conflict")
+	// ErrInvalidVersionField indicates a version field which isn't a top-level field, or is reserved.
+	ErrInvalidVersionField = errors.New("invalid version field")
+
+	dsVersionFields = dsPrefix.ChildString("versionfield")
+)
+
+// validVersionField returns an error if field can't hold instance versions.
+func validVersionField(field string) error {
+	if field == "" {
+		return nil
+	}
+	if field == idFieldName || field == modFieldName || strings.ContainsAny(field, ".*?") {
+		return fmt.Errorf("%w: %q", ErrInvalidVersionField, field)
+	}
+	return nil
+}
+
+// getVersion returns the version of instance, which is zero if it doesn't have one.
+func (c *Collection) getVersion(instance []byte) int64 {
+	return gjson.GetBytes(instance, c.versionField).Int()
+}
+
+// setVersion returns instance with version v.
+func (c *Collection) setVersion(instance []byte, v int64) ([]byte, error) {
+	return jsonpatch.MergePatch(instance, []byte(fmt.Sprintf(`{"%s": %d}`, c.versionField, v)))
+}
+
+// nextVersion checks that the version of next is the current version of its instance, and
+// returns next with the version incremented. The current version is that of the last pending
+// action on the instance, or of stored if there's none.
+func (c *Collection) nextVersion(id core.InstanceID, next, stored []byte, pending ...[]core.Action) ([]byte, error) {
+	current := c.getVersion(stored)
+	found := false
+	for i := len(pending) - 1; i >= 0 && !found; i-- {
+		for j := len(pending[i]) - 1; j >= 0; j-- {
+			a := pending[i][j]
+			if a.InstanceID != id || a.CollectionName != c.name {
+				continue
+			}
+			if a.Type == core.Delete {
+				current = 0
+			} else {
+				current = c.getVersion(a.Current)
+			}
+			found = true
+			break
+		}
+	}
+	if v := c.getVersion(next); v != current {
+		return nil, fmt.Errorf("%w: instance %s is at version %d, not %d", ErrVersionConflict, id, current, v)
+	}
+	return c.setVersion(next, current+1)
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/util"
+)
+
+type versionedPerson struct {
+	ID      core.InstanceID `json:"_id"`
+	Mod     int64           `json:"_mod"`
+	Version int64           `json:"_version"`
+	Name    string
+	Age     int
+}
+
+func TestCollectionVersionField(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:         "Person",
+		Schema:       util.SchemaFromInstance(&versionedPerson{}, false),
+		VersionField: "_version",
+	})
+	checkErr(t, err)
+	if c.GetVersionField() != "_version" {
+		t.Fatalf("expected version field _version, got %s", c.GetVersionField())
+	}
+
+	find := func(id core.InstanceID) versionedPerson {
+		t.Helper()
+		data, err := c.FindByID(id)
+		checkErr(t, err)
+		var p versionedPerson
+		checkErr(t, json.Unmarshal(data, &p))
+		return p
+	}
+
+	id, err := c.Create(util.JSONFromInstance(versionedPerson{Name: "Alice", Age: 42}))
+	checkErr(t, err)
+	p := find(id)
+	if p.Version != 1 {
+		t.Fatalf("expected version 1 after create, got %d", p.Version)
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		p.Age = 43
+		checkErr(t, c.Save(util.JSONFromInstance(p)))
+		if v := find(id).Version; v != 2 {
+			t.Fatalf("expected version 2 after save, got %d", v)
+		}
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		stale := p // Still at version 1
+		stale.Age = 44
+		if err := c.Save(util.JSONFromInstance(stale)); !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("expected error %v, got %v", ErrVersionConflict, err)
+		}
+		current := find(id)
+		if current.Age != 43 || current.Version != 2 {
+			t.Fatalf("expected conflicting save not to be applied, got %+v", current)
+		}
+	})
+
+	t.Run("SameTxn", func(t *testing.T) {
+		current := find(id)
+		err := c.WriteTxn(func(txn *Txn) error {
+			current.Age = 45
+			if err := txn.Save(util.JSONFromInstance(current)); err != nil {
+				return err
+			}
+			current.Version++
+			current.Age = 46
+			return txn.Save(util.JSONFromInstance(current))
+		})
+		checkErr(t, err)
+		if current := find(id); current.Age != 46 || current.Version != 4 {
+			t.Fatalf("expected saves of one txn to be applied in turn, got %+v", current)
+		}
+	})
+
+	_, err = db.NewCollection(CollectionConfig{
+		Name:         "Invalid",
+		Schema:       util.SchemaFromInstance(&versionedPerson{}, false),
+		VersionField: "_mod",
+	})
+	if !errors.Is(err, ErrInvalidVersionField) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidVersionField, err)
+	}
+}
@@ -0,0 +1,309 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/alecthomas/jsonschema"
+	"github.com/ipfs/go-cid"
+	threadcbor "github.com/textileio/go-threads/cbor"
+	"github.com/textileio/go-threads/core/app"
+	core "github.com/textileio/go-threads/core/db"
+	"github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrViewHeadNotFound indicates the head of a view isn't a record of any log of the db thread.
+var ErrViewHeadNotFound = errors.New("record not found in db thread")
+
+// ReadOnlyDB is a read-only copy of the collections of a db at a past record.
+// See DB.ViewAt.
+type ReadOnlyDB struct {
+	db *DB
+}
+
+// ReadOnlyCollection is a read-only collection of a ReadOnlyDB.
+type ReadOnlyCollection struct {
+	c *Collection
+}
+
+// viewEvent is an event replayed in a view, with its position in the thread.
+type viewEvent struct {
+	time  int64
+	log   string
+	seq   int
+	event core.Event
+}
+
+// ViewAt returns the collections of the db as they were when the record head was added.
+// Events of the thread are replayed into an in-memory store, which is discarded with the view.
+// The events of head and of the records preceding it in its log are replayed. Records of other
+// logs are replayed up to the last one whose events are not newer than the events of head.
+// Events are replayed in order of time, so the view only depends on the records of the thread,
+// and not on the order in which they were received.
+// Collections are replayed with their current config. Events of deleted collections are skipped.
+// Records whose events were compacted can't be replayed.
+func (d *DB) ViewAt(ctx context.Context, head cid.Cid, opts ...Option) (*ReadOnlyDB, error) {
+	log.Debugf("viewing %s at %s", d.name, head)
+	args := &Options{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if err := d.connector.Validate(args.Token, true); err != nil {
+		return nil, err
+	}
+	info, err := d.connector.Net.GetThread(ctx, d.connector.ThreadID(), net.WithThreadToken(args.Token))
+	if err != nil {
+		return nil, err
+	}
+	if !info.Key.CanRead() {
+		return nil, ErrThreadReadKeyRequired
+	}
+
+	logs := make(map[string][][]viewEvent, len(info.Logs))
+	var (
+		headLog  string
+		headTime int64
+		found    bool
+	)
+	for _, lg := range info.Logs {
+		recs, err := d.viewLogEvents(ctx, info, lg, args.Token)
+		if err != nil {
+			return nil, err
+		}
+		for i, rec := range recs {
+			if rec.cid.Equals(head) {
+				headLog = lg.ID.String()
+				headTime = rec.time
+				found = true
+				recs = recs[i:]
+				break
+			}
+		}
+		events := make([][]viewEvent, len(recs))
+		for i, rec := range recs {
+			events[i] = rec.events
+		}
+		logs[lg.ID.String()] = events
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrViewHeadNotFound, head)
+	}
+
+	var events []viewEvent
+	for lid, recs := range logs {
+		if lid != headLog {
+			// Skip the newest records, whose events follow head
+			for len(recs) > 0 && recordTime(recs[0]) > headTime {
+				recs = recs[1:]
+			}
+		}
+		for _, rec := range recs {
+			events = append(events, rec...)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].time != events[j].time {
+			return events[i].time < events[j].time
+		}
+		if events[i].log != events[j].log {
+			return events[i].log < events[j].log
+		}
+		return events[i].seq < events[j].seq
+	})
+
+	view, err := d.newView()
+	if err != nil {
+		return nil, err
+	}
+	replay := make([]core.Event, 0, len(events))
+	for _, e := range events {
+		if _, ok := view.collections[e.event.Collection()]; ok {
+			replay = append(replay, e.event)
+		}
+	}
+	if len(replay) > 0 {
+		if err := view.Reduce(replay); err != nil {
+			return nil, err
+		}
+	}
+	return &ReadOnlyDB{db: view}, nil
+}
+
+// viewRecord holds the events of a record.
+type viewRecord struct {
+	cid    cid.Cid
+	time   int64
+	events []viewEvent
+}
+
+// viewLogEvents returns the records of a log with their events, from newest to oldest.
+func (d *DB) viewLogEvents(ctx context.Context, info thread.Info, lg thread.LogInfo, token thread.Token) ([]viewRecord, error) {
+	// Records created before a key rotation are encrypted under a previous read key
+	keys, err := d.connector.Net.ReadKeys(ctx, info.ID, net.WithThreadToken(token))
+	if err != nil {
+		return nil, err
+	}
+	var recs []viewRecord
+	for cursor := lg.Head.ID; cursor.Defined(); {
+		rec, err := d.connector.Net.GetRecord(ctx, info.ID, cursor, net.WithThreadToken(token))
+		if err != nil {
+			return nil, err
+		}
+		event, err := threadcbor.EventFromRecord(ctx, d.connector.Net, rec)
+		if err != nil {
+			return nil, fmt.Errorf("getting event of record %s: %w", cursor, err)
+		}
+		body, _, err := event.GetBodyWithKeys(ctx, d.connector.Net, keys)
+		if err != nil {
+			return nil, fmt.Errorf("getting body of record %s: %w", cursor, err)
+		}
+		if _, ok := threadcbor.KeyRotationFromNode(body); ok {
+			cursor = rec.PrevID()
+			continue
+		}
+		events, err := d.eventcodec.EventsFromBytes(body.RawData())
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, viewRecord{cid: cursor, events: make([]viewEvent, len(events))})
+		r := &recs[len(recs)-1]
+		for i, e := range events {
+			t, err := eventTime(e)
+			if err != nil {
+				return nil, err
+			}
+			r.events[i] = viewEvent{time: t, log: lg.ID.String(), event: e}
+			if i == 0 || t > r.time {
+				r.time = t
+			}
+		}
+		cursor = rec.PrevID()
+	}
+	// Number events in log order
+	var seq int
+	for i := len(recs) - 1; i >= 0; i-- {
+		for j := range recs[i].events {
+			recs[i].events[j].seq = seq
+			seq++
+		}
+	}
+	return recs, nil
+}
+
+// recordTime returns the time of the newest event of a record.
+func recordTime(events []viewEvent) int64 {
+	var t int64
+	for i, e := range events {
+		if i == 0 || e.time > t {
+			t = e.time
+		}
+	}
+	return t
+}
+
+// eventTime decodes the time of an event.
+func eventTime(e core.Event) (int64, error) {
+	var t int64
+	if err := binary.Read(bytes.NewBuffer(e.Time()), binary.BigEndian, &t); err != nil {
+		return 0, fmt.Errorf("decoding event time: %w", err)
+	}
+	return t, nil
+}
+
+// newView returns a db holding the current collections and indexes in an in-memory store.
+// Its collections are empty.
+func (d *DB) newView() (*DB, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	view := &DB{
+		name:                d.name,
+		connector:           d.connector,
+		view:                true,
+		datastore:           NewTxMapDatastore(),
+		schemaDocs:          d.schemaDocs,
+		acl:                 d.acl,
+		codecs:              d.codecs,
+		middleware:          d.middleware,
+		collections:         make(map[string]*Collection, len(d.collections)),
+		localEventsBus:      app.NewLocalEventsBus(),
+		stateChangedNotifee: newStateChangedNotifee(),
+	}
+	view.eventcodec = &eventCodecs{db: view}
+	for name, c := range d.collections {
+		schema := &jsonschema.Schema{}
+		if err := json.Unmarshal(c.GetSchema(), schema); err != nil {
+			return nil, err
+		}
+		vc, err := newCollection(view, CollectionConfig{
+			Name:            name,
+			Schema:          schema,
+			WriteValidator:  string(c.rawWriteValidator),
+			ReadFilter:      string(c.rawReadFilter),
+			ReportConflicts: c.reportConflicts,
+			EventCodec:      c.eventCodec,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for n, index := range c.indexes {
+			vc.indexes[n] = index
+		}
+		view.collections[name] = vc
+	}
+	return view, nil
+}
+
+// Collection returns a read-only collection by name.
+func (v *ReadOnlyDB) Collection(name string) (*ReadOnlyCollection, error) {
+	v.db.lock.RLock()
+	defer v.db.lock.RUnlock()
+	c, ok := v.db.collections[name]
+	if !ok {
+		return nil, ErrCollectionNotFound
+	}
+	return &ReadOnlyCollection{c: c}, nil
+}
+
+// ListCollections returns the names of all collections.
+func (v *ReadOnlyDB) ListCollections() []string {
+	v.db.lock.RLock()
+	defer v.db.lock.RUnlock()
+	names := make([]string, 0, len(v.db.collections))
+	for name := range v.db.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetName returns the collection name.
+func (c *ReadOnlyCollection) GetName() string {
+	return c.c.GetName()
+}
+
+// FindByID finds an instance by its ID.
+// If doesn't exists returns ErrInstanceNotFound.
+func (c *ReadOnlyCollection) FindByID(id core.InstanceID, opts ...TxnOption) ([]byte, error) {
+	return c.c.FindByID(id, opts...)
+}
+
+// Has returns true if ID exists in the collection, false otherwise.
+func (c *ReadOnlyCollection) Has(id core.InstanceID, opts ...TxnOption) (bool, error) {
+	return c.c.Has(id, opts...)
+}
+
+// Find executes a Query and returns the result.
+func (c *ReadOnlyCollection) Find(q *Query, opts ...TxnOption) ([][]byte, error) {
+	return c.c.Find(q, opts...)
+}
+
+// Count returns the number of instances matching a Query.
+func (c *ReadOnlyCollection) Count(q *Query, opts ...TxnOption) (int, error) {
+	return c.c.Count(q, opts...)
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/textileio/go-threads/util"
+)
+
+func TestDB_ViewAt(t *testing.T) {
+	t.Parallel()
+	db, clean := createTestDB(t)
+	defer clean()
+	c, err := db.NewCollection(CollectionConfig{
+		Name:    "Person",
+		Schema:  util.SchemaFromInstance(&Person{}, false),
+		Indexes: []Index{{Path: "Age"}},
+	})
+	checkErr(t, err)
+
+	ctx := context.Background()
+	p := &Person{Name: "Alice", Age: 30}
+	id, err := c.Create(util.JSONFromInstance(p))
+	checkErr(t, err)
+	p.ID = id
+	created := threadHead(t, db)
+	p.Age = 31
+	checkErr(t, c.Save(util.JSONFromInstance(p)))
+	saved := threadHead(t, db)
+	checkErr(t, c.Delete(id))
+
+	t.Run("Created", func(t *testing.T) {
+		view, err := db.ViewAt(ctx, created)
+		checkErr(t, err)
+		checkViewAge(t, view, p, 30)
+	})
+	t.Run("Saved", func(t *testing.T) {
+		view, err := db.ViewAt(ctx, saved)
+		checkErr(t, err)
+		checkViewAge(t, view, p, 31)
+		vc, err := view.Collection("Person")
+		checkErr(t, err)
+		n, err := vc.Count(&Query{})
+		checkErr(t, err)
+		if n != 1 {
+			t.Fatalf("expected 1 instance, got %d", n)
+		}
+		res, err := vc.Find(Where("Age").Eq(31.0).UseIndex("Age"))
+		checkErr(t, err)
+		if len(res) != 1 {
+			t.Fatalf("expected 1 indexed instance, got %d", len(res))
+		}
+	})
+	t.Run("LiveUnchanged", func(t *testing.T) {
+		_, err := db.ViewAt(ctx, created)
+		checkErr(t, err)
+		exists, err := c.Has(id)
+		checkErr(t, err)
+		if exists {
+			t.Fatal("view shouldn't change the live collection")
+		}
+	})
+	t.Run("UnknownHead", func(t *testing.T) {
+		unknown, err := cid.Decode("bafkqaaa")
+		checkErr(t, err)
+		if _, err := db.ViewAt(ctx, unknown); !errors.Is(err, ErrViewHeadNotFound) {
+			t.Fatalf("expected head not found, got %v", err)
+		}
+	})
+}
+
+// threadHead returns the head of the only log of the db thread.
+func threadHead(t *testing.T, db *DB) cid.Cid {
+	info, err := db.connector.Net.GetThread(context.Background(), db.connector.ThreadID())
+	checkErr(t, err)
+	if len(info.Logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(info.Logs))
+	}
+	return info.Logs[0].Head.ID
+}
+
+func checkViewAge(t *testing.T, view *ReadOnlyDB, p *Person, age int) {
+	t.Helper()
+	vc, err := view.Collection("Person")
+	checkErr(t, err)
+	res, err := vc.FindByID(p.ID)
+	checkErr(t, err)
+	got := &Person{}
+	util.InstanceFromJSON(res, got)
+	if got.Name != p.Name || got.Age != age {
+		t.Fatalf("expected %s aged %d, got %s aged %d", p.Name, age, got.Name, got.Age)
+	}
+}
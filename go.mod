@@ -33,6 +33,7 @@ require (
 	github.com/ipfs/go-log/v2 v2.3.0
 	github.com/ipfs/go-merkledag v0.3.2
 	github.com/libp2p/go-libp2p v0.14.4
+	github.com/libp2p/go-libp2p-circuit v0.4.0
 	github.com/libp2p/go-libp2p-connmgr v0.2.4
 	github.com/libp2p/go-libp2p-core v0.8.6
 	github.com/libp2p/go-libp2p-gostream v0.3.1
@@ -45,6 +46,7 @@ require (
 	github.com/namsral/flag v1.7.4-pre
 	github.com/oklog/ulid/v2 v2.0.2
 	github.com/phayes/freeport v0.0.0-20180830031419-95f893ade6f2
+	github.com/prometheus/client_golang v1.11.0
 	github.com/rs/cors v1.7.0 // indirect
 	github.com/textileio/crypto v0.0.0-20210928200545-9b5a55171e1b
 	github.com/textileio/go-datastore-extensions v1.0.1
@@ -3,10 +3,13 @@ package jsonpatcher
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/gob"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -18,6 +21,10 @@ import (
 	core "github.com/textileio/go-threads/core/db"
 )
 
+// modFieldName is the instance field holding the last modification time,
+// which is set by the db on every write.
+const modFieldName = "_mod"
+
 type operationType int
 
 const (
@@ -48,21 +55,53 @@ type operation struct {
 	Type       operationType
 	InstanceID core.InstanceID
 	JSONPatch  []byte
+	// Base is a merge patch holding the previous values of the fields changed by JSONPatch.
+	Base []byte `refmt:",omitempty"`
 }
 
-type jsonPatcher struct{}
+// ConflictResolver returns the value of a field which was changed by a save, and by another
+// writer after the save was made. local is the value written by the other writer, which was
+// reduced first, and remote is the value written by the save. Either is nil if the field was
+// removed, and returning nil removes the field. path is dot-separated, like "Meta.City".
+type ConflictResolver func(path string, local, remote json.RawMessage) json.RawMessage
+
+type jsonPatcher struct {
+	resolver ConflictResolver
+}
 
 var _ core.EventCodec = (*jsonPatcher)(nil)
 
+// Option configures a JSON-Patcher EventCodec.
+type Option func(*jsonPatcher)
+
+// WithConflictResolver resolves conflicting saves with r.
+// Saves only hold the fields they change, so saves of different fields of an instance are
+// always merged. Without a resolver, the save which is reduced last wins fields changed by
+// both. Conflicts are found with the previous values of fields held by saves, which are
+// recorded by codecs with a resolver, and in collections which report conflicts. Saves without
+// them are applied as is, e.g., those created by peers without a resolver.
+// r must be deterministic, so that peers reducing the same saves end up with the same instance.
+func WithConflictResolver(r ConflictResolver) Option {
+	return func(jp *jsonPatcher) {
+		jp.resolver = r
+	}
+}
+
 func init() {
 	cbornode.RegisterCborType(patchEvent{})
 	cbornode.RegisterCborType(recordEvents{})
 	cbornode.RegisterCborType(operation{})
+	// Events are gob-encoded with the name of their codec, see db.codecEvent.
+	gob.Register(patchEvent{})
 }
 
 // New returns a JSON-Patcher EventCodec
-func New() core.EventCodec {
-	return &jsonPatcher{}
+func New(opts ...Option) core.EventCodec {
+	jp := &jsonPatcher{}
+	for _, opt := range opts {
+		opt(jp)
+	}
+	return jp
 }
 
 func (jp *jsonPatcher) Create(actions []core.Action) ([]core.Event, format.Node, error) {
@@ -78,7 +117,8 @@ func (jp *jsonPatcher) Create(actions []core.Action) ([]core.Event, format.Node,
 		case core.Create:
 			op, err = createEvent(actions[i].InstanceID, actions[i].Current)
 		case core.Save:
-			op, err = saveEvent(actions[i].InstanceID, actions[i].Previous, actions[i].Current)
+			withBase := actions[i].DetectConflicts || jp.resolver != nil
+			op, err = saveEvent(actions[i].InstanceID, actions[i].Previous, actions[i].Current, withBase)
 		case core.Delete:
 			op, err = deleteEvent(actions[i].InstanceID)
 		default:
@@ -108,6 +148,7 @@ func (jp *jsonPatcher) Reduce(
 	store ds.TxnDatastore,
 	baseKey ds.Key,
 	indexFunc core.IndexFunc,
+	conflictFunc core.ConflictFunc,
 ) ([]core.ReduceAction, error) {
 	txn, err := store.NewTransaction(false)
 	if err != nil {
@@ -161,6 +202,12 @@ func (jp *jsonPatcher) Reduce(
 			if err != nil {
 				return nil, fmt.Errorf("error when reducing save event: %w", err)
 			}
+			if jp.resolver != nil && je.Patch.Base != nil {
+				patchedValue, err = resolveConflicts(jp.resolver, value, patchedValue, je.Patch.JSONPatch, je.Patch.Base)
+				if err != nil {
+					return nil, fmt.Errorf("error when resolving conflicts: %w", err)
+				}
+			}
 			if err = txn.Put(key, patchedValue); err != nil {
 				return nil, err
 			}
@@ -168,10 +215,21 @@ func (jp *jsonPatcher) Reduce(
 				return nil, fmt.Errorf("error when indexing created data: %w", err)
 			}
 			actions[i] = core.ReduceAction{Type: core.Save, Collection: e.Collection(), InstanceID: e.InstanceID()}
+			if conflictFunc != nil && conflictFunc(e.Collection()) && je.Patch.Base != nil {
+				actions[i].Conflicts, err = detectConflicts(value, patchedValue, je.Patch.JSONPatch, je.Patch.Base)
+				if err != nil {
+					return nil, fmt.Errorf("error when detecting conflicts: %w", err)
+				}
+			}
 			log.Debug("\tsave operation applied")
 		case del:
 			value, err := txn.Get(key)
-			if err != nil {
+			if errors.Is(err, ds.ErrNotFound) {
+				// the instance was created before the records of a partially pulled thread
+				actions[i] = core.ReduceAction{Type: core.Delete, Collection: e.Collection(), InstanceID: e.InstanceID()}
+				log.Debug("\tdelete operation of missing instance ignored")
+				continue
+			} else if err != nil {
 				return nil, err
 			}
 			if err := txn.Delete(key); err != nil {
@@ -193,6 +251,174 @@ func (jp *jsonPatcher) Reduce(
 	return actions, nil
 }
 
+// detectConflicts returns the fields changed by patch that were also changed by another
+// writer after the patch was created, i.e., fields whose value in current differs from
+// the value in base, the merge patch holding the previous values of the patch's fields.
+// Winners are taken from patched, the result of applying patch to current.
+func detectConflicts(current, patched, patch, base []byte) ([]core.Conflict, error) {
+	var docs [4]map[string]interface{}
+	for i, data := range [][]byte{current, patched, patch, base} {
+		var err error
+		if docs[i], err = decodeObject(data); err != nil {
+			return nil, err
+		}
+	}
+	delete(docs[2], modFieldName)
+	var conflicts []core.Conflict
+	if err := conflictPaths("", docs[0], docs[2], docs[3], func(pth string, loser interface{}) error {
+		c := core.Conflict{Path: pth}
+		if loser != nil {
+			b, err := json.Marshal(loser)
+			if err != nil {
+				return err
+			}
+			c.Loser = b
+		}
+		if winner, ok := valueAtPath(docs[1], pth); ok && winner != nil {
+			b, err := json.Marshal(winner)
+			if err != nil {
+				return err
+			}
+			c.Winner = b
+		}
+		conflicts = append(conflicts, c)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// conflictPaths calls fn with the current value of each path in changes whose
+// current value differs from both its base and changed value.
+func conflictPaths(
+	prefix string,
+	current, changes, base map[string]interface{},
+	fn func(pth string, loser interface{}) error,
+) error {
+	keys := make([]string, 0, len(changes))
+	for k := range changes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pth := k
+		if prefix != "" {
+			pth = prefix + "." + k
+		}
+		cv := current[k]
+		pm, pok := changes[k].(map[string]interface{})
+		bm, bok := base[k].(map[string]interface{})
+		if pok && bok {
+			// The patch only changed some nested fields of an existing object.
+			if cm, ok := cv.(map[string]interface{}); ok {
+				if err := conflictPaths(pth, cm, pm, bm, fn); err != nil {
+					return err
+				}
+				continue
+			}
+		} else if reflect.DeepEqual(cv, base[k]) || reflect.DeepEqual(cv, changes[k]) {
+			// Either nobody else changed the field, or they made the same change.
+			continue
+		}
+		if err := fn(pth, cv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveConflicts returns patched, the result of applying patch to current, with the values
+// of conflicting fields set by r. See detectConflicts.
+func resolveConflicts(r ConflictResolver, current, patched, patch, base []byte) ([]byte, error) {
+	var docs [4]map[string]interface{}
+	for i, data := range [][]byte{current, patched, patch, base} {
+		var err error
+		if docs[i], err = decodeObject(data); err != nil {
+			return nil, err
+		}
+	}
+	delete(docs[2], modFieldName)
+	var resolved bool
+	if err := conflictPaths("", docs[0], docs[2], docs[3], func(pth string, local interface{}) error {
+		l, err := marshalValue(local)
+		if err != nil {
+			return err
+		}
+		remote, _ := valueAtPath(docs[2], pth)
+		rm, err := marshalValue(remote)
+		if err != nil {
+			return err
+		}
+		resolved = true
+		return setValueAtPath(docs[1], pth, r(pth, l, rm))
+	}); err != nil {
+		return nil, err
+	}
+	if !resolved {
+		return patched, nil
+	}
+	return json.Marshal(docs[1])
+}
+
+// marshalValue returns the JSON encoding of v, or nil if v is nil.
+func marshalValue(v interface{}) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// setValueAtPath sets the field at pth in doc to the JSON value v, or removes it if v is nil.
+func setValueAtPath(doc map[string]interface{}, pth string, v json.RawMessage) error {
+	keys := strings.Split(pth, ".")
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := doc[k].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			doc[k] = next
+		}
+		doc = next
+	}
+	last := keys[len(keys)-1]
+	if v == nil {
+		delete(doc, last)
+		return nil
+	}
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(v))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	doc[last] = value
+	return nil
+}
+
+func valueAtPath(doc map[string]interface{}, pth string) (interface{}, bool) {
+	var v interface{} = doc
+	for _, k := range strings.Split(pth, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
 type recordEvents struct {
 	Patches []patchEvent
 }
@@ -220,15 +446,23 @@ func createEvent(id core.InstanceID, v []byte) (*operation, error) {
 	}, nil
 }
 
-func saveEvent(id core.InstanceID, prev []byte, curr []byte) (*operation, error) {
+func saveEvent(id core.InstanceID, prev []byte, curr []byte, withBase bool) (*operation, error) {
 	jsonPatch, err := jsonpatch.CreateMergePatch(prev, curr)
 	if err != nil {
 		return nil, err
 	}
+	var base []byte
+	if withBase {
+		base, err = jsonpatch.CreateMergePatch(curr, prev)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &operation{
 		Type:       save,
 		InstanceID: id,
 		JSONPatch:  jsonPatch,
+		Base:       base,
 	}, nil
 }
 
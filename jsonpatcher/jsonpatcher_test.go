@@ -3,9 +3,13 @@ package jsonpatcher
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	mh "github.com/multiformats/go-multihash"
 	core "github.com/textileio/go-threads/core/db"
@@ -79,3 +83,115 @@ func TestJsonPatcher_Migration(t *testing.T) {
 		t.Error("encodable time should be equal to input")
 	}
 }
+
+func TestJsonPatcher_DetectConflicts(t *testing.T) {
+	original := []byte(`{"_id":"123","_mod":1,"Name":"Alice","Age":30,"Meta":{"City":"Paris","Zip":"75001"}}`)
+	// Another writer's save, which was reduced first.
+	current := []byte(`{"_id":"123","_mod":2,"Name":"Bob","Age":30,"Meta":{"City":"Lyon","Zip":"75001"}}`)
+
+	detect := func(t *testing.T, next []byte) []core.Conflict {
+		op, err := saveEvent("123", original, next, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patched, err := jsonpatch.MergePatch(current, op.JSONPatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conflicts, err := detectConflicts(current, patched, op.JSONPatch, op.Base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conflicts
+	}
+
+	t.Run("SameFields", func(t *testing.T) {
+		conflicts := detect(t, []byte(`{"_id":"123","_mod":3,"Name":"Carol","Age":30,"Meta":{"Zip":"75001"}}`))
+		expected := []core.Conflict{
+			{Path: "Meta.City", Winner: nil, Loser: []byte(`"Lyon"`)},
+			{Path: "Name", Winner: []byte(`"Carol"`), Loser: []byte(`"Bob"`)},
+		}
+		if !reflect.DeepEqual(conflicts, expected) {
+			t.Fatalf("expected conflicts %v, got %v", expected, conflicts)
+		}
+	})
+	t.Run("DifferentFields", func(t *testing.T) {
+		conflicts := detect(t, []byte(`{"_id":"123","_mod":3,"Name":"Alice","Age":31,"Meta":{"City":"Paris","Zip":"75002"}}`))
+		if len(conflicts) != 0 {
+			t.Fatalf("expected no conflicts, got %v", conflicts)
+		}
+	})
+	t.Run("SameChange", func(t *testing.T) {
+		conflicts := detect(t, []byte(`{"_id":"123","_mod":3,"Name":"Bob","Age":30,"Meta":{"City":"Paris","Zip":"75001"}}`))
+		if len(conflicts) != 0 {
+			t.Fatalf("expected no conflicts, got %v", conflicts)
+		}
+	})
+}
+
+func TestJsonPatcher_ResolveConflicts(t *testing.T) {
+	original := []byte(`{"_id":"123","_mod":1,"Name":"Alice","Count":1,"Meta":{"City":"Paris","Zip":"75001"}}`)
+	// Another writer's save, which was reduced first.
+	current := []byte(`{"_id":"123","_mod":2,"Name":"Bob","Count":2,"Meta":{"City":"Lyon","Zip":"75001"}}`)
+	// Adds both increments of Count, and keeps the other writer's value of other fields.
+	resolver := func(pth string, local, remote json.RawMessage) json.RawMessage {
+		if pth != "Count" {
+			return local
+		}
+		var l, r int
+		if err := json.Unmarshal(local, &l); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(remote, &r); err != nil {
+			t.Fatal(err)
+		}
+		return json.RawMessage(strconv.Itoa(l + r - 1))
+	}
+
+	resolve := func(t *testing.T, next []byte) map[string]interface{} {
+		op, err := saveEvent("123", original, next, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		patched, err := jsonpatch.MergePatch(current, op.JSONPatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resolved, err := resolveConflicts(resolver, current, patched, op.JSONPatch, op.Base)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := make(map[string]interface{})
+		if err := json.Unmarshal(resolved, &doc); err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+
+	t.Run("SameFields", func(t *testing.T) {
+		doc := resolve(t, []byte(`{"_id":"123","_mod":3,"Name":"Carol","Count":3,"Meta":{"Zip":"75001"}}`))
+		expected := map[string]interface{}{
+			"_id":   "123",
+			"_mod":  float64(3),
+			"Name":  "Bob",
+			"Count": float64(4),
+			"Meta":  map[string]interface{}{"City": "Lyon", "Zip": "75001"},
+		}
+		if !reflect.DeepEqual(doc, expected) {
+			t.Fatalf("expected %v, got %v", expected, doc)
+		}
+	})
+	t.Run("DifferentFields", func(t *testing.T) {
+		doc := resolve(t, []byte(`{"_id":"123","_mod":3,"Name":"Alice","Count":1,"Meta":{"City":"Paris","Zip":"75002"}}`))
+		expected := map[string]interface{}{
+			"_id":   "123",
+			"_mod":  float64(3),
+			"Name":  "Bob",
+			"Count": float64(2),
+			"Meta":  map[string]interface{}{"City": "Lyon", "Zip": "75002"},
+		}
+		if !reflect.DeepEqual(doc, expected) {
+			t.Fatalf("expected %v, got %v", expected, doc)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"time"
 
 	"github.com/ipfs/go-cid"
 	"github.com/ipfs/go-ipld-format"
@@ -174,6 +175,31 @@ func (c *Client) GetThread(ctx context.Context, id thread.ID, opts ...core.Threa
 	return threadInfoFromProto(resp)
 }
 
+func (c *Client) ListThreads(ctx context.Context, opts ...core.ListThreadsOption) ([]thread.Info, error) {
+	args := &core.ListThreadsOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	req := &pb.ListThreadsRequest{
+		Limit: int32(args.Limit),
+	}
+	if args.Offset.Defined() {
+		req.Offset = args.Offset.Bytes()
+	}
+	resp, err := c.c.ListThreads(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]thread.Info, len(resp.Threads))
+	for i, t := range resp.Threads {
+		if infos[i], err = threadInfoFromProto(t); err != nil {
+			return nil, err
+		}
+	}
+	return infos, nil
+}
+
 func (c *Client) PullThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -198,6 +224,18 @@ func (c *Client) DeleteThread(ctx context.Context, id thread.ID, opts ...core.Th
 	return err
 }
 
+func (c *Client) ResyncThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	_, err := c.c.ResyncThread(ctx, &pb.ResyncThreadRequest{
+		ThreadID: id.Bytes(),
+	})
+	return err
+}
+
 func (c *Client) AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multiaddr, opts ...core.ThreadOption) (pid peer.ID, err error) {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -214,6 +252,21 @@ func (c *Client) AddReplicator(ctx context.Context, id thread.ID, paddr ma.Multi
 	return peer.IDFromBytes(resp.PeerID)
 }
 
+func (c *Client) GetReplicators(ctx context.Context, id thread.ID, opts ...core.ThreadOption) ([]core.ReplicatorInfo, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	resp, err := c.c.GetReplicators(ctx, &pb.GetReplicatorsRequest{
+		ThreadID: id.Bytes(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return replicatorsFromProto(resp.Replicators)
+}
+
 func (c *Client) CreateRecord(ctx context.Context, id thread.ID, body format.Node, opts ...core.ThreadOption) (core.ThreadRecord, error) {
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
@@ -273,6 +326,57 @@ func (c *Client) GetRecord(ctx context.Context, id thread.ID, rid cid.Cid, opts
 	return cbor.RecordFromProto(util.RecToServiceRec(resp.Record), info.Key.Service())
 }
 
+// GetRecords returns up to limit records of a log, walking its chain from the record from,
+// or from the log head if from is undefined, towards the first record of the log.
+// Records are fetched in a single stream. A non-positive limit returns all the records
+// up to the first record.
+func (c *Client) GetRecords(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	from cid.Cid,
+	limit int,
+	opts ...core.ThreadOption,
+) ([]core.Record, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	info, err := c.GetThread(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	lidb, _ := lid.Marshal()
+	req := &pb.GetRecordsRequest{
+		ThreadID: id.Bytes(),
+		LogID:    lidb,
+		Limit:    int32(limit),
+	}
+	if from.Defined() {
+		req.From = from.Bytes()
+	}
+	ctx = thread.NewTokenContext(ctx, args.Token)
+	stream, err := c.c.GetRecords(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var recs []core.Record
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return recs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rec, err := cbor.RecordFromProto(util.RecToServiceRec(resp.Record), info.Key.Service())
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+}
+
 func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan core.ThreadRecord, error) {
 	args := &core.SubOptions{}
 	for _, opt := range opts {
@@ -283,9 +387,16 @@ func (c *Client) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan
 	for i, id := range args.ThreadIDs {
 		ids[i] = id.Bytes()
 	}
+	logIDs := make([][]byte, len(args.LogIDs))
+	for i, id := range args.LogIDs {
+		if logIDs[i], err = id.Marshal(); err != nil {
+			return nil, err
+		}
+	}
 	ctx = thread.NewTokenContext(ctx, args.Token)
 	stream, err := c.c.Subscribe(ctx, &pb.SubscribeRequest{
 		ThreadIDs: ids,
+		LogIDs:    logIDs,
 	})
 	if err != nil {
 		return nil, err
@@ -413,14 +524,50 @@ func threadInfoFromProto(reply *pb.ThreadInfoReply) (info thread.Info, err error
 			return info, err
 		}
 	}
+	var updated time.Time
+	if reply.Updated != 0 {
+		updated = time.Unix(0, reply.Updated)
+	}
 	return thread.Info{
-		ID:    threadID,
-		Key:   k,
-		Logs:  logs,
-		Addrs: addrs,
+		ID:      threadID,
+		Key:     k,
+		Logs:    logs,
+		Addrs:   addrs,
+		Updated: updated,
 	}, nil
 }
 
+func replicatorsFromProto(pbreps []*pb.ReplicatorInfo) ([]core.ReplicatorInfo, error) {
+	reps := make([]core.ReplicatorInfo, len(pbreps))
+	for i, r := range pbreps {
+		id, err := peer.IDFromBytes(r.PeerID)
+		if err != nil {
+			return nil, err
+		}
+		logs := make([]core.ReplicatorLogInfo, len(r.Logs))
+		for j, lg := range r.Logs {
+			lid, err := peer.IDFromBytes(lg.LogID)
+			if err != nil {
+				return nil, err
+			}
+			head := cid.Undef
+			if len(lg.Head) > 0 {
+				head, err = cid.Cast(lg.Head)
+				if err != nil {
+					return nil, err
+				}
+			}
+			logs[j] = core.ReplicatorLogInfo{ID: lid, Head: head, Lag: lg.Lag}
+		}
+		var lastContact time.Time
+		if r.LastContact != 0 {
+			lastContact = time.Unix(0, r.LastContact)
+		}
+		reps[i] = core.ReplicatorInfo{ID: id, Logs: logs, LastContact: lastContact}
+	}
+	return reps, nil
+}
+
 func threadRecordFromProto(reply *pb.NewRecordReply, key crypto.DecryptionKey) (core.ThreadRecord, error) {
 	threadID, err := thread.Cast(reply.ThreadID)
 	if err != nil {
@@ -120,6 +120,24 @@ func TestClient_GetThread(t *testing.T) {
 	})
 }
 
+func TestClient_ListThreads(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+
+	t.Run("test list threads", func(t *testing.T) {
+		infos, err := client.ListThreads(context.Background())
+		if err != nil {
+			t.Fatalf("failed to list threads: %v", err)
+		}
+		if len(infos) != 1 || !infos[0].ID.Equals(info.ID) {
+			t.Fatal("got bad threads from list threads")
+		}
+	})
+}
+
 func TestClient_PullThread(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -176,6 +194,37 @@ func TestClient_AddReplicator(t *testing.T) {
 	})
 }
 
+func TestClient_GetReplicators(t *testing.T) {
+	t.Parallel()
+	_, client1, done1 := setup(t)
+	defer done1()
+	hostAddr2, client2, done2 := setup(t)
+	defer done2()
+
+	info := createThread(t, client1)
+	hostID2, err := client2.GetHostID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := peerAddr(t, hostAddr2, hostID2)
+	if _, err := client1.AddReplicator(context.Background(), info.ID, addr); err != nil {
+		t.Fatalf("failed to add replicator: %v", err)
+	}
+
+	t.Run("test get replicators", func(t *testing.T) {
+		reps, err := client1.GetReplicators(context.Background(), info.ID)
+		if err != nil {
+			t.Fatalf("failed to get replicators: %v", err)
+		}
+		if len(reps) != 1 {
+			t.Fatalf("expected 1 replicator, got %d", len(reps))
+		}
+		if reps[0].ID != hostID2 {
+			t.Fatalf("expected replicator %s, got %s", hostID2, reps[0].ID)
+		}
+	})
+}
+
 func TestClient_CreateRecord(t *testing.T) {
 	t.Parallel()
 	_, client, done := setup(t)
@@ -305,6 +354,54 @@ func TestClient_GetRecord(t *testing.T) {
 	})
 }
 
+func TestClient_GetRecords(t *testing.T) {
+	t.Parallel()
+	_, client, done := setup(t)
+	defer done()
+
+	info := createThread(t, client)
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"foo": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec, err := client.CreateRecord(context.Background(), info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+	lid := recs[0].LogID()
+
+	t.Run("test get records from head", func(t *testing.T) {
+		got, err := client.GetRecords(context.Background(), info.ID, lid, cid.Undef, 0)
+		if err != nil {
+			t.Fatalf("failed to get records: %v", err)
+		}
+		if len(got) != 3 {
+			t.Fatalf("expected 3 records, got %d", len(got))
+		}
+		for i, rec := range got {
+			if !rec.Cid().Equals(recs[2-i].Value().Cid()) {
+				t.Fatalf("got bad record %d from get records", i)
+			}
+		}
+	})
+
+	t.Run("test get records with limit", func(t *testing.T) {
+		got, err := client.GetRecords(context.Background(), info.ID, lid, recs[1].Value().Cid(), 1)
+		if err != nil {
+			t.Fatalf("failed to get records: %v", err)
+		}
+		if len(got) != 1 || !got[0].Cid().Equals(recs[1].Value().Cid()) {
+			t.Fatal("got bad records from get records")
+		}
+	})
+}
+
 func TestClient_Subscribe(t *testing.T) {
 	t.Parallel()
 	_, client1, done1 := setup(t)
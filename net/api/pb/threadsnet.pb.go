@@ -384,6 +384,7 @@ type ThreadInfoReply struct {
 	ThreadKey []byte     `protobuf:"bytes,2,opt,name=threadKey,proto3" json:"threadKey,omitempty"`
 	Logs      []*LogInfo `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
 	Addrs     [][]byte   `protobuf:"bytes,4,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	Updated   int64      `protobuf:"varint,5,opt,name=updated,proto3" json:"updated,omitempty"`
 }
 
 func (x *ThreadInfoReply) Reset() {
@@ -446,6 +447,13 @@ func (x *ThreadInfoReply) GetAddrs() [][]byte {
 	return nil
 }
 
+func (x *ThreadInfoReply) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
 type LogInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -635,6 +643,108 @@ func (x *GetThreadRequest) GetThreadID() []byte {
 	return nil
 }
 
+type ListThreadsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset []byte `protobuf:"bytes,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListThreadsRequest) Reset() {
+	*x = ListThreadsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListThreadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadsRequest) ProtoMessage() {}
+
+func (x *ListThreadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadsRequest.ProtoReflect.Descriptor instead.
+func (*ListThreadsRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListThreadsRequest) GetOffset() []byte {
+	if x != nil {
+		return x.Offset
+	}
+	return nil
+}
+
+func (x *ListThreadsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListThreadsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Threads []*ThreadInfoReply `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+}
+
+func (x *ListThreadsReply) Reset() {
+	*x = ListThreadsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListThreadsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadsReply) ProtoMessage() {}
+
+func (x *ListThreadsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadsReply.ProtoReflect.Descriptor instead.
+func (*ListThreadsReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListThreadsReply) GetThreads() []*ThreadInfoReply {
+	if x != nil {
+		return x.Threads
+	}
+	return nil
+}
+
 type PullThreadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -646,7 +756,7 @@ type PullThreadRequest struct {
 func (x *PullThreadRequest) Reset() {
 	*x = PullThreadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[10]
+		mi := &file_threadsnet_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -659,7 +769,7 @@ func (x *PullThreadRequest) String() string {
 func (*PullThreadRequest) ProtoMessage() {}
 
 func (x *PullThreadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[10]
+	mi := &file_threadsnet_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -672,7 +782,7 @@ func (x *PullThreadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullThreadRequest.ProtoReflect.Descriptor instead.
 func (*PullThreadRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{10}
+	return file_threadsnet_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *PullThreadRequest) GetThreadID() []byte {
@@ -691,7 +801,7 @@ type PullThreadReply struct {
 func (x *PullThreadReply) Reset() {
 	*x = PullThreadReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[11]
+		mi := &file_threadsnet_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -704,7 +814,7 @@ func (x *PullThreadReply) String() string {
 func (*PullThreadReply) ProtoMessage() {}
 
 func (x *PullThreadReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[11]
+	mi := &file_threadsnet_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,7 +827,7 @@ func (x *PullThreadReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullThreadReply.ProtoReflect.Descriptor instead.
 func (*PullThreadReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{11}
+	return file_threadsnet_proto_rawDescGZIP(), []int{13}
 }
 
 type DeleteThreadRequest struct {
@@ -731,7 +841,7 @@ type DeleteThreadRequest struct {
 func (x *DeleteThreadRequest) Reset() {
 	*x = DeleteThreadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[12]
+		mi := &file_threadsnet_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -744,7 +854,7 @@ func (x *DeleteThreadRequest) String() string {
 func (*DeleteThreadRequest) ProtoMessage() {}
 
 func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[12]
+	mi := &file_threadsnet_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -757,7 +867,7 @@ func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteThreadRequest.ProtoReflect.Descriptor instead.
 func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{12}
+	return file_threadsnet_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *DeleteThreadRequest) GetThreadID() []byte {
@@ -776,7 +886,7 @@ type DeleteThreadReply struct {
 func (x *DeleteThreadReply) Reset() {
 	*x = DeleteThreadReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[13]
+		mi := &file_threadsnet_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -789,7 +899,7 @@ func (x *DeleteThreadReply) String() string {
 func (*DeleteThreadReply) ProtoMessage() {}
 
 func (x *DeleteThreadReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[13]
+	mi := &file_threadsnet_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -802,7 +912,7 @@ func (x *DeleteThreadReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteThreadReply.ProtoReflect.Descriptor instead.
 func (*DeleteThreadReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{13}
+	return file_threadsnet_proto_rawDescGZIP(), []int{15}
 }
 
 type AddReplicatorRequest struct {
@@ -817,7 +927,7 @@ type AddReplicatorRequest struct {
 func (x *AddReplicatorRequest) Reset() {
 	*x = AddReplicatorRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[14]
+		mi := &file_threadsnet_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -830,7 +940,7 @@ func (x *AddReplicatorRequest) String() string {
 func (*AddReplicatorRequest) ProtoMessage() {}
 
 func (x *AddReplicatorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[14]
+	mi := &file_threadsnet_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +953,7 @@ func (x *AddReplicatorRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddReplicatorRequest.ProtoReflect.Descriptor instead.
 func (*AddReplicatorRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{14}
+	return file_threadsnet_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AddReplicatorRequest) GetThreadID() []byte {
@@ -871,7 +981,7 @@ type AddReplicatorReply struct {
 func (x *AddReplicatorReply) Reset() {
 	*x = AddReplicatorReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[15]
+		mi := &file_threadsnet_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -884,7 +994,7 @@ func (x *AddReplicatorReply) String() string {
 func (*AddReplicatorReply) ProtoMessage() {}
 
 func (x *AddReplicatorReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[15]
+	mi := &file_threadsnet_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -897,7 +1007,7 @@ func (x *AddReplicatorReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddReplicatorReply.ProtoReflect.Descriptor instead.
 func (*AddReplicatorReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{15}
+	return file_threadsnet_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *AddReplicatorReply) GetPeerID() []byte {
@@ -907,32 +1017,31 @@ func (x *AddReplicatorReply) GetPeerID() []byte {
 	return nil
 }
 
-type CreateRecordRequest struct {
+type GetReplicatorsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	Body     []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 }
 
-func (x *CreateRecordRequest) Reset() {
-	*x = CreateRecordRequest{}
+func (x *GetReplicatorsRequest) Reset() {
+	*x = GetReplicatorsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[16]
+		mi := &file_threadsnet_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateRecordRequest) String() string {
+func (x *GetReplicatorsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRecordRequest) ProtoMessage() {}
+func (*GetReplicatorsRequest) ProtoMessage() {}
 
-func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[16]
+func (x *GetReplicatorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,52 +1052,43 @@ func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRecordRequest.ProtoReflect.Descriptor instead.
-func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetReplicatorsRequest.ProtoReflect.Descriptor instead.
+func (*GetReplicatorsRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CreateRecordRequest) GetThreadID() []byte {
+func (x *GetReplicatorsRequest) GetThreadID() []byte {
 	if x != nil {
 		return x.ThreadID
 	}
 	return nil
 }
 
-func (x *CreateRecordRequest) GetBody() []byte {
-	if x != nil {
-		return x.Body
-	}
-	return nil
-}
-
-type NewRecordReply struct {
+type GetReplicatorsReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	Replicators []*ReplicatorInfo `protobuf:"bytes,1,rep,name=replicators,proto3" json:"replicators,omitempty"`
 }
 
-func (x *NewRecordReply) Reset() {
-	*x = NewRecordReply{}
+func (x *GetReplicatorsReply) Reset() {
+	*x = GetReplicatorsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[17]
+		mi := &file_threadsnet_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NewRecordReply) String() string {
+func (x *GetReplicatorsReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NewRecordReply) ProtoMessage() {}
+func (*GetReplicatorsReply) ProtoMessage() {}
 
-func (x *NewRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[17]
+func (x *GetReplicatorsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,59 +1099,45 @@ func (x *NewRecordReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NewRecordReply.ProtoReflect.Descriptor instead.
-func (*NewRecordReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *NewRecordReply) GetThreadID() []byte {
-	if x != nil {
-		return x.ThreadID
-	}
-	return nil
-}
-
-func (x *NewRecordReply) GetLogID() []byte {
-	if x != nil {
-		return x.LogID
-	}
-	return nil
+// Deprecated: Use GetReplicatorsReply.ProtoReflect.Descriptor instead.
+func (*GetReplicatorsReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *NewRecordReply) GetRecord() *Record {
+func (x *GetReplicatorsReply) GetReplicators() []*ReplicatorInfo {
 	if x != nil {
-		return x.Record
+		return x.Replicators
 	}
 	return nil
 }
 
-type AddRecordRequest struct {
+type ReplicatorInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
-	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
-	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	PeerID      []byte               `protobuf:"bytes,1,opt,name=peerID,proto3" json:"peerID,omitempty"`
+	Logs        []*ReplicatorLogInfo `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
+	LastContact int64                `protobuf:"varint,3,opt,name=lastContact,proto3" json:"lastContact,omitempty"`
 }
 
-func (x *AddRecordRequest) Reset() {
-	*x = AddRecordRequest{}
+func (x *ReplicatorInfo) Reset() {
+	*x = ReplicatorInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[18]
+		mi := &file_threadsnet_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddRecordRequest) String() string {
+func (x *ReplicatorInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddRecordRequest) ProtoMessage() {}
+func (*ReplicatorInfo) ProtoMessage() {}
 
-func (x *AddRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[18]
+func (x *ReplicatorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1062,60 +1148,59 @@ func (x *AddRecordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddRecordRequest.ProtoReflect.Descriptor instead.
-func (*AddRecordRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ReplicatorInfo.ProtoReflect.Descriptor instead.
+func (*ReplicatorInfo) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *AddRecordRequest) GetThreadID() []byte {
+func (x *ReplicatorInfo) GetPeerID() []byte {
 	if x != nil {
-		return x.ThreadID
+		return x.PeerID
 	}
 	return nil
 }
 
-func (x *AddRecordRequest) GetLogID() []byte {
+func (x *ReplicatorInfo) GetLogs() []*ReplicatorLogInfo {
 	if x != nil {
-		return x.LogID
+		return x.Logs
 	}
 	return nil
 }
 
-func (x *AddRecordRequest) GetRecord() *Record {
+func (x *ReplicatorInfo) GetLastContact() int64 {
 	if x != nil {
-		return x.Record
+		return x.LastContact
 	}
-	return nil
+	return 0
 }
 
-type Record struct {
+type ReplicatorLogInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
-	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
-	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
-	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+	LogID []byte `protobuf:"bytes,1,opt,name=logID,proto3" json:"logID,omitempty"`
+	Head  []byte `protobuf:"bytes,2,opt,name=head,proto3" json:"head,omitempty"`
+	Lag   int64  `protobuf:"varint,3,opt,name=lag,proto3" json:"lag,omitempty"`
 }
 
-func (x *Record) Reset() {
-	*x = Record{}
+func (x *ReplicatorLogInfo) Reset() {
+	*x = ReplicatorLogInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[19]
+		mi := &file_threadsnet_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Record) String() string {
+func (x *ReplicatorLogInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Record) ProtoMessage() {}
+func (*ReplicatorLogInfo) ProtoMessage() {}
 
-func (x *Record) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[19]
+func (x *ReplicatorLogInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1126,62 +1211,58 @@ func (x *Record) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Record.ProtoReflect.Descriptor instead.
-func (*Record) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *Record) GetRecordNode() []byte {
-	if x != nil {
-		return x.RecordNode
-	}
-	return nil
+// Deprecated: Use ReplicatorLogInfo.ProtoReflect.Descriptor instead.
+func (*ReplicatorLogInfo) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *Record) GetEventNode() []byte {
+func (x *ReplicatorLogInfo) GetLogID() []byte {
 	if x != nil {
-		return x.EventNode
+		return x.LogID
 	}
 	return nil
 }
 
-func (x *Record) GetHeaderNode() []byte {
+func (x *ReplicatorLogInfo) GetHead() []byte {
 	if x != nil {
-		return x.HeaderNode
+		return x.Head
 	}
 	return nil
 }
 
-func (x *Record) GetBodyNode() []byte {
+func (x *ReplicatorLogInfo) GetLag() int64 {
 	if x != nil {
-		return x.BodyNode
+		return x.Lag
 	}
-	return nil
+	return 0
 }
 
-type AddRecordReply struct {
+type CreateRecordRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	Body     []byte `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 }
 
-func (x *AddRecordReply) Reset() {
-	*x = AddRecordReply{}
+func (x *CreateRecordRequest) Reset() {
+	*x = CreateRecordRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[20]
+		mi := &file_threadsnet_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AddRecordReply) String() string {
+func (x *CreateRecordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddRecordReply) ProtoMessage() {}
+func (*CreateRecordRequest) ProtoMessage() {}
 
-func (x *AddRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[20]
+func (x *CreateRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1192,11 +1273,260 @@ func (x *AddRecordReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddRecordReply.ProtoReflect.Descriptor instead.
-func (*AddRecordReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{20}
-}
-
+// Deprecated: Use CreateRecordRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecordRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateRecordRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *CreateRecordRequest) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+type NewRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *NewRecordReply) Reset() {
+	*x = NewRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewRecordReply) ProtoMessage() {}
+
+func (x *NewRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewRecordReply.ProtoReflect.Descriptor instead.
+func (*NewRecordReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *NewRecordReply) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *NewRecordReply) GetLogID() []byte {
+	if x != nil {
+		return x.LogID
+	}
+	return nil
+}
+
+func (x *NewRecordReply) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type AddRecordRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte  `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte  `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	Record   *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *AddRecordRequest) Reset() {
+	*x = AddRecordRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRecordRequest) ProtoMessage() {}
+
+func (x *AddRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRecordRequest.ProtoReflect.Descriptor instead.
+func (*AddRecordRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddRecordRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *AddRecordRequest) GetLogID() []byte {
+	if x != nil {
+		return x.LogID
+	}
+	return nil
+}
+
+func (x *AddRecordRequest) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type Record struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecordNode []byte `protobuf:"bytes,1,opt,name=recordNode,proto3" json:"recordNode,omitempty"`
+	EventNode  []byte `protobuf:"bytes,2,opt,name=eventNode,proto3" json:"eventNode,omitempty"`
+	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
+	BodyNode   []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Record) GetRecordNode() []byte {
+	if x != nil {
+		return x.RecordNode
+	}
+	return nil
+}
+
+func (x *Record) GetEventNode() []byte {
+	if x != nil {
+		return x.EventNode
+	}
+	return nil
+}
+
+func (x *Record) GetHeaderNode() []byte {
+	if x != nil {
+		return x.HeaderNode
+	}
+	return nil
+}
+
+func (x *Record) GetBodyNode() []byte {
+	if x != nil {
+		return x.BodyNode
+	}
+	return nil
+}
+
+type AddRecordReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddRecordReply) Reset() {
+	*x = AddRecordReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddRecordReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRecordReply) ProtoMessage() {}
+
+func (x *AddRecordReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRecordReply.ProtoReflect.Descriptor instead.
+func (*AddRecordReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{26}
+}
+
 type GetRecordRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1209,7 +1539,7 @@ type GetRecordRequest struct {
 func (x *GetRecordRequest) Reset() {
 	*x = GetRecordRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[21]
+		mi := &file_threadsnet_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1222,7 +1552,7 @@ func (x *GetRecordRequest) String() string {
 func (*GetRecordRequest) ProtoMessage() {}
 
 func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[21]
+	mi := &file_threadsnet_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1235,7 +1565,7 @@ func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRecordRequest.ProtoReflect.Descriptor instead.
 func (*GetRecordRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{21}
+	return file_threadsnet_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetRecordRequest) GetThreadID() []byte {
@@ -1263,7 +1593,7 @@ type GetRecordReply struct {
 func (x *GetRecordReply) Reset() {
 	*x = GetRecordReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[22]
+		mi := &file_threadsnet_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1276,7 +1606,7 @@ func (x *GetRecordReply) String() string {
 func (*GetRecordReply) ProtoMessage() {}
 
 func (x *GetRecordReply) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[22]
+	mi := &file_threadsnet_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1289,7 +1619,7 @@ func (x *GetRecordReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRecordReply.ProtoReflect.Descriptor instead.
 func (*GetRecordReply) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{22}
+	return file_threadsnet_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GetRecordReply) GetRecord() *Record {
@@ -1299,18 +1629,90 @@ func (x *GetRecordReply) GetRecord() *Record {
 	return nil
 }
 
+type GetRecordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+	LogID    []byte `protobuf:"bytes,2,opt,name=logID,proto3" json:"logID,omitempty"`
+	From     []byte `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	Limit    int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *GetRecordsRequest) Reset() {
+	*x = GetRecordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordsRequest) ProtoMessage() {}
+
+func (x *GetRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetRecordsRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetLogID() []byte {
+	if x != nil {
+		return x.LogID
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type SubscribeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ThreadIDs [][]byte `protobuf:"bytes,1,rep,name=threadIDs,proto3" json:"threadIDs,omitempty"`
+	LogIDs    [][]byte `protobuf:"bytes,2,rep,name=logIDs,proto3" json:"logIDs,omitempty"`
 }
 
 func (x *SubscribeRequest) Reset() {
 	*x = SubscribeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_threadsnet_proto_msgTypes[23]
+		mi := &file_threadsnet_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1323,7 +1725,7 @@ func (x *SubscribeRequest) String() string {
 func (*SubscribeRequest) ProtoMessage() {}
 
 func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_threadsnet_proto_msgTypes[23]
+	mi := &file_threadsnet_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,7 +1738,7 @@ func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeRequest) Descriptor() ([]byte, []int) {
-	return file_threadsnet_proto_rawDescGZIP(), []int{23}
+	return file_threadsnet_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SubscribeRequest) GetThreadIDs() [][]byte {
@@ -1346,6 +1748,98 @@ func (x *SubscribeRequest) GetThreadIDs() [][]byte {
 	return nil
 }
 
+func (x *SubscribeRequest) GetLogIDs() [][]byte {
+	if x != nil {
+		return x.LogIDs
+	}
+	return nil
+}
+
+type ResyncThreadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ThreadID []byte `protobuf:"bytes,1,opt,name=threadID,proto3" json:"threadID,omitempty"`
+}
+
+func (x *ResyncThreadRequest) Reset() {
+	*x = ResyncThreadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResyncThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResyncThreadRequest) ProtoMessage() {}
+
+func (x *ResyncThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResyncThreadRequest.ProtoReflect.Descriptor instead.
+func (*ResyncThreadRequest) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ResyncThreadRequest) GetThreadID() []byte {
+	if x != nil {
+		return x.ThreadID
+	}
+	return nil
+}
+
+type ResyncThreadReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ResyncThreadReply) Reset() {
+	*x = ResyncThreadReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_threadsnet_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResyncThreadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResyncThreadReply) ProtoMessage() {}
+
+func (x *ResyncThreadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_threadsnet_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResyncThreadReply.ProtoReflect.Descriptor instead.
+func (*ResyncThreadReply) Descriptor() ([]byte, []int) {
+	return file_threadsnet_proto_rawDescGZIP(), []int{32}
+}
+
 var File_threadsnet_proto protoreflect.FileDescriptor
 
 var file_threadsnet_proto_rawDesc = []byte{
@@ -1375,7 +1869,7 @@ var file_threadsnet_proto_rawDesc = []byte{
 	0x68, 0x72, 0x65, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
 	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67,
 	0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x4b, 0x65,
-	0x79, 0x22, 0x8e, 0x01, 0x0a, 0x0f, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f,
+	0x79, 0x22, 0xa8, 0x01, 0x0a, 0x0f, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f,
 	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49,
 	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49,
 	0x44, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02,
@@ -1384,40 +1878,73 @@ var file_threadsnet_proto_rawDesc = []byte{
 	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
 	0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05,
 	0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x64, 0x64,
-	0x72, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x07, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e,
-	0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16,
-	0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
-	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x4b, 0x65,
-	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x4b, 0x65, 0x79,
-	0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52,
-	0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x65, 0x72, 0x22, 0x50, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x28, 0x0a, 0x04,
-	0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x73,
-	0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x2e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x2f, 0x0a, 0x11, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x11, 0x0a, 0x0f, 0x50, 0x75, 0x6c, 0x6c, 0x54,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x31, 0x0a, 0x13, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x13, 0x0a,
-	0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x46, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
-	0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0x2c, 0x0a, 0x12, 0x41, 0x64,
-	0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x22, 0x45, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61,
+	0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x22, 0x8f, 0x01, 0x0a,
+	0x07, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x4b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64,
+	0x64, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x68, 0x65, 0x61, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x22, 0x50,
+	0x0a, 0x10, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x12, 0x28, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
+	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x22, 0x2e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
+	0x22, 0x42, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x4d, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x39, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x07, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x22, 0x2f, 0x0a, 0x11, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x49, 0x44, 0x22, 0x11, 0x0a, 0x0f, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x31, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x13, 0x0a, 0x11, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x46, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x61, 0x64, 0x64, 0x72, 0x22, 0x2c, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x44, 0x22, 0x33, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x57, 0x0a, 0x13, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x40, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x44, 0x12, 0x35,
+	0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x04, 0x6c, 0x6f, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x22, 0x4f, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x6f, 0x72, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6c, 0x6f, 0x67,
+	0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x6c, 0x61, 0x67, 0x22, 0x45, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61,
 	0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x62,
@@ -1454,82 +1981,117 @@ var file_threadsnet_proto_rawDesc = []byte{
 	0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2e,
 	0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
 	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x30,
-	0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73,
-	0x32, 0xfb, 0x07, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48,
-	0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x49, 0x44,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74,
-	0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x08, 0x47, 0x65, 0x74,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x6f,
+	0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
+	0x6c, 0x6f, 0x67, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
+	0x48, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x49, 0x44, 0x73, 0x22, 0x31, 0x0a, 0x13, 0x52, 0x65, 0x73,
+	0x79, 0x6e, 0x63, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x44, 0x22, 0x13, 0x0a, 0x11,
+	0x52, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x32, 0xdf, 0x0a, 0x0a, 0x03, 0x41, 0x50, 0x49, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65, 0x74,
+	0x48, 0x6f, 0x73, 0x74, 0x49, 0x44, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73, 0x74, 0x49,
+	0x44, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x6f, 0x73,
+	0x74, 0x49, 0x44, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
 	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x0c, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x0c,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
+	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
+	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x52, 0x0a, 0x0a, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x21, 0x2e,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50,
+	0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x41, 0x64, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
-	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0a, 0x50, 0x75, 0x6c, 0x6c, 0x54,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
-	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0c, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
-	0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c,
-	0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x24, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69,
-	0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64,
-	0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74,
-	0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x62, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
+	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5b, 0x0a,
+	0x0d, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x24,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
+	0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
+	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x6f, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x74,
+	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68, 0x72,
+	0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x4f, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20,
+	0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e,
+	0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
+	0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x12, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e,
+	0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x51, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e,
+	0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
 	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09, 0x41, 0x64, 0x64,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
-	0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x09, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64,
-	0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x09, 0x53,
-	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x20, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x77, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x42, 0x69,
-	0x0a, 0x1b, 0x69, 0x6f, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x69, 0x6c, 0x65, 0x2e, 0x74, 0x68, 0x72,
-	0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x42, 0x0a, 0x54,
-	0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x4e, 0x65, 0x74, 0x50, 0x01, 0x5a, 0x2f, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x74, 0x68, 0x72, 0x65, 0x61,
-	0x64, 0x73, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x2f, 0x74, 0x68,
-	0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x62, 0xa2, 0x02, 0x0a, 0x54,
-	0x48, 0x52, 0x45, 0x41, 0x44, 0x53, 0x4e, 0x45, 0x54, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x72, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x0c, 0x52,
+	0x65, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x12, 0x23, 0x2e, 0x74, 0x68,
+	0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
+	0x79, 0x6e, 0x63, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x42, 0x69, 0x0a, 0x1b, 0x69, 0x6f, 0x2e, 0x74, 0x65, 0x78, 0x74, 0x69, 0x6c,
+	0x65, 0x2e, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x67, 0x72,
+	0x70, 0x63, 0x42, 0x0a, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x4e, 0x65, 0x74, 0x50, 0x01,
+	0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x62, 0x2f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x70,
+	0x62, 0xa2, 0x02, 0x0a, 0x54, 0x48, 0x52, 0x45, 0x41, 0x44, 0x53, 0x4e, 0x45, 0x54, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1544,69 +2106,89 @@ func file_threadsnet_proto_rawDescGZIP() []byte {
 	return file_threadsnet_proto_rawDescData
 }
 
-var file_threadsnet_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_threadsnet_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_threadsnet_proto_goTypes = []interface{}{
-	(*GetHostIDRequest)(nil),     // 0: threads.net.pb.GetHostIDRequest
-	(*GetHostIDReply)(nil),       // 1: threads.net.pb.GetHostIDReply
-	(*GetTokenRequest)(nil),      // 2: threads.net.pb.GetTokenRequest
-	(*GetTokenReply)(nil),        // 3: threads.net.pb.GetTokenReply
-	(*CreateThreadRequest)(nil),  // 4: threads.net.pb.CreateThreadRequest
-	(*Keys)(nil),                 // 5: threads.net.pb.Keys
-	(*ThreadInfoReply)(nil),      // 6: threads.net.pb.ThreadInfoReply
-	(*LogInfo)(nil),              // 7: threads.net.pb.LogInfo
-	(*AddThreadRequest)(nil),     // 8: threads.net.pb.AddThreadRequest
-	(*GetThreadRequest)(nil),     // 9: threads.net.pb.GetThreadRequest
-	(*PullThreadRequest)(nil),    // 10: threads.net.pb.PullThreadRequest
-	(*PullThreadReply)(nil),      // 11: threads.net.pb.PullThreadReply
-	(*DeleteThreadRequest)(nil),  // 12: threads.net.pb.DeleteThreadRequest
-	(*DeleteThreadReply)(nil),    // 13: threads.net.pb.DeleteThreadReply
-	(*AddReplicatorRequest)(nil), // 14: threads.net.pb.AddReplicatorRequest
-	(*AddReplicatorReply)(nil),   // 15: threads.net.pb.AddReplicatorReply
-	(*CreateRecordRequest)(nil),  // 16: threads.net.pb.CreateRecordRequest
-	(*NewRecordReply)(nil),       // 17: threads.net.pb.NewRecordReply
-	(*AddRecordRequest)(nil),     // 18: threads.net.pb.AddRecordRequest
-	(*Record)(nil),               // 19: threads.net.pb.Record
-	(*AddRecordReply)(nil),       // 20: threads.net.pb.AddRecordReply
-	(*GetRecordRequest)(nil),     // 21: threads.net.pb.GetRecordRequest
-	(*GetRecordReply)(nil),       // 22: threads.net.pb.GetRecordReply
-	(*SubscribeRequest)(nil),     // 23: threads.net.pb.SubscribeRequest
+	(*GetHostIDRequest)(nil),      // 0: threads.net.pb.GetHostIDRequest
+	(*GetHostIDReply)(nil),        // 1: threads.net.pb.GetHostIDReply
+	(*GetTokenRequest)(nil),       // 2: threads.net.pb.GetTokenRequest
+	(*GetTokenReply)(nil),         // 3: threads.net.pb.GetTokenReply
+	(*CreateThreadRequest)(nil),   // 4: threads.net.pb.CreateThreadRequest
+	(*Keys)(nil),                  // 5: threads.net.pb.Keys
+	(*ThreadInfoReply)(nil),       // 6: threads.net.pb.ThreadInfoReply
+	(*LogInfo)(nil),               // 7: threads.net.pb.LogInfo
+	(*AddThreadRequest)(nil),      // 8: threads.net.pb.AddThreadRequest
+	(*GetThreadRequest)(nil),      // 9: threads.net.pb.GetThreadRequest
+	(*ListThreadsRequest)(nil),    // 10: threads.net.pb.ListThreadsRequest
+	(*ListThreadsReply)(nil),      // 11: threads.net.pb.ListThreadsReply
+	(*PullThreadRequest)(nil),     // 12: threads.net.pb.PullThreadRequest
+	(*PullThreadReply)(nil),       // 13: threads.net.pb.PullThreadReply
+	(*DeleteThreadRequest)(nil),   // 14: threads.net.pb.DeleteThreadRequest
+	(*DeleteThreadReply)(nil),     // 15: threads.net.pb.DeleteThreadReply
+	(*AddReplicatorRequest)(nil),  // 16: threads.net.pb.AddReplicatorRequest
+	(*AddReplicatorReply)(nil),    // 17: threads.net.pb.AddReplicatorReply
+	(*GetReplicatorsRequest)(nil), // 18: threads.net.pb.GetReplicatorsRequest
+	(*GetReplicatorsReply)(nil),   // 19: threads.net.pb.GetReplicatorsReply
+	(*ReplicatorInfo)(nil),        // 20: threads.net.pb.ReplicatorInfo
+	(*ReplicatorLogInfo)(nil),     // 21: threads.net.pb.ReplicatorLogInfo
+	(*CreateRecordRequest)(nil),   // 22: threads.net.pb.CreateRecordRequest
+	(*NewRecordReply)(nil),        // 23: threads.net.pb.NewRecordReply
+	(*AddRecordRequest)(nil),      // 24: threads.net.pb.AddRecordRequest
+	(*Record)(nil),                // 25: threads.net.pb.Record
+	(*AddRecordReply)(nil),        // 26: threads.net.pb.AddRecordReply
+	(*GetRecordRequest)(nil),      // 27: threads.net.pb.GetRecordRequest
+	(*GetRecordReply)(nil),        // 28: threads.net.pb.GetRecordReply
+	(*GetRecordsRequest)(nil),     // 29: threads.net.pb.GetRecordsRequest
+	(*SubscribeRequest)(nil),      // 30: threads.net.pb.SubscribeRequest
+	(*ResyncThreadRequest)(nil),   // 31: threads.net.pb.ResyncThreadRequest
+	(*ResyncThreadReply)(nil),     // 32: threads.net.pb.ResyncThreadReply
 }
 var file_threadsnet_proto_depIdxs = []int32{
 	5,  // 0: threads.net.pb.CreateThreadRequest.keys:type_name -> threads.net.pb.Keys
 	7,  // 1: threads.net.pb.ThreadInfoReply.logs:type_name -> threads.net.pb.LogInfo
 	5,  // 2: threads.net.pb.AddThreadRequest.keys:type_name -> threads.net.pb.Keys
-	19, // 3: threads.net.pb.NewRecordReply.record:type_name -> threads.net.pb.Record
-	19, // 4: threads.net.pb.AddRecordRequest.record:type_name -> threads.net.pb.Record
-	19, // 5: threads.net.pb.GetRecordReply.record:type_name -> threads.net.pb.Record
-	0,  // 6: threads.net.pb.API.GetHostID:input_type -> threads.net.pb.GetHostIDRequest
-	2,  // 7: threads.net.pb.API.GetToken:input_type -> threads.net.pb.GetTokenRequest
-	4,  // 8: threads.net.pb.API.CreateThread:input_type -> threads.net.pb.CreateThreadRequest
-	8,  // 9: threads.net.pb.API.AddThread:input_type -> threads.net.pb.AddThreadRequest
-	9,  // 10: threads.net.pb.API.GetThread:input_type -> threads.net.pb.GetThreadRequest
-	10, // 11: threads.net.pb.API.PullThread:input_type -> threads.net.pb.PullThreadRequest
-	12, // 12: threads.net.pb.API.DeleteThread:input_type -> threads.net.pb.DeleteThreadRequest
-	14, // 13: threads.net.pb.API.AddReplicator:input_type -> threads.net.pb.AddReplicatorRequest
-	16, // 14: threads.net.pb.API.CreateRecord:input_type -> threads.net.pb.CreateRecordRequest
-	18, // 15: threads.net.pb.API.AddRecord:input_type -> threads.net.pb.AddRecordRequest
-	21, // 16: threads.net.pb.API.GetRecord:input_type -> threads.net.pb.GetRecordRequest
-	23, // 17: threads.net.pb.API.Subscribe:input_type -> threads.net.pb.SubscribeRequest
-	1,  // 18: threads.net.pb.API.GetHostID:output_type -> threads.net.pb.GetHostIDReply
-	3,  // 19: threads.net.pb.API.GetToken:output_type -> threads.net.pb.GetTokenReply
-	6,  // 20: threads.net.pb.API.CreateThread:output_type -> threads.net.pb.ThreadInfoReply
-	6,  // 21: threads.net.pb.API.AddThread:output_type -> threads.net.pb.ThreadInfoReply
-	6,  // 22: threads.net.pb.API.GetThread:output_type -> threads.net.pb.ThreadInfoReply
-	11, // 23: threads.net.pb.API.PullThread:output_type -> threads.net.pb.PullThreadReply
-	13, // 24: threads.net.pb.API.DeleteThread:output_type -> threads.net.pb.DeleteThreadReply
-	15, // 25: threads.net.pb.API.AddReplicator:output_type -> threads.net.pb.AddReplicatorReply
-	17, // 26: threads.net.pb.API.CreateRecord:output_type -> threads.net.pb.NewRecordReply
-	20, // 27: threads.net.pb.API.AddRecord:output_type -> threads.net.pb.AddRecordReply
-	22, // 28: threads.net.pb.API.GetRecord:output_type -> threads.net.pb.GetRecordReply
-	17, // 29: threads.net.pb.API.Subscribe:output_type -> threads.net.pb.NewRecordReply
-	18, // [18:30] is the sub-list for method output_type
-	6,  // [6:18] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	6,  // 3: threads.net.pb.ListThreadsReply.threads:type_name -> threads.net.pb.ThreadInfoReply
+	20, // 4: threads.net.pb.GetReplicatorsReply.replicators:type_name -> threads.net.pb.ReplicatorInfo
+	21, // 5: threads.net.pb.ReplicatorInfo.logs:type_name -> threads.net.pb.ReplicatorLogInfo
+	25, // 6: threads.net.pb.NewRecordReply.record:type_name -> threads.net.pb.Record
+	25, // 7: threads.net.pb.AddRecordRequest.record:type_name -> threads.net.pb.Record
+	25, // 8: threads.net.pb.GetRecordReply.record:type_name -> threads.net.pb.Record
+	0,  // 9: threads.net.pb.API.GetHostID:input_type -> threads.net.pb.GetHostIDRequest
+	2,  // 10: threads.net.pb.API.GetToken:input_type -> threads.net.pb.GetTokenRequest
+	4,  // 11: threads.net.pb.API.CreateThread:input_type -> threads.net.pb.CreateThreadRequest
+	8,  // 12: threads.net.pb.API.AddThread:input_type -> threads.net.pb.AddThreadRequest
+	9,  // 13: threads.net.pb.API.GetThread:input_type -> threads.net.pb.GetThreadRequest
+	10, // 14: threads.net.pb.API.ListThreads:input_type -> threads.net.pb.ListThreadsRequest
+	12, // 15: threads.net.pb.API.PullThread:input_type -> threads.net.pb.PullThreadRequest
+	14, // 16: threads.net.pb.API.DeleteThread:input_type -> threads.net.pb.DeleteThreadRequest
+	16, // 17: threads.net.pb.API.AddReplicator:input_type -> threads.net.pb.AddReplicatorRequest
+	18, // 18: threads.net.pb.API.GetReplicators:input_type -> threads.net.pb.GetReplicatorsRequest
+	22, // 19: threads.net.pb.API.CreateRecord:input_type -> threads.net.pb.CreateRecordRequest
+	24, // 20: threads.net.pb.API.AddRecord:input_type -> threads.net.pb.AddRecordRequest
+	27, // 21: threads.net.pb.API.GetRecord:input_type -> threads.net.pb.GetRecordRequest
+	29, // 22: threads.net.pb.API.GetRecords:input_type -> threads.net.pb.GetRecordsRequest
+	30, // 23: threads.net.pb.API.Subscribe:input_type -> threads.net.pb.SubscribeRequest
+	31, // 24: threads.net.pb.API.ResyncThread:input_type -> threads.net.pb.ResyncThreadRequest
+	1,  // 25: threads.net.pb.API.GetHostID:output_type -> threads.net.pb.GetHostIDReply
+	3,  // 26: threads.net.pb.API.GetToken:output_type -> threads.net.pb.GetTokenReply
+	6,  // 27: threads.net.pb.API.CreateThread:output_type -> threads.net.pb.ThreadInfoReply
+	6,  // 28: threads.net.pb.API.AddThread:output_type -> threads.net.pb.ThreadInfoReply
+	6,  // 29: threads.net.pb.API.GetThread:output_type -> threads.net.pb.ThreadInfoReply
+	11, // 30: threads.net.pb.API.ListThreads:output_type -> threads.net.pb.ListThreadsReply
+	13, // 31: threads.net.pb.API.PullThread:output_type -> threads.net.pb.PullThreadReply
+	15, // 32: threads.net.pb.API.DeleteThread:output_type -> threads.net.pb.DeleteThreadReply
+	17, // 33: threads.net.pb.API.AddReplicator:output_type -> threads.net.pb.AddReplicatorReply
+	19, // 34: threads.net.pb.API.GetReplicators:output_type -> threads.net.pb.GetReplicatorsReply
+	23, // 35: threads.net.pb.API.CreateRecord:output_type -> threads.net.pb.NewRecordReply
+	26, // 36: threads.net.pb.API.AddRecord:output_type -> threads.net.pb.AddRecordReply
+	28, // 37: threads.net.pb.API.GetRecord:output_type -> threads.net.pb.GetRecordReply
+	28, // 38: threads.net.pb.API.GetRecords:output_type -> threads.net.pb.GetRecordReply
+	23, // 39: threads.net.pb.API.Subscribe:output_type -> threads.net.pb.NewRecordReply
+	32, // 40: threads.net.pb.API.ResyncThread:output_type -> threads.net.pb.ResyncThreadReply
+	25, // [25:41] is the sub-list for method output_type
+	9,  // [9:25] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_threadsnet_proto_init() }
@@ -1736,7 +2318,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PullThreadRequest); i {
+			switch v := v.(*ListThreadsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1748,7 +2330,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PullThreadReply); i {
+			switch v := v.(*ListThreadsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1760,7 +2342,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteThreadRequest); i {
+			switch v := v.(*PullThreadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1772,7 +2354,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteThreadReply); i {
+			switch v := v.(*PullThreadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1784,7 +2366,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddReplicatorRequest); i {
+			switch v := v.(*DeleteThreadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1796,7 +2378,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddReplicatorReply); i {
+			switch v := v.(*DeleteThreadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1808,7 +2390,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateRecordRequest); i {
+			switch v := v.(*AddReplicatorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1820,7 +2402,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewRecordReply); i {
+			switch v := v.(*AddReplicatorReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1832,7 +2414,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRecordRequest); i {
+			switch v := v.(*GetReplicatorsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1844,7 +2426,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Record); i {
+			switch v := v.(*GetReplicatorsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1856,7 +2438,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddRecordReply); i {
+			switch v := v.(*ReplicatorInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1868,7 +2450,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRecordRequest); i {
+			switch v := v.(*ReplicatorLogInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1880,7 +2462,7 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRecordReply); i {
+			switch v := v.(*CreateRecordRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1892,6 +2474,90 @@ func file_threadsnet_proto_init() {
 			}
 		}
 		file_threadsnet_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Record); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRecordsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SubscribeRequest); i {
 			case 0:
 				return &v.state
@@ -1903,6 +2569,30 @@ func file_threadsnet_proto_init() {
 				return nil
 			}
 		}
+		file_threadsnet_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResyncThreadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_threadsnet_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResyncThreadReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_threadsnet_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*GetTokenRequest_Key)(nil),
@@ -1918,7 +2608,7 @@ func file_threadsnet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_threadsnet_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,13 +23,17 @@ type APIClient interface {
 	CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
 	AddThread(ctx context.Context, in *AddThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
 	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*ThreadInfoReply, error)
+	ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsReply, error)
 	PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error)
 	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*DeleteThreadReply, error)
 	AddReplicator(ctx context.Context, in *AddReplicatorRequest, opts ...grpc.CallOption) (*AddReplicatorReply, error)
+	GetReplicators(ctx context.Context, in *GetReplicatorsRequest, opts ...grpc.CallOption) (*GetReplicatorsReply, error)
 	CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error)
 	AddRecord(ctx context.Context, in *AddRecordRequest, opts ...grpc.CallOption) (*AddRecordReply, error)
 	GetRecord(ctx context.Context, in *GetRecordRequest, opts ...grpc.CallOption) (*GetRecordReply, error)
+	GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (API_GetRecordsClient, error)
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error)
+	ResyncThread(ctx context.Context, in *ResyncThreadRequest, opts ...grpc.CallOption) (*ResyncThreadReply, error)
 }
 
 type aPIClient struct {
@@ -107,6 +111,15 @@ func (c *aPIClient) GetThread(ctx context.Context, in *GetThreadRequest, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsReply, error) {
+	out := new(ListThreadsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/ListThreads", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) PullThread(ctx context.Context, in *PullThreadRequest, opts ...grpc.CallOption) (*PullThreadReply, error) {
 	out := new(PullThreadReply)
 	err := c.cc.Invoke(ctx, "/threads.net.pb.API/PullThread", in, out, opts...)
@@ -134,6 +147,15 @@ func (c *aPIClient) AddReplicator(ctx context.Context, in *AddReplicatorRequest,
 	return out, nil
 }
 
+func (c *aPIClient) GetReplicators(ctx context.Context, in *GetReplicatorsRequest, opts ...grpc.CallOption) (*GetReplicatorsReply, error) {
+	out := new(GetReplicatorsReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/GetReplicators", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) CreateRecord(ctx context.Context, in *CreateRecordRequest, opts ...grpc.CallOption) (*NewRecordReply, error) {
 	out := new(NewRecordReply)
 	err := c.cc.Invoke(ctx, "/threads.net.pb.API/CreateRecord", in, out, opts...)
@@ -161,8 +183,40 @@ func (c *aPIClient) GetRecord(ctx context.Context, in *GetRecordRequest, opts ..
 	return out, nil
 }
 
+func (c *aPIClient) GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (API_GetRecordsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], "/threads.net.pb.API/GetRecords", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIGetRecordsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_GetRecordsClient interface {
+	Recv() (*GetRecordReply, error)
+	grpc.ClientStream
+}
+
+type aPIGetRecordsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIGetRecordsClient) Recv() (*GetRecordReply, error) {
+	m := new(GetRecordReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *aPIClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (API_SubscribeClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], "/threads.net.pb.API/Subscribe", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[2], "/threads.net.pb.API/Subscribe", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +247,15 @@ func (x *aPISubscribeClient) Recv() (*NewRecordReply, error) {
 	return m, nil
 }
 
+func (c *aPIClient) ResyncThread(ctx context.Context, in *ResyncThreadRequest, opts ...grpc.CallOption) (*ResyncThreadReply, error) {
+	out := new(ResyncThreadReply)
+	err := c.cc.Invoke(ctx, "/threads.net.pb.API/ResyncThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 // All implementations must embed UnimplementedAPIServer
 // for forward compatibility
@@ -202,13 +265,17 @@ type APIServer interface {
 	CreateThread(context.Context, *CreateThreadRequest) (*ThreadInfoReply, error)
 	AddThread(context.Context, *AddThreadRequest) (*ThreadInfoReply, error)
 	GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error)
+	ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsReply, error)
 	PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error)
 	DeleteThread(context.Context, *DeleteThreadRequest) (*DeleteThreadReply, error)
 	AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error)
+	GetReplicators(context.Context, *GetReplicatorsRequest) (*GetReplicatorsReply, error)
 	CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error)
 	AddRecord(context.Context, *AddRecordRequest) (*AddRecordReply, error)
 	GetRecord(context.Context, *GetRecordRequest) (*GetRecordReply, error)
+	GetRecords(*GetRecordsRequest, API_GetRecordsServer) error
 	Subscribe(*SubscribeRequest, API_SubscribeServer) error
+	ResyncThread(context.Context, *ResyncThreadRequest) (*ResyncThreadReply, error)
 	mustEmbedUnimplementedAPIServer()
 }
 
@@ -231,6 +298,9 @@ func (UnimplementedAPIServer) AddThread(context.Context, *AddThreadRequest) (*Th
 func (UnimplementedAPIServer) GetThread(context.Context, *GetThreadRequest) (*ThreadInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
 }
+func (UnimplementedAPIServer) ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreads not implemented")
+}
 func (UnimplementedAPIServer) PullThread(context.Context, *PullThreadRequest) (*PullThreadReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PullThread not implemented")
 }
@@ -240,6 +310,9 @@ func (UnimplementedAPIServer) DeleteThread(context.Context, *DeleteThreadRequest
 func (UnimplementedAPIServer) AddReplicator(context.Context, *AddReplicatorRequest) (*AddReplicatorReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddReplicator not implemented")
 }
+func (UnimplementedAPIServer) GetReplicators(context.Context, *GetReplicatorsRequest) (*GetReplicatorsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplicators not implemented")
+}
 func (UnimplementedAPIServer) CreateRecord(context.Context, *CreateRecordRequest) (*NewRecordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateRecord not implemented")
 }
@@ -249,9 +322,15 @@ func (UnimplementedAPIServer) AddRecord(context.Context, *AddRecordRequest) (*Ad
 func (UnimplementedAPIServer) GetRecord(context.Context, *GetRecordRequest) (*GetRecordReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRecord not implemented")
 }
+func (UnimplementedAPIServer) GetRecords(*GetRecordsRequest, API_GetRecordsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRecords not implemented")
+}
 func (UnimplementedAPIServer) Subscribe(*SubscribeRequest, API_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedAPIServer) ResyncThread(context.Context, *ResyncThreadRequest) (*ResyncThreadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResyncThread not implemented")
+}
 func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
 
 // UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -363,6 +442,24 @@ func _API_GetThread_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_ListThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/ListThreads",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListThreads(ctx, req.(*ListThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_PullThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PullThreadRequest)
 	if err := dec(in); err != nil {
@@ -417,6 +514,24 @@ func _API_AddReplicator_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetReplicators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplicatorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetReplicators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/GetReplicators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetReplicators(ctx, req.(*GetReplicatorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_CreateRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRecordRequest)
 	if err := dec(in); err != nil {
@@ -471,6 +586,27 @@ func _API_GetRecord_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetRecords_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRecordsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).GetRecords(m, &aPIGetRecordsServer{stream})
+}
+
+type API_GetRecordsServer interface {
+	Send(*GetRecordReply) error
+	grpc.ServerStream
+}
+
+type aPIGetRecordsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIGetRecordsServer) Send(m *GetRecordReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _API_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -492,6 +628,24 @@ func (x *aPISubscribeServer) Send(m *NewRecordReply) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _API_ResyncThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResyncThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ResyncThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/threads.net.pb.API/ResyncThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ResyncThread(ctx, req.(*ResyncThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // API_ServiceDesc is the grpc.ServiceDesc for API service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -515,6 +669,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetThread",
 			Handler:    _API_GetThread_Handler,
 		},
+		{
+			MethodName: "ListThreads",
+			Handler:    _API_ListThreads_Handler,
+		},
 		{
 			MethodName: "PullThread",
 			Handler:    _API_PullThread_Handler,
@@ -527,6 +685,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddReplicator",
 			Handler:    _API_AddReplicator_Handler,
 		},
+		{
+			MethodName: "GetReplicators",
+			Handler:    _API_GetReplicators_Handler,
+		},
 		{
 			MethodName: "CreateRecord",
 			Handler:    _API_CreateRecord_Handler,
@@ -539,6 +701,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRecord",
 			Handler:    _API_GetRecord_Handler,
 		},
+		{
+			MethodName: "ResyncThread",
+			Handler:    _API_ResyncThread_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -547,6 +713,11 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "GetRecords",
+			Handler:       _API_GetRecords_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Subscribe",
 			Handler:       _API_Subscribe_Handler,
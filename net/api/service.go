@@ -222,6 +222,37 @@ func (s *Service) GetThread(ctx context.Context, req *pb.GetThreadRequest) (*pb.
 	return threadInfoToProto(info)
 }
 
+func (s *Service) ListThreads(ctx context.Context, req *pb.ListThreadsRequest) (*pb.ListThreadsReply, error) {
+	log.Debugf("received list threads request")
+
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := []net.ListThreadsOption{
+		net.WithListToken(token),
+		net.WithListLimit(int(req.Limit)),
+	}
+	if len(req.Offset) > 0 {
+		offset, err := thread.Cast(req.Offset)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, net.WithListOffset(offset))
+	}
+	infos, err := s.net.ListThreads(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	threads := make([]*pb.ThreadInfoReply, len(infos))
+	for i, info := range infos {
+		if threads[i], err = threadInfoToProto(info); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.ListThreadsReply{Threads: threads}, nil
+}
+
 func (s *Service) PullThread(ctx context.Context, req *pb.PullThreadRequest) (*pb.PullThreadReply, error) {
 	log.Debugf("received pull thread request")
 
@@ -256,6 +287,23 @@ func (s *Service) DeleteThread(ctx context.Context, req *pb.DeleteThreadRequest)
 	return &pb.DeleteThreadReply{}, nil
 }
 
+func (s *Service) ResyncThread(ctx context.Context, req *pb.ResyncThreadRequest) (*pb.ResyncThreadReply, error) {
+	log.Debugf("received resync thread request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.net.ResyncThread(ctx, id, net.WithThreadToken(token)); err != nil {
+		return nil, err
+	}
+	return &pb.ResyncThreadReply{}, nil
+}
+
 func (s *Service) AddReplicator(ctx context.Context, req *pb.AddReplicatorRequest) (*pb.AddReplicatorReply, error) {
 	log.Debugf("received add replicator request")
 
@@ -280,6 +328,26 @@ func (s *Service) AddReplicator(ctx context.Context, req *pb.AddReplicatorReques
 	}, nil
 }
 
+func (s *Service) GetReplicators(ctx context.Context, req *pb.GetReplicatorsRequest) (*pb.GetReplicatorsReply, error) {
+	log.Debugf("received get replicators request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reps, err := s.net.GetReplicators(ctx, id, net.WithThreadToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetReplicatorsReply{
+		Replicators: replicatorsToProto(reps),
+	}, nil
+}
+
 func (s *Service) CreateRecord(ctx context.Context, req *pb.CreateRecordRequest) (*pb.NewRecordReply, error) {
 	log.Debugf("received create record request")
 
@@ -367,6 +435,64 @@ func (s *Service) GetRecord(ctx context.Context, req *pb.GetRecordRequest) (*pb.
 	}, nil
 }
 
+// GetRecords streams up to req.Limit records of a log, walking its chain from req.From,
+// or from the log head if req.From is empty, towards the first record of the log.
+// A non-positive limit streams all the records up to the first record.
+func (s *Service) GetRecords(req *pb.GetRecordsRequest, server pb.API_GetRecordsServer) error {
+	log.Debugf("received get records request")
+
+	id, err := thread.Cast(req.ThreadID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	logID, err := peer.IDFromBytes(req.LogID)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx := server.Context()
+	token, err := thread.NewTokenFromMD(ctx)
+	if err != nil {
+		return err
+	}
+	info, err := s.net.GetThread(ctx, id, net.WithThreadToken(token))
+	if err != nil {
+		return err
+	}
+	var cursor cid.Cid
+	found := false
+	for _, lg := range info.Logs {
+		if lg.ID == logID {
+			cursor, found = lg.Head.ID, true
+			break
+		}
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "log %s not found", logID)
+	}
+	if len(req.From) > 0 {
+		if cursor, err = cid.Cast(req.From); err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	for sent := 0; cursor.Defined() && (req.Limit <= 0 || sent < int(req.Limit)); sent++ {
+		rec, err := s.net.GetRecord(ctx, id, cursor, net.WithThreadToken(token))
+		if err != nil {
+			return err
+		}
+		prec, err := cbor.RecordToProto(ctx, s.net, rec)
+		if err != nil {
+			return err
+		}
+		if err := server.Send(&pb.GetRecordReply{
+			Record: util.RecFromServiceRec(prec),
+		}); err != nil {
+			return err
+		}
+		cursor = rec.PrevID()
+	}
+	return nil
+}
+
 func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeServer) error {
 	log.Debugf("received subscribe request")
 
@@ -378,6 +504,13 @@ func (s *Service) Subscribe(req *pb.SubscribeRequest, server pb.API_SubscribeSer
 		}
 		opts[i] = net.WithSubFilter(id)
 	}
+	for _, id := range req.LogIDs {
+		logID, err := peer.IDFromBytes(id)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		opts = append(opts, net.WithSubLogs(logID))
+	}
 
 	token, err := thread.NewTokenFromMD(server.Context())
 	if err != nil {
@@ -435,6 +568,30 @@ func getKeyOptions(keys *pb.Keys) (opts []net.NewThreadOption, err error) {
 	return opts, nil
 }
 
+func replicatorsToProto(reps []net.ReplicatorInfo) []*pb.ReplicatorInfo {
+	pbreps := make([]*pb.ReplicatorInfo, len(reps))
+	for i, r := range reps {
+		logs := make([]*pb.ReplicatorLogInfo, len(r.Logs))
+		for j, lg := range r.Logs {
+			logs[j] = &pb.ReplicatorLogInfo{
+				LogID: marshalPeerID(lg.ID),
+				Head:  lg.Head.Bytes(),
+				Lag:   lg.Lag,
+			}
+		}
+		var lastContact int64
+		if !r.LastContact.IsZero() {
+			lastContact = r.LastContact.UnixNano()
+		}
+		pbreps[i] = &pb.ReplicatorInfo{
+			PeerID:      marshalPeerID(r.ID),
+			Logs:        logs,
+			LastContact: lastContact,
+		}
+	}
+	return pbreps
+}
+
 func threadInfoToProto(info thread.Info) (*pb.ThreadInfoReply, error) {
 	logs := make([]*pb.LogInfo, len(info.Logs))
 	for i, lg := range info.Logs {
@@ -468,10 +625,15 @@ func threadInfoToProto(info thread.Info) (*pb.ThreadInfoReply, error) {
 	for i, addr := range info.Addrs {
 		addrs[i] = addr.Bytes()
 	}
+	var updated int64
+	if !info.Updated.IsZero() {
+		updated = info.Updated.UnixNano()
+	}
 	return &pb.ThreadInfoReply{
 		ThreadID:  info.ID.Bytes(),
 		ThreadKey: info.Key.Bytes(),
 		Logs:      logs,
 		Addrs:     addrs,
+		Updated:   updated,
 	}, nil
 }
@@ -0,0 +1,337 @@
+package net
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+
+	format "github.com/ipfs/go-ipld-format"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+	pb "github.com/textileio/go-threads/net/pb"
+)
+
+const archiveVersion = 1
+
+var (
+	// ErrInvalidArchive indicates a thread archive is malformed or truncated.
+	ErrInvalidArchive = errors.New("invalid thread archive")
+
+	// ErrArchiveRecordInvalid indicates a record of a thread archive doesn't verify
+	// against the log which contains it.
+	ErrArchiveRecordInvalid = errors.New("thread archive record doesn't verify")
+)
+
+// archiveHeader starts a thread archive stream.
+type archiveHeader struct {
+	Version int
+	// Thread is the thread id.
+	Thread []byte
+	// Key is the thread key, including its named read keys.
+	Key []byte
+	// Logs are the thread logs, encoded as pb.Log.
+	Logs [][]byte
+	// ReadKeys are the read keys retained by key rotations, from newest to oldest.
+	ReadKeys [][]byte
+}
+
+// archiveRecord is a record of a thread archive, encoded as pb.Log_Record.
+// Records of a log follow each other from oldest to newest.
+// The last record of a stream has End set, and no record.
+type archiveRecord struct {
+	Log    int
+	Record []byte
+	End    bool
+}
+
+// ExportThread writes the key, logs and records of a thread to w.
+// Records are written with their event, header and body blocks, so the archive can be
+// imported by a node which isn't connected to any peer of the thread.
+// Private keys of logs aren't exported. Records of compacted logs can't be exported.
+func (n *net) ExportThread(ctx context.Context, id thread.ID, w io.Writer, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+
+	// Must block in case the thread is being pulled
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return err
+	}
+	if info.Key, err = n.withNamedReadKeys(id, info.Key); err != nil {
+		return err
+	}
+	header := archiveHeader{
+		Version: archiveVersion,
+		Thread:  id.Bytes(),
+		Key:     info.Key.Bytes(),
+		Logs:    make([][]byte, len(info.Logs)),
+	}
+	for i, lg := range info.Logs {
+		if header.Logs[i], err = logToProto(lg).Marshal(); err != nil {
+			return err
+		}
+	}
+	retained, err := n.retainedReadKeys(id)
+	if err != nil {
+		return err
+	}
+	for _, k := range retained {
+		header.ReadKeys = append(header.ReadKeys, k.Bytes())
+	}
+	enc := gob.NewEncoder(w)
+	if err := enc.Encode(header); err != nil {
+		return err
+	}
+
+	sk := info.Key.Service()
+	for i, lg := range info.Logs {
+		var recs []core.Record
+		for cursor := lg.Head.ID; cursor.Defined(); {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+			if err != nil {
+				return err
+			}
+			recs = append(recs, rec)
+			cursor = rec.PrevID()
+		}
+		for j := len(recs) - 1; j >= 0; j-- {
+			prec, err := cbor.RecordToProto(ctx, n, recs[j])
+			if err != nil {
+				return fmt.Errorf("exporting record %s: %w", recs[j].Cid(), err)
+			}
+			data, err := prec.Marshal()
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(archiveRecord{Log: i, Record: data}); err != nil {
+				return err
+			}
+		}
+	}
+	if err := enc.Encode(archiveRecord{End: true}); err != nil {
+		return err
+	}
+	log.Debugf("exported thread %s", id)
+	return nil
+}
+
+// ImportThread adds a thread from an archive written by ExportThread, returning its id.
+// All records are verified against the keys of their logs before anything is added,
+// so an archive containing an invalid record is rejected as a whole.
+// Imported logs are read-only, since their private keys aren't archived.
+func (n *net) ImportThread(ctx context.Context, r io.Reader, opts ...core.ThreadOption) (thread.ID, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	dec := gob.NewDecoder(r)
+	var header archiveHeader
+	if err := dec.Decode(&header); err != nil {
+		return thread.Undef, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if header.Version != archiveVersion {
+		return thread.Undef, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, header.Version)
+	}
+	id, err := thread.Cast(header.Thread)
+	if err != nil {
+		return thread.Undef, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	key, err := thread.KeyFromBytes(header.Key)
+	if err != nil {
+		return thread.Undef, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	for _, b := range header.ReadKeys {
+		if _, err := sym.FromBytes(b); err != nil {
+			return thread.Undef, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return thread.Undef, err
+	}
+	logs := make([]thread.LogInfo, len(header.Logs))
+	for i, data := range header.Logs {
+		plog := &pb.Log{}
+		if err := plog.Unmarshal(data); err != nil {
+			return thread.Undef, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		if plog.ID == nil || plog.PubKey == nil || plog.Head == nil {
+			return thread.Undef, fmt.Errorf("%w: incomplete log %d", ErrInvalidArchive, i)
+		}
+		logs[i] = logFromProto(plog)
+	}
+
+	nodes, err := readArchiveRecords(ctx, dec, key.Service(), logs)
+	if err != nil {
+		return thread.Undef, err
+	}
+
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	if _, err := n.store.GetThread(id); err == nil {
+		return thread.Undef, lstore.ErrThreadExists
+	} else if !errors.Is(err, lstore.ErrThreadNotFound) {
+		return thread.Undef, err
+	}
+	if err := n.AddMany(ctx, nodes); err != nil {
+		return thread.Undef, err
+	}
+	if err := n.addArchivedThread(thread.Info{ID: id, Key: key}, logs, header.ReadKeys); err != nil {
+		if err := n.store.DeleteThread(id); err != nil {
+			log.Errorf("cleaning up import of thread %s: %v", id, err)
+		}
+		return thread.Undef, err
+	}
+	if err := n.server.addPubsubTopic(id); err != nil {
+		return thread.Undef, err
+	}
+	log.Debugf("imported thread %s", id)
+	return id, nil
+}
+
+// readArchiveRecords decodes and verifies the records of a thread archive,
+// returning their blocks. Each log must be complete up to its head, whose counter
+// is set to the number of records of the log.
+func readArchiveRecords(
+	ctx context.Context,
+	dec *gob.Decoder,
+	sk *sym.Key,
+	logs []thread.LogInfo,
+) ([]format.Node, error) {
+	var (
+		nodes  []format.Node
+		heads  = make([]core.Record, len(logs))
+		counts = make([]int64, len(logs))
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var entry archiveRecord
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		if entry.End {
+			break
+		}
+		if entry.Log < 0 || entry.Log >= len(logs) {
+			return nil, fmt.Errorf("%w: unknown log %d", ErrInvalidArchive, entry.Log)
+		}
+		lg := logs[entry.Log]
+		prec := &pb.Log_Record{}
+		if err := prec.Unmarshal(entry.Record); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		rec, err := cbor.RecordFromProto(prec, sk)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		rnodes, err := verifyArchiveRecord(ctx, rec, heads[entry.Log], lg)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, rnodes...)
+		heads[entry.Log] = rec
+		counts[entry.Log]++
+	}
+	for i, lg := range logs {
+		var head = thread.HeadUndef.ID
+		if heads[i] != nil {
+			head = heads[i].Cid()
+		}
+		if !head.Equals(lg.Head.ID) {
+			return nil, fmt.Errorf("%w: log %s is incomplete", ErrInvalidArchive, lg.ID)
+		}
+		logs[i].Head.Counter = counts[i]
+	}
+	return nodes, nil
+}
+
+// verifyArchiveRecord returns ErrArchiveRecordInvalid if rec isn't signed by the key of log lg,
+// doesn't follow prev, or doesn't link its blocks. Otherwise, it returns the record blocks.
+func verifyArchiveRecord(ctx context.Context, rec core.Record, prev core.Record, lg thread.LogInfo) ([]format.Node, error) {
+	var prevID = thread.HeadUndef.ID
+	if prev != nil {
+		prevID = prev.Cid()
+	}
+	if !rec.PrevID().Equals(prevID) {
+		return nil, fmt.Errorf("%w: record %s doesn't follow %s in log %s", ErrArchiveRecordInvalid, rec.Cid(), prevID, lg.ID)
+	}
+	// The blocks are already loaded, the dag isn't used
+	event, err := cbor.EventFromRecord(ctx, nil, rec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if !event.Cid().Equals(rec.BlockID()) {
+		return nil, fmt.Errorf("%w: record %s event doesn't match", ErrArchiveRecordInvalid, rec.Cid())
+	}
+	if err := rec.Verify(lg.PubKey); err != nil {
+		return nil, fmt.Errorf("%w: record %s in log %s: %v", ErrArchiveRecordInvalid, rec.Cid(), lg.ID, err)
+	}
+	header, err := event.GetHeader(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	body, err := event.GetBody(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if !header.Cid().Equals(event.HeaderID()) || !body.Cid().Equals(event.BodyID()) {
+		return nil, fmt.Errorf("%w: record %s blocks don't match its event", ErrArchiveRecordInvalid, rec.Cid())
+	}
+	// Chunks were checked against the body links when the record was decoded
+	chunks, err := event.GetChunks(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: record %s: %v", ErrInvalidArchive, rec.Cid(), err)
+	}
+	return append([]format.Node{rec, event, header, body}, chunks...), nil
+}
+
+// addArchivedThread adds an imported thread, its logs and retained read keys to the logstore.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) addArchivedThread(info thread.Info, logs []thread.LogInfo, readKeys [][]byte) error {
+	if err := n.store.AddThread(info); err != nil {
+		return err
+	}
+	if err := n.addNamedReadKeys(info.ID, info.Key); err != nil {
+		return err
+	}
+	if len(readKeys) > 0 {
+		if err := n.putRetainedReadKeys(info.ID, readKeys); err != nil {
+			return err
+		}
+	}
+	for _, lg := range logs {
+		head := lg.Head
+		lg.Head = thread.HeadUndef
+		if err := n.store.AddLog(info.ID, lg); err != nil {
+			return err
+		}
+		if head.ID.Defined() {
+			if err := n.store.SetHead(info.ID, lg.ID, head); err != nil {
+				return err
+			}
+		}
+	}
+	n.notifyMembership(info.ID, core.LogAdded, info.Key.CanRead(), logs...)
+	return nil
+}
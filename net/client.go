@@ -91,21 +91,25 @@ func (s *server) pushLog(ctx context.Context, id thread.ID, lg thread.LogInfo, p
 
 	log.Debugf("pushing log %s to %s...", lg.ID, pid)
 
-	client, err := s.dial(pid)
-	if err != nil {
-		return fmt.Errorf("dial %s failed: %w", pid, err)
-	}
-	cctx, cancel := context.WithTimeout(ctx, PushTimeout)
-	defer cancel()
-	_, err = client.PushLog(cctx, lreq)
+	err := s.net.retry(ctx, func() error {
+		client, err := s.dial(pid)
+		if err != nil {
+			return fmt.Errorf("dial %s failed: %w", pid, err)
+		}
+		cctx, cancel := context.WithTimeout(ctx, PushTimeout)
+		defer cancel()
+		_, err = client.PushLog(cctx, lreq)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("push log to %s failed: %w", pid, err)
 	}
-	return err
+	return nil
 }
 
 // getRecords from specified peers.
 func (s *server) getRecords(
+	ctx context.Context,
 	peers []peer.ID,
 	tid thread.ID,
 	offsets map[peer.ID]thread.Head,
@@ -128,7 +132,7 @@ func (s *server) getRecords(
 		go withErrLog(p, func(pid peer.ID) error {
 			defer wg.Done()
 
-			return s.net.queueGetRecords.Call(pid, tid, func(ctx context.Context, pid peer.ID, tid thread.ID) error {
+			return s.net.queueGetRecords.Call(pid, tid, func(_ context.Context, pid peer.ID, tid thread.ID) error {
 				recs, err := s.getRecordsFromPeer(ctx, tid, pid, req, sk)
 				if err != nil {
 					return err
@@ -198,19 +202,23 @@ func (s *server) getRecordsFromPeer(
 	serviceKey *sym.Key,
 ) (map[peer.ID]peerRecords, error) {
 	log.Debugf("getting records from %s...", pid)
-	client, err := s.dial(pid)
-	if err != nil {
-		return nil, fmt.Errorf("dial %s failed: %w", pid, err)
-	}
-
 	recs := make(map[peer.ID]peerRecords)
-	cctx, cancel := context.WithTimeout(ctx, PullTimeout)
-	defer cancel()
-	reply, err := client.GetRecords(cctx, req)
+	var reply *pb.GetRecordsReply
+	err := s.net.retry(ctx, func() error {
+		client, err := s.dial(pid)
+		if err != nil {
+			return fmt.Errorf("dial %s failed: %w", pid, err)
+		}
+		cctx, cancel := context.WithTimeout(ctx, PullTimeout)
+		defer cancel()
+		reply, err = client.GetRecords(cctx, req)
+		return err
+	})
 	if err != nil {
 		log.Warnf("get records from %s failed: %s", pid, err)
 		return recs, nil
 	}
+	s.net.touchPeer(pid)
 
 	for _, l := range reply.Logs {
 		var logID = l.LogID.ID
@@ -325,6 +333,7 @@ func (s *server) pushRecordToPeer(
 	defer cancel()
 	_, err = client.PushRecord(rctx, req)
 	if err == nil {
+		s.net.touchPeer(pid)
 		return nil
 	}
 
@@ -0,0 +1,189 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	bs "github.com/ipfs/go-ipfs-blockstore"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var (
+	// ErrRecordNotFound indicates a record doesn't belong to any log of a thread.
+	ErrRecordNotFound = errors.New("record not found in thread")
+
+	// ErrUnacknowledgedRecords indicates records can't be compacted because a replicator
+	// of the thread hasn't acknowledged them yet.
+	ErrUnacknowledgedRecords = errors.New("records not acknowledged by replicator")
+)
+
+func (n *net) Compact(ctx context.Context, id thread.ID, before cid.Cid, opts ...core.ThreadOption) error {
+	_, err := n.compactThread(ctx, id, before, false, opts...)
+	return err
+}
+
+func (n *net) CompactDryRun(
+	ctx context.Context,
+	id thread.ID,
+	before cid.Cid,
+	opts ...core.ThreadOption,
+) (int64, error) {
+	return n.compactThread(ctx, id, before, true, opts...)
+}
+
+func (n *net) compactThread(
+	ctx context.Context,
+	id thread.ID,
+	before cid.Cid,
+	dryRun bool,
+	opts ...core.ThreadOption,
+) (int64, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, dryRun); err != nil {
+		return 0, err
+	}
+
+	// Must block in case the thread is being pulled
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	return n.compact(ctx, id, before, dryRun)
+}
+
+// compact removes the events of the records preceding before in its log, returning the
+// number of bytes reclaimed. Records are kept, so the log can still be traversed.
+// Records which a replicator of the thread hasn't acknowledged are never removed.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) compact(ctx context.Context, id thread.ID, before cid.Cid, dryRun bool) (int64, error) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return 0, err
+	}
+	sk := info.Key.Service()
+	if sk == nil {
+		return 0, fmt.Errorf("a service-key is required to compact records")
+	}
+	rec, err := cbor.GetRecord(ctx, n, before, sk)
+	if errors.Is(err, format.ErrNotFound) {
+		return 0, fmt.Errorf("%w: %s", ErrRecordNotFound, before)
+	} else if err != nil {
+		return 0, err
+	}
+	lid, ok := recordLog(info, rec)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrRecordNotFound, before)
+	}
+	counter, err := n.countRecords(ctx, id, before)
+	if err != nil {
+		return 0, err
+	}
+	if counter <= 1 {
+		return 0, nil
+	}
+	if err := n.checkAcknowledged(info, lid, counter-1); err != nil {
+		return 0, err
+	}
+
+	var reclaimed int64
+	for cursor := rec.PrevID(); cursor.Defined(); {
+		if err := ctx.Err(); err != nil {
+			return reclaimed, err
+		}
+		r, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			return reclaimed, err
+		}
+		size, ok, err := n.compactRecord(ctx, r, dryRun)
+		if err != nil {
+			return reclaimed, err
+		} else if !ok {
+			// Preceding records were compacted along with this one
+			break
+		}
+		reclaimed += size
+		cursor = r.PrevID()
+	}
+	if !dryRun {
+		log.Debugf("compacted thread %s up to %s, %d bytes reclaimed", id, before, reclaimed)
+	}
+	return reclaimed, nil
+}
+
+// compactRecord removes the event of a record, returning its size in bytes, including the
+// chunks of its body.
+// It returns false if the event was already removed.
+func (n *net) compactRecord(ctx context.Context, rec core.Record, dryRun bool) (int64, bool, error) {
+	if ok, err := n.bstore.Has(rec.BlockID()); err != nil || !ok {
+		return 0, false, err
+	}
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if err != nil {
+		return 0, false, err
+	}
+	ids := []cid.Cid{event.Cid(), event.HeaderID(), event.BodyID()}
+	// The chunks of a chunked body are removed along with the event
+	if ok, err := n.bstore.Has(event.BodyID()); err != nil {
+		return 0, false, err
+	} else if ok {
+		chunks, err := event.ChunkIDs(ctx, n)
+		if err != nil {
+			return 0, false, err
+		}
+		ids = append(ids, chunks...)
+	}
+	var size int64
+	for _, c := range ids {
+		s, err := n.bstore.GetSize(c)
+		if errors.Is(err, bs.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return 0, false, err
+		}
+		size += int64(s)
+	}
+	if !dryRun {
+		if err := cbor.RemoveEvent(ctx, n, event); err != nil {
+			return 0, false, err
+		}
+	}
+	return size, true, nil
+}
+
+// recordLog returns the id of the thread log which signed rec.
+func recordLog(info thread.Info, rec core.Record) (peer.ID, bool) {
+	for _, lg := range info.Logs {
+		if lg.PubKey != nil && rec.Verify(lg.PubKey) == nil {
+			return lg.ID, true
+		}
+	}
+	return "", false
+}
+
+// checkAcknowledged returns ErrUnacknowledgedRecords if a replicator of the thread hasn't
+// acknowledged the records of log lid up to counter.
+func (n *net) checkAcknowledged(info thread.Info, lid peer.ID, counter int64) error {
+	peers, err := n.threadPeers(info)
+	if err != nil {
+		return err
+	}
+	for _, p := range peers {
+		acked, err := n.store.GetInt64(info.ID, ackKey(p, lid, ackCounter))
+		if err != nil {
+			return err
+		}
+		if acked == nil || *acked < counter {
+			return fmt.Errorf("%w: %s (log %s)", ErrUnacknowledgedRecords, p, lid)
+		}
+	}
+	return nil
+}
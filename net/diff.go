@@ -0,0 +1,301 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+const (
+	// diffProtocol is the libp2p protocol serving the record ids of threads to DiffThread.
+	diffProtocol protocol.ID = "/" + thread.Name + "/diff/" + thread.Version
+
+	// diffTimeout bounds a diff request, including the walk of the logs by the peer.
+	diffTimeout = time.Minute
+
+	// maxDiffRecords bounds the number of record ids of a log sent in a diff reply.
+	// Older records of longer logs aren't compared.
+	maxDiffRecords = 1 << 20
+)
+
+// ErrDiffFailed indicates a peer couldn't serve the record ids of a thread.
+var ErrDiffFailed = errors.New("thread diff failed")
+
+// diffRequest requests the record ids of the logs of a thread.
+type diffRequest struct {
+	Thread []byte
+	// ServiceKey must be the service key of the thread.
+	ServiceKey []byte
+}
+
+// diffReply holds the record ids of the logs of a thread, or the error serving them.
+type diffReply struct {
+	Error string
+	Logs  []diffLog
+}
+
+// diffLog holds the record ids of a log, from oldest to newest.
+type diffLog struct {
+	ID      peer.ID
+	Head    []byte
+	Counter int64
+	Records [][]byte
+}
+
+// logChain is the head of a log and the ids of its records, from oldest to newest.
+type logChain struct {
+	head    thread.Head
+	records []cid.Cid
+}
+
+// DiffThread fetches the record ids of each log of a thread from peer p over the diff protocol,
+// and compares them with the local record ids. The peer must be known to the host, and only
+// replies if it hosts the thread under the same service key.
+func (n *net) DiffThread(
+	ctx context.Context,
+	id thread.ID,
+	p peer.ID,
+	opts ...core.ThreadOption,
+) (core.DiffReport, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	report := core.DiffReport{ThreadID: id, Peer: p}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return report, err
+	}
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return report, err
+	}
+	if sk == nil {
+		return report, fmt.Errorf("a service-key is required to diff records")
+	}
+	local, err := n.recordIDs(ctx, id, sk)
+	if err != nil {
+		return report, err
+	}
+	remote, err := n.remoteRecordIDs(ctx, id, p, sk)
+	if err != nil {
+		return report, err
+	}
+	report.Logs = diffLogs(local, remote)
+	log.Debugf("diffed thread %s with %s: %d logs differ", id, p, len(report.Logs))
+	return report, nil
+}
+
+// remoteRecordIDs requests the record ids of the logs of a thread from peer p.
+func (n *net) remoteRecordIDs(
+	ctx context.Context,
+	id thread.ID,
+	p peer.ID,
+	sk *sym.Key,
+) (map[peer.ID]logChain, error) {
+	ctx, cancel := context.WithTimeout(ctx, diffTimeout)
+	defer cancel()
+	s, err := n.host.NewStream(ctx, p, diffProtocol)
+	if err != nil {
+		return nil, fmt.Errorf("opening diff stream to %s: %w", p, err)
+	}
+	defer s.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetDeadline(deadline)
+	}
+	if err := gob.NewEncoder(s).Encode(diffRequest{Thread: id.Bytes(), ServiceKey: sk.Bytes()}); err != nil {
+		_ = s.Reset()
+		return nil, fmt.Errorf("sending diff request to %s: %w", p, err)
+	}
+	var reply diffReply
+	if err := gob.NewDecoder(s).Decode(&reply); err != nil {
+		_ = s.Reset()
+		return nil, fmt.Errorf("receiving diff reply from %s: %w", p, err)
+	}
+	if reply.Error != "" {
+		return nil, fmt.Errorf("%w: %s: %s", ErrDiffFailed, p, reply.Error)
+	}
+
+	chains := make(map[peer.ID]logChain, len(reply.Logs))
+	for _, l := range reply.Logs {
+		chain := logChain{head: thread.HeadUndef, records: make([]cid.Cid, len(l.Records))}
+		if len(l.Head) > 0 {
+			if chain.head.ID, err = cid.Cast(l.Head); err != nil {
+				return nil, fmt.Errorf("%w: %s: invalid head of log %s", ErrDiffFailed, p, l.ID)
+			}
+			chain.head.Counter = l.Counter
+		}
+		for i, r := range l.Records {
+			if chain.records[i], err = cid.Cast(r); err != nil {
+				return nil, fmt.Errorf("%w: %s: invalid record of log %s", ErrDiffFailed, p, l.ID)
+			}
+		}
+		chains[l.ID] = chain
+	}
+	return chains, nil
+}
+
+// handleDiff serves a diff request received on s.
+func (n *net) handleDiff(s network.Stream) {
+	defer s.Close()
+	_ = s.SetDeadline(time.Now().Add(diffTimeout))
+	pid := s.Conn().RemotePeer()
+	var req diffRequest
+	if err := gob.NewDecoder(s).Decode(&req); err != nil {
+		log.Warnf("receiving diff request from %s: %v", pid, err)
+		_ = s.Reset()
+		return
+	}
+	log.Debugf("received diff request from %s", pid)
+	if err := gob.NewEncoder(s).Encode(n.diffReply(req)); err != nil {
+		log.Warnf("sending diff reply to %s: %v", pid, err)
+		_ = s.Reset()
+	}
+}
+
+// diffReply returns the record ids of the thread of req, if the service key of req is valid.
+func (n *net) diffReply(req diffRequest) diffReply {
+	id, err := thread.Cast(req.Thread)
+	if err != nil {
+		return diffReply{Error: err.Error()}
+	}
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return diffReply{Error: err.Error()}
+	}
+	if sk == nil || !bytes.Equal(req.ServiceKey, sk.Bytes()) {
+		// Don't tell unknown threads from invalid keys
+		return diffReply{Error: "invalid service-key"}
+	}
+	ctx, cancel := context.WithTimeout(n.ctx, diffTimeout)
+	defer cancel()
+	chains, err := n.recordIDs(ctx, id, sk)
+	if err != nil {
+		return diffReply{Error: err.Error()}
+	}
+	reply := diffReply{Logs: make([]diffLog, 0, len(chains))}
+	for lid, chain := range chains {
+		l := diffLog{ID: lid, Records: make([][]byte, len(chain.records))}
+		if chain.head.ID.Defined() {
+			l.Head = chain.head.ID.Bytes()
+			l.Counter = chain.head.Counter
+		}
+		for i, r := range chain.records {
+			l.Records[i] = r.Bytes()
+		}
+		reply.Logs = append(reply.Logs, l)
+	}
+	return reply
+}
+
+// recordIDs returns the ids of the stored records of each log of a thread, walking back from
+// the heads until a record isn't stored, e.g., because it was skipped by PullThreadDepth.
+// The heads are read once, and the thread-lock isn't held.
+func (n *net) recordIDs(ctx context.Context, id thread.ID, sk *sym.Key) (map[peer.ID]logChain, error) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return nil, err
+	}
+	chains := make(map[peer.ID]logChain, len(info.Logs))
+	for _, lg := range info.Logs {
+		var recs []cid.Cid
+		for cursor := lg.Head.ID; cursor.Defined() && len(recs) < maxDiffRecords; {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if ok, err := n.isKnown(cursor); err != nil {
+				return nil, err
+			} else if !ok {
+				break
+			}
+			rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+			if err != nil {
+				return nil, err
+			}
+			recs = append(recs, cursor)
+			cursor = rec.PrevID()
+		}
+		for i, j := 0, len(recs)-1; i < j; i, j = i+1, j-1 {
+			recs[i], recs[j] = recs[j], recs[i]
+		}
+		chains[lg.ID] = logChain{head: lg.Head, records: recs}
+	}
+	return chains, nil
+}
+
+// diffLogs compares the record ids of the logs of a thread on the host and on a peer,
+// returning the logs whose records differ, in order of log id.
+func diffLogs(local, remote map[peer.ID]logChain) []core.LogDiff {
+	ids := make([]peer.ID, 0, len(local)+len(remote))
+	for lid := range local {
+		ids = append(ids, lid)
+	}
+	for lid := range remote {
+		if _, ok := local[lid]; !ok {
+			ids = append(ids, lid)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var diffs []core.LogDiff
+	for _, lid := range ids {
+		l, ok := local[lid]
+		if !ok {
+			l.head = thread.HeadUndef
+		}
+		r, ok := remote[lid]
+		if !ok {
+			r.head = thread.HeadUndef
+		}
+		i := 0
+		for i < len(l.records) && i < len(r.records) && l.records[i].Equals(r.records[i]) {
+			i++
+		}
+		if i == len(l.records) && i == len(r.records) {
+			continue
+		}
+		d := core.LogDiff{
+			ID:            lid,
+			LocalHead:     l.head,
+			RemoteHead:    r.head,
+			Index:         i,
+			MissingLocal:  missingRecords(r.records, l.records),
+			MissingRemote: missingRecords(l.records, r.records),
+		}
+		if i < len(l.records) {
+			d.Local = l.records[i]
+		}
+		if i < len(r.records) {
+			d.Remote = r.records[i]
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// missingRecords returns the records of from which aren't in to, keeping their order.
+func missingRecords(from, to []cid.Cid) []cid.Cid {
+	has := make(map[cid.Cid]struct{}, len(to))
+	for _, r := range to {
+		has[r] = struct{}{}
+	}
+	var missing []cid.Cid
+	for _, r := range from {
+		if _, ok := has[r]; !ok {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
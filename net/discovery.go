@@ -0,0 +1,104 @@
+package net
+
+import (
+	"context"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var (
+	// DiscoveryInterval is the interval at which threads are advertised and their peers looked up.
+	DiscoveryInterval = time.Minute * 10
+
+	// DiscoveryTimeout is the maximum duration of advertising or looking up a single thread.
+	DiscoveryTimeout = time.Minute
+
+	// DiscoveryPeerLimit is the maximum number of peers looked up per thread and cycle.
+	DiscoveryPeerLimit = 20
+
+	// discoveryKeyPrefix namespaces rendezvous keys, so they don't collide with other content.
+	discoveryKeyPrefix = []byte("/threads/discovery/")
+)
+
+// discoveryKey returns the rendezvous key under which peers of a thread advertise themselves.
+// It's a hash of the thread id, so the thread can't be derived from the key.
+func discoveryKey(id thread.ID) (cid.Cid, error) {
+	data := append(append([]byte{}, discoveryKeyPrefix...), id.Bytes()...)
+	hash, err := mh.Sum(data, mh.SHA2_256, -1)
+	if err != nil {
+		return cid.Undef, err
+	}
+	return cid.NewCidV1(cid.Raw, hash), nil
+}
+
+// startDiscovery periodically advertises all threads and connects to their other advertisers.
+func (n *net) startDiscovery() {
+	if n.conf.Discovery == nil {
+		return
+	}
+	ticker := time.NewTicker(DiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-n.ctx.Done():
+			return
+		}
+		ts, err := n.store.Threads()
+		if err != nil {
+			log.Errorf("error listing threads for discovery: %s", err)
+			continue
+		}
+		for _, tid := range ts {
+			if n.pulls.isPaused(tid) {
+				continue
+			}
+			n.discoverThread(tid)
+		}
+	}
+}
+
+// discover advertises a thread and connects to its other advertisers in the background.
+func (n *net) discover(id thread.ID) {
+	if n.conf.Discovery == nil {
+		return
+	}
+	go n.discoverThread(id)
+}
+
+// discoverThread advertises a thread under its rendezvous key, and fetches logs and records
+// from the other advertisers. Logs of discovered peers are added to the thread, so they're
+// pulled from with the other peers of the thread from then on.
+func (n *net) discoverThread(id thread.ID) {
+	key, err := discoveryKey(id)
+	if err != nil {
+		log.Errorf("error building discovery key of thread %s: %v", id, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(n.ctx, DiscoveryTimeout)
+	defer cancel()
+
+	if err := n.conf.Discovery.Provide(ctx, key, true); err != nil {
+		log.Debugf("advertising thread %s failed: %v", id, err)
+	}
+	for ai := range n.conf.Discovery.FindProvidersAsync(ctx, key, DiscoveryPeerLimit) {
+		if ai.ID == n.host.ID() {
+			continue
+		}
+		if err := n.host.Connect(ctx, ai); err != nil {
+			log.Debugf("connecting to discovered peer %s of thread %s failed: %v", ai.ID, id, err)
+			continue
+		}
+		log.Debugf("discovered peer %s of thread %s", ai.ID, id)
+		n.queueGetLogs.Schedule(ai.ID, id, callPriorityLow, func(ctx context.Context, p peer.ID, t thread.ID) error {
+			if err := n.updateLogsFromPeer(ctx, p, t); err != nil {
+				return err
+			}
+			return n.updateRecordsFromPeer(ctx, p, t)
+		})
+	}
+}
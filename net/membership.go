@@ -0,0 +1,76 @@
+package net
+
+import (
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// membershipRecord is sent to subscribers when a log of a thread changes.
+type membershipRecord struct {
+	change   core.MembershipChange
+	threadID thread.ID
+	logID    peer.ID
+	pubKey   crypto.PubKey
+	readable bool
+}
+
+var _ core.MembershipRecord = (*membershipRecord)(nil)
+
+func (m *membershipRecord) Value() core.Record {
+	return nil
+}
+
+func (m *membershipRecord) ThreadID() thread.ID {
+	return m.threadID
+}
+
+func (m *membershipRecord) LogID() peer.ID {
+	return m.logID
+}
+
+func (m *membershipRecord) Change() core.MembershipChange {
+	return m.change
+}
+
+func (m *membershipRecord) PubKey() crypto.PubKey {
+	return m.pubKey
+}
+
+func (m *membershipRecord) Readable() bool {
+	return m.readable
+}
+
+// notifyMembership sends a membership record for each of the given logs of thread id.
+// Errors are only logged, since the change has already been applied.
+func (n *net) notifyMembership(id thread.ID, change core.MembershipChange, readable bool, logs ...thread.LogInfo) {
+	for _, lg := range logs {
+		rec := &membershipRecord{
+			change:   change,
+			threadID: id,
+			logID:    lg.ID,
+			pubKey:   lg.PubKey,
+			readable: readable,
+		}
+		if err := n.bus.SendWithTimeout(rec, notifyTimeout); err != nil {
+			log.Warnf("notifying %s of log %s (thread=%s): %v", change, lg.ID, id, err)
+		}
+	}
+}
+
+// canRead returns whether the read key of thread id is known.
+func (n *net) canRead(id thread.ID) bool {
+	rk, err := n.store.ReadKey(id)
+	return err == nil && rk != nil
+}
+
+// notifyKeyReceived sends a KeyReceived membership record for each log of thread id.
+func (n *net) notifyKeyReceived(id thread.ID) {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		log.Warnf("getting thread %s to notify of its read key: %v", id, err)
+		return
+	}
+	n.notifyMembership(id, core.KeyReceived, true, info.Logs...)
+}
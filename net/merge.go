@@ -0,0 +1,316 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/cbor"
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrCannotMergeLogs indicates logs can't be merged, e.g., because their records have different authors.
+var ErrCannotMergeLogs = errors.New("cannot merge logs")
+
+const (
+	// retiredLogPrefix prefixes the thread metadata keys of the logs retired by merges.
+	retiredLogPrefix = "merge/retired/"
+	// mergedRecordsPrefix prefixes the thread metadata keys of the records re-anchored in a log
+	// which are yet to be received, in order.
+	mergedRecordsPrefix = "merge/records/"
+)
+
+// mergedRecord is a record re-anchored by a merge, with its counter in the log it was merged into.
+type mergedRecord struct {
+	rec     core.Record
+	counter int64
+}
+
+func (n *net) MergeLogs(ctx context.Context, id thread.ID, into peer.ID, from ...peer.ID) error {
+	if len(from) == 0 {
+		return nil
+	}
+	if err := n.checkNotPaused(id); err != nil {
+		return err
+	}
+	recs, err := n.mergeLogs(ctx, id, into, from)
+	if err != nil {
+		return err
+	}
+	for _, r := range recs {
+		if err := n.server.pushRecord(ctx, id, into, r.rec, r.counter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeLogs appends an announcement of the merge and the records of the from logs to the log into,
+// which are then retired. It returns the created records.
+func (n *net) mergeLogs(ctx context.Context, id thread.ID, into peer.ID, from []peer.ID) ([]mergedRecord, error) {
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return nil, err
+	}
+	sk := info.Key.Service()
+	if sk == nil {
+		return nil, fmt.Errorf("a service-key is required to merge logs")
+	}
+	lg, err := n.store.GetLog(id, into)
+	if err != nil {
+		return nil, err
+	}
+	if lg.PrivKey == nil && n.getSigner(into) == nil {
+		return nil, fmt.Errorf("%w: log %s can't be signed by this host", ErrCannotMergeLogs, into)
+	}
+	var author []byte
+	if lg.Head.ID.Defined() {
+		head, err := cbor.GetRecord(ctx, n, lg.Head.ID, sk)
+		if err != nil {
+			return nil, err
+		}
+		author = head.PubKey()
+	}
+	var originals []core.Record
+	seen := make(map[peer.ID]struct{}, len(from))
+	for _, lid := range from {
+		if lid == into {
+			return nil, fmt.Errorf("%w: log %s merged into itself", ErrCannotMergeLogs, lid)
+		} else if _, ok := seen[lid]; ok {
+			return nil, fmt.Errorf("%w: log %s merged twice", ErrCannotMergeLogs, lid)
+		}
+		seen[lid] = struct{}{}
+		fl, err := n.store.GetLog(id, lid)
+		if err != nil {
+			return nil, err
+		}
+		recs, err := n.logRecords(ctx, fl, sk)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range recs {
+			if author == nil {
+				author = r.PubKey()
+			} else if !bytes.Equal(author, r.PubKey()) {
+				return nil, fmt.Errorf("%w: records of log %s have another author", ErrCannotMergeLogs, lid)
+			}
+		}
+		originals = append(originals, recs...)
+	}
+	identity := thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	if len(author) > 0 {
+		pk := &thread.Libp2pPubKey{}
+		if err := pk.UnmarshalBinary(author); err != nil {
+			return nil, err
+		}
+		identity = pk
+	}
+
+	ids := make([]cid.Cid, len(originals))
+	for i, r := range originals {
+		ids[i] = r.Cid()
+	}
+	body, err := cbor.NewLogMerge(from, ids)
+	if err != nil {
+		return nil, err
+	}
+	// The announcement precedes the merged records, so that hosts receiving them know
+	// which records they re-anchor
+	r, err := n.newRecord(ctx, id, lg, body, identity, "")
+	if err != nil {
+		return nil, err
+	}
+	created := make([]mergedRecord, 0, len(originals)+1)
+	if lg, err = n.appendRecord(id, lg, r); err != nil {
+		return nil, err
+	}
+	created = append(created, mergedRecord{rec: r, counter: lg.Head.Counter})
+	for _, o := range originals {
+		block, err := o.GetBlock(ctx, n)
+		if err != nil {
+			return nil, fmt.Errorf("getting event of record %s: %w", o.Cid(), err)
+		}
+		config := cbor.CreateRecordConfig{
+			Block:      block,
+			Prev:       lg.Head.ID,
+			PubKey:     identity,
+			ServiceKey: sk,
+		}
+		if signer := n.getSigner(into); signer != nil {
+			r, err = n.signRecord(ctx, lg, signer, config)
+		} else {
+			config.Key = lg.PrivKey
+			r, err = cbor.CreateRecord(ctx, n, config)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if lg, err = n.appendRecord(id, lg, r); err != nil {
+			return nil, err
+		}
+		created = append(created, mergedRecord{rec: r, counter: lg.Head.Counter})
+	}
+
+	for _, lid := range from {
+		if err := n.retireLog(id, lid); err != nil {
+			return nil, err
+		}
+	}
+	// New records of the author go to the merged log
+	lidb, err := into.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := n.store.PutBytes(id, identity.String(), lidb); err != nil {
+		return nil, err
+	}
+	if err := n.touchThread(id); err != nil {
+		return nil, err
+	}
+	log.Debugf("merged %d records of %d logs into log %s (thread=%s)", len(originals), len(from), into, id)
+	return created, nil
+}
+
+// appendRecord makes r the head of log lg, returning the updated log.
+func (n *net) appendRecord(id thread.ID, lg thread.LogInfo, r core.Record) (thread.LogInfo, error) {
+	lg.Head = thread.Head{ID: r.Cid(), Counter: lg.Head.Counter + 1}
+	if err := n.store.SetHead(id, lg.ID, lg.Head); err != nil {
+		return lg, err
+	}
+	return lg, nil
+}
+
+// logRecords returns the records of log lg, from oldest to newest.
+func (n *net) logRecords(ctx context.Context, lg thread.LogInfo, sk *sym.Key) ([]core.Record, error) {
+	var recs []core.Record
+	for cursor := lg.Head.ID; cursor.Defined(); {
+		r, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, r)
+		cursor = r.PrevID()
+	}
+	for i, j := 0, len(recs)-1; i < j; i, j = i+1, j-1 {
+		recs[i], recs[j] = recs[j], recs[i]
+	}
+	return recs, nil
+}
+
+// retireLog deletes log lid from a thread, and keeps it from being added again by peers
+// which don't know of its merge yet.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) retireLog(id thread.ID, lid peer.ID) error {
+	if err := n.store.PutBytes(id, retiredLogPrefix+lid.String(), []byte{1}); err != nil {
+		return err
+	}
+	lg, err := n.store.GetLog(id, lid)
+	if err != nil {
+		return err
+	}
+	if err := n.store.DeleteLog(id, lid); err != nil {
+		return err
+	}
+	n.notifyMembership(id, core.LogRemoved, n.canRead(id), lg)
+	return nil
+}
+
+// isRetiredLog returns whether log lid of a thread was retired by a merge.
+func (n *net) isRetiredLog(id thread.ID, lid peer.ID) (bool, error) {
+	v, err := n.store.GetBytes(id, retiredLogPrefix+lid.String())
+	return v != nil, err
+}
+
+// applyLogMerge handles the announcement of a merge into log lid by author, retiring the merged
+// logs whose records have the same author, and queuing the merged records, which follow it.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) applyLogMerge(
+	ctx context.Context,
+	id thread.ID,
+	lid peer.ID,
+	author []byte,
+	logs []peer.ID,
+	records []cid.Cid,
+) error {
+	sk, err := n.store.ServiceKey(id)
+	if err != nil {
+		return err
+	}
+	for _, ml := range logs {
+		lg, err := n.store.GetLog(id, ml)
+		if errors.Is(err, lstore.ErrLogNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if lg.Head.ID.Defined() {
+			head, err := cbor.GetRecord(ctx, n, lg.Head.ID, sk)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(head.PubKey(), author) {
+				log.Warnf("ignoring merge of log %s into %s by another author", ml, lid)
+				continue
+			}
+		}
+		if err := n.retireLog(id, ml); err != nil {
+			return err
+		}
+	}
+	return n.putMergedRecords(id, lid, records)
+}
+
+// peekMergedRecord returns the record which the next record re-anchored in log lid re-anchors,
+// or false if no records are queued. The record stays queued until dropMergedRecord is called,
+// so it's kept if the re-anchored record fails to apply.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) peekMergedRecord(id thread.ID, lid peer.ID) (cid.Cid, bool, error) {
+	records, err := n.getMergedRecords(id, lid)
+	if err != nil || len(records) == 0 {
+		return cid.Undef, false, err
+	}
+	return records[0], true, nil
+}
+
+// dropMergedRecord removes the next record re-anchored in log lid from the queue, once it's applied.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) dropMergedRecord(id thread.ID, lid peer.ID) error {
+	records, err := n.getMergedRecords(id, lid)
+	if err != nil || len(records) == 0 {
+		return err
+	}
+	return n.putMergedRecords(id, lid, records[1:])
+}
+
+// getMergedRecords returns the queue of records re-anchored in log lid.
+func (n *net) getMergedRecords(id thread.ID, lid peer.ID) ([]cid.Cid, error) {
+	data, err := n.store.GetBytes(id, mergedRecordsPrefix+lid.String())
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var records []cid.Cid
+	if err := json.Unmarshal(*data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// putMergedRecords replaces the queue of records re-anchored in log lid with records.
+func (n *net) putMergedRecords(id thread.ID, lid peer.ID, records []cid.Cid) error {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(id, mergedRecordsPrefix+lid.String(), data)
+}
@@ -0,0 +1,103 @@
+package net
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	tu "github.com/textileio/go-threads/util"
+)
+
+// metrics instruments record and pull operations.
+// A nil *metrics is valid and records nothing.
+type metrics struct {
+	recordsCreated  prometheus.Counter
+	recordsReceived prometheus.Counter
+	recordsApplied  prometheus.Counter
+	createLatency   prometheus.Histogram
+	applyLatency    prometheus.Histogram
+	pullLatency     prometheus.Histogram
+}
+
+// newMetrics registers net metrics with reg. It returns nil if reg is nil.
+func newMetrics(reg prometheus.Registerer) (*metrics, error) {
+	if reg == nil {
+		return nil, nil
+	}
+	m := &metrics{}
+	for _, c := range []struct {
+		dst  *prometheus.Counter
+		name string
+		help string
+	}{
+		{&m.recordsCreated, "records_created_total", "Number of records created by this peer."},
+		{&m.recordsReceived, "records_received_total", "Number of records received from other peers."},
+		{&m.recordsApplied, "records_applied_total", "Number of received records added to logs."},
+	} {
+		col, err := tu.RegisterCollector(reg, prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "threads",
+			Subsystem: "net",
+			Name:      c.name,
+			Help:      c.help,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		*c.dst = col.(prometheus.Counter)
+	}
+	for _, h := range []struct {
+		dst  *prometheus.Histogram
+		name string
+		help string
+	}{
+		{&m.createLatency, "record_create_seconds", "Latency of creating a record."},
+		{&m.applyLatency, "record_apply_seconds", "Latency of adding a received record to its log."},
+		{&m.pullLatency, "pull_seconds", "Latency of pulling the records of a thread."},
+	} {
+		col, err := tu.RegisterCollector(reg, prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "threads",
+			Subsystem: "net",
+			Name:      h.name,
+			Help:      h.help,
+			Buckets:   prometheus.DefBuckets,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		*h.dst = col.(prometheus.Histogram)
+	}
+	return m, nil
+}
+
+// recordCreated observes a record created since start.
+func (m *metrics) recordCreated(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.recordsCreated.Inc()
+	m.createLatency.Observe(time.Since(start).Seconds())
+}
+
+// recordsReceivedFromPeer counts received records.
+func (m *metrics) recordsReceivedFromPeer(count int) {
+	if m == nil {
+		return
+	}
+	m.recordsReceived.Add(float64(count))
+}
+
+// recordApplied observes a received record added to its log since start.
+func (m *metrics) recordApplied(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.recordsApplied.Inc()
+	m.applyLatency.Observe(time.Since(start).Seconds())
+}
+
+// pulled observes a thread pull started at start.
+func (m *metrics) pulled(start time.Time) {
+	if m == nil {
+		return
+	}
+	m.pullLatency.Observe(time.Since(start).Seconds())
+}
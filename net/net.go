@@ -20,8 +20,10 @@ import (
 	"github.com/libp2p/go-libp2p-core/host"
 	"github.com/libp2p/go-libp2p-core/peer"
 	pstore "github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/routing"
 	gostream "github.com/libp2p/go-libp2p-gostream"
 	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
 	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/broadcast"
 	"github.com/textileio/go-threads/cbor"
@@ -51,6 +53,10 @@ var (
 	// EventBusCapacity is the buffer size of local event bus listeners.
 	EventBusCapacity = 1
 
+	// ErrRecordTooLarge indicates a record body is larger than cbor.MaxRecordBodySize.
+	// Bodies above Config.MaxRecordSize are chunked, but bodies above that limit are rejected.
+	ErrRecordTooLarge = cbor.ErrRecordTooLarge
+
 	// notifyTimeout is the duration to wait for a subscriber to read a new record.
 	notifyTimeout = time.Second * 5
 
@@ -94,7 +100,20 @@ type net struct {
 	connectors map[thread.ID]*app.Connector
 	connLock   sync.RWMutex
 
+	signers    map[peer.ID]thread.Signer
+	signerLock sync.RWMutex
+
+	ackLock     sync.Mutex
+	contacts    map[peer.ID]time.Time
+	contactLock sync.Mutex
+
+	pulls        *pullSchedule
+	metrics      *metrics
+	recordErrors *recordErrors
+
 	semaphores      *util.SemaphorePool
+	peerLimiter     *rateLimiter
+	threadLimiter   *rateLimiter
 	queueGetLogs    queue.CallQueue
 	queueGetRecords queue.CallQueue
 
@@ -111,6 +130,15 @@ type Config struct {
 	NoNetPulling              bool
 	NoExchangeEdgesMigration  bool
 	PubSub                    bool
+	PubSubPeerRateLimit       int
+	PubSubThreadRateLimit     int
+	DialRetryMax              int
+	DialRetryBase             time.Duration
+	Metrics                   prometheus.Registerer
+	RecordErrorHandler        RecordErrorHandler
+	RecordCodec               cbor.Codec
+	MaxRecordSize             int
+	Discovery                 routing.ContentRouting
 	Debug                     bool
 }
 
@@ -127,6 +155,15 @@ func (c Config) Validate() error {
 	if c.NetPullingInterval <= 0 {
 		return errors.New("NetPullingInterval must be greater than zero")
 	}
+	if c.DialRetryMax < 0 {
+		return errors.New("DialRetryMax must not be negative")
+	}
+	if c.DialRetryMax > 0 && c.DialRetryBase <= 0 {
+		return errors.New("DialRetryBase must be greater than zero")
+	}
+	if c.MaxRecordSize < 0 {
+		return errors.New("MaxRecordSize must not be negative")
+	}
 	return nil
 }
 
@@ -152,6 +189,14 @@ func NewNetwork(
 		return nil, err
 	}
 
+	m, err := newMetrics(conf.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("registering metrics: %v", err)
+	}
+	if conf.RecordCodec != nil {
+		cbor.RegisterCodec(conf.RecordCodec)
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	n := &net{
 		conf:            conf,
@@ -162,14 +207,21 @@ func NewNetwork(
 		rpc:             grpc.NewServer(serverOptions...),
 		bus:             broadcast.NewBroadcaster(EventBusCapacity),
 		connectors:      make(map[thread.ID]*app.Connector),
+		signers:         make(map[peer.ID]thread.Signer),
+		contacts:        make(map[peer.ID]time.Time),
+		pulls:           newPullSchedule(),
+		metrics:         m,
+		recordErrors:    newRecordErrors(ctx, conf.RecordErrorHandler),
 		ctx:             ctx,
 		cancel:          cancel,
 		semaphores:      util.NewSemaphorePool(1),
+		peerLimiter:     newRateLimiter(conf.PubSubPeerRateLimit),
+		threadLimiter:   newRateLimiter(conf.PubSubThreadRateLimit),
 		queueGetLogs:    queue.NewFFQueue(ctx, QueuePollInterval, conf.NetPullingInterval),
 		queueGetRecords: queue.NewFFQueue(ctx, QueuePollInterval, conf.NetPullingInterval),
 	}
 
-	err := n.migrateHeadsIfNeeded(ctx, ls)
+	err = n.migrateHeadsIfNeeded(ctx, ls)
 	if err != nil {
 		return nil, err
 	}
@@ -189,8 +241,10 @@ func NewNetwork(
 			log.Fatalf("serve error: %v", err)
 		}
 	}()
+	h.SetStreamHandler(diffProtocol, n.handleDiff)
 
 	go n.startPulling()
+	go n.startDiscovery()
 	return n, nil
 }
 
@@ -290,6 +344,7 @@ func (n *net) Close() (err error) {
 		}
 	}
 	tu.StopGRPCServer(n.rpc)
+	n.host.RemoveStreamHandler(diffProtocol)
 
 	var errs []error
 	weakClose := func(name string, c interface{}) {
@@ -375,12 +430,16 @@ func (n *net) CreateThread(
 	if err = n.store.AddThread(info); err != nil {
 		return
 	}
+	if err = n.addNamedReadKeys(id, info.Key); err != nil {
+		return
+	}
 	if _, err = n.createLog(id, args.LogKey, identity); err != nil {
 		return
 	}
 	if err = n.server.addPubsubTopic(id); err != nil {
 		return
 	}
+	n.discover(id)
 
 	return n.getThreadWithAddrs(id)
 }
@@ -434,13 +493,20 @@ func (n *net) AddThread(
 	}
 
 	// Even if we already have the thread locally, we might still need to add a new log
+	couldRead := n.canRead(id)
 	if err = n.store.AddThread(thread.Info{
 		ID:  id,
 		Key: args.ThreadKey,
 	}); err != nil {
 		return
 	}
-	if args.ThreadKey.CanRead() || args.LogKey != nil {
+	if err = n.addNamedReadKeys(id, args.ThreadKey); err != nil {
+		return
+	}
+	if !couldRead && args.ThreadKey.CanRead() {
+		n.notifyKeyReceived(id)
+	}
+	if args.ThreadKey.CanReadAny() || args.LogKey != nil {
 		if _, err = n.createLog(id, args.LogKey, identity); err != nil {
 			return
 		}
@@ -461,6 +527,7 @@ func (n *net) AddThread(
 			return
 		}
 	}
+	n.discover(id)
 	return n.getThreadWithAddrs(id)
 }
 
@@ -483,6 +550,10 @@ func (n *net) getThreadWithAddrs(id thread.ID) (info thread.Info, err error) {
 	if err != nil {
 		return
 	}
+	tinfo.Key, err = n.withNamedReadKeys(id, tinfo.Key)
+	if err != nil {
+		return
+	}
 	peerID, err = ma.NewComponent("p2p", n.host.ID().String())
 	if err != nil {
 		return
@@ -497,6 +568,10 @@ func (n *net) getThreadWithAddrs(id thread.ID) (info thread.Info, err error) {
 		res[i] = addrs[i].Encapsulate(peerID).Encapsulate(threadID)
 	}
 	tinfo.Addrs = res
+	tinfo.Updated, err = n.threadUpdated(id)
+	if err != nil {
+		return
+	}
 	return tinfo, nil
 }
 
@@ -508,18 +583,22 @@ func (n *net) PullThread(ctx context.Context, id thread.ID, opts ...core.ThreadO
 	if _, err := n.Validate(id, args.Token, true); err != nil {
 		return err
 	}
+	if err := n.checkNotPaused(id); err != nil {
+		return err
+	}
 	return n.pullThread(ctx, id)
 }
 
 // pullThread for the new records. This method is thread-safe.
 func (n *net) pullThread(ctx context.Context, tid thread.ID) error {
+	defer n.metrics.pulled(time.Now())
 	offsets, peers, err := n.threadOffsets(tid)
 	if err != nil {
 		return err
 	}
 
 	// Pull from peers
-	recs, err := n.server.getRecords(peers, tid, offsets, n.conf.NetPullingLimit)
+	recs, err := n.server.getRecords(ctx, peers, tid, offsets, n.conf.NetPullingLimit)
 	if err != nil {
 		return err
 	}
@@ -574,6 +653,11 @@ func (n *net) deleteThread(ctx context.Context, id thread.ID) error {
 	for _, lg := range info.Logs { // Walk logs, removing record and event nodes
 		head := lg.Head.ID
 		for head.Defined() {
+			if missing, err := n.missingFromPartial(id, head); err != nil {
+				return err
+			} else if missing {
+				break
+			}
 			head, err = n.deleteRecord(ctx, head, info.Key.Service())
 			if err != nil {
 				return err
@@ -581,7 +665,12 @@ func (n *net) deleteThread(ctx context.Context, id thread.ID) error {
 		}
 	}
 
-	return n.store.DeleteThread(id) // Delete logstore keys, addresses, heads, and metadata
+	n.pulls.remove(id)
+	if err = n.store.DeleteThread(id); err != nil { // Delete logstore keys, addresses, heads, and metadata
+		return err
+	}
+	n.notifyMembership(id, core.LogRemoved, info.Key.CanRead(), info.Logs...)
+	return nil
 }
 
 func (n *net) AddReplicator(
@@ -597,6 +686,9 @@ func (n *net) AddReplicator(
 	if _, err = n.Validate(id, args.Token, true); err != nil {
 		return
 	}
+	if err = n.checkNotPaused(id); err != nil {
+		return
+	}
 
 	info, err := n.store.GetThread(id)
 	if err != nil {
@@ -734,6 +826,7 @@ func (n *net) CreateRecord(
 	body format.Node,
 	opts ...core.ThreadOption,
 ) (tr core.ThreadRecord, err error) {
+	start := time.Now()
 	args := &core.ThreadOptions{}
 	for _, opt := range opts {
 		opt(args)
@@ -742,6 +835,9 @@ func (n *net) CreateRecord(
 	if err != nil {
 		return
 	}
+	if err = n.checkNotPaused(id); err != nil {
+		return
+	}
 	if identity == nil {
 		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
 	}
@@ -758,7 +854,7 @@ func (n *net) CreateRecord(
 	if err != nil {
 		return
 	}
-	r, err := n.newRecord(ctx, id, lg, body, identity)
+	r, err := n.newRecord(ctx, id, lg, body, identity, args.ReadKey)
 	if err != nil {
 		return
 	}
@@ -770,6 +866,9 @@ func (n *net) CreateRecord(
 	if err = n.store.SetHead(id, lg.ID, head); err != nil {
 		return
 	}
+	if err = n.touchThread(id); err != nil {
+		return
+	}
 	log.Debugf("created record %s (thread=%s, log=%s)", tr.Value().Cid(), id, lg.ID)
 	if err = n.bus.SendWithTimeout(tr, notifyTimeout); err != nil {
 		return
@@ -777,6 +876,7 @@ func (n *net) CreateRecord(
 	if err = n.server.pushRecord(ctx, id, lg.ID, tr.Value(), lg.Head.Counter+1); err != nil {
 		return
 	}
+	n.metrics.recordCreated(start)
 	return tr, nil
 }
 
@@ -794,6 +894,9 @@ func (n *net) AddRecord(
 	if _, err := n.Validate(id, args.Token, false); err != nil {
 		return err
 	}
+	if err := n.checkNotPaused(id); err != nil {
+		return err
+	}
 
 	logpk, err := n.store.PubKey(id, lid)
 	if err != nil {
@@ -887,10 +990,72 @@ func (n *net) Subscribe(ctx context.Context, opts ...core.SubOption) (<-chan cor
 			filter[id] = struct{}{}
 		}
 	}
-	return n.subscribe(ctx, filter)
+	logs := make(map[peer.ID]struct{})
+	for _, id := range args.LogIDs {
+		if err := id.Validate(); err != nil {
+			return nil, err
+		}
+		logs[id] = struct{}{}
+	}
+	authors, err := newAuthorFilter(args.Authors, args.ExcludeAuthors)
+	if err != nil {
+		return nil, err
+	}
+	return n.subscribe(ctx, filter, logs, authors, args.Membership)
+}
+
+// authorFilter matches records by the identity which authored them.
+type authorFilter struct {
+	include map[string]struct{}
+	exclude map[string]struct{}
+}
+
+func newAuthorFilter(include, exclude []thread.PubKey) (*authorFilter, error) {
+	f := &authorFilter{
+		include: make(map[string]struct{}, len(include)),
+		exclude: make(map[string]struct{}, len(exclude)),
+	}
+	for _, k := range include {
+		b, err := k.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("marshaling author: %w", err)
+		}
+		f.include[string(b)] = struct{}{}
+	}
+	for _, k := range exclude {
+		b, err := k.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("marshaling author: %w", err)
+		}
+		f.exclude[string(b)] = struct{}{}
+	}
+	return f, nil
 }
 
-func (n *net) subscribe(ctx context.Context, filter map[thread.ID]struct{}) (<-chan core.ThreadRecord, error) {
+// match returns whether rec wasn't authored by an excluded identity, and, if authors are
+// included, whether it was authored by one of them.
+func (f *authorFilter) match(rec core.Record) bool {
+	author := string(rec.PubKey())
+	if _, ok := f.exclude[author]; ok {
+		return false
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	_, ok := f.include[author]
+	return ok
+}
+
+// subscribe returns a channel of records of the threads in filter and of the logs in logs,
+// which match authors. An empty filter or logs matches all threads or logs. If membership is
+// true, membership records of the same threads and logs are sent as well.
+func (n *net) subscribe(
+	ctx context.Context,
+	filter map[thread.ID]struct{},
+	logs map[peer.ID]struct{},
+	authors *authorFilter,
+	membership bool,
+) (<-chan core.ThreadRecord, error) {
 	channel := make(chan core.ThreadRecord)
 	go func() {
 		defer close(channel)
@@ -904,17 +1069,33 @@ func (n *net) subscribe(ctx context.Context, filter map[thread.ID]struct{}) (<-c
 				if !ok {
 					return
 				}
-				if rec, ok := i.(*Record); ok {
-					if len(filter) > 0 {
-						if _, ok := filter[rec.threadID]; ok {
-							channel <- rec
-						}
-					} else {
-						channel <- rec
+				var rec core.ThreadRecord
+				switch v := i.(type) {
+				case *Record:
+					if !authors.match(v.Value()) {
+						continue
 					}
-				} else {
+					rec = v
+				case *membershipRecord:
+					if !membership {
+						continue
+					}
+					rec = v
+				default:
 					log.Warn("listener received a non-record value")
+					continue
+				}
+				if len(filter) > 0 {
+					if _, ok := filter[rec.ThreadID()]; !ok {
+						continue
+					}
 				}
+				if len(logs) > 0 {
+					if _, ok := logs[rec.LogID()]; !ok {
+						continue
+					}
+				}
+				channel <- rec
 			}
 		}
 	}()
@@ -981,7 +1162,15 @@ func (n *net) PutRecord(ctx context.Context, id thread.ID, lid peer.ID, rec core
 }
 
 // putRecords adds existing records. This method is thread-safe.
-func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs []core.Record, counter int64) error {
+func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs []core.Record, counter int64) (err error) {
+	n.metrics.recordsReceivedFromPeer(len(recs))
+	if retired, err := n.isRetiredLog(tid, lid); err != nil {
+		return err
+	} else if retired {
+		// the records were re-anchored in the log the log was merged into
+		log.Debugf("ignoring %d records of retired log %s", len(recs), lid)
+		return nil
+	}
 	chain, head, err := n.loadRecords(ctx, tid, lid, recs, counter)
 	if err != nil {
 		return fmt.Errorf("loading records failed: %w", err)
@@ -993,6 +1182,12 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 	ts.Acquire()
 	defer ts.Release()
 
+	if n.pulls.isPaused(tid) {
+		// the records will be pulled again when the thread is resumed
+		log.Debugf("ignoring %d records of paused thread %s", len(chain), tid)
+		return nil
+	}
+
 	// check the head again, as some other process could change the log concurrently
 	if current, err := n.currentHead(tid, lid); err != nil {
 		return fmt.Errorf("fetching head failed: %w", err)
@@ -1016,22 +1211,51 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 	var (
 		connector, appConnected = n.getConnector(tid)
 		identity                = &thread.Libp2pPubKey{}
-		readKey                 *sym.Key
-		validate                bool
 		// setting new counters for heads
 		updatedCounter = head.Counter
 	)
 
-	if appConnected {
-		var err error
-		if readKey, err = n.store.ReadKey(tid); err != nil {
-			return err
-		} else if readKey != nil {
-			validate = true
-		}
+	// Bodies are decrypted whenever the read key is known, so that key rotations
+	// are followed even if no app is connected.
+	readKeys, err := n.readKeys(tid)
+	if err != nil {
+		return err
+	}
+	namedKeys, err := n.namedReadKeys(tid)
+	if err != nil {
+		return err
 	}
+	validate := len(readKeys) > 0 || len(namedKeys) > 0
+
+	// applying is the record being applied, whose failure is reported to the record error handler
+	var applying cid.Cid
+	defer func() {
+		if err != nil && applying.Defined() {
+			n.recordErrors.report(tid, lid, applying, err)
+		}
+	}()
 
 	for _, record := range chain {
+		start := time.Now()
+		applying = record.Value().Cid()
+		var (
+			recordKey *sym.Key
+			rotation  bool
+			opaque    bool
+			merge     bool
+			duplicate bool
+			queued    bool
+		)
+		// Records re-anchored by a merge aren't handled again if their originals were.
+		// The original stays queued until the record is applied, in case applying fails.
+		if original, ok, err := n.peekMergedRecord(tid, lid); err != nil {
+			return err
+		} else if ok {
+			queued = true
+			if duplicate, err = n.isKnown(original); err != nil {
+				return err
+			}
+		}
 		if validate {
 			block, err := record.Value().GetBlock(ctx, n)
 			if err != nil {
@@ -1046,34 +1270,55 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 				}
 			}
 
-			dbody, err := event.GetBody(ctx, n, readKey)
-			if err != nil {
+			var dbody format.Node
+			dbody, recordKey, err = event.GetBodyWithKeys(ctx, n, withNamedKeys(readKeys, namedKeys))
+			if err != nil && len(namedKeys) == 0 {
 				return err
 			}
 
-			if err = identity.UnmarshalBinary(record.Value().PubKey()); err != nil {
-				return err
-			}
-
-			if err = connector.ValidateNetRecordBody(ctx, dbody, identity); err != nil {
-				userErr := err
-
-				// remove stored internal blocks
-				header, err := event.GetHeader(ctx, n, nil)
-				if err != nil {
-					return err
+			var rotated *sym.Key
+			if err != nil {
+				// Records encrypted under named read keys this host doesn't hold are kept,
+				// so they're synced to other peers, but they aren't read.
+				log.Debugf("record %s is encrypted under an unknown read key", record.Value().Cid())
+				opaque, err = true, nil
+			} else if rotated, rotation = cbor.KeyRotationFromNode(dbody); rotation && isNamedKey(recordKey, namedKeys) {
+				// Holders of a named read key only may not replace the read key
+				return fmt.Errorf("read key rotation under a named read key")
+			} else if rotation {
+				if readKeys, err = n.addRotatedReadKey(tid, readKeys, recordKey, rotated); err != nil {
+					return fmt.Errorf("rotating read key failed: %w", err)
 				}
-
-				body, err := event.GetBody(ctx, n, nil)
-				if err != nil {
+			} else if logs, records, ok := cbor.LogMergeFromNode(dbody); ok {
+				merge = true
+				if err = n.applyLogMerge(ctx, tid, lid, record.Value().PubKey(), logs, records); err != nil {
+					return fmt.Errorf("merging logs failed: %w", err)
+				}
+			} else if appConnected {
+				if err = identity.UnmarshalBinary(record.Value().PubKey()); err != nil {
 					return err
 				}
 
-				if err := n.RemoveMany(ctx, []cid.Cid{event.Cid(), header.Cid(), body.Cid()}); err != nil {
-					return fmt.Errorf("removing invalid blocks: %w", err)
-				}
+				if err = connector.ValidateNetRecordBody(ctx, dbody, identity); err != nil {
+					userErr := err
 
-				return userErr
+					// remove stored internal blocks
+					header, err := event.GetHeader(ctx, n, nil)
+					if err != nil {
+						return err
+					}
+
+					body, err := event.GetBody(ctx, n, nil)
+					if err != nil {
+						return err
+					}
+
+					if err := n.RemoveMany(ctx, []cid.Cid{event.Cid(), header.Cid(), body.Cid()}); err != nil {
+						return fmt.Errorf("removing invalid blocks: %w", err)
+					}
+
+					return userErr
+				}
 			}
 		}
 
@@ -1087,9 +1332,12 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 			}); err != nil {
 			return fmt.Errorf("setting log head failed: %w", err)
 		}
+		if err := n.touchThread(tid); err != nil {
+			return fmt.Errorf("setting thread update time failed: %w", err)
+		}
 
-		if appConnected {
-			if err := connector.HandleNetRecord(ctx, record); err != nil {
+		if appConnected && !rotation && !opaque && !merge && !duplicate {
+			if err := connector.HandleNetRecord(ctx, record, recordKey); err != nil {
 				// Future improvement notes.
 				// If record handling fails there are two options available:
 				// 1. Just interrupt and return error (current behaviour). Log head remains moved and some events
@@ -1105,6 +1353,13 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 		if err := n.Add(ctx, record.Value()); err != nil {
 			return fmt.Errorf("adding record to the blockstore failed: %w", err)
 		}
+		// The queue of a merge record is replaced by the records it merges
+		if queued && !merge {
+			if err := n.dropMergedRecord(tid, lid); err != nil {
+				return fmt.Errorf("dequeuing merged record failed: %w", err)
+			}
+		}
+		applying = cid.Undef
 
 		// Generally broadcasting should not block for too long, i.e. we have to run it
 		// under the semaphore to ensure consistent order seen by the listeners. Record
@@ -1112,6 +1367,7 @@ func (n *net) putRecords(ctx context.Context, tid thread.ID, lid peer.ID, recs [
 		if err = n.bus.SendWithTimeout(record, notifyTimeout); err != nil {
 			return err
 		}
+		n.metrics.recordApplied(start)
 	}
 
 	return nil
@@ -1209,8 +1465,13 @@ func (n *net) loadRecords(
 			return nil, head, err
 		}
 
+		chunks, err := event.GetChunks(ctx, n)
+		if err != nil {
+			return nil, head, err
+		}
+
 		// store internal blocks locally, record envelope will be added by the caller after successful processing
-		if err = n.AddMany(ctx, []format.Node{event, header, body}); err != nil {
+		if err = n.AddMany(ctx, append([]format.Node{event, header, body}, chunks...)); err != nil {
 			return nil, head, err
 		}
 
@@ -1240,16 +1501,19 @@ func (n *net) currentHead(tid thread.ID, lid peer.ID) (thread.Head, error) {
 	return head, nil
 }
 
-// newRecord creates a new record with the given body as a new event body.
+// newRecord creates a new record with the given body as a new event body, encrypted under
+// the read key named rkName, or the read key of the thread if rkName is empty.
 func (n *net) newRecord(
 	ctx context.Context,
 	id thread.ID,
 	lg thread.LogInfo,
 	body format.Node,
 	pk thread.PubKey,
+	rkName string,
 ) (core.Record, error) {
-	if lg.PrivKey == nil {
-		return nil, fmt.Errorf("a private-key is required to create records")
+	signer := n.getSigner(lg.ID)
+	if lg.PrivKey == nil && signer == nil {
+		return nil, fmt.Errorf("a private-key or signer is required to create records")
 	}
 	sk, err := n.store.ServiceKey(id)
 	if err != nil {
@@ -1258,24 +1522,42 @@ func (n *net) newRecord(
 	if sk == nil {
 		return nil, fmt.Errorf("a service-key is required to create records")
 	}
-	rk, err := n.store.ReadKey(id)
+	rk, err := n.recordReadKey(id, rkName)
 	if err != nil {
 		return nil, err
 	}
-	if rk == nil {
-		return nil, fmt.Errorf("a read-key is required to create records")
+	if size := len(body.RawData()); size > cbor.MaxRecordBodySize {
+		return nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrRecordTooLarge, size, cbor.MaxRecordBodySize)
 	}
-	event, err := cbor.CreateEvent(ctx, n, body, rk)
+	event, err := cbor.CreateChunkedEvent(ctx, n, body, rk, n.conf.RecordCodec, n.conf.MaxRecordSize)
 	if err != nil {
 		return nil, err
 	}
-	return cbor.CreateRecord(ctx, n, cbor.CreateRecordConfig{
+	if signer == nil {
+		return cbor.CreateRecord(ctx, n, cbor.CreateRecordConfig{
+			Block:      event,
+			Prev:       lg.Head.ID,
+			Key:        lg.PrivKey,
+			PubKey:     pk,
+			ServiceKey: sk,
+		})
+	}
+	rec, err := n.signRecord(ctx, lg, signer, cbor.CreateRecordConfig{
 		Block:      event,
 		Prev:       lg.Head.ID,
-		Key:        lg.PrivKey,
 		PubKey:     pk,
 		ServiceKey: sk,
 	})
+	if err != nil {
+		// Don't leave the blocks of an event which no record links to
+		if e, ok := event.(*cbor.Event); ok {
+			if err := cbor.RemoveEvent(ctx, n, e); err != nil {
+				log.Errorf("removing event of unsigned record: %v", err)
+			}
+		}
+		return nil, err
+	}
+	return rec, nil
 }
 
 // getPrivKey returns the host's private key.
@@ -1330,6 +1612,11 @@ func (n *net) getLocalRecords(
 		if !cursor.Defined() || cursor.String() == offset.String() {
 			break
 		}
+		if missing, err := n.missingFromPartial(id, cursor); err != nil {
+			return recs, err
+		} else if missing {
+			break
+		}
 		r, err := cbor.GetRecord(ctx, n, cursor, sk) // Important invariant: heads are always in blockstore
 		if err != nil {
 			// return records fetched so far
@@ -1362,6 +1649,9 @@ func (n *net) deleteRecord(ctx context.Context, rid cid.Cid, sk *sym.Key) (prev
 }
 
 // startPulling periodically pulls on all threads.
+// Each cycle spreads pulls of the due threads over the shortest pull interval, randomly
+// shortened or lengthened by PullJitter. Threads updated over pubsub within their interval
+// aren't due, so threads which are actively receiving records aren't pulled.
 func (n *net) startPulling() {
 	if n.conf.NoNetPulling {
 		return
@@ -1380,7 +1670,6 @@ func (n *net) startPulling() {
 	var compressor = queue.NewThreadPacker(n.ctx, MaxThreadsExchanged, ExchangeCompressionTimeout)
 	go n.startExchange(compressor)
 
-PullCycle:
 	for {
 		ts, err := n.store.Threads()
 		if err != nil {
@@ -1393,8 +1682,8 @@ PullCycle:
 			// if there are no threads served, just wait and retry
 			select {
 			case <-time.After(interval):
-				interval = n.conf.NetPullingInterval
-				continue PullCycle
+				interval = jitter(n.pulls.shortest(n.conf.NetPullingInterval))
+				continue
 			case <-n.ctx.Done():
 				return
 			}
@@ -1403,35 +1692,41 @@ PullCycle:
 		var (
 			period = interval / time.Duration(len(ts))
 			ticker = time.NewTicker(period)
-			idx    = 0
 		)
-
-		for {
+		for _, tid := range ts {
+			if !n.pulls.due(tid, time.Now(), n.conf.NetPullingInterval) {
+				continue
+			}
 			select {
 			case <-ticker.C:
-				var tid = ts[idx]
-				if _, peers, err := n.threadOffsets(tid); err != nil {
-					log.Errorf("error getting thread info %s: %s", tid, err)
-					return
-				} else {
-					for _, pid := range peers {
-						compressor.Add(pid, tid)
-					}
-				}
-
-				idx++
-				if idx >= len(ts) {
-					ticker.Stop()
-					interval = n.conf.NetPullingInterval
-					continue PullCycle
-				}
-
 			case <-n.ctx.Done():
 				ticker.Stop()
 				return
 			}
+			if _, peers, err := n.threadOffsets(tid); err != nil {
+				log.Errorf("error getting thread info %s: %s", tid, err)
+				ticker.Stop()
+				return
+			} else {
+				for _, pid := range peers {
+					compressor.Add(pid, tid)
+				}
+			}
+			n.pulls.touch(tid, time.Now())
 		}
+		ticker.Stop()
+		interval = jitter(n.pulls.shortest(n.conf.NetPullingInterval))
+	}
+}
+
+// SetPullInterval overrides the interval at which a thread is periodically pulled.
+// A non-positive interval restores the default interval of the network.
+func (n *net) SetPullInterval(id thread.ID, d time.Duration) error {
+	if err := id.Validate(); err != nil {
+		return err
 	}
+	n.pulls.setInterval(id, d)
+	return nil
 }
 
 func (n *net) startExchange(compressor queue.ThreadPacker) {
@@ -1480,6 +1775,7 @@ func (n *net) createLog(id thread.ID, key crypto.Key, identity thread.PubKey) (i
 	if err = n.store.PutBytes(id, identity.String(), lidb); err != nil {
 		return info, err
 	}
+	n.notifyMembership(id, core.LogAdded, n.canRead(id), info)
 	return info, nil
 }
 
@@ -1508,7 +1804,12 @@ func (n *net) getOrCreateLog(id thread.ID, identity thread.PubKey) (info thread.
 		if err != nil {
 			return info, err
 		}
-		return n.store.GetLog(id, lid)
+		// A log merged by another host is replaced by a new one
+		if retired, err := n.isRetiredLog(id, lid); err != nil {
+			return info, err
+		} else if !retired {
+			return n.store.GetLog(id, lid)
+		}
 	}
 	return n.createLog(id, nil, identity)
 }
@@ -1523,14 +1824,32 @@ func (n *net) createExternalLogsIfNotExist(
 	ts.Acquire()
 	defer ts.Release()
 
+	var added []thread.LogInfo
+	defer func() {
+		if len(added) > 0 {
+			n.notifyMembership(tid, core.LogAdded, n.canRead(tid), added...)
+		}
+	}()
 	for _, li := range lis {
+		if retired, err := n.isRetiredLog(tid, li.ID); err != nil {
+			return err
+		} else if retired {
+			continue
+		}
 		if currHeads, err := n.Store().Heads(tid, li.ID); err != nil {
 			return err
 		} else if len(currHeads) == 0 {
+			pk, err := n.Store().PubKey(tid, li.ID)
+			if err != nil {
+				return err
+			}
 			li.Head = thread.HeadUndef
 			if err = n.Store().AddLog(tid, li); err != nil {
 				return err
 			}
+			if pk == nil {
+				added = append(added, li)
+			}
 		} else {
 			// update log addresses
 			if err = n.Store().AddAddrs(tid, li.ID, li.Addrs, pstore.PermanentAddrTTL); err != nil {
@@ -1598,6 +1917,10 @@ func (n *net) ensureUniqueLog(id thread.ID, key crypto.Key, identity thread.PubK
 
 // updateRecordsFromPeer fetches new logs & records from the peer and adds them in the local peer store.
 func (n *net) updateRecordsFromPeer(ctx context.Context, pid peer.ID, tid thread.ID) error {
+	if n.pulls.isPaused(tid) {
+		return nil
+	}
+	defer n.metrics.pulled(time.Now())
 	offsets, _, err := n.threadOffsets(tid)
 	if err != nil {
 		return fmt.Errorf("getting offsets for thread %s failed: %w", tid, err)
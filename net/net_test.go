@@ -1,29 +1,47 @@
 package net
 
 import (
+	"bytes"
 	"context"
 	rand "crypto/rand"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	blocks "github.com/ipfs/go-block-format"
 	bserv "github.com/ipfs/go-blockservice"
+	"github.com/ipfs/go-cid"
 	ds "github.com/ipfs/go-datastore"
 	syncds "github.com/ipfs/go-datastore/sync"
 	bstore "github.com/ipfs/go-ipfs-blockstore"
 	offline "github.com/ipfs/go-ipfs-exchange-offline"
 	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
 	dag "github.com/ipfs/go-merkledag"
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/peerstore"
+	"github.com/libp2p/go-libp2p-core/routing"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ma "github.com/multiformats/go-multiaddr"
 	mh "github.com/multiformats/go-multihash"
+	sym "github.com/textileio/crypto/symmetric"
 	"github.com/textileio/go-threads/cbor"
 	"github.com/textileio/go-threads/core/logstore"
 	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	tstore "github.com/textileio/go-threads/logstore/lstoremem"
+	pb "github.com/textileio/go-threads/net/pb"
 	"github.com/textileio/go-threads/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNet_GetToken(t *testing.T) {
@@ -107,6 +125,331 @@ func TestNet_CreateRecord(t *testing.T) {
 	})
 }
 
+type unregisteredCodec struct{}
+
+func (unregisteredCodec) Name() string { return "unregistered" }
+
+func (unregisteredCodec) Compress(data []byte) ([]byte, error) { return data, nil }
+
+func (unregisteredCodec) Decompress(data []byte) ([]byte, error) { return data, nil }
+
+func TestNet_RecordCompression(t *testing.T) {
+	n := makeNetworkWithConfig(t, Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		RecordCodec:               cbor.Gzip,
+		Debug:                     true,
+	})
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := cbor.GetEvent(ctx, n, r.Value().BlockID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := event.GetBody(ctx, n, info.Key.Read())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body.String() != back.String() {
+		t.Fatalf("retrieved body does not equal input body")
+	}
+
+	// Uncompressed events remain readable
+	plain, err := cbor.CreateEvent(ctx, n, body, info.Key.Read())
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err = cbor.GetEvent(ctx, n, plain.Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back, err = plain.GetBody(ctx, n, info.Key.Read()); err != nil {
+		t.Fatal(err)
+	}
+	if body.String() != back.String() {
+		t.Fatalf("retrieved body does not equal input body")
+	}
+
+	unsupported, err := cbor.CreateCompressedEvent(ctx, n, body, info.Key.Read(), unregisteredCodec{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsupported, err = cbor.GetEvent(ctx, n, unsupported.Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = unsupported.GetBody(ctx, n, info.Key.Read()); !errors.Is(err, cbor.ErrUnsupportedEncoding) {
+		t.Fatalf("expected error %v, got %v", cbor.ErrUnsupportedEncoding, err)
+	}
+}
+
+func TestNet_MaxRecordSize(t *testing.T) {
+	n := makeNetworkWithConfig(t, Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		MaxRecordSize:             1024,
+		Debug:                     true,
+	})
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	payload := make([]byte, 10*1024)
+	if _, err := rand.Read(payload); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test chunked body", func(t *testing.T) {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"data": payload,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.GetEvent(ctx, n, r.Value().BlockID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		coded, err := n.Get(ctx, event.BodyID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(coded.RawData()) >= len(payload) {
+			t.Fatalf("expected body to be chunked, got a %d byte body block", len(coded.RawData()))
+		}
+		back, err := event.GetBody(ctx, n, info.Key.Read())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(body.RawData(), back.RawData()) {
+			t.Fatalf("retrieved body does not equal input body")
+		}
+	})
+
+	t.Run("test small body", func(t *testing.T) {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.GetEvent(ctx, n, r.Value().BlockID())
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := event.GetBody(ctx, n, info.Key.Read())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body.String() != back.String() {
+			t.Fatalf("retrieved body does not equal input body")
+		}
+	})
+
+	chunked, err := cbornode.WrapObject(map[string]interface{}{
+		"data": payload,
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// checkBody checks that the body of record rid of the thread on n is the chunked body
+	checkBody := func(t *testing.T, n core.Net, id thread.ID, rid cid.Cid) {
+		rec, err := n.GetRecord(ctx, id, rid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		event, err := cbor.EventFromRecord(ctx, n, rec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := event.GetBody(ctx, n, info.Key.Read())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(chunked.RawData(), back.RawData()) {
+			t.Fatalf("retrieved body does not equal input body")
+		}
+	}
+
+	t.Run("test replicate chunked body", func(t *testing.T) {
+		r, err := n.CreateRecord(ctx, info.ID, chunked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Blocks are only exchanged with the records, so chunks must be sent along
+		n2 := makeNetwork(t)
+		defer n2.Close()
+		n.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+		n2.Host().Peerstore().AddAddrs(n.Host().ID(), n.Host().Addrs(), peerstore.PermanentAddrTTL)
+		addr, err := ma.NewMultiaddr("/p2p/" + n.Host().ID().String() + "/thread/" + info.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+			t.Fatal(err)
+		}
+		if err := n2.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		checkBody(t, n2, info.ID, r.Value().Cid())
+	})
+
+	t.Run("test export chunked body", func(t *testing.T) {
+		r, err := n.CreateRecord(ctx, info.ID, chunked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var archive bytes.Buffer
+		if err := n.ExportThread(ctx, info.ID, &archive); err != nil {
+			t.Fatal(err)
+		}
+		n2 := makeNetwork(t)
+		defer n2.Close()
+		if _, err := n2.ImportThread(ctx, &archive); err != nil {
+			t.Fatal(err)
+		}
+		checkBody(t, n2, info.ID, r.Value().Cid())
+	})
+
+	t.Run("test compact chunked body", func(t *testing.T) {
+		info := createThread(t, ctx, n)
+		if _, err := n.CreateRecord(ctx, info.ID, chunked); err != nil {
+			t.Fatal(err)
+		}
+		r, err := n.CreateRecord(ctx, info.ID, chunked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size, err := n.CompactDryRun(ctx, info.ID, r.Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size < int64(len(payload)) {
+			t.Fatalf("expected at least %d bytes to be reclaimed, got %d", len(payload), size)
+		}
+	})
+
+	t.Run("test hard limit", func(t *testing.T) {
+		r, err := n.CreateRecord(ctx, info.ID, chunked)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prec, err := cbor.RecordToProto(ctx, n, r.Value())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(prec.ChunkNodes) == 0 {
+			t.Fatal("expected chunks to be sent with the record")
+		}
+
+		limit := cbor.MaxRecordBodySize
+		cbor.MaxRecordBodySize = len(payload) / 2
+		defer func() { cbor.MaxRecordBodySize = limit }()
+
+		if _, err = n.CreateRecord(ctx, info.ID, chunked); !errors.Is(err, ErrRecordTooLarge) {
+			t.Fatalf("expected error %v, got %v", ErrRecordTooLarge, err)
+		}
+		// Records received from peers are held to the limit too
+		if _, err = cbor.RecordFromProto(prec, info.Key.Service()); !errors.Is(err, ErrRecordTooLarge) {
+			t.Fatalf("expected error %v, got %v", ErrRecordTooLarge, err)
+		}
+	})
+}
+
+// remoteSigner is a signer which signs with key unless it's set to fail or to sign badly.
+type remoteSigner struct {
+	key  crypto.PrivKey
+	fail bool
+	bad  bool
+}
+
+func (s *remoteSigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	if s.fail {
+		return nil, errors.New("device unavailable")
+	}
+	if s.bad {
+		msg = append(msg, 0)
+	}
+	return s.key.Sign(msg)
+}
+
+func (s *remoteSigner) PublicKey() crypto.PubKey {
+	return s.key.GetPublic()
+}
+
+func TestNet_RegisterLogSigner(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	sk, pk, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := &remoteSigner{key: sk}
+	ctx := context.Background()
+	info, err := n.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithLogKey(pk))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.CreateRecord(ctx, info.ID, body); err == nil {
+		t.Fatal("expected record creation without a private key or signer to fail")
+	}
+	if err := n.RegisterLogSigner(signer); err != nil {
+		t.Fatal(err)
+	}
+	r, err := n.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Value().Verify(pk); err != nil {
+		t.Fatalf("expected record signed by the log key: %v", err)
+	}
+
+	for _, mode := range []string{"fail", "bad"} {
+		signer.fail, signer.bad = mode == "fail", mode == "bad"
+		if _, err := n.CreateRecord(ctx, info.ID, body); !errors.Is(err, ErrSigningFailed) {
+			t.Fatalf("expected error %v when signing is %s, got %v", ErrSigningFailed, mode, err)
+		}
+	}
+	lg, err := n.(*net).store.GetLog(info.ID, r.LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lg.Head.ID.Equals(r.Value().Cid()) {
+		t.Fatalf("expected failed signing to leave the log head at %s, got %s", r.Value().Cid(), lg.Head.ID)
+	}
+}
+
 func TestNet_AddThread(t *testing.T) {
 	n1 := makeNetwork(t)
 	defer n1.Close()
@@ -315,6 +658,83 @@ func TestNet_AddReplicator(t *testing.T) {
 	}
 }
 
+func TestNet_GetReplicators(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n2.Host().ID().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n1.AddReplicator(ctx, info.ID, addr); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second pull acknowledges the records received with the first one
+	for i := 0; i < 2; i++ {
+		if err := n2.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reps, err := n1.GetReplicators(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reps) != 1 {
+		t.Fatalf("expected 1 replicator got %d", len(reps))
+	}
+	if reps[0].ID != n2.Host().ID() {
+		t.Fatalf("expected replicator %s got %s", n2.Host().ID(), reps[0].ID)
+	}
+	if reps[0].LastContact.IsZero() {
+		t.Fatal("expected replicator to be contacted")
+	}
+	if len(reps[0].Logs) != 1 {
+		t.Fatalf("expected 1 log got %d", len(reps[0].Logs))
+	}
+	lg := reps[0].Logs[0]
+	if lg.ID != rec.LogID() {
+		t.Fatalf("expected log %s got %s", rec.LogID(), lg.ID)
+	}
+	if !lg.Head.Equals(rec.Value().Cid()) {
+		t.Fatalf("expected head %s got %s", rec.Value().Cid(), lg.Head)
+	}
+	if lg.Lag != 0 {
+		t.Fatalf("expected no lag got %d", lg.Lag)
+	}
+
+	if _, err := n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+	reps, err = n1.GetReplicators(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reps[0].Logs[0].Lag != 1 {
+		t.Fatalf("expected lag of 1 got %d", reps[0].Logs[0].Lag)
+	}
+}
+
 func TestNet_AddReplicatorManaged(t *testing.T) {
 	n1 := makeNetwork(t)
 	defer n1.Close()
@@ -401,9 +821,1629 @@ func TestNet_DeleteThread(t *testing.T) {
 	}
 }
 
-func TestClose(t *testing.T) {
-	n := makeNetwork(t)
-	defer n.Close()
+func TestNet_ResyncThread(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test resync thread", func(t *testing.T) {
+		if err := n2.ResyncThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n2.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+			t.Fatalf("record was not pulled again: %v", err)
+		}
+		info2, err := n2.GetThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, lg := range info2.Logs {
+			if lg.ID == r.LogID() && !lg.Head.ID.Equals(r.Value().Cid()) {
+				t.Fatalf("expected head %s, got %s", r.Value().Cid(), lg.Head.ID)
+			}
+		}
+	})
+
+	t.Run("test resync thread without peers", func(t *testing.T) {
+		other := createThread(t, ctx, n1)
+		if err := n1.ResyncThread(ctx, other.ID); !errors.Is(err, ErrNoResyncPeers) {
+			t.Fatalf("expected error %v, got %v", ErrNoResyncPeers, err)
+		}
+	})
+}
+
+func TestNet_PullThreadDepth(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	var recs []core.ThreadRecord
+	for i := 0; i < 5; i++ {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"i": i,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThreadDepth(ctx, info.ID, 0); !errors.Is(err, ErrInvalidPullDepth) {
+		t.Fatalf("expected error %v, got %v", ErrInvalidPullDepth, err)
+	}
+	if err := n2.PullThreadDepth(ctx, info.ID, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	if partial, err := n2.IsPartial(info.ID); err != nil || !partial {
+		t.Fatalf("expected thread to be partial, got %v (%v)", partial, err)
+	}
+	if partial, err := n1.IsPartial(info.ID); err != nil || partial {
+		t.Fatalf("expected thread not to be partial, got %v (%v)", partial, err)
+	}
+	nn := n2.(*net)
+	lg, err := nn.store.GetLog(info.ID, recs[0].LogID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lg.Head.ID.Equals(recs[4].Value().Cid()) || lg.Head.Counter != 5 {
+		t.Fatalf("expected head %s at 5, got %s at %d", recs[4].Value().Cid(), lg.Head.ID, lg.Head.Counter)
+	}
+	for i, r := range recs {
+		known, err := nn.isKnown(r.Value().Cid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if known != (i >= 3) {
+			t.Fatalf("expected record %d to be pulled: %v, got %v", i, i >= 3, known)
+		}
+	}
+
+	t.Run("test pull after partial pull", func(t *testing.T) {
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"i": 5,
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := n2.PullThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if known, err := nn.isKnown(r.Value().Cid()); err != nil || !known {
+			t.Fatalf("expected new record to be pulled, got %v (%v)", known, err)
+		}
+		if known, err := nn.isKnown(recs[0].Value().Cid()); err != nil || known {
+			t.Fatalf("expected skipped record not to be pulled, got %v (%v)", known, err)
+		}
+	})
+
+	t.Run("test resync partial thread", func(t *testing.T) {
+		if err := n2.ResyncThread(ctx, info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if partial, err := n2.IsPartial(info.ID); err != nil || partial {
+			t.Fatalf("expected thread not to be partial, got %v (%v)", partial, err)
+		}
+		if known, err := nn.isKnown(recs[0].Value().Cid()); err != nil || !known {
+			t.Fatalf("expected skipped record to be pulled, got %v (%v)", known, err)
+		}
+	})
+}
+
+func TestNet_ListThreads(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	infos, err := n.ListThreads(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("expected no threads, got %d", len(infos))
+	}
+
+	created := make(map[thread.ID]bool)
+	for i := 0; i < 3; i++ {
+		created[createThread(t, ctx, n).ID] = true
+	}
+	var id thread.ID
+	for k := range created {
+		id = k
+		break
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n.CreateRecord(ctx, id, body); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err = n.ListThreads(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 threads, got %d", len(infos))
+	}
+	for _, info := range infos {
+		if !created[info.ID] {
+			t.Fatalf("unexpected thread %s", info.ID)
+		}
+		if info.ID == id {
+			if len(info.Logs) != 1 || info.Logs[0].Head.Counter != 1 {
+				t.Fatalf("expected one log with one record, got %v", info.Logs)
+			}
+			if info.Updated.IsZero() {
+				t.Fatal("expected thread to be updated")
+			}
+		} else if !info.Updated.IsZero() {
+			t.Fatal("expected thread not to be updated")
+		}
+	}
+
+	// Page through the threads.
+	var paged []thread.ID
+	var offset thread.ID
+	for {
+		page, err := n.ListThreads(ctx, core.WithListOffset(offset), core.WithListLimit(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, info := range page {
+			paged = append(paged, info.ID)
+		}
+		offset = page[len(page)-1].ID
+	}
+	if len(paged) != 3 {
+		t.Fatalf("expected 3 paged threads, got %d", len(paged))
+	}
+	for i := range paged {
+		if paged[i] != infos[i].ID {
+			t.Fatal("expected pages to be in order of id")
+		}
+	}
+}
+
+func TestNet_Compact(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+		"baz": []byte("howdy"),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	last := recs[2].Value().Cid()
+
+	t.Run("test unacknowledged", func(t *testing.T) {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pid, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, err := ma.NewMultiaddr("/p2p/" + pid.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		nn := n.(*net)
+		if err := nn.store.AddAddr(info.ID, recs[0].LogID(), addr, peerstore.PermanentAddrTTL); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n.CompactDryRun(ctx, info.ID, last); !errors.Is(err, ErrUnacknowledgedRecords) {
+			t.Fatalf("expected unacknowledged records error, got %v", err)
+		}
+		if err := nn.ackRecords(info.ID, pid, recs[0].LogID(), thread.Head{ID: recs[1].Value().Cid(), Counter: 2}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("test dry run", func(t *testing.T) {
+		size, err := n.CompactDryRun(ctx, info.ID, last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size <= 0 {
+			t.Fatalf("expected bytes to be reclaimed, got %d", size)
+		}
+		if _, err := cbor.GetEvent(ctx, n, recs[0].Value().BlockID()); err != nil {
+			t.Fatal("dry run removed event")
+		}
+	})
+
+	t.Run("test compact", func(t *testing.T) {
+		if err := n.Compact(ctx, info.ID, last); err != nil {
+			t.Fatal(err)
+		}
+		for i, r := range recs {
+			if _, err := n.GetRecord(ctx, info.ID, r.Value().Cid()); err != nil {
+				t.Fatalf("getting record %d: %v", i, err)
+			}
+			_, err := cbor.GetEvent(ctx, n, r.Value().BlockID())
+			if i < 2 && err == nil {
+				t.Fatalf("expected event %d to be removed", i)
+			} else if i == 2 && err != nil {
+				t.Fatalf("expected event %d to be kept, got %v", i, err)
+			}
+		}
+		size, err := n.CompactDryRun(ctx, info.ID, last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != 0 {
+			t.Fatalf("expected no bytes to be reclaimed, got %d", size)
+		}
+	})
+}
+
+func TestNet_VerifyThread(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+		"baz": []byte("howdy"),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []core.ThreadRecord
+	for i := 0; i < 3; i++ {
+		r, err := n.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+	nn := n.(*net)
+	kinds := func(report core.VerifyReport) map[core.VerifyProblemKind]cid.Cid {
+		found := make(map[core.VerifyProblemKind]cid.Cid)
+		for _, p := range report.Problems {
+			found[p.Kind] = p.Record
+		}
+		return found
+	}
+
+	t.Run("test valid", func(t *testing.T) {
+		report, err := n.VerifyThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.OK() {
+			t.Fatalf("expected no problems, got %v", report.Problems)
+		}
+		if report.Records != 3 {
+			t.Fatalf("expected 3 records to be checked, got %d", report.Records)
+		}
+	})
+
+	t.Run("test compacted", func(t *testing.T) {
+		if err := n.Compact(ctx, info.ID, recs[1].Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+		report, err := n.VerifyThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !report.OK() {
+			t.Fatalf("expected compacted events not to be reported, got %v", report.Problems)
+		}
+	})
+
+	t.Run("test corrupt", func(t *testing.T) {
+		eid := recs[2].Value().BlockID()
+		if err := nn.bstore.DeleteBlock(eid); err != nil {
+			t.Fatal(err)
+		}
+		corrupt, err := blocks.NewBlockWithCid([]byte("garbage"), eid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := nn.bstore.Put(corrupt); err != nil {
+			t.Fatal(err)
+		}
+		report, err := n.VerifyThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := kinds(report)
+		if len(report.Problems) != 1 || !found[core.RecordCorrupt].Equals(recs[2].Value().Cid()) {
+			t.Fatalf("expected record %s to be corrupt, got %v", recs[2].Value().Cid(), report.Problems)
+		}
+	})
+
+	t.Run("test missing", func(t *testing.T) {
+		if err := nn.bstore.DeleteBlock(recs[1].Value().Cid()); err != nil {
+			t.Fatal(err)
+		}
+		report, err := n.VerifyThread(ctx, info.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := kinds(report)
+		if !found[core.RecordMissing].Equals(recs[1].Value().Cid()) {
+			t.Fatalf("expected record %s to be missing, got %v", recs[1].Value().Cid(), report.Problems)
+		}
+		if _, ok := found[core.ChainBroken]; !ok {
+			t.Fatalf("expected broken chain, got %v", report.Problems)
+		}
+		if report.Records != 1 {
+			t.Fatalf("expected 1 record to be checked, got %d", report.Records)
+		}
+	})
+}
+
+func TestNet_DiffThread(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := n2.DiffThread(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Equal() {
+		t.Fatalf("expected equal threads, got %d differing logs", len(report.Logs))
+	}
+
+	// Keep n2 from receiving the next record
+	if err := n2.PauseThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	body2, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo again!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r2, err := n1.CreateRecord(ctx, info.ID, body2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = n2.DiffThread(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Logs) != 1 {
+		t.Fatalf("expected 1 differing log, got %d", len(report.Logs))
+	}
+	d := report.Logs[0]
+	if d.ID != r2.LogID() {
+		t.Fatalf("expected log %s to differ, got %s", r2.LogID(), d.ID)
+	}
+	if d.Index != 1 || d.Local.Defined() || !d.Remote.Equals(r2.Value().Cid()) {
+		t.Fatalf("expected record 1 to be missing locally, got index %d, local %s, remote %s", d.Index, d.Local, d.Remote)
+	}
+	if !d.LocalHead.ID.Equals(r1.Value().Cid()) || !d.RemoteHead.ID.Equals(r2.Value().Cid()) {
+		t.Fatalf("expected heads %s and %s, got %s and %s", r1.Value().Cid(), r2.Value().Cid(), d.LocalHead.ID, d.RemoteHead.ID)
+	}
+	if len(d.MissingLocal) != 1 || !d.MissingLocal[0].Equals(r2.Value().Cid()) {
+		t.Fatalf("expected %s to be missing locally, got %v", r2.Value().Cid(), d.MissingLocal)
+	}
+	if len(d.MissingRemote) != 0 {
+		t.Fatalf("expected no records to be missing remotely, got %v", d.MissingRemote)
+	}
+
+	// The reverse diff reports the same record missing remotely
+	report, err = n1.DiffThread(ctx, info.ID, n2.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Logs) != 1 || len(report.Logs[0].MissingRemote) != 1 {
+		t.Fatalf("expected 1 record to be missing remotely, got %+v", report.Logs)
+	}
+
+	if err := n2.ResumeThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	report, err = n2.DiffThread(ctx, info.ID, n1.Host().ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Equal() {
+		t.Fatalf("expected equal threads after resuming, got %d differing logs", len(report.Logs))
+	}
+
+	// Peers without the thread don't reply
+	n3 := makeNetwork(t)
+	defer n3.Close()
+	n2.Host().Peerstore().AddAddrs(n3.Host().ID(), n3.Host().Addrs(), peerstore.PermanentAddrTTL)
+	if _, err := n2.DiffThread(ctx, info.ID, n3.Host().ID()); !errors.Is(err, ErrDiffFailed) {
+		t.Fatalf("expected error %v, got %v", ErrDiffFailed, err)
+	}
+}
+
+func TestNet_SubscribeLogs(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info1 := createThread(t, ctx, n)
+	info2 := createThread(t, ctx, n)
+	log1 := info1.Logs[0].ID
+	log2 := info2.Logs[0].ID
+
+	subs := map[string][]core.SubOption{
+		"all":            nil,
+		"log":            {core.WithSubLogs(log1)},
+		"logs":           {core.WithSubLogs(log1, log2)},
+		"thread and log": {core.WithSubFilter(info1.ID), core.WithSubLogs(log1)},
+		"disjoint":       {core.WithSubFilter(info1.ID), core.WithSubLogs(log2)},
+	}
+	expected := map[string]int{"all": 4, "log": 2, "logs": 4, "thread and log": 2, "disjoint": 0}
+
+	var (
+		counts = make(map[string]int)
+		lock   sync.Mutex
+	)
+	for name, opts := range subs {
+		sub, err := n.Subscribe(ctx, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func(name string) {
+			for rec := range sub {
+				if name == "log" && rec.LogID() != log1 {
+					t.Errorf("got record of log %s", rec.LogID())
+				}
+				lock.Lock()
+				counts[name]++
+				lock.Unlock()
+			}
+		}(name)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		for _, id := range []thread.ID{info1.ID, info2.ID} {
+			if _, err := n.CreateRecord(ctx, id, body); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	time.Sleep(time.Second)
+
+	lock.Lock()
+	defer lock.Unlock()
+	for name, count := range expected {
+		if counts[name] != count {
+			t.Errorf("expected %d records for %s, got %d", count, name, counts[name])
+		}
+	}
+}
+
+func TestNet_SubscribeMembership(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	info := createThread(t, ctx, n1)
+	body, err := cbornode.WrapObject(map[string]interface{}{"foo": "bar"}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n1.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := n2.Subscribe(ctx, core.WithSubMembership())
+	if err != nil {
+		t.Fatal(err)
+	}
+	recSub, err := n2.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		changes []core.MembershipRecord
+		lock    sync.Mutex
+	)
+	go func() {
+		for rec := range sub {
+			if m, ok := rec.(core.MembershipRecord); ok {
+				lock.Lock()
+				changes = append(changes, m)
+				lock.Unlock()
+			}
+		}
+	}()
+	go func() {
+		for rec := range recSub {
+			if _, ok := rec.(core.MembershipRecord); ok {
+				t.Errorf("got membership record without WithSubMembership")
+			}
+		}
+	}()
+	check := func(expected ...core.MembershipChange) []core.MembershipRecord {
+		time.Sleep(time.Second)
+		lock.Lock()
+		defer lock.Unlock()
+		got := changes
+		changes = nil
+		if len(got) != len(expected) {
+			t.Fatalf("expected %d membership records, got %d", len(expected), len(got))
+		}
+		for i, m := range got {
+			if m.Change() != expected[i] {
+				t.Fatalf("expected change %s, got %s", expected[i], m.Change())
+			}
+			if m.ThreadID() != info.ID {
+				t.Fatalf("expected thread %s, got %s", info.ID, m.ThreadID())
+			}
+			if m.Value() != nil {
+				t.Fatal("expected membership record to have no value")
+			}
+			if m.PubKey() == nil {
+				t.Fatal("expected membership record to have a log key")
+			}
+		}
+		return got
+	}
+
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(thread.NewServiceKey(info.Key.Service()))); err != nil {
+		t.Fatal(err)
+	}
+	added := check(core.LogAdded)
+	if added[0].LogID() != info.Logs[0].ID || added[0].Readable() {
+		t.Fatalf("expected unreadable log %s to be added", info.Logs[0].ID)
+	}
+
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range check(core.KeyReceived, core.LogAdded) {
+		if !m.Readable() {
+			t.Fatalf("expected log %s to be readable after %s", m.LogID(), m.Change())
+		}
+	}
+
+	if err = n2.DeleteThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	check(core.LogRemoved, core.LogRemoved)
+}
+
+func TestNet_ExportThread(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"foo": "bar",
+		"baz": []byte("howdy"),
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []core.ThreadRecord
+	for i := 0; i < 2; i++ {
+		r, err := n1.CreateRecord(ctx, info.ID, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, r)
+	}
+
+	var archive bytes.Buffer
+	if err := n1.ExportThread(ctx, info.ID, &archive); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("test tampered archive", func(t *testing.T) {
+		tampered := tamperArchive(t, archive.Bytes())
+		if _, err := n2.ImportThread(ctx, bytes.NewReader(tampered)); !errors.Is(err, ErrArchiveRecordInvalid) {
+			t.Fatalf("expected invalid record error, got %v", err)
+		}
+		if _, err := n2.GetThread(ctx, info.ID); !errors.Is(err, logstore.ErrThreadNotFound) {
+			t.Fatalf("expected thread not to be added, got %v", err)
+		}
+	})
+
+	t.Run("test import", func(t *testing.T) {
+		id, err := n2.ImportThread(ctx, bytes.NewReader(archive.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !id.Equals(info.ID) {
+			t.Fatalf("expected thread %s, got %s", info.ID, id)
+		}
+		imported, err := n2.GetThread(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !imported.Key.CanRead() {
+			t.Fatal("expected read key to be imported")
+		}
+		if len(imported.Logs) != 1 {
+			t.Fatalf("expected 1 log, got %d", len(imported.Logs))
+		}
+		head := imported.Logs[0].Head
+		if !head.ID.Equals(recs[1].Value().Cid()) || head.Counter != 2 {
+			t.Fatalf("expected head %s at 2, got %s at %d", recs[1].Value().Cid(), head.ID, head.Counter)
+		}
+		for i, r := range recs {
+			rec, err := n2.GetRecord(ctx, id, r.Value().Cid())
+			if err != nil {
+				t.Fatalf("getting record %d: %v", i, err)
+			}
+			event, err := cbor.EventFromRecord(ctx, n2, rec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := event.GetBody(ctx, n2, imported.Key.Read()); err != nil {
+				t.Fatalf("getting body of record %d: %v", i, err)
+			}
+		}
+	})
+
+	t.Run("test import existing", func(t *testing.T) {
+		if _, err := n1.ImportThread(ctx, bytes.NewReader(archive.Bytes())); !errors.Is(err, logstore.ErrThreadExists) {
+			t.Fatalf("expected thread exists error, got %v", err)
+		}
+	})
+}
+
+// tamperArchive replaces the key of the first log of a thread archive.
+func tamperArchive(t *testing.T, archive []byte) []byte {
+	dec := gob.NewDecoder(bytes.NewReader(archive))
+	var header archiveHeader
+	if err := dec.Decode(&header); err != nil {
+		t.Fatal(err)
+	}
+	plog := &pb.Log{}
+	if err := plog.Unmarshal(header.Logs[0]); err != nil {
+		t.Fatal(err)
+	}
+	_, pk, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plog.PubKey = &pb.ProtoPubKey{PubKey: pk}
+	if header.Logs[0], err = plog.Marshal(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(header); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		var entry archiveRecord
+		if err := dec.Decode(&entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := enc.Encode(entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry.End {
+			return buf.Bytes()
+		}
+	}
+}
+
+func TestNet_SetPullInterval(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	other := createThread(t, ctx, n)
+	pulls := n.(*net).pulls
+
+	now := time.Now()
+	if !pulls.due(info.ID, now, time.Minute) {
+		t.Fatal("expected thread which was never pulled to be due")
+	}
+	pulls.touch(info.ID, now)
+	pulls.touch(other.ID, now)
+	if pulls.due(info.ID, now.Add(time.Second*10), time.Minute) {
+		t.Fatal("expected recently updated thread not to be due")
+	}
+	if !pulls.due(info.ID, now.Add(time.Minute), time.Minute) {
+		t.Fatal("expected thread to be due after the interval")
+	}
+
+	if err := n.SetPullInterval(info.ID, time.Second*10); err != nil {
+		t.Fatal(err)
+	}
+	if !pulls.due(info.ID, now.Add(time.Second*10), time.Minute) {
+		t.Fatal("expected thread to be due after the overridden interval")
+	}
+	if pulls.due(other.ID, now.Add(time.Second*10), time.Minute) {
+		t.Fatal("expected override not to apply to other threads")
+	}
+	if d := pulls.shortest(time.Minute); d != time.Second*10 {
+		t.Fatalf("expected shortest interval of 10s, got %s", d)
+	}
+
+	if err := n.SetPullInterval(info.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	if pulls.due(info.ID, now.Add(time.Second*10), time.Minute) {
+		t.Fatal("expected override to be removed")
+	}
+}
+
+func TestNet_PauseThread(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	pulls := n.(*net).pulls
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := n.PauseThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if pulls.due(info.ID, time.Now(), time.Minute) {
+		t.Fatal("expected paused thread not to be due")
+	}
+	if _, err := n.CreateRecord(ctx, info.ID, body); !errors.Is(err, ErrThreadPaused) {
+		t.Fatalf("expected error %v, got %v", ErrThreadPaused, err)
+	}
+	if err := n.PullThread(ctx, info.ID); !errors.Is(err, ErrThreadPaused) {
+		t.Fatalf("expected error %v, got %v", ErrThreadPaused, err)
+	}
+	if _, err := n.GetThread(ctx, info.ID); err != nil {
+		t.Fatalf("expected paused thread to be readable, got %v", err)
+	}
+
+	if err := n.ResumeThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	if pulls.isPaused(info.ID) {
+		t.Fatal("expected thread to be resumed")
+	}
+	if _, err := n.CreateRecord(ctx, info.ID, body); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNet_Retry(t *testing.T) {
+	n := &net{conf: Config{DialRetryMax: 2, DialRetryBase: time.Millisecond}}
+	transient := fmt.Errorf("dial failed: %w", context.DeadlineExceeded)
+
+	var calls int
+	err := n.retry(context.Background(), func() error {
+		calls++
+		return transient
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected error to wrap the last cause, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", calls)
+	}
+
+	calls = 0
+	err = n.retry(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return status.Error(codes.Unavailable, "connection reset")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", calls)
+	}
+
+	calls = 0
+	err = n.retry(context.Background(), func() error {
+		calls++
+		return status.Error(codes.PermissionDenied, "bad thread key")
+	})
+	if status.Code(err) != codes.PermissionDenied || calls != 1 {
+		t.Fatalf("expected permanent error after 1 attempt, got %v after %d", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = n.retry(ctx, func() error {
+		calls++
+		cancel()
+		return transient
+	})
+	if !errors.Is(err, context.DeadlineExceeded) || calls != 1 {
+		t.Fatalf("expected to give up when context is done, got %v after %d", err, calls)
+	}
+}
+
+func TestNet_RotateKey(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := n1.RotateKey(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	keys1, err := n1.ReadKeys(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys1) != 2 {
+		t.Fatalf("expected 2 read keys, got %d", len(keys1))
+	}
+	if !bytes.Equal(keys1[1].Bytes(), info.Key.Read().Bytes()) {
+		t.Fatal("expected previous read key to be retained")
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	keys2, err := n2.ReadKeys(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys2) != 2 || !bytes.Equal(keys2[0].Bytes(), keys1[0].Bytes()) {
+		t.Fatal("expected rotated read key to be distributed")
+	}
+	rec, err := n2.GetRecord(ctx, info.ID, r.Value().Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := cbor.EventFromRecord(ctx, n2, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := event.GetBody(ctx, n2, info.Key.Read()); err == nil {
+		t.Fatal("expected record to be unreadable with the previous read key")
+	}
+	if _, err := event.GetBody(ctx, n2, keys2[0]); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNet_NamedReadKeys(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	ka, kb := sym.New(), sym.New()
+	key := thread.NewRandomKey().WithNamedRead("a", ka).WithNamedRead("b", kb)
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithThreadKey(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Key.NamedRead("a") == nil || info.Key.NamedRead("b") == nil {
+		t.Fatal("expected thread info to hold the named read keys")
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ra, err := n1.CreateRecord(ctx, info.ID, body, core.WithReadKeyName("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rb, err := n1.CreateRecord(ctx, info.ID, body, core.WithReadKeyName("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// n2 only holds the read key named "a"
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(thread.NewServiceKey(info.Key.Service()).WithNamedRead("a", ka))); err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info2.Key.CanRead() || info2.Key.NamedRead("a") == nil || info2.Key.NamedRead("b") != nil {
+		t.Fatal("expected thread info to only hold the read key named a")
+	}
+
+	rec, err := n2.GetRecord(ctx, info.ID, ra.Value().Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := cbor.EventFromRecord(ctx, n2, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := event.GetBody(ctx, n2, ka); err != nil {
+		t.Fatal(err)
+	}
+	// The record encrypted under the read key named "b" is synced, but unreadable
+	rec, err = n2.GetRecord(ctx, info.ID, rb.Value().Cid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err = cbor.EventFromRecord(ctx, n2, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := event.GetBody(ctx, n2, ka); err == nil {
+		t.Fatal("expected record to be unreadable with the read key named a")
+	}
+
+	if _, err := n2.CreateRecord(ctx, info.ID, body, core.WithReadKeyName("b")); !errors.Is(err, ErrReadKeyNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrReadKeyNotFound, err)
+	}
+}
+
+func TestNet_MergeLogs(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	n2 := makeNetwork(t)
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	// The author writes to a log on each host, e.g., after a reinstall
+	ctx := context.Background()
+	sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := thread.NewLibp2pIdentity(sk)
+	tok1, err := n1.GetToken(ctx, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok2, err := n2.GetToken(ctx, identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := n1.CreateThread(ctx, thread.NewIDV1(thread.Raw, 32), core.WithNewThreadToken(tok1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var originals []cid.Cid
+	for i := 0; i < 2; i++ {
+		r, err := n1.CreateRecord(ctx, info.ID, body, core.WithThreadToken(tok1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		originals = append(originals, r.Value().Cid())
+	}
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key), core.WithNewThreadToken(tok2)); err != nil {
+		t.Fatal(err)
+	}
+	into, err := n2.CreateRecord(ctx, info.ID, body, core.WithThreadToken(tok2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n2.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	info2, err := n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(info2.Logs))
+	}
+	var from peer.ID
+	for _, lg := range info2.Logs {
+		if lg.ID != into.LogID() {
+			from = lg.ID
+		}
+	}
+
+	if err := n2.MergeLogs(ctx, info.ID, from, into.LogID()); !errors.Is(err, ErrCannotMergeLogs) {
+		t.Fatalf("expected error %v, got %v", ErrCannotMergeLogs, err)
+	}
+	if err := n2.MergeLogs(ctx, info.ID, into.LogID(), from); err != nil {
+		t.Fatal(err)
+	}
+	info2, err = n2.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 1 || info2.Logs[0].ID != into.LogID() {
+		t.Fatal("expected the merged log to be retired")
+	}
+	// The record of the log, the announcement, and the merged records
+	if info2.Logs[0].Head.Counter != 4 {
+		t.Fatalf("expected 4 records in merged log, got %d", info2.Logs[0].Head.Counter)
+	}
+	rec, err := n2.GetRecord(ctx, info.ID, info2.Logs[0].Head.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig, err := n2.GetRecord(ctx, info.ID, originals[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rec.BlockID().Equals(orig.BlockID()) {
+		t.Fatal("expected merged records to keep their events and order")
+	}
+
+	// The other host retires the merged log, and writes to a new one
+	if err := n1.PullThread(ctx, info.ID); err != nil {
+		t.Fatal(err)
+	}
+	info1, err := n1.GetThread(ctx, info.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, lg := range info1.Logs {
+		if lg.ID == from {
+			t.Fatal("expected the merged log to be retired by the other host")
+		}
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body, core.WithThreadToken(tok1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.LogID() == from || r.LogID() == into.LogID() {
+		t.Fatal("expected record to be added to a new log")
+	}
+}
+
+type rejectingApp struct{}
+
+func (rejectingApp) ValidateNetRecordBody(context.Context, format.Node, thread.PubKey) error {
+	return errors.New("rejected")
+}
+
+func (rejectingApp) HandleNetRecord(context.Context, core.ThreadRecord, thread.Key) error {
+	return nil
+}
+
+func TestNet_RecordErrorHandler(t *testing.T) {
+	n1 := makeNetwork(t)
+	defer n1.Close()
+	type failure struct {
+		lid peer.ID
+		rec cid.Cid
+	}
+	failures := make(chan failure, 10)
+	n2 := makeNetworkWithConfig(t, Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		PubSub:                    true,
+		RecordErrorHandler: func(_ thread.ID, lid peer.ID, rec cid.Cid, _ error) {
+			failures <- failure{lid: lid, rec: rec}
+		},
+		Debug: true,
+	})
+	defer n2.Close()
+
+	n1.Host().Peerstore().AddAddrs(n2.Host().ID(), n2.Host().Addrs(), peerstore.PermanentAddrTTL)
+	n2.Host().Peerstore().AddAddrs(n1.Host().ID(), n1.Host().Addrs(), peerstore.PermanentAddrTTL)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n1)
+	addr, err := ma.NewMultiaddr("/p2p/" + n1.Host().ID().String() + "/thread/" + info.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.AddThread(ctx, addr, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n2.(*net).ConnectApp(rejectingApp{}, info.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := n1.CreateRecord(ctx, info.ID, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The record may have been pushed already, in which case pulling doesn't apply it again
+	_ = n2.PullThread(ctx, info.ID)
+
+	select {
+	case f := <-failures:
+		if f.lid != r.LogID() || !f.rec.Equals(r.Value().Cid()) {
+			t.Fatalf("expected failure of record %s in log %s, got %s in log %s", r.Value().Cid(), r.LogID(), f.rec, f.lid)
+		}
+	case <-time.After(time.Second * 10):
+		t.Fatal("expected record error handler to be called")
+	}
+}
+
+func TestNet_PubSubRateLimit(t *testing.T) {
+	const (
+		peerLimit   = 10
+		threadLimit = 50
+		peers       = 20
+		flood       = 200
+	)
+	n := makeNetworkWithConfig(t, Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		PubSub:                    true,
+		PubSubPeerRateLimit:       peerLimit,
+		PubSubThreadRateLimit:     threadLimit,
+	})
+	defer n.Close()
+	nn := n.(*net)
+
+	// Buckets only refill when the clock is advanced
+	var (
+		clockLock sync.Mutex
+		now       = time.Now()
+	)
+	clock := func() time.Time {
+		clockLock.Lock()
+		defer clockLock.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		clockLock.Lock()
+		defer clockLock.Unlock()
+		now = now.Add(d)
+	}
+	nn.peerLimiter = newRateLimiterWithClock(peerLimit, clock)
+	nn.threadLimiter = newRateLimiterWithClock(threadLimit, clock)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+
+	var mem runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&mem)
+	heap := mem.HeapAlloc
+	goroutines := runtime.NumGoroutine()
+
+	var (
+		accepted, rejected, ignored int64
+		wg                          sync.WaitGroup
+		pids                        = make([]peer.ID, peers)
+	)
+	for i := range pids {
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pids[i], err = peer.IDFromPrivateKey(sk); err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func(pid peer.ID) {
+			defer wg.Done()
+			for j := 0; j < flood; j++ {
+				switch nn.server.limitRecord(pid, info.ID) {
+				case pubsub.ValidationAccept:
+					atomic.AddInt64(&accepted, 1)
+				case pubsub.ValidationReject:
+					atomic.AddInt64(&rejected, 1)
+				case pubsub.ValidationIgnore:
+					atomic.AddInt64(&ignored, 1)
+				}
+			}
+		}(pids[i])
+	}
+	wg.Wait()
+
+	// Each peer gets peerLimit tokens, which are shared by threadLimit thread tokens
+	if accepted != threadLimit {
+		t.Fatalf("expected %d accepted records, got %d", threadLimit, accepted)
+	}
+	if expected := int64(peers * (flood - peerLimit)); rejected != expected {
+		t.Fatalf("expected %d rejected records, got %d", expected, rejected)
+	}
+	if expected := int64(peers*peerLimit - threadLimit); ignored != expected {
+		t.Fatalf("expected %d ignored records, got %d", expected, ignored)
+	}
+	if nn.server.limitRecord(nn.host.ID(), info.ID) != pubsub.ValidationAccept {
+		t.Fatal("expected own records to be accepted")
+	}
+
+	// A tenth of a second refills a token of each peer
+	advance(time.Second / peerLimit)
+	if res := nn.server.limitRecord(pids[0], info.ID); res != pubsub.ValidationAccept {
+		t.Fatalf("expected record to be accepted after refill, got %v", res)
+	}
+	if res := nn.server.limitRecord(pids[0], info.ID); res != pubsub.ValidationReject {
+		t.Fatalf("expected record to be rejected once the refill is spent, got %v", res)
+	}
+
+	if l := nn.peerLimiter.Len(); l != peers {
+		t.Fatalf("expected %d tracked peers, got %d", peers, l)
+	}
+	if l := nn.threadLimiter.Len(); l != 1 {
+		t.Fatalf("expected 1 tracked thread, got %d", l)
+	}
+	// Idle buckets are dropped once they're full again
+	advance(time.Second)
+	nn.peerLimiter.Allow(pids[0].String())
+	if l := nn.peerLimiter.Len(); l != 1 {
+		t.Fatalf("expected 1 tracked peer, got %d", l)
+	}
+	if g := runtime.NumGoroutine(); g > goroutines+10 {
+		t.Fatalf("goroutines grew from %d to %d", goroutines, g)
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&mem)
+	if mem.HeapAlloc > heap && mem.HeapAlloc-heap > 16<<20 {
+		t.Fatalf("heap grew by %d bytes", mem.HeapAlloc-heap)
+	}
+
+	// Penalties are bounded
+	if v := lowerPenalty(0); v != -1 {
+		t.Fatalf("expected penalty -1, got %d", v)
+	}
+	if v := lowerPenalty(pubSubMinPenalty); v != pubSubMinPenalty {
+		t.Fatalf("expected penalty to stop at %d, got %d", pubSubMinPenalty, v)
+	}
+}
+
+func TestNet_PubSubControl(t *testing.T) {
+	n := makeNetworkWithConfig(t, Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		PubSub:                    true,
+	})
+	defer n.Close()
+	nn := n.(*net)
+
+	ctx := context.Background()
+	info := createThread(t, ctx, n)
+	hasTopic := func() bool {
+		nn.server.Lock()
+		defer nn.server.Unlock()
+		_, ok := nn.server.topics[info.ID]
+		return ok
+	}
+	if !hasTopic() {
+		t.Fatal("expected thread to join its topic")
+	}
+
+	t.Run("SetThreadPubSub", func(t *testing.T) {
+		if err := n.SetThreadPubSub(info.ID, false); err != nil {
+			t.Fatal(err)
+		}
+		if hasTopic() {
+			t.Fatal("expected thread to leave its topic")
+		}
+		// Records are still created, just not published
+		body, err := cbornode.WrapObject(map[string]interface{}{
+			"msg": "yo!",
+		}, mh.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := n.CreateRecord(ctx, info.ID, body); err != nil {
+			t.Fatal(err)
+		}
+		// Topics aren't joined again for threads with pubsub disabled
+		if err := nn.server.addPubsubTopic(info.ID); err != nil {
+			t.Fatal(err)
+		}
+		if hasTopic() {
+			t.Fatal("expected thread to stay out of its topic")
+		}
+		if err := n.SetThreadPubSub(info.ID, true); err != nil {
+			t.Fatal(err)
+		}
+		if !hasTopic() {
+			t.Fatal("expected thread to join its topic again")
+		}
+	})
+
+	t.Run("Validator", func(t *testing.T) {
+		const maxSize = 16
+		n.RegisterPubSubValidator(func(_ context.Context, _ peer.ID, _ thread.ID, msg []byte) error {
+			if len(msg) > maxSize {
+				return fmt.Errorf("message of %d bytes is too large", len(msg))
+			}
+			return nil
+		})
+		sk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pid, err := peer.IDFromPrivateKey(sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res := nn.server.validateRecord(ctx, pid, info.ID, make([]byte, maxSize)); res != pubsub.ValidationAccept {
+			t.Fatalf("expected small record to be accepted, got %v", res)
+		}
+		if res := nn.server.validateRecord(ctx, pid, info.ID, make([]byte, maxSize+1)); res != pubsub.ValidationReject {
+			t.Fatalf("expected large record to be rejected, got %v", res)
+		}
+		if ti := nn.host.ConnManager().GetTagInfo(pid); ti == nil || ti.Tags[pubSubPenaltyTag] >= 0 {
+			t.Fatal("expected publisher of rejected record to be penalized")
+		}
+	})
+}
+
+// memoryRouting is an in-memory content router shared by the networks of a test.
+type memoryRouting struct {
+	sync.Mutex
+	providers map[cid.Cid][]peer.AddrInfo
+}
+
+type memoryProvider struct {
+	r *memoryRouting
+	h host.Host
+}
+
+var _ routing.ContentRouting = (*memoryProvider)(nil)
+
+func (p *memoryProvider) Provide(_ context.Context, c cid.Cid, _ bool) error {
+	p.r.Lock()
+	defer p.r.Unlock()
+	p.r.providers[c] = append(p.r.providers[c], peer.AddrInfo{ID: p.h.ID(), Addrs: p.h.Addrs()})
+	return nil
+}
+
+func (p *memoryProvider) FindProvidersAsync(_ context.Context, c cid.Cid, limit int) <-chan peer.AddrInfo {
+	p.r.Lock()
+	defer p.r.Unlock()
+	ch := make(chan peer.AddrInfo, len(p.r.providers[c]))
+	for i, ai := range p.r.providers[c] {
+		if i == limit {
+			break
+		}
+		ch <- ai
+	}
+	close(ch)
+	return ch
+}
+
+func TestNet_ThreadDiscovery(t *testing.T) {
+	id := thread.NewIDV1(thread.Raw, 32)
+	key, err := discoveryKey(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(key.Bytes(), id.Bytes()) {
+		t.Fatal("expected discovery key not to contain the thread id")
+	}
+
+	router := &memoryRouting{providers: make(map[cid.Cid][]peer.AddrInfo)}
+	newNetwork := func() core.Net {
+		p := &memoryProvider{r: router}
+		n := makeNetworkWithConfig(t, Config{
+			NetPullingLimit:           10000,
+			NetPullingStartAfter:      time.Second,
+			NetPullingInitialInterval: time.Second,
+			NetPullingInterval:        time.Second * 10,
+			Discovery:                 p,
+		})
+		p.h = n.Host()
+		return n
+	}
+	n1 := newNetwork()
+	defer n1.Close()
+	n2 := newNetwork()
+	defer n2.Close()
+
+	ctx := context.Background()
+	info, err := n1.CreateThread(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := cbornode.WrapObject(map[string]interface{}{
+		"msg": "yo!",
+	}, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n1.CreateRecord(ctx, id, body); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second network finds the first one without knowing its address.
+	if _, err := n2.CreateThread(ctx, id, core.WithThreadKey(info.Key)); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Second * 2)
+
+	info2, err := n2.GetThread(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info2.Logs) != 2 {
+		t.Fatalf("expected 2 logs got %d", len(info2.Logs))
+	}
+	info1, err := n1.GetThread(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lg1 := info1.Logs[0]
+	lg2, err := n2.Store().GetLog(id, lg1.ID)
+	if err != nil {
+		t.Fatalf("expected log of discovered peer to be added: %v", err)
+	}
+	if lg2.Head.ID != lg1.Head.ID {
+		t.Fatal("expected records of discovered peer to be pulled")
+	}
+}
+
+func TestClose(t *testing.T) {
+	n := makeNetwork(t)
+	defer n.Close()
 
 	t.Run("test close", func(t *testing.T) {
 		if err := n.Close(); err != nil {
@@ -413,6 +2453,17 @@ func TestClose(t *testing.T) {
 }
 
 func makeNetwork(t *testing.T) core.Net {
+	return makeNetworkWithConfig(t, Config{
+		NetPullingLimit:           10000,
+		NetPullingStartAfter:      time.Second,
+		NetPullingInitialInterval: time.Second,
+		NetPullingInterval:        time.Second * 10,
+		PubSub:                    true,
+		Debug:                     true,
+	})
+}
+
+func makeNetworkWithConfig(t *testing.T, conf Config) core.Net {
 	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 0)
 	if err != nil {
 		t.Fatal(err)
@@ -435,14 +2486,7 @@ func makeNetwork(t *testing.T) core.Net {
 		bsrv.Blockstore(),
 		dag.NewDAGService(bsrv),
 		tstore.NewLogstore(),
-		Config{
-			NetPullingLimit:           10000,
-			NetPullingStartAfter:      time.Second,
-			NetPullingInitialInterval: time.Second,
-			NetPullingInterval:        time.Second * 10,
-			PubSub:                    true,
-			Debug:                     true,
-		}, nil, nil)
+		conf, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
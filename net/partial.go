@@ -0,0 +1,123 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// partialKey is the thread metadata key marking a thread whose logs don't go back to their
+// first records.
+const partialKey = "partial"
+
+// ErrInvalidPullDepth indicates a non-positive number of records to pull.
+var ErrInvalidPullDepth = errors.New("pull depth must be positive")
+
+// PullThreadDepth pulls at most maxRecords of the newest records of each log of a thread.
+// Older records which haven't been pulled already are skipped, instead of being fetched to
+// bridge the gap to the local head of the log, and the thread is marked as partial.
+// A connected app only handles the pulled records, so it holds a view of the recent activity
+// of the thread. Later pulls continue from the pulled records.
+func (n *net) PullThreadDepth(ctx context.Context, id thread.ID, maxRecords int, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if maxRecords <= 0 {
+		return ErrInvalidPullDepth
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return err
+	}
+	if err := n.checkNotPaused(id); err != nil {
+		return err
+	}
+
+	defer n.metrics.pulled(time.Now())
+	offsets, peers, err := n.threadOffsets(id)
+	if err != nil {
+		return err
+	}
+	recs, err := n.server.getRecords(ctx, peers, id, offsets, uint(maxRecords))
+	if err != nil {
+		return err
+	}
+	for lid, rs := range recs {
+		if len(rs.records) == 0 {
+			continue
+		}
+		if len(rs.records) > maxRecords {
+			// Peers with different heads may have returned overlapping windows
+			rs.records = rs.records[len(rs.records)-maxRecords:]
+		}
+		if rs.counter != thread.CounterUndef {
+			base := thread.Head{
+				ID:      rs.records[0].PrevID(),
+				Counter: rs.counter - int64(len(rs.records)),
+			}
+			if err := n.skipToHead(id, lid, base); err != nil {
+				return err
+			}
+		}
+		if err = n.putRecords(ctx, id, lid, rs.records, rs.counter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipToHead moves the head of a log forward to base if it's behind it, marking the thread as
+// partial, so that the records between the head and base aren't pulled.
+func (n *net) skipToHead(tid thread.ID, lid peer.ID, base thread.Head) error {
+	if !base.ID.Defined() {
+		return nil
+	}
+	ts := n.semaphores.Get(semaThreadUpdate(tid))
+	ts.Acquire()
+	defer ts.Release()
+
+	head, err := n.currentHead(tid, lid)
+	if err != nil {
+		return err
+	}
+	if head.Counter >= base.Counter {
+		return nil
+	}
+	if err := n.store.PutBool(tid, partialKey, true); err != nil {
+		return err
+	}
+	if err := n.store.SetHead(tid, lid, base); err != nil {
+		return fmt.Errorf("setting log head failed: %w", err)
+	}
+	log.Debugf("skipped %d records of log %s (thread %s)", base.Counter-head.Counter, lid, tid)
+	return nil
+}
+
+// IsPartial returns whether records of a thread were skipped by PullThreadDepth, so that its
+// logs don't go back to their first records.
+func (n *net) IsPartial(id thread.ID) (bool, error) {
+	partial, err := n.store.GetBool(id, partialKey)
+	if err != nil || partial == nil {
+		return false, err
+	}
+	return *partial, nil
+}
+
+// missingFromPartial returns whether a record of a partial thread was skipped by
+// PullThreadDepth, so that it's neither stored locally nor should it be fetched.
+func (n *net) missingFromPartial(tid thread.ID, rid cid.Cid) (bool, error) {
+	if partial, err := n.IsPartial(tid); err != nil || !partial {
+		return false, err
+	}
+	known, err := n.isKnown(rid)
+	if err != nil {
+		return false, err
+	}
+	return !known, nil
+}
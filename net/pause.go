@@ -0,0 +1,72 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrThreadPaused indicates an operation could not be completed because the thread is paused.
+var ErrThreadPaused = errors.New("thread is paused")
+
+// PauseThread stops a thread from being pulled, pushed to peers, and updated by peers,
+// until it's resumed. Records received while paused are ignored, and pulled on resume.
+// Creating or adding records, adding replicators, and rotating keys fail with ErrThreadPaused.
+// The thread can still be read, and its records are still served to peers.
+func (n *net) PauseThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+
+	// Wait for records being put to be processed
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	n.pulls.pause(id)
+	if err := n.server.removePubsubTopic(id); err != nil {
+		return fmt.Errorf("leaving pubsub topic: %w", err)
+	}
+	log.Debugf("paused thread %s", id)
+	return nil
+}
+
+// ResumeThread resumes a paused thread, and pulls records from its peers to catch up.
+func (n *net) ResumeThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if !n.pulls.resume(id) {
+		return nil
+	}
+	if err := n.server.addPubsubTopic(id); err != nil {
+		return fmt.Errorf("joining pubsub topic: %w", err)
+	}
+	log.Debugf("resumed thread %s", id)
+	return n.pullThread(ctx, id)
+}
+
+// checkNotPaused returns ErrThreadPaused if a thread is paused.
+func (n *net) checkNotPaused(id thread.ID) error {
+	if n.pulls.isPaused(id) {
+		return fmt.Errorf("%w: %s", ErrThreadPaused, id)
+	}
+	return nil
+}
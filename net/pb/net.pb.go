@@ -91,6 +91,8 @@ type Log_Record struct {
 	HeaderNode []byte `protobuf:"bytes,3,opt,name=headerNode,proto3" json:"headerNode,omitempty"`
 	// bodyNode is the body node's raw data.
 	BodyNode []byte `protobuf:"bytes,4,opt,name=bodyNode,proto3" json:"bodyNode,omitempty"`
+	// chunkNodes are the raw data of the chunks of a chunked body, in order.
+	ChunkNodes [][]byte `protobuf:"bytes,5,rep,name=chunkNodes,proto3" json:"chunkNodes,omitempty"`
 }
 
 func (m *Log_Record) Reset()         { *m = Log_Record{} }
@@ -154,6 +156,13 @@ func (m *Log_Record) GetBodyNode() []byte {
 	return nil
 }
 
+func (m *Log_Record) GetChunkNodes() [][]byte {
+	if m != nil {
+		return m.ChunkNodes
+	}
+	return nil
+}
+
 // GetLogsRequest is used to request thread logs.
 type GetLogsRequest struct {
 	// body is the message body.
@@ -1103,64 +1112,64 @@ func init() {
 func init() { proto.RegisterFile("net.proto", fileDescriptor_a5b10ce944527a32) }
 
 var fileDescriptor_a5b10ce944527a32 = []byte{
-	// 912 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x3b, 0x8f, 0x23, 0x45,
-	0x10, 0x76, 0xcf, 0x8c, 0x1f, 0x5b, 0xf6, 0xee, 0xe2, 0xd6, 0xea, 0x18, 0x86, 0x63, 0x3c, 0x18,
-	0xb8, 0xb3, 0xd0, 0xad, 0x2d, 0x19, 0x08, 0x10, 0x24, 0x98, 0x5d, 0xad, 0x96, 0x5b, 0xa1, 0x55,
-	0xc3, 0x1f, 0xb0, 0x3d, 0xbd, 0x63, 0x4b, 0x3e, 0xb7, 0x99, 0x19, 0x9f, 0xce, 0x12, 0x22, 0x20,
-	0x81, 0x90, 0x80, 0x7f, 0x40, 0x86, 0x48, 0xc9, 0xc9, 0x20, 0x41, 0xba, 0x80, 0x00, 0x39, 0xb0,
-	0xc0, 0x1b, 0x91, 0x22, 0x02, 0x44, 0x84, 0xfa, 0x31, 0x2f, 0xbf, 0x4e, 0x7b, 0xc1, 0x66, 0xae,
-	0xfa, 0xaa, 0x7a, 0xea, 0xab, 0xfa, 0xaa, 0xdb, 0xb0, 0x37, 0xa6, 0x61, 0x73, 0xe2, 0xb3, 0x90,
-	0xe1, 0x82, 0xf8, 0xd9, 0xb3, 0x8e, 0xbd, 0x61, 0x38, 0x98, 0xf6, 0x9a, 0x7d, 0xf6, 0xa8, 0xe5,
-	0x31, 0x8f, 0xb5, 0x04, 0xdc, 0x9b, 0x5e, 0x09, 0x4b, 0x18, 0xe2, 0x97, 0x4c, 0xab, 0xff, 0xa8,
-	0x81, 0x7e, 0xc1, 0x3c, 0x5c, 0x03, 0xed, 0xfc, 0xc4, 0x44, 0x0e, 0x6a, 0x54, 0x3a, 0x87, 0xf3,
-	0x45, 0xad, 0x7c, 0xc9, 0xe1, 0x4b, 0x4a, 0xfd, 0xf3, 0x13, 0xa2, 0x9d, 0x9f, 0xe0, 0xfb, 0x50,
-	0x98, 0x4c, 0x7b, 0x0f, 0xe9, 0xcc, 0xd4, 0x56, 0x83, 0x84, 0x9b, 0x28, 0x18, 0xbf, 0x06, 0xf9,
-	0xae, 0xeb, 0xfa, 0x81, 0xa9, 0x3b, 0x7a, 0xa3, 0xd2, 0xd9, 0x9f, 0x2f, 0x6a, 0x7b, 0x22, 0xee,
-	0x03, 0xd7, 0xf5, 0x89, 0xc4, 0xb0, 0x03, 0xc6, 0x80, 0x76, 0x5d, 0xd3, 0x10, 0x67, 0x55, 0xe6,
-	0x8b, 0x5a, 0x49, 0xc4, 0x7c, 0x38, 0x74, 0x89, 0x40, 0xb0, 0x09, 0xc5, 0x3e, 0x9b, 0x8e, 0x43,
-	0xea, 0x9b, 0x79, 0x07, 0x35, 0x74, 0x12, 0x99, 0xd6, 0x97, 0x08, 0x0a, 0x84, 0xf6, 0x99, 0xef,
-	0x62, 0x1b, 0xc0, 0x17, 0xbf, 0x3e, 0x66, 0x2e, 0x95, 0xd5, 0x93, 0x94, 0x07, 0xdf, 0x85, 0x3d,
-	0xfa, 0x98, 0x8e, 0x43, 0x01, 0x8b, 0xba, 0x49, 0xe2, 0xe0, 0xd9, 0xfc, 0x53, 0xd4, 0x17, 0xb0,
-	0x2e, 0xb3, 0x13, 0x0f, 0xb6, 0xa0, 0xd4, 0x63, 0xee, 0x4c, 0xa0, 0xa2, 0x50, 0x12, 0xdb, 0xf5,
-	0x1f, 0x10, 0x1c, 0x9c, 0xd1, 0xf0, 0x82, 0x79, 0x01, 0xa1, 0x9f, 0x4d, 0x69, 0x10, 0xe2, 0x16,
-	0x18, 0x1c, 0x16, 0xdf, 0x29, 0xb7, 0x5f, 0x6e, 0xca, 0x81, 0x34, 0xb3, 0x51, 0xcd, 0x0e, 0x73,
-	0x67, 0x44, 0x04, 0x5a, 0x7d, 0x30, 0xb8, 0x85, 0x8f, 0xa1, 0x14, 0x0e, 0x7c, 0xda, 0x75, 0xe3,
-	0x09, 0x54, 0xe7, 0x8b, 0xda, 0xbe, 0x68, 0xc8, 0xa7, 0x0a, 0x20, 0x71, 0x08, 0x7e, 0x00, 0x10,
-	0x50, 0xff, 0xf1, 0xb0, 0x4f, 0x93, 0x69, 0x24, 0x1d, 0xe4, 0xa3, 0x48, 0xe1, 0x1f, 0x19, 0x25,
-	0xf4, 0x82, 0x56, 0x6f, 0x41, 0x25, 0xae, 0x63, 0x32, 0x9a, 0xe1, 0x1a, 0x18, 0x23, 0xe6, 0x05,
-	0x26, 0x72, 0xf4, 0x46, 0xb9, 0x5d, 0x8e, 0x6a, 0xbd, 0x60, 0x1e, 0x11, 0x40, 0xfd, 0x1f, 0x04,
-	0x07, 0x97, 0xd3, 0x60, 0xc0, 0x3d, 0xbb, 0xf9, 0x65, 0xa3, 0xd2, 0xfc, 0xbe, 0x47, 0xb7, 0x40,
-	0x10, 0xdf, 0x83, 0x22, 0xcf, 0xe3, 0xa1, 0xfa, 0x86, 0xd0, 0x08, 0xc4, 0xaf, 0x80, 0x3e, 0x62,
-	0x9e, 0x18, 0xe4, 0x0a, 0x63, 0xee, 0x57, 0x7d, 0x3a, 0x80, 0x4a, 0xcc, 0x67, 0x32, 0x9a, 0xd5,
-	0xff, 0xd3, 0xa0, 0x7a, 0x46, 0x43, 0x29, 0xb7, 0x78, 0xd2, 0xed, 0x4c, 0x27, 0xec, 0xd4, 0xa4,
-	0xb3, 0x81, 0x99, 0x66, 0x68, 0xb7, 0xd1, 0x8c, 0xf7, 0xd4, 0x5c, 0x75, 0x31, 0xd7, 0xfb, 0xbb,
-	0x2b, 0xe3, 0xe4, 0x4f, 0xc7, 0xa1, 0x3f, 0x93, 0x33, 0xb7, 0xbe, 0x42, 0x50, 0x8a, 0x5c, 0xf8,
-	0x0d, 0xc8, 0x8f, 0x98, 0xb7, 0xfd, 0x4e, 0x90, 0x28, 0x7e, 0x1d, 0x0a, 0xec, 0xea, 0x2a, 0xa0,
-	0xe1, 0x5a, 0x69, 0x7c, 0x95, 0x15, 0x86, 0x8f, 0x20, 0x3f, 0x1a, 0x3e, 0x1a, 0x86, 0x62, 0x42,
-	0x79, 0x22, 0x8d, 0xf4, 0x8a, 0x1b, 0x99, 0x15, 0x57, 0xc3, 0xf8, 0x19, 0xc1, 0x61, 0xba, 0x72,
-	0x2e, 0xdc, 0xb7, 0x33, 0xc2, 0x75, 0x36, 0x11, 0x9c, 0x8c, 0xd6, 0x98, 0x7d, 0x71, 0x73, 0x62,
-	0x0f, 0xb8, 0xac, 0xc4, 0x89, 0xa6, 0x26, 0xbe, 0x85, 0x53, 0x92, 0x69, 0xca, 0x8f, 0x91, 0x28,
-	0x24, 0x12, 0x97, 0xbe, 0x59, 0x5c, 0xf5, 0xbf, 0x11, 0x54, 0xb9, 0xae, 0x54, 0xda, 0x6e, 0x19,
-	0xad, 0x05, 0xa6, 0x64, 0x94, 0xee, 0x99, 0x9e, 0xbd, 0x16, 0xbf, 0x7e, 0xce, 0x6d, 0x8b, 0xfb,
-	0xa1, 0xed, 0xec, 0xc7, 0x9b, 0x50, 0x90, 0x64, 0x15, 0xc9, 0x4d, 0xed, 0x50, 0x11, 0x6a, 0x7c,
-	0x55, 0x38, 0x4c, 0x53, 0xe1, 0xeb, 0xf4, 0x9d, 0x06, 0x47, 0xa7, 0x4f, 0xfa, 0x83, 0xee, 0xd8,
-	0xa3, 0xa7, 0xae, 0x47, 0xe3, 0x8d, 0x7a, 0x27, 0xd3, 0x8a, 0x57, 0xa3, 0xb3, 0x37, 0xc5, 0xa6,
-	0x97, 0xea, 0xd7, 0x88, 0xf3, 0x19, 0x14, 0x25, 0xa1, 0x48, 0x19, 0xc7, 0xcf, 0x3c, 0xa2, 0x29,
-	0x7b, 0x21, 0x65, 0x12, 0x65, 0x5b, 0x9f, 0x43, 0x39, 0xe5, 0xbf, 0x69, 0x2f, 0x1d, 0x28, 0xf3,
-	0xf7, 0x8d, 0x06, 0x01, 0xff, 0x9c, 0x60, 0x63, 0x90, 0xb4, 0x8b, 0xbf, 0x48, 0xfc, 0x85, 0x91,
-	0xb8, 0x2e, 0xf0, 0xc4, 0xa1, 0x1a, 0xf7, 0x17, 0x02, 0xbc, 0x52, 0x36, 0x97, 0xfe, 0xfb, 0x90,
-	0xa7, 0xdc, 0x52, 0x0c, 0xef, 0x6d, 0x61, 0xc8, 0xe5, 0xaf, 0x28, 0x08, 0x87, 0x4c, 0xb2, 0xbe,
-	0x45, 0x31, 0x33, 0x6e, 0xdf, 0x94, 0xd9, 0x1d, 0x28, 0xd0, 0x27, 0xc3, 0x20, 0x0c, 0x04, 0xa9,
-	0x12, 0x51, 0xd6, 0x2a, 0x63, 0xfd, 0x19, 0x8c, 0x8d, 0x15, 0xc6, 0xed, 0xdf, 0x34, 0x28, 0x7e,
-	0x22, 0xef, 0x2f, 0xfc, 0x2e, 0x14, 0xd5, 0x23, 0x85, 0xef, 0x6c, 0x7e, 0x3d, 0xad, 0xa3, 0x35,
-	0x3f, 0x97, 0x55, 0x8e, 0xa7, 0xaa, 0x7b, 0x3b, 0x49, 0xcd, 0x3e, 0x4c, 0x49, 0x6a, 0xe6, 0x82,
-	0xcf, 0xe1, 0x0e, 0x40, 0x72, 0x7b, 0xe0, 0x97, 0xb6, 0x5e, 0x99, 0xd6, 0x8b, 0x5b, 0x2e, 0x1b,
-	0x79, 0x46, 0x22, 0xf5, 0xe4, 0x8c, 0xb5, 0x4d, 0x4e, 0xce, 0x58, 0xdd, 0x8c, 0x1c, 0x7e, 0x08,
-	0xfb, 0x99, 0x49, 0xe2, 0xbb, 0xbb, 0x24, 0x6c, 0x59, 0xdb, 0xc7, 0x5f, 0xcf, 0x75, 0x9c, 0x7f,
-	0xff, 0xb4, 0xd1, 0x4f, 0x4b, 0x1b, 0xfd, 0xb2, 0xb4, 0xd1, 0xd3, 0xa5, 0x8d, 0xfe, 0x58, 0xda,
-	0xe8, 0x9b, 0x6b, 0x3b, 0xf7, 0xf4, 0xda, 0xce, 0xfd, 0x7e, 0x6d, 0xe7, 0x7a, 0x05, 0xf1, 0xff,
-	0xef, 0xad, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x8d, 0xfc, 0x77, 0x2b, 0x43, 0x0a, 0x00, 0x00,
+	// 898 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbd, 0x56, 0x3b, 0x6f, 0x13, 0x41,
+	0x10, 0xf6, 0x3d, 0xfc, 0xc8, 0xd8, 0x49, 0xf0, 0x2a, 0x0a, 0xe6, 0x08, 0x8e, 0x31, 0x90, 0x20,
+	0x94, 0xd8, 0x52, 0x80, 0x02, 0x41, 0x83, 0x49, 0x14, 0x85, 0x20, 0x14, 0x2d, 0xfc, 0x81, 0xf8,
+	0x6e, 0x63, 0x5b, 0x24, 0xbe, 0x70, 0x77, 0x8e, 0x62, 0x09, 0xd1, 0x42, 0x49, 0xc1, 0x2f, 0x80,
+	0x0e, 0xf1, 0x23, 0xe8, 0xa0, 0x41, 0x4a, 0x01, 0x12, 0xa2, 0x40, 0x3c, 0x2a, 0x5a, 0x44, 0x81,
+	0xa8, 0xd8, 0xd7, 0xbd, 0xfc, 0x8a, 0x42, 0x91, 0x62, 0xa5, 0x9b, 0x99, 0x6f, 0x76, 0xe7, 0x9b,
+	0x99, 0x9d, 0x3d, 0x18, 0x6b, 0x13, 0xaf, 0xb2, 0xeb, 0xd8, 0x9e, 0x8d, 0x52, 0xfc, 0xb3, 0x6e,
+	0x2c, 0x36, 0x5a, 0x5e, 0xb3, 0x53, 0xaf, 0x98, 0xf6, 0x4e, 0xb5, 0x61, 0x37, 0xec, 0x2a, 0x37,
+	0xd7, 0x3b, 0x5b, 0x5c, 0xe2, 0x02, 0xff, 0x12, 0x6e, 0xe5, 0x8f, 0x2a, 0x68, 0x77, 0xec, 0x06,
+	0x9a, 0x05, 0x75, 0x6d, 0xb9, 0xa0, 0x94, 0x94, 0x8b, 0xb9, 0xda, 0xe4, 0xe7, 0x2f, 0xb3, 0xd9,
+	0x0d, 0x66, 0xde, 0x20, 0xc4, 0x59, 0x5b, 0xc6, 0xd4, 0x84, 0xe6, 0x21, 0xb5, 0xdb, 0xa9, 0xaf,
+	0x93, 0x6e, 0x41, 0xed, 0x05, 0x71, 0x35, 0x96, 0x66, 0x74, 0x0e, 0x92, 0x9b, 0x96, 0xe5, 0xb8,
+	0x05, 0xad, 0xa4, 0x51, 0xdc, 0x38, 0xc5, 0x8d, 0x71, 0xdc, 0x4d, 0xaa, 0xc5, 0xc2, 0x86, 0x4a,
+	0xa0, 0x37, 0xc9, 0xa6, 0x55, 0xd0, 0xf9, 0x5e, 0x39, 0x8a, 0xc9, 0x70, 0xcc, 0xad, 0x96, 0x85,
+	0xb9, 0x05, 0x15, 0x20, 0x6d, 0xda, 0x9d, 0xb6, 0x47, 0x9c, 0x42, 0x92, 0x82, 0x34, 0xec, 0x8b,
+	0xc6, 0x0b, 0x05, 0x52, 0x98, 0x98, 0xb6, 0x63, 0xa1, 0x22, 0x80, 0xc3, 0xbf, 0xee, 0xda, 0x16,
+	0x11, 0xd1, 0xe3, 0x88, 0x06, 0xcd, 0xc0, 0x18, 0xd9, 0x23, 0x6d, 0x8f, 0x9b, 0x79, 0xdc, 0x38,
+	0x54, 0x30, 0x6f, 0x76, 0x14, 0x71, 0xb8, 0x59, 0x13, 0xde, 0xa1, 0x06, 0x19, 0x90, 0xa9, 0xdb,
+	0x56, 0x97, 0x5b, 0x79, 0xa0, 0x38, 0x90, 0x99, 0xaf, 0xd9, 0xec, 0xb4, 0x1f, 0x30, 0xc1, 0xa5,
+	0x11, 0x6a, 0xcc, 0x37, 0xd4, 0x94, 0x5f, 0x2b, 0x30, 0xb1, 0x4a, 0x3c, 0x9a, 0x5a, 0x17, 0x93,
+	0x87, 0x1d, 0xe2, 0x7a, 0xa8, 0x0a, 0x3a, 0x73, 0xe7, 0x71, 0x64, 0x97, 0x4e, 0x57, 0x44, 0xc1,
+	0x2a, 0x71, 0x54, 0xa5, 0x46, 0x21, 0x98, 0x03, 0x0d, 0x13, 0x74, 0x26, 0xa1, 0x45, 0xc8, 0x78,
+	0x4d, 0x87, 0xc6, 0x15, 0x54, 0x28, 0x4f, 0x13, 0x36, 0xce, 0x13, 0x76, 0x5f, 0x1a, 0x70, 0x00,
+	0x41, 0x0b, 0x00, 0x2e, 0x71, 0xf6, 0x5a, 0x26, 0x09, 0xab, 0x15, 0x66, 0x98, 0x95, 0x2a, 0x62,
+	0xbf, 0xad, 0x67, 0x94, 0x13, 0x6a, 0xb9, 0x0a, 0xb9, 0x20, 0x8e, 0xdd, 0xed, 0x2e, 0x6d, 0x07,
+	0x7d, 0x9b, 0x0a, 0xf4, 0x38, 0x8d, 0xc6, 0x9a, 0xf5, 0x63, 0xa5, 0x00, 0xcc, 0x0d, 0xe5, 0xdf,
+	0x94, 0xdf, 0x46, 0xc7, 0x6d, 0x32, 0xcd, 0x68, 0x7e, 0x71, 0x54, 0x94, 0xdf, 0x2b, 0xe5, 0x18,
+	0x08, 0xa2, 0x39, 0x48, 0x33, 0x3f, 0x06, 0xd5, 0x06, 0x40, 0x7d, 0x23, 0x3a, 0x03, 0x1a, 0x65,
+	0xc6, 0x0b, 0xdd, 0xc3, 0x98, 0xe9, 0x65, 0x9e, 0x26, 0x20, 0x17, 0xf0, 0xa1, 0x79, 0x2a, 0xff,
+	0x55, 0x21, 0x4f, 0x13, 0x27, 0xda, 0x31, 0xa8, 0xf4, 0x52, 0x2c, 0x13, 0xc5, 0x48, 0xa5, 0xe3,
+	0xc0, 0x58, 0x32, 0xd4, 0xe3, 0x48, 0xc6, 0x75, 0x59, 0x57, 0x8d, 0xd7, 0x75, 0x7e, 0x74, 0x64,
+	0x8c, 0xfc, 0x4a, 0xdb, 0x73, 0xba, 0xa2, 0xe6, 0xc6, 0x13, 0x05, 0x32, 0xbe, 0x0a, 0x5d, 0x80,
+	0x24, 0x55, 0x0e, 0x9f, 0x19, 0xc2, 0x8a, 0xce, 0x43, 0xca, 0xde, 0xda, 0x72, 0x89, 0xd7, 0x17,
+	0x1a, 0xbb, 0xea, 0xd2, 0x86, 0xa6, 0xe8, 0x66, 0xad, 0x9d, 0x96, 0xc7, 0x2b, 0x94, 0xc4, 0x42,
+	0x88, 0x8e, 0x00, 0x3d, 0x36, 0x02, 0x64, 0x31, 0xde, 0x2a, 0x30, 0x19, 0x8d, 0x9c, 0x35, 0xee,
+	0x95, 0x58, 0xe3, 0x96, 0x06, 0x11, 0xa4, 0xb0, 0x5e, 0x66, 0x8f, 0x8f, 0x4e, 0x6c, 0x81, 0xb5,
+	0x15, 0xdf, 0x91, 0x32, 0x63, 0x67, 0xa1, 0x48, 0xcb, 0x54, 0xc4, 0x61, 0xd8, 0x87, 0xf8, 0xcd,
+	0xa5, 0x0d, 0x6e, 0xae, 0xf2, 0x2f, 0x05, 0xf2, 0xac, 0xaf, 0xa4, 0xdb, 0xe8, 0x36, 0xea, 0x03,
+	0x46, 0xda, 0x28, 0x9a, 0x33, 0x2d, 0x3e, 0x36, 0x9f, 0xfe, 0xe7, 0x6d, 0x0b, 0xf2, 0xa1, 0x8e,
+	0xcc, 0xc7, 0x25, 0x48, 0x09, 0xb2, 0x92, 0xe4, 0xa0, 0x74, 0x48, 0x84, 0x2c, 0x5f, 0x1e, 0x26,
+	0xa3, 0x54, 0xd8, 0x75, 0x7a, 0xa9, 0xc2, 0xd4, 0xca, 0xbe, 0xd9, 0xdc, 0x6c, 0x37, 0xc8, 0x8a,
+	0xd5, 0x20, 0xc1, 0x8d, 0xba, 0x1a, 0x4b, 0xc5, 0x59, 0x7f, 0xef, 0x41, 0xd8, 0xe8, 0xa5, 0x7a,
+	0xef, 0x73, 0x5e, 0x85, 0xb4, 0x20, 0xe4, 0x77, 0xc6, 0xe2, 0xa1, 0x5b, 0x54, 0x44, 0x2e, 0x44,
+	0x9b, 0xf8, 0xde, 0xc6, 0x23, 0xc8, 0x46, 0xf4, 0x47, 0xcd, 0x65, 0x09, 0xb2, 0xec, 0xfd, 0x23,
+	0xae, 0xcb, 0x8e, 0xe3, 0x6c, 0x74, 0x1c, 0x55, 0xb1, 0x17, 0x8b, 0xbd, 0x40, 0xc2, 0xae, 0x71,
+	0x7b, 0xa8, 0x90, 0x89, 0xfb, 0xa9, 0x00, 0xea, 0x09, 0x9b, 0xb5, 0xfe, 0x0d, 0x48, 0x12, 0x26,
+	0x49, 0x86, 0x73, 0x43, 0x18, 0xb2, 0xf6, 0x97, 0x14, 0xb8, 0x42, 0x38, 0x19, 0xcf, 0x95, 0x80,
+	0x19, 0x93, 0x8f, 0xca, 0x6c, 0x1a, 0x52, 0x64, 0xbf, 0xe5, 0x7a, 0x2e, 0x27, 0x95, 0xc1, 0x52,
+	0xea, 0x65, 0xac, 0x1d, 0xc2, 0x58, 0xef, 0x61, 0xbc, 0xf4, 0x41, 0x85, 0xf4, 0x3d, 0x31, 0xbf,
+	0xd0, 0x35, 0x48, 0xcb, 0x47, 0x0a, 0x4d, 0x0f, 0x7e, 0x3d, 0x8d, 0xa9, 0x3e, 0x3d, 0x6b, 0xab,
+	0x04, 0x73, 0x95, 0x73, 0x3b, 0x74, 0x8d, 0x3f, 0x4c, 0xa1, 0x6b, 0x6c, 0xc0, 0x27, 0x50, 0x0d,
+	0x20, 0x9c, 0x1e, 0xe8, 0xd4, 0xd0, 0x91, 0x69, 0x9c, 0x1c, 0x32, 0x6c, 0xc4, 0x1e, 0x61, 0xab,
+	0x87, 0x7b, 0xf4, 0xdd, 0xe4, 0x70, 0x8f, 0xde, 0x9b, 0x91, 0x40, 0xeb, 0x30, 0x1e, 0xab, 0x24,
+	0x9a, 0x19, 0xd5, 0xc2, 0x86, 0x31, 0xbc, 0xfc, 0xe5, 0x44, 0xad, 0xf4, 0xe7, 0x5b, 0x51, 0x79,
+	0xf3, 0xbd, 0xa8, 0xbc, 0xa3, 0xeb, 0x80, 0xae, 0xaf, 0x74, 0x3d, 0xfb, 0x51, 0x4c, 0x1c, 0xd0,
+	0xf5, 0x89, 0xae, 0x7a, 0x8a, 0xff, 0x1f, 0x5e, 0xfe, 0x07, 0x4d, 0x14, 0x9b, 0xf9, 0x63, 0x0a,
+	0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1495,6 +1504,15 @@ func (m *Log_Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ChunkNodes) > 0 {
+		for iNdEx := len(m.ChunkNodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ChunkNodes[iNdEx])
+			copy(dAtA[i:], m.ChunkNodes[iNdEx])
+			i = encodeVarintNet(dAtA, i, uint64(len(m.ChunkNodes[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
 	if len(m.BodyNode) > 0 {
 		i -= len(m.BodyNode)
 		copy(dAtA[i:], m.BodyNode)
@@ -2409,6 +2427,15 @@ func NewPopulatedLog_Record(r randyNet, easy bool) *Log_Record {
 	for i := 0; i < v6; i++ {
 		this.BodyNode[i] = byte(r.Intn(256))
 	}
+	v7 := r.Intn(10)
+	this.ChunkNodes = make([][]byte, v7)
+	for i := 0; i < v7; i++ {
+		v8 := r.Intn(100)
+		this.ChunkNodes[i] = make([]byte, v8)
+		for j := 0; j < v8; j++ {
+			this.ChunkNodes[i][j] = byte(r.Intn(256))
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
 	}
 	return this
@@ -2436,9 +2463,9 @@ func NewPopulatedGetLogsRequest_Body(r randyNet, easy bool) *GetLogsRequest_Body
 func NewPopulatedGetLogsReply(r randyNet, easy bool) *GetLogsReply {
 	this := &GetLogsReply{}
 	if r.Intn(5) != 0 {
-		v7 := r.Intn(5)
-		this.Logs = make([]*Log, v7)
-		for i := 0; i < v7; i++ {
+		v9 := r.Intn(5)
+		this.Logs = make([]*Log, v9)
+		for i := 0; i < v9; i++ {
 			this.Logs[i] = NewPopulatedLog(r, easy)
 		}
 	}
@@ -2492,9 +2519,9 @@ func NewPopulatedGetRecordsRequest_Body(r randyNet, easy bool) *GetRecordsReques
 	this.ThreadID = NewPopulatedProtoThreadID(r)
 	this.ServiceKey = NewPopulatedProtoKey(r)
 	if r.Intn(5) != 0 {
-		v8 := r.Intn(5)
-		this.Logs = make([]*GetRecordsRequest_Body_LogEntry, v8)
-		for i := 0; i < v8; i++ {
+		v10 := r.Intn(5)
+		this.Logs = make([]*GetRecordsRequest_Body_LogEntry, v10)
+		for i := 0; i < v10; i++ {
 			this.Logs[i] = NewPopulatedGetRecordsRequest_Body_LogEntry(r, easy)
 		}
 	}
@@ -2523,9 +2550,9 @@ func NewPopulatedGetRecordsRequest_Body_LogEntry(r randyNet, easy bool) *GetReco
 func NewPopulatedGetRecordsReply(r randyNet, easy bool) *GetRecordsReply {
 	this := &GetRecordsReply{}
 	if r.Intn(5) != 0 {
-		v9 := r.Intn(5)
-		this.Logs = make([]*GetRecordsReply_LogEntry, v9)
-		for i := 0; i < v9; i++ {
+		v11 := r.Intn(5)
+		this.Logs = make([]*GetRecordsReply_LogEntry, v11)
+		for i := 0; i < v11; i++ {
 			this.Logs[i] = NewPopulatedGetRecordsReply_LogEntry(r, easy)
 		}
 	}
@@ -2538,9 +2565,9 @@ func NewPopulatedGetRecordsReply_LogEntry(r randyNet, easy bool) *GetRecordsRepl
 	this := &GetRecordsReply_LogEntry{}
 	this.LogID = NewPopulatedProtoPeerID(r)
 	if r.Intn(5) != 0 {
-		v10 := r.Intn(5)
-		this.Records = make([]*Log_Record, v10)
-		for i := 0; i < v10; i++ {
+		v12 := r.Intn(5)
+		this.Records = make([]*Log_Record, v12)
+		for i := 0; i < v12; i++ {
 			this.Records[i] = NewPopulatedLog_Record(r, easy)
 		}
 	}
@@ -2598,9 +2625,9 @@ func NewPopulatedExchangeEdgesRequest(r randyNet, easy bool) *ExchangeEdgesReque
 func NewPopulatedExchangeEdgesRequest_Body(r randyNet, easy bool) *ExchangeEdgesRequest_Body {
 	this := &ExchangeEdgesRequest_Body{}
 	if r.Intn(5) != 0 {
-		v11 := r.Intn(5)
-		this.Threads = make([]*ExchangeEdgesRequest_Body_ThreadEntry, v11)
-		for i := 0; i < v11; i++ {
+		v13 := r.Intn(5)
+		this.Threads = make([]*ExchangeEdgesRequest_Body_ThreadEntry, v13)
+		for i := 0; i < v13; i++ {
 			this.Threads[i] = NewPopulatedExchangeEdgesRequest_Body_ThreadEntry(r, easy)
 		}
 	}
@@ -2622,9 +2649,9 @@ func NewPopulatedExchangeEdgesRequest_Body_ThreadEntry(r randyNet, easy bool) *E
 func NewPopulatedExchangeEdgesReply(r randyNet, easy bool) *ExchangeEdgesReply {
 	this := &ExchangeEdgesReply{}
 	if r.Intn(5) != 0 {
-		v12 := r.Intn(5)
-		this.Edges = make([]*ExchangeEdgesReply_ThreadEdges, v12)
-		for i := 0; i < v12; i++ {
+		v14 := r.Intn(5)
+		this.Edges = make([]*ExchangeEdgesReply_ThreadEdges, v14)
+		for i := 0; i < v14; i++ {
 			this.Edges[i] = NewPopulatedExchangeEdgesReply_ThreadEdges(r, easy)
 		}
 	}
@@ -2663,9 +2690,9 @@ func randUTF8RuneNet(r randyNet) rune {
 	return rune(ru + 61)
 }
 func randStringNet(r randyNet) string {
-	v13 := r.Intn(100)
-	tmps := make([]rune, v13)
-	for i := 0; i < v13; i++ {
+	v15 := r.Intn(100)
+	tmps := make([]rune, v15)
+	for i := 0; i < v15; i++ {
 		tmps[i] = randUTF8RuneNet(r)
 	}
 	return string(tmps)
@@ -2687,11 +2714,11 @@ func randFieldNet(dAtA []byte, r randyNet, fieldNumber int, wire int) []byte {
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
-		v14 := r.Int63()
+		v16 := r.Int63()
 		if r.Intn(2) == 0 {
-			v14 *= -1
+			v16 *= -1
 		}
-		dAtA = encodeVarintPopulateNet(dAtA, uint64(v14))
+		dAtA = encodeVarintPopulateNet(dAtA, uint64(v16))
 	case 1:
 		dAtA = encodeVarintPopulateNet(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -2768,6 +2795,12 @@ func (m *Log_Record) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovNet(uint64(l))
 	}
+	if len(m.ChunkNodes) > 0 {
+		for _, b := range m.ChunkNodes {
+			l = len(b)
+			n += 1 + l + sovNet(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -3470,6 +3503,38 @@ func (m *Log_Record) Unmarshal(dAtA []byte) error {
 				m.BodyNode = []byte{}
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChunkNodes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNet
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthNet
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthNet
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChunkNodes = append(m.ChunkNodes, make([]byte, postIndex-iNdEx))
+			copy(m.ChunkNodes[len(m.ChunkNodes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipNet(dAtA[iNdEx:])
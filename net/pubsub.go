@@ -0,0 +1,55 @@
+package net
+
+import (
+	"errors"
+	"fmt"
+
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrPubSubDisabled indicates pubsub can't be enabled for a thread, since it's disabled for the host.
+var ErrPubSubDisabled = errors.New("pubsub is disabled")
+
+// pubSubDisabledKey is the thread metadata key marking threads which don't use pubsub.
+const pubSubDisabledKey = "pubsub/disabled"
+
+func (n *net) RegisterPubSubValidator(v core.PubSubValidator) {
+	n.server.addValidator(v)
+}
+
+func (n *net) SetThreadPubSub(id thread.ID, enabled bool) error {
+	if !n.conf.PubSub {
+		return ErrPubSubDisabled
+	}
+	if _, err := n.store.GetThread(id); err != nil {
+		return err
+	}
+	if err := n.store.PutBool(id, pubSubDisabledKey, !enabled); err != nil {
+		return err
+	}
+	if !enabled {
+		if err := n.server.removePubsubTopic(id); err != nil {
+			return fmt.Errorf("leaving pubsub topic: %w", err)
+		}
+		log.Debugf("disabled pubsub for thread %s", id)
+		return nil
+	}
+	// Paused threads join their topic once resumed
+	if !n.pulls.isPaused(id) {
+		if err := n.server.addPubsubTopic(id); err != nil {
+			return fmt.Errorf("joining pubsub topic: %w", err)
+		}
+	}
+	log.Debugf("enabled pubsub for thread %s", id)
+	return nil
+}
+
+// isPubSubDisabled returns whether pubsub was disabled for a thread with SetThreadPubSub.
+func (n *net) isPubSubDisabled(id thread.ID) (bool, error) {
+	v, err := n.store.GetBool(id, pubSubDisabledKey)
+	if err != nil {
+		return false, err
+	}
+	return v != nil && *v, nil
+}
@@ -0,0 +1,121 @@
+package net
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// PullJitter is the fraction of the pull interval by which each pull cycle is randomly
+// shortened or lengthened, so peers sharing replicators don't pull them in lockstep.
+var PullJitter = 0.2
+
+// pullSchedule tracks when threads were last updated, by a pull or over pubsub,
+// their pull interval overrides, and paused threads. It's safe for concurrent use.
+type pullSchedule struct {
+	lk        sync.Mutex
+	intervals map[thread.ID]time.Duration
+	updated   map[thread.ID]time.Time
+	paused    map[thread.ID]struct{}
+}
+
+func newPullSchedule() *pullSchedule {
+	return &pullSchedule{
+		intervals: make(map[thread.ID]time.Duration),
+		updated:   make(map[thread.ID]time.Time),
+		paused:    make(map[thread.ID]struct{}),
+	}
+}
+
+// pause marks a thread as paused, so it's never due.
+func (s *pullSchedule) pause(id thread.ID) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.paused[id] = struct{}{}
+}
+
+// resume unmarks a paused thread, returning whether it was paused.
+func (s *pullSchedule) resume(id thread.ID) bool {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	_, ok := s.paused[id]
+	delete(s.paused, id)
+	return ok
+}
+
+// isPaused returns whether a thread is paused.
+func (s *pullSchedule) isPaused(id thread.ID) bool {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	_, ok := s.paused[id]
+	return ok
+}
+
+// setInterval overrides the pull interval of a thread. A non-positive interval
+// removes the override.
+func (s *pullSchedule) setInterval(id thread.ID, d time.Duration) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if d <= 0 {
+		delete(s.intervals, id)
+	} else {
+		s.intervals[id] = d
+	}
+}
+
+// shortest returns the shortest pull interval of all threads, given the default interval def.
+func (s *pullSchedule) shortest(def time.Duration) time.Duration {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	for _, d := range s.intervals {
+		if d < def {
+			def = d
+		}
+	}
+	return def
+}
+
+// touch marks a thread as updated at t.
+func (s *pullSchedule) touch(id thread.ID, t time.Time) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.updated[id] = t
+}
+
+// due returns whether a thread should be pulled at now, given the default interval def.
+// Threads are due once their interval, less the jitter, has elapsed since their last update.
+// Paused threads are never due.
+func (s *pullSchedule) due(id thread.ID, now time.Time, def time.Duration) bool {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	if _, ok := s.paused[id]; ok {
+		return false
+	}
+	last, ok := s.updated[id]
+	if !ok {
+		return true
+	}
+	if d, ok := s.intervals[id]; ok {
+		def = d
+	}
+	return now.Sub(last) >= time.Duration(float64(def)*(1-PullJitter))
+}
+
+// remove drops the schedule of a thread.
+func (s *pullSchedule) remove(id thread.ID) {
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	delete(s.intervals, id)
+	delete(s.updated, id)
+	delete(s.paused, id)
+}
+
+// jitter randomly shortens or lengthens d by up to PullJitter.
+func jitter(d time.Duration) time.Duration {
+	if PullJitter <= 0 {
+		return d
+	}
+	return d + time.Duration((rand.Float64()*2-1)*PullJitter*float64(d))
+}
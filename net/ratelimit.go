@@ -0,0 +1,86 @@
+package net
+
+import (
+	"sync"
+	"time"
+)
+
+// newRateLimiter returns a limiter allowing rate events per second for each key,
+// in bursts of up to rate events. A rate of zero or less allows all events.
+func newRateLimiter(rate int) *rateLimiter {
+	return newRateLimiterWithClock(rate, time.Now)
+}
+
+// newRateLimiterWithClock returns a limiter like newRateLimiter, which reads the time
+// from now, so that tests control how buckets refill.
+func newRateLimiterWithClock(rate int, now func() time.Time) *rateLimiter {
+	return &rateLimiter{
+		rate:    float64(rate),
+		buckets: make(map[string]*rateBucket),
+		now:     now,
+	}
+}
+
+// rateLimiter limits the rate of events by key with token buckets.
+// Keys idle long enough to refill their bucket are dropped, so memory is bounded
+// by the number of recently active keys.
+type rateLimiter struct {
+	rate      float64
+	buckets   map[string]*rateBucket
+	lastPrune time.Time
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Allow reports whether an event for key is within the limit.
+func (l *rateLimiter) Allow(key string) bool {
+	if l.rate <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &rateBucket{tokens: l.rate}
+		l.buckets[key] = b
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * l.rate
+		if b.tokens > l.rate {
+			b.tokens = l.rate
+		}
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Len returns the number of tracked keys.
+func (l *rateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// prune drops the buckets of keys idle for a second, which are full again.
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Second {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= time.Second {
+			delete(l.buckets, key)
+		}
+	}
+}
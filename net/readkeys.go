@@ -0,0 +1,118 @@
+package net
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// namedReadKeysKey is the thread metadata key of the named read keys of a thread.
+const namedReadKeysKey = "readKeys/named"
+
+// ErrReadKeyNotFound indicates a record was created with a named read key the host doesn't know.
+var ErrReadKeyNotFound = errors.New("read key not found")
+
+// namedReadKeys returns the named read keys of a thread by name.
+func (n *net) namedReadKeys(id thread.ID) (map[string]*sym.Key, error) {
+	data, err := n.store.GetBytes(id, namedReadKeysKey)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var raw map[string][]byte
+	if err := json.Unmarshal(*data, &raw); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*sym.Key, len(raw))
+	for name, b := range raw {
+		if keys[name], err = sym.FromBytes(b); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// addNamedReadKeys adds the named read keys of key to those known for a thread.
+// Keys of known names are replaced.
+func (n *net) addNamedReadKeys(id thread.ID, key thread.Key) error {
+	named := key.NamedReads()
+	if len(named) == 0 {
+		return nil
+	}
+	keys, err := n.namedReadKeys(id)
+	if err != nil {
+		return err
+	}
+	raw := make(map[string][]byte, len(keys)+len(named))
+	for name, k := range keys {
+		raw[name] = k.Bytes()
+	}
+	for name, k := range named {
+		raw[name] = k.Bytes()
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(id, namedReadKeysKey, data)
+}
+
+// withNamedReadKeys returns key holding the named read keys known for a thread.
+func (n *net) withNamedReadKeys(id thread.ID, key thread.Key) (thread.Key, error) {
+	keys, err := n.namedReadKeys(id)
+	if err != nil {
+		return key, err
+	}
+	for name, k := range keys {
+		key = key.WithNamedRead(name, k)
+	}
+	return key, nil
+}
+
+// recordReadKey returns the read key which encrypts records created with the read key named name,
+// or the read key if name is empty.
+func (n *net) recordReadKey(id thread.ID, name string) (*sym.Key, error) {
+	if name == "" {
+		rk, err := n.store.ReadKey(id)
+		if err != nil {
+			return nil, err
+		}
+		if rk == nil {
+			return nil, fmt.Errorf("a read-key is required to create records")
+		}
+		return rk, nil
+	}
+	keys, err := n.namedReadKeys(id)
+	if err != nil {
+		return nil, err
+	}
+	rk, ok := keys[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrReadKeyNotFound, name)
+	}
+	return rk, nil
+}
+
+// withNamedKeys returns the read keys followed by the named read keys, which decrypt
+// the records of a thread.
+func withNamedKeys(keys []*sym.Key, named map[string]*sym.Key) []*sym.Key {
+	all := make([]*sym.Key, 0, len(keys)+len(named))
+	all = append(all, keys...)
+	for _, k := range named {
+		all = append(all, k)
+	}
+	return all
+}
+
+// isNamedKey returns whether k is one of the named read keys.
+func isNamedKey(k *sym.Key, named map[string]*sym.Key) bool {
+	for _, nk := range named {
+		if bytes.Equal(k.Bytes(), nk.Bytes()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package net
+
+import (
+	"context"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// RecordErrorQueueSize is the number of record errors which may wait for the handler.
+// Errors reported while the queue is full are dropped.
+var RecordErrorQueueSize = 1000
+
+// RecordErrorHandler is called with records received from peers which failed to be applied,
+// e.g., because they're corrupt, or the app rejected them.
+type RecordErrorHandler func(id thread.ID, lid peer.ID, rec cid.Cid, err error)
+
+type recordError struct {
+	id  thread.ID
+	lid peer.ID
+	rec cid.Cid
+	err error
+}
+
+// recordErrors passes record errors to a handler from its own goroutine,
+// so that a slow handler doesn't block records from being applied.
+type recordErrors struct {
+	handler RecordErrorHandler
+	queue   chan recordError
+}
+
+// newRecordErrors returns a queue of errors passed to handler until ctx is done,
+// or nil if handler is nil.
+func newRecordErrors(ctx context.Context, handler RecordErrorHandler) *recordErrors {
+	if handler == nil {
+		return nil
+	}
+	re := &recordErrors{
+		handler: handler,
+		queue:   make(chan recordError, RecordErrorQueueSize),
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-re.queue:
+				re.handler(e.id, e.lid, e.rec, e.err)
+			}
+		}
+	}()
+	return re
+}
+
+// report queues err of record rec for the handler, without blocking.
+func (re *recordErrors) report(id thread.ID, lid peer.ID, rec cid.Cid, err error) {
+	if re == nil {
+		return
+	}
+	select {
+	case re.queue <- recordError{id: id, lid: lid, rec: rec, err: err}:
+	default:
+		log.Warnf("record error queue is full, dropping error of record %s (thread=%s, log=%s): %v", rec, id, lid, err)
+	}
+}
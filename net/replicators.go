@@ -0,0 +1,133 @@
+package net
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// Fields of the heads acknowledged by replicators, stored as thread metadata.
+const (
+	ackCounter = "counter"
+	ackHead    = "head"
+)
+
+func (n *net) GetReplicators(
+	ctx context.Context,
+	id thread.ID,
+	opts ...core.ThreadOption,
+) ([]core.ReplicatorInfo, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return nil, err
+	}
+	peers, err := n.threadPeers(info)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+
+	reps := make([]core.ReplicatorInfo, len(peers))
+	for i, p := range peers {
+		reps[i] = core.ReplicatorInfo{
+			ID:          p,
+			Logs:        make([]core.ReplicatorLogInfo, len(info.Logs)),
+			LastContact: n.lastContact(p),
+		}
+		for j, lg := range info.Logs {
+			head, err := n.ackedHead(id, p, lg.ID)
+			if err != nil {
+				return nil, err
+			}
+			lag := lg.Head.Counter - head.Counter
+			if lag < 0 {
+				lag = 0
+			}
+			reps[i].Logs[j] = core.ReplicatorLogInfo{ID: lg.ID, Head: head.ID, Lag: lag}
+		}
+	}
+	return reps, nil
+}
+
+// threadPeers returns the peers replicating a thread, excluding the host.
+func (n *net) threadPeers(info thread.Info) ([]peer.ID, error) {
+	var addrs []ma.Multiaddr
+	for _, lg := range info.Logs {
+		addrs = append(addrs, lg.Addrs...)
+	}
+	return n.uniquePeers(addrs)
+}
+
+// ackRecords records that peer pid has the records of log lid up to head.
+// Peers acknowledge records by requesting the records which follow them.
+func (n *net) ackRecords(tid thread.ID, pid, lid peer.ID, head thread.Head) error {
+	if head.Counter == thread.CounterUndef {
+		return nil
+	}
+	n.ackLock.Lock()
+	defer n.ackLock.Unlock()
+	acked, err := n.store.GetInt64(tid, ackKey(pid, lid, ackCounter))
+	if err != nil {
+		return err
+	}
+	if acked != nil && *acked >= head.Counter {
+		return nil
+	}
+	if head.ID.Defined() {
+		if err := n.store.PutBytes(tid, ackKey(pid, lid, ackHead), head.ID.Bytes()); err != nil {
+			return err
+		}
+	}
+	return n.store.PutInt64(tid, ackKey(pid, lid, ackCounter), head.Counter)
+}
+
+// ackedHead returns the head of log lid acknowledged by peer pid.
+func (n *net) ackedHead(tid thread.ID, pid, lid peer.ID) (thread.Head, error) {
+	head := thread.HeadUndef
+	counter, err := n.store.GetInt64(tid, ackKey(pid, lid, ackCounter))
+	if err != nil || counter == nil {
+		return head, err
+	}
+	head.Counter = *counter
+	id, err := n.store.GetBytes(tid, ackKey(pid, lid, ackHead))
+	if err != nil || id == nil {
+		return head, err
+	}
+	if head.ID, err = cid.Cast(*id); err != nil {
+		return thread.HeadUndef, err
+	}
+	return head, nil
+}
+
+// ackKey returns the thread metadata key of a field of the head of log lid acknowledged by pid.
+func ackKey(pid, lid peer.ID, field string) string {
+	return "ack/" + pid.String() + "/" + lid.String() + "/" + field
+}
+
+// touchPeer records a successful contact with peer pid.
+func (n *net) touchPeer(pid peer.ID) {
+	n.contactLock.Lock()
+	defer n.contactLock.Unlock()
+	n.contacts[pid] = time.Now()
+}
+
+// lastContact returns the time of the last successful contact with peer pid.
+func (n *net) lastContact(pid peer.ID) time.Time {
+	n.contactLock.Lock()
+	defer n.contactLock.Unlock()
+	return n.contacts[pid]
+}
@@ -0,0 +1,131 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// ErrNoResyncPeers indicates a thread can't be resynced because it has no peers to pull from.
+var ErrNoResyncPeers = errors.New("thread has no peers to resync from")
+
+// ResyncThread drops the local records of a thread, keeping its keys and logs, and pulls
+// them again from the peers of the thread. Pulled records are verified against the keys of
+// their logs before they're applied, as with any pull. An app connected to the thread is
+// reset, if it's an app.Resetter, and handles the records again from the start of the thread.
+// Threads whose local logs contain records which a replicator hasn't acknowledged can't be
+// resynced, since the records would be lost.
+func (n *net) ResyncThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, false); err != nil {
+		return err
+	}
+	if err := n.checkNotPaused(id); err != nil {
+		return err
+	}
+
+	log.Debugf("resyncing thread %s...", id)
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+
+	// Must block in case the thread is being pulled
+	ts.Acquire()
+	err := n.resetThread(ctx, id)
+	ts.Release()
+	if err != nil {
+		return err
+	}
+
+	// Pulls are limited to NetPullingLimit records per log, so pull until the logs are complete
+	for {
+		before, _, err := n.threadOffsets(id)
+		if err != nil {
+			return err
+		}
+		if err := n.pullThread(ctx, id); err != nil {
+			return fmt.Errorf("pulling thread %s: %w", id, err)
+		}
+		after, _, err := n.threadOffsets(id)
+		if err != nil {
+			return err
+		}
+		if sameOffsets(before, after) {
+			break
+		}
+	}
+	log.Debugf("resynced thread %s", id)
+	return nil
+}
+
+// resetThread removes the records and events of a thread, clears the heads of its logs, and
+// resets the connected app, if any.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) resetThread(ctx context.Context, id thread.ID) error {
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return err
+	}
+	peers, err := n.threadPeers(info)
+	if err != nil {
+		return err
+	}
+	if len(peers) == 0 {
+		return fmt.Errorf("%w: %s", ErrNoResyncPeers, id)
+	}
+	for _, lg := range info.Logs {
+		if lg.PrivKey == nil || !lg.Head.ID.Defined() {
+			continue
+		}
+		if err := n.checkAcknowledged(info, lg.ID, lg.Head.Counter); err != nil {
+			return err
+		}
+	}
+
+	for _, lg := range info.Logs {
+		head := lg.Head.ID
+		for head.Defined() {
+			if missing, err := n.missingFromPartial(id, head); err != nil {
+				return err
+			} else if missing {
+				break
+			}
+			if head, err = n.deleteRecord(ctx, head, info.Key.Service()); err != nil {
+				// The rest of the log can't be reached, it's pulled over whatever is left
+				log.Warnf("removing records of log %s (thread %s): %v", lg.ID, id, err)
+				break
+			}
+		}
+		if err := n.store.ClearHeads(id, lg.ID); err != nil {
+			return err
+		}
+	}
+	// The logs are pulled from their first records
+	if err := n.store.PutBool(id, partialKey, false); err != nil {
+		return err
+	}
+	if connector, ok := n.getConnector(id); ok {
+		if err := connector.ResetNetRecords(ctx); err != nil {
+			return fmt.Errorf("resetting app: %w", err)
+		}
+	}
+	return nil
+}
+
+// sameOffsets returns whether two sets of log heads are equal.
+func sameOffsets(a, b map[peer.ID]thread.Head) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for lid, h := range a {
+		if o, ok := b[lid]; !ok || !o.ID.Equals(h.ID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	nnet "net"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isTransient returns whether err is a dial or stream failure which may not recur, e.g., a timeout
+// or a reset connection, as opposed to a permanent failure, e.g., a request rejected by the peer.
+func isTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var nerr nnet.Error
+	if errors.As(err, &nerr) && nerr.Timeout() {
+		return true
+	}
+	var serr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &serr) {
+		switch serr.GRPCStatus().Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted, codes.ResourceExhausted:
+			return true
+		}
+	}
+	return false
+}
+
+// retry calls f until it succeeds, fails permanently, or has failed transiently DialRetryMax
+// more times, doubling the wait between attempts from DialRetryBase.
+// It gives up early if ctx is done, or if its deadline comes before the next attempt.
+// The returned error wraps the last error of f.
+func (n *net) retry(ctx context.Context, f func() error) error {
+	wait := n.conf.DialRetryBase
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || !isTransient(err) {
+			return err
+		}
+		if attempt > n.conf.DialRetryMax {
+			if attempt == 1 {
+				return err
+			}
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		if dl, ok := ctx.Deadline(); ok && time.Until(dl) < wait {
+			return fmt.Errorf("giving up after %d attempts, deadline before next attempt: %w", attempt, err)
+		}
+		log.Debugf("attempt %d failed, retrying in %s: %v", attempt, wait, err)
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return fmt.Errorf("giving up after %d attempts, %v: %w", attempt, ctx.Err(), err)
+		case <-t.C:
+		}
+		wait *= 2
+	}
+}
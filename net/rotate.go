@@ -0,0 +1,183 @@
+package net
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	format "github.com/ipfs/go-ipld-format"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// retainedReadKeys is the thread metadata key of the read keys replaced by rotations.
+const retainedReadKeys = "rotation/readKeys"
+
+func (n *net) RotateKey(ctx context.Context, id thread.ID, opts ...core.ThreadOption) error {
+	start := time.Now()
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	identity, err := n.Validate(id, args.Token, false)
+	if err != nil {
+		return err
+	}
+	if err := n.checkNotPaused(id); err != nil {
+		return err
+	}
+	if identity == nil {
+		identity = thread.NewLibp2pPubKey(n.getPrivKey().GetPublic())
+	}
+	rk, err := sym.NewRandom()
+	if err != nil {
+		return err
+	}
+	body, err := cbor.NewKeyRotation(rk)
+	if err != nil {
+		return err
+	}
+
+	tr, err := n.rotateKey(ctx, id, identity, body, rk)
+	if err != nil {
+		return err
+	}
+	log.Debugf("rotated read key with record %s (thread=%s, log=%s)", tr.Value().Cid(), id, tr.LogID())
+
+	if err = n.bus.SendWithTimeout(tr, notifyTimeout); err != nil {
+		return err
+	}
+	if err = n.server.pushRecord(ctx, id, tr.LogID(), tr.Value(), thread.CounterUndef); err != nil {
+		return err
+	}
+	n.metrics.recordCreated(start)
+	return nil
+}
+
+// rotateKey adds body, the announcement of rk, to the log of identity, and makes rk the read key.
+func (n *net) rotateKey(
+	ctx context.Context,
+	id thread.ID,
+	identity thread.PubKey,
+	body format.Node,
+	rk *sym.Key,
+) (core.ThreadRecord, error) {
+	ts := n.semaphores.Get(semaThreadUpdate(id))
+	ts.Acquire()
+	defer ts.Release()
+
+	keys, err := n.readKeys(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("a read-key is required to rotate keys")
+	}
+	lg, err := n.getOrCreateLog(id, identity)
+	if err != nil {
+		return nil, err
+	}
+	// The announcement is created before the rotation, so it's encrypted under the previous key
+	r, err := n.newRecord(ctx, id, lg, body, identity, "")
+	if err != nil {
+		return nil, err
+	}
+	if err = n.store.SetHead(id, lg.ID, thread.Head{ID: r.Cid(), Counter: lg.Head.Counter + 1}); err != nil {
+		return nil, err
+	}
+	if _, err = n.addRotatedReadKey(id, keys, keys[0], rk); err != nil {
+		return nil, err
+	}
+	return NewRecord(r, id, lg.ID), nil
+}
+
+func (n *net) ReadKeys(ctx context.Context, id thread.ID, opts ...core.ThreadOption) ([]*sym.Key, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return nil, err
+	}
+	return n.readKeys(id)
+}
+
+// readKeys returns the current read key of a thread followed by the retained keys,
+// from newest to oldest. It returns no keys if the read key isn't known.
+func (n *net) readKeys(id thread.ID) ([]*sym.Key, error) {
+	rk, err := n.store.ReadKey(id)
+	if err != nil || rk == nil {
+		return nil, err
+	}
+	retained, err := n.retainedReadKeys(id)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*sym.Key{rk}, retained...), nil
+}
+
+// retainedReadKeys returns the read keys of a thread replaced by rotations, from newest to oldest.
+func (n *net) retainedReadKeys(id thread.ID) ([]*sym.Key, error) {
+	data, err := n.store.GetBytes(id, retainedReadKeys)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var raw [][]byte
+	if err := json.Unmarshal(*data, &raw); err != nil {
+		return nil, err
+	}
+	keys := make([]*sym.Key, len(raw))
+	for i, b := range raw {
+		if keys[i], err = sym.FromBytes(b); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// putRetainedReadKeys replaces the retained read keys of a thread with keys.
+func (n *net) putRetainedReadKeys(id thread.ID, keys [][]byte) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	return n.store.PutBytes(id, retainedReadKeys, data)
+}
+
+// addRotatedReadKey adds rk, announced under the read key by, to keys, the read keys of a thread,
+// returning the updated keys. If by is the current key, rk replaces it, and it's retained.
+// Otherwise, the rotation was concurrent with, or older than, another rotation known to this
+// host, and rk is only retained, so that records encrypted under it remain readable.
+// This method is internal and *not* thread-safe. It assumes we currently own the thread-lock.
+func (n *net) addRotatedReadKey(id thread.ID, keys []*sym.Key, by, rk *sym.Key) ([]*sym.Key, error) {
+	for _, k := range keys {
+		if bytes.Equal(k.Bytes(), rk.Bytes()) {
+			return keys, nil
+		}
+	}
+	current := bytes.Equal(by.Bytes(), keys[0].Bytes())
+	var updated []*sym.Key
+	if current {
+		updated = append([]*sym.Key{rk}, keys...)
+	} else {
+		updated = append([]*sym.Key{keys[0], rk}, keys[1:]...)
+	}
+	raw := make([][]byte, len(updated)-1)
+	for i, k := range updated[1:] {
+		raw[i] = k.Bytes()
+	}
+	// Retain the keys before replacing the current key, so it's never lost
+	if err := n.putRetainedReadKeys(id, raw); err != nil {
+		return nil, err
+	}
+	if current {
+		if err := n.store.AddReadKey(id, rk); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
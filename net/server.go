@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/status"
@@ -17,6 +18,7 @@ import (
 	rpc "github.com/textileio/go-libp2p-pubsub-rpc"
 	"github.com/textileio/go-threads/cbor"
 	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
 	"github.com/textileio/go-threads/core/thread"
 	"github.com/textileio/go-threads/logstore/lstoreds"
 	pb "github.com/textileio/go-threads/net/pb"
@@ -26,6 +28,15 @@ import (
 	grpcpeer "google.golang.org/grpc/peer"
 )
 
+const (
+	// pubSubPenaltyTag is the connection manager tag of peers exceeding the pubsub rate limit,
+	// or publishing records rejected by a validator.
+	pubSubPenaltyTag = "threads-pubsub-penalty"
+
+	// pubSubMinPenalty is the lowest value of the penalty tag of a peer.
+	pubSubMinPenalty = -100
+)
+
 var (
 	errNoAddrsEdge = errors.New("no addresses to compute edge")
 	errNoHeadsEdge = errors.New("no heads to compute edge")
@@ -40,6 +51,9 @@ type server struct {
 	ps     *pubsub.PubSub
 	topics map[thread.ID]*rpc.Topic
 
+	validators []core.PubSubValidator
+	vlk        sync.RWMutex
+
 	sync.Mutex
 }
 
@@ -140,6 +154,7 @@ func (s *server) PushLog(ctx context.Context, req *pb.PushLogRequest) (*pb.PushL
 			if err = s.net.store.AddReadKey(req.Body.ThreadID.ID, req.Body.ReadKey.Key); err != nil {
 				return nil, status.Error(codes.Internal, err.Error())
 			}
+			s.net.notifyKeyReceived(req.Body.ThreadID.ID)
 		}
 	}
 
@@ -167,6 +182,19 @@ func (s *server) GetRecords(ctx context.Context, req *pb.GetRecordsRequest) (*pb
 		return pbrecs, err
 	}
 
+	s.net.touchPeer(pid)
+
+	// requested offsets are the records the peer already has
+	for _, l := range req.Body.Logs {
+		head := thread.Head{ID: cid.Undef, Counter: l.Counter}
+		if l.Offset != nil {
+			head.ID = l.Offset.Cid
+		}
+		if err := s.net.ackRecords(req.Body.ThreadID.ID, pid, l.LogID.ID, head); err != nil {
+			log.Errorf("acknowledging records of log %s by %s: %v", l.LogID.ID, pid, err)
+		}
+	}
+
 	// fast check if requested offsets are equal with thread heads
 	if changed, err := s.headsChanged(req); err != nil {
 		return nil, err
@@ -261,6 +289,10 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 	}
 	log.Debugf("received push record request from %s", pid)
 
+	if s.net.pulls.isPaused(req.Body.ThreadID.ID) {
+		return nil, status.Error(codes.FailedPrecondition, ErrThreadPaused.Error())
+	}
+
 	// A log is required to accept new records
 	logpk, err := s.net.store.PubKey(req.Body.ThreadID.ID, req.Body.LogID.ID)
 	if err != nil {
@@ -285,6 +317,7 @@ func (s *server) PushRecord(ctx context.Context, req *pb.PushRecordRequest) (*pb
 	if err = s.net.PutRecord(ctx, req.Body.ThreadID.ID, req.Body.LogID.ID, rec, req.Counter); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	s.net.touchPeer(pid)
 	return &pb.PushRecordReply{}, nil
 }
 
@@ -424,14 +457,23 @@ func (s *server) addPubsubTopic(id thread.ID) error {
 	if s.ps == nil {
 		return nil
 	}
+	if disabled, err := s.net.isPubSubDisabled(id); err != nil {
+		return err
+	} else if disabled {
+		return nil
+	}
 	s.Lock()
 	defer s.Unlock()
 	if _, ok := s.topics[id]; ok {
 		return nil
 	}
 
+	if err := s.ps.RegisterTopicValidator(id.String(), s.pubSubRecordValidator(id)); err != nil {
+		return err
+	}
 	t, err := rpc.NewTopic(s.net.ctx, s.ps, s.net.host.ID(), id.String(), true)
 	if err != nil {
+		s.unregisterValidator(id)
 		return err
 	}
 	t.SetEventHandler(s.pubSubEventHandler)
@@ -449,6 +491,7 @@ func (s *server) removePubsubTopic(id thread.ID) error {
 	defer s.Unlock()
 	if t, ok := s.topics[id]; ok {
 		delete(s.topics, id)
+		s.unregisterValidator(id)
 		return t.Close()
 	}
 	return nil
@@ -463,6 +506,7 @@ func (s *server) removeAllPubsubTopics() error {
 	defer s.Unlock()
 	for id, t := range s.topics {
 		delete(s.topics, id)
+		s.unregisterValidator(id)
 		if err := t.Close(); err != nil {
 			return err
 		}
@@ -479,6 +523,9 @@ func (s *server) publishRecord(ctx context.Context, topic thread.ID, req *pb.Pus
 	t, ok := s.topics[topic]
 	s.Unlock()
 	if !ok {
+		if disabled, err := s.net.isPubSubDisabled(topic); err == nil && disabled {
+			return nil
+		}
 		return fmt.Errorf("publish to unknown thread %s", topic)
 	}
 
@@ -520,10 +567,99 @@ func (s *server) pubSubRecordHandler(from peer.ID, topic string, msg []byte) ([]
 		log.Debugf("error handling pubsub record: %v", err)
 	} else if err != nil {
 		return nil, fmt.Errorf("pushing record to thread %s: %v", topic, err)
+	} else if id, err := thread.Decode(topic); err == nil {
+		// The thread is receiving records, so there's no need to pull it
+		s.net.pulls.touch(id, time.Now())
 	}
 	return nil, nil
 }
 
+// rateLimited returns whether inbound pubsub records are rate limited.
+func (s *server) rateLimited() bool {
+	return s.net.conf.PubSubPeerRateLimit > 0 || s.net.conf.PubSubThreadRateLimit > 0
+}
+
+// unregisterValidator removes the record validator of a thread topic.
+func (s *server) unregisterValidator(id thread.ID) {
+	if err := s.ps.UnregisterTopicValidator(id.String()); err != nil {
+		log.Errorf("unregistering pubsub validator (thread %s): %v", id, err)
+	}
+}
+
+// pubSubRecordValidator drops records over the inbound rate limits, and records rejected by
+// the registered validators, before they're handled, so a flood of records can't queue up.
+func (s *server) pubSubRecordValidator(id thread.ID) pubsub.ValidatorEx {
+	return func(ctx context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		if s.rateLimited() {
+			// Limit the peer which delivered the record rather than its publisher, since
+			// records of one publisher may be relayed by any peer.
+			if res := s.limitRecord(msg.ReceivedFrom, id); res != pubsub.ValidationAccept {
+				return res
+			}
+		}
+		return s.validateRecord(ctx, msg.GetFrom(), id, msg.GetData())
+	}
+}
+
+// addValidator registers a validator of pubsub records.
+func (s *server) addValidator(v core.PubSubValidator) {
+	s.vlk.Lock()
+	defer s.vlk.Unlock()
+	s.validators = append(s.validators, v)
+}
+
+// validateRecord applies the registered validators to a pubsub record published by from.
+// Peers publishing rejected records are penalized.
+func (s *server) validateRecord(ctx context.Context, from peer.ID, id thread.ID, msg []byte) pubsub.ValidationResult {
+	if from == s.net.host.ID() {
+		return pubsub.ValidationAccept
+	}
+	s.vlk.RLock()
+	validators := s.validators
+	s.vlk.RUnlock()
+	for _, v := range validators {
+		if err := v(ctx, from, id, msg); err != nil {
+			log.Warnf("dropping pubsub record from %s (thread %s): %v", from, id, err)
+			s.penalize(from)
+			return pubsub.ValidationReject
+		}
+	}
+	return pubsub.ValidationAccept
+}
+
+// penalize lowers the connection manager score of a peer publishing unwanted pubsub records,
+// down to pubSubMinPenalty. Rejected records also count against the peer in gossipsub, which
+// stops relaying them.
+func (s *server) penalize(from peer.ID) {
+	s.net.host.ConnManager().UpsertTag(from, pubSubPenaltyTag, lowerPenalty)
+}
+
+// lowerPenalty returns the penalty tag value v lowered by one, down to pubSubMinPenalty.
+func lowerPenalty(v int) int {
+	if v <= pubSubMinPenalty {
+		return pubSubMinPenalty
+	}
+	return v - 1
+}
+
+// limitRecord applies the per-peer and per-thread inbound rate limits to a pubsub record
+// delivered by from. Peers over their limit are penalized.
+func (s *server) limitRecord(from peer.ID, id thread.ID) pubsub.ValidationResult {
+	if from == s.net.host.ID() {
+		return pubsub.ValidationAccept
+	}
+	if !s.net.peerLimiter.Allow(from.String()) {
+		log.Warnf("dropping pubsub record from %s (thread %s): peer rate limit exceeded", from, id)
+		s.penalize(from)
+		return pubsub.ValidationReject
+	}
+	if !s.net.threadLimiter.Allow(id.String()) {
+		log.Warnf("dropping pubsub record from %s (thread %s): thread rate limit exceeded", from, id)
+		return pubsub.ValidationIgnore
+	}
+	return pubsub.ValidationAccept
+}
+
 // pubSubEventHandler logs pubsub peer events.
 func (s *server) pubSubEventHandler(from peer.ID, topic string, msg []byte) {
 	log.Debugf("%s peer event: %s %s", topic, from, msg)
@@ -0,0 +1,71 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+var (
+	// SignTimeout is the maximum duration of signing a record with a Signer.
+	SignTimeout = time.Second * 30
+
+	// ErrSigningFailed indicates a Signer failed to sign a record, which isn't created.
+	ErrSigningFailed = errors.New("signing record failed")
+)
+
+// RegisterLogSigner makes signer sign the records of the logs of its public key, in any
+// thread, so that the logs don't need a private key. Logs of the key are created by passing
+// the public key of signer to WithLogKey. Signers aren't persisted, and must be registered
+// again when the network is restarted.
+func (n *net) RegisterLogSigner(signer thread.Signer) error {
+	lid, err := peer.IDFromPublicKey(signer.PublicKey())
+	if err != nil {
+		return err
+	}
+	n.signerLock.Lock()
+	defer n.signerLock.Unlock()
+	n.signers[lid] = signer
+	return nil
+}
+
+// getSigner returns the signer of a log, or nil if it has none.
+func (n *net) getSigner(lid peer.ID) thread.Signer {
+	n.signerLock.RLock()
+	defer n.signerLock.RUnlock()
+	return n.signers[lid]
+}
+
+// signRecord creates a record of lg signed by signer, which is given SignTimeout to sign.
+// Signatures which don't verify against the log key are rejected, since peers would reject
+// the record.
+func (n *net) signRecord(
+	ctx context.Context,
+	lg thread.LogInfo,
+	signer thread.Signer,
+	config cbor.CreateRecordConfig,
+) (core.Record, error) {
+	sctx, cancel := context.WithTimeout(ctx, SignTimeout)
+	defer cancel()
+	config.Signer = signer
+	start := time.Now()
+	// Records are only added to the DAG once their signature is verified
+	rec, err := cbor.CreateRecord(sctx, nil, config)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSigningFailed, err)
+	}
+	log.Debugf("signed record of log %s in %s", lg.ID, time.Since(start))
+	if err := rec.Verify(lg.PubKey); err != nil {
+		return nil, fmt.Errorf("%w: invalid signature: %v", ErrSigningFailed, err)
+	}
+	if err := n.Add(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
@@ -0,0 +1,63 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	lstore "github.com/textileio/go-threads/core/logstore"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// threadUpdatedKey is the thread metadata key of the last time a record was added to the thread.
+const threadUpdatedKey = "updated"
+
+func (n *net) ListThreads(_ context.Context, opts ...core.ListThreadsOption) ([]thread.Info, error) {
+	args := &core.ListThreadsOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	if _, err := args.Token.Validate(n.getPrivKey()); err != nil {
+		return nil, err
+	}
+
+	ids, err := n.store.Threads()
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(ids)
+	infos := make([]thread.Info, 0)
+	for _, id := range ids {
+		if args.Limit > 0 && len(infos) == args.Limit {
+			break
+		}
+		if args.Offset.Defined() && id <= args.Offset {
+			continue
+		}
+		info, err := n.getThreadWithAddrs(id)
+		if errors.Is(err, lstore.ErrThreadNotFound) {
+			// The thread was deleted while listing.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// touchThread records that a record was added to thread tid.
+func (n *net) touchThread(tid thread.ID) error {
+	return n.store.PutInt64(tid, threadUpdatedKey, time.Now().UnixNano())
+}
+
+// threadUpdated returns the last time a record was added to thread tid, or zero if none was.
+func (n *net) threadUpdated(tid thread.ID) (time.Time, error) {
+	updated, err := n.store.GetInt64(tid, threadUpdatedKey)
+	if err != nil || updated == nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, *updated), nil
+}
@@ -0,0 +1,167 @@
+package net
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	bs "github.com/ipfs/go-ipfs-blockstore"
+	format "github.com/ipfs/go-ipld-format"
+	sym "github.com/textileio/crypto/symmetric"
+	"github.com/textileio/go-threads/cbor"
+	core "github.com/textileio/go-threads/core/net"
+	"github.com/textileio/go-threads/core/thread"
+)
+
+// VerifyThread walks the logs of a thread from their heads, checking each stored record and
+// the header and body blocks of its event. Blocks are read from the blockstore and hashed again,
+// so corruption which the blockstore doesn't detect on read is caught.
+// The heads are read once and the thread-lock isn't held, so records added while it runs
+// aren't checked, and pulls aren't blocked.
+func (n *net) VerifyThread(ctx context.Context, id thread.ID, opts ...core.ThreadOption) (core.VerifyReport, error) {
+	args := &core.ThreadOptions{}
+	for _, opt := range opts {
+		opt(args)
+	}
+	report := core.VerifyReport{ThreadID: id}
+	if _, err := n.Validate(id, args.Token, true); err != nil {
+		return report, err
+	}
+	info, err := n.store.GetThread(id)
+	if err != nil {
+		return report, err
+	}
+	sk := info.Key.Service()
+	if sk == nil {
+		return report, fmt.Errorf("a service-key is required to verify records")
+	}
+	partial, err := n.IsPartial(id)
+	if err != nil {
+		return report, err
+	}
+	for _, lg := range info.Logs {
+		if err := n.verifyLog(ctx, lg, sk, partial, &report); err != nil {
+			return report, err
+		}
+	}
+	if !report.OK() {
+		log.Warnf("verified thread %s: %d problems in %d records", id, len(report.Problems), report.Records)
+	} else {
+		log.Debugf("verified thread %s: %d records", id, report.Records)
+	}
+	return report, nil
+}
+
+// verifyLog checks the records of log lg from its head, adding the problems found to report.
+// Records preceding a missing or corrupt record aren't reachable, so they aren't checked.
+func (n *net) verifyLog(
+	ctx context.Context,
+	lg thread.LogInfo,
+	sk *sym.Key,
+	partial bool,
+	report *core.VerifyReport,
+) error {
+	problem := func(rid cid.Cid, kind core.VerifyProblemKind, reason string, args ...interface{}) {
+		report.Problems = append(report.Problems, core.VerifyProblem{
+			Log:    lg.ID,
+			Record: rid,
+			Kind:   kind,
+			Reason: fmt.Sprintf(reason, args...),
+		})
+	}
+
+	var count int64
+	for cursor := lg.Head.ID; cursor.Defined(); {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		ok, err := n.verifyBlock(cursor)
+		if errors.Is(err, bs.ErrNotFound) {
+			if !partial {
+				problem(cursor, core.RecordMissing, "record isn't stored")
+			}
+			// Records of partial threads end where PullThreadDepth skipped them
+			return nil
+		} else if err != nil {
+			return err
+		} else if !ok {
+			problem(cursor, core.RecordCorrupt, "record doesn't hash to its cid")
+			return nil
+		}
+		rec, err := cbor.GetRecord(ctx, n, cursor, sk)
+		if err != nil {
+			problem(cursor, core.RecordCorrupt, "decoding record: %v", err)
+			return nil
+		}
+		report.Records++
+		count++
+
+		if lg.PubKey != nil {
+			if err := rec.Verify(lg.PubKey); err != nil {
+				problem(cursor, core.RecordCorrupt, "signature doesn't verify: %v", err)
+			}
+		}
+		kind, reason, err := n.verifyEvent(ctx, rec)
+		if err != nil {
+			return err
+		} else if reason != "" {
+			problem(cursor, kind, reason)
+		}
+		cursor = rec.PrevID()
+	}
+	if !partial && lg.Head.Counter != thread.CounterUndef && count != lg.Head.Counter {
+		problem(lg.Head.ID, core.ChainBroken, "found %d records, head counter is %d", count, lg.Head.Counter)
+	}
+	return nil
+}
+
+// verifyEvent checks the event blocks of rec, returning a description of the problem found,
+// or an empty description if there's none. Events removed by Compact aren't checked.
+func (n *net) verifyEvent(ctx context.Context, rec core.Record) (core.VerifyProblemKind, string, error) {
+	ok, err := n.verifyBlock(rec.BlockID())
+	if errors.Is(err, bs.ErrNotFound) {
+		return 0, "", nil
+	} else if err != nil {
+		return 0, "", err
+	} else if !ok {
+		return core.RecordCorrupt, "event doesn't hash to its cid", nil
+	}
+	event, err := cbor.EventFromRecord(ctx, n, rec)
+	if errors.Is(err, format.ErrNotFound) {
+		// Compacted since it was hashed
+		return 0, "", nil
+	} else if err != nil {
+		return core.RecordCorrupt, fmt.Sprintf("decoding event: %v", err), nil
+	}
+	for _, block := range []struct {
+		name string
+		id   cid.Cid
+	}{
+		{name: "header", id: event.HeaderID()},
+		{name: "body", id: event.BodyID()},
+	} {
+		ok, err := n.verifyBlock(block.id)
+		if errors.Is(err, bs.ErrNotFound) {
+			return core.RecordMissing, fmt.Sprintf("event %s isn't stored", block.name), nil
+		} else if err != nil {
+			return 0, "", err
+		} else if !ok {
+			return core.RecordCorrupt, fmt.Sprintf("event %s doesn't hash to its cid", block.name), nil
+		}
+	}
+	return 0, "", nil
+}
+
+// verifyBlock returns whether the stored block of c hashes to c.
+func (n *net) verifyBlock(c cid.Cid) (bool, error) {
+	blk, err := n.bstore.Get(c)
+	if err != nil {
+		return false, err
+	}
+	sum, err := c.Prefix().Sum(blk.RawData())
+	if err != nil {
+		return false, err
+	}
+	return sum.Equals(c), nil
+}
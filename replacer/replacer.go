@@ -0,0 +1,224 @@
+// Package replacer implements an EventCodec whose events hold whole instances,
+// which replace stored instances when reduced.
+package replacer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	format "github.com/ipfs/go-ipld-format"
+	"github.com/multiformats/go-multihash"
+	core "github.com/textileio/go-threads/core/db"
+)
+
+var (
+	errCantCreateExistingInstance = errors.New("cant't create already existent instance")
+	errUnknownActionType          = errors.New("unknown action type")
+)
+
+type replacer struct{}
+
+var _ core.EventCodec = (*replacer)(nil)
+
+func init() {
+	cbornode.RegisterCborType(replaceEvent{})
+	cbornode.RegisterCborType(recordEvents{})
+	// Events are gob-encoded with the name of their codec, see db.codecEvent.
+	gob.Register(replaceEvent{})
+}
+
+// New returns a Replacer EventCodec.
+// Unlike JSON-Patcher events, which hold the fields changed by a save, Replacer events hold the
+// whole instance, so creating them doesn't require diffing instances. This suits collections of
+// large values which are mostly rewritten, at the cost of larger events for small changes.
+// Saves of an instance made concurrently by different writers aren't merged: the save which is
+// reduced last wins. Conflicts aren't detected.
+func New() core.EventCodec {
+	return &replacer{}
+}
+
+func (r *replacer) Create(actions []core.Action) ([]core.Event, format.Node, error) {
+	if len(actions) == 0 {
+		return nil, nil, nil
+	}
+	revents := recordEvents{Events: make([]replaceEvent, len(actions))}
+	events := make([]core.Event, len(actions))
+	for i, a := range actions {
+		e := replaceEvent{
+			Timestamp:      time.Now().UnixNano(),
+			ID:             a.InstanceID,
+			CollectionName: a.CollectionName,
+			Type:           a.Type,
+		}
+		switch a.Type {
+		case core.Create, core.Save:
+			e.Value = a.Current
+		case core.Delete:
+		default:
+			return nil, nil, errUnknownActionType
+		}
+		revents.Events[i] = e
+		events[i] = e
+	}
+	n, err := cbornode.WrapObject(revents, multihash.SHA2_256, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	return events, n, nil
+}
+
+func (r *replacer) Reduce(
+	events []core.Event,
+	store ds.TxnDatastore,
+	baseKey ds.Key,
+	indexFunc core.IndexFunc,
+	_ core.ConflictFunc,
+) ([]core.ReduceAction, error) {
+	txn, err := store.NewTransaction(false)
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Discard()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		ei, oki := events[i].(replaceEvent)
+		ej, okj := events[j].(replaceEvent)
+		return oki && okj && ei.Timestamp < ej.Timestamp
+	})
+
+	actions := make([]core.ReduceAction, len(events))
+	for i, e := range events {
+		re, ok := e.(replaceEvent)
+		if !ok {
+			return nil, fmt.Errorf("event unrecognized for replacer eventcodec")
+		}
+		key := baseKey.ChildString(e.Collection()).ChildString(e.InstanceID().String())
+		value, err := txn.Get(key)
+		if errors.Is(err, ds.ErrNotFound) {
+			value = nil
+		} else if err != nil {
+			return nil, err
+		}
+		switch re.Type {
+		case core.Create, core.Save:
+			if re.Type == core.Create && value != nil {
+				return nil, errCantCreateExistingInstance
+			}
+			if err := txn.Put(key, re.Value); err != nil {
+				return nil, fmt.Errorf("error when reducing %s event: %w", typeNames[re.Type], err)
+			}
+		case core.Delete:
+			if value == nil {
+				return nil, ds.ErrNotFound
+			}
+			if err := txn.Delete(key); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errUnknownActionType
+		}
+		var newValue []byte
+		if re.Type != core.Delete {
+			newValue = re.Value
+		}
+		if err := indexFunc(e.Collection(), key, value, newValue, txn); err != nil {
+			return nil, fmt.Errorf("error when indexing reduced data: %w", err)
+		}
+		actions[i] = core.ReduceAction{Type: re.Type, Collection: e.Collection(), InstanceID: e.InstanceID()}
+	}
+	if err := txn.Commit(); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// EventsFromBytes returns the events held by a record body created by Create.
+func (r *replacer) EventsFromBytes(data []byte) ([]core.Event, error) {
+	revents := recordEvents{}
+	if err := cbornode.DecodeInto(data, &revents); err != nil {
+		return nil, err
+	}
+	res := make([]core.Event, len(revents.Events))
+	for i := range revents.Events {
+		res[i] = revents.Events[i]
+	}
+	return res, nil
+}
+
+type recordEvents struct {
+	Events []replaceEvent
+}
+
+type replaceEvent struct {
+	Timestamp      int64
+	ID             core.InstanceID
+	CollectionName string
+	Type           core.ActionType
+	// Value is the instance after the action, or nil for deletes.
+	Value []byte
+}
+
+func (re replaceEvent) Time() []byte {
+	buf := new(bytes.Buffer)
+	// Use big endian to preserve lexicographic sorting
+	_ = binary.Write(buf, binary.BigEndian, re.Timestamp)
+	return buf.Bytes()
+}
+
+func (re replaceEvent) InstanceID() core.InstanceID {
+	return re.ID
+}
+
+func (re replaceEvent) Collection() string {
+	return re.CollectionName
+}
+
+// Marshal encodes the event like JSON-Patcher events, so write validators can handle the events
+// of both codecs. The patch of creates and saves holds the whole instance.
+func (re replaceEvent) Marshal() ([]byte, error) {
+	var value interface{}
+	if re.Value != nil {
+		if err := json.Unmarshal(re.Value, &value); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(replaceEventJSON{
+		Timestamp:      re.Timestamp,
+		ID:             re.ID.String(),
+		CollectionName: re.CollectionName,
+		Patch: operationJSON{
+			Type:       typeNames[re.Type],
+			InstanceID: re.ID.String(),
+			JSONPatch:  value,
+		},
+	})
+}
+
+var _ core.Event = (*replaceEvent)(nil)
+
+var typeNames = map[core.ActionType]string{
+	core.Create: "create",
+	core.Save:   "save",
+	core.Delete: "delete",
+}
+
+type replaceEventJSON struct {
+	Timestamp      int64         `json:"timestamp"`
+	ID             string        `json:"_id"`
+	CollectionName string        `json:"collection_name"`
+	Patch          operationJSON `json:"patch"`
+}
+
+type operationJSON struct {
+	Type       string      `json:"type"`
+	InstanceID string      `json:"instance_id"`
+	JSONPatch  interface{} `json:"json_patch,omitempty"`
+}
@@ -0,0 +1,55 @@
+package replacer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	core "github.com/textileio/go-threads/core/db"
+)
+
+func TestReplacer_EventsFromBytes(t *testing.T) {
+	r := New()
+	value := []byte(`{"_id":"123","Name":"Alice"}`)
+	events, node, err := r.Create([]core.Action{
+		{Type: core.Create, InstanceID: "123", CollectionName: "Person", Current: value},
+		{Type: core.Delete, InstanceID: "123", CollectionName: "Person", Previous: value},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := r.EventsFromBytes(node.RawData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(events) {
+		t.Fatalf("expected %d events, got %d", len(events), len(decoded))
+	}
+	for i, e := range decoded {
+		re, ok := e.(replaceEvent)
+		if !ok {
+			t.Fatalf("unexpected event type %T", e)
+		}
+		expected := events[i].(replaceEvent)
+		if re.ID != expected.ID || re.Type != expected.Type || !bytes.Equal(re.Value, expected.Value) {
+			t.Fatalf("expected event %v, got %v", expected, re)
+		}
+	}
+
+	b, err := decoded[0].Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e struct {
+		Patch struct {
+			Type      string
+			JSONPatch map[string]interface{} `json:"json_patch"`
+		}
+	}
+	if err := json.Unmarshal(b, &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Patch.Type != "create" || e.Patch.JSONPatch["Name"] != "Alice" {
+		t.Fatalf("unexpected marshaled event %s", b)
+	}
+}
@@ -2,20 +2,33 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	ds "github.com/ipfs/go-datastore"
 	logging "github.com/ipfs/go-log/v2"
-	connmgr "github.com/libp2p/go-libp2p-connmgr"
+	"github.com/libp2p/go-libp2p-core/peer"
 	ma "github.com/multiformats/go-multiaddr"
+	mbase "github.com/multiformats/go-multibase"
 	"github.com/namsral/flag"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	sym "github.com/textileio/crypto/symmetric"
+	badger "github.com/textileio/go-ds-badger"
 	mongods "github.com/textileio/go-ds-mongo"
 	"github.com/textileio/go-threads/api"
 	pb "github.com/textileio/go-threads/api/pb"
@@ -25,14 +38,21 @@ import (
 	netpb "github.com/textileio/go-threads/net/api/pb"
 	"github.com/textileio/go-threads/util"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var log = logging.Logger("threadsd")
 
+// debugLogSystems are the logging systems whose level is set by the debug flag.
+var debugLogSystems = []string{"threadsd", "threadsapi", "netapi", "net", "logstore", "db"}
+
 func main() {
 	fs := flag.NewFlagSetWithEnvPrefix(os.Args[0], "THRDS", 0)
 
 	repo := fs.String("repo", ".threads", "Repo location")
+	profile := fs.String("profile", "default", "Resource profile: default, or lowmem for constrained devices (lowers connection watermarks, pull sizes and Badger memory use, unless set by other flags)")
 	hostAddrStr := fs.String("hostAddr", "/ip4/0.0.0.0/tcp/4006", "Libp2p host bind address")
 	announceAddrStr := fs.String("announceAddr", "", "Libp2p announce address") // Should be supplied as multiaddr, /ip4/<your_public_ip>/tcp/4006
 	apiAddrStr := fs.String("apiAddr", "/ip4/127.0.0.1/tcp/6006", "gRPC API bind address")
@@ -40,22 +60,44 @@ func main() {
 	connLowWater := fs.Uint("connLowWater", 100, "Low watermark of libp2p connections that'll be maintained")
 	connHighWater := fs.Uint("connHighWater", 400, "High watermark of libp2p connections that'll be maintained")
 	connGracePeriod := fs.Duration("connGracePeriod", time.Second*20, "Duration a new opened connection is not subject to pruning")
+	bootstrap := fs.String("bootstrap", "", "Comma-separated multiaddrs of bootstrap peers, which replace the default ones (reloaded on SIGHUP)")
+	noDefaultBootstrap := fs.Bool("noDefaultBootstrap", false, "Disables the default bootstrap peers, so the node starts isolated unless bootstrap is set (reloaded on SIGHUP)")
+	peerAllowList := fs.String("peerAllowList", "", "File of peer ids, one per line, which are the only peers libp2p connections are accepted from and made to")
+	peerDenyList := fs.String("peerDenyList", "", "File of peer ids, one per line, which libp2p connections are rejected from and not made to")
+	apiTLSCert := fs.String("apiTLSCert", "", "TLS certificate file of the gRPC API and proxy (if not provided, they're served over plaintext)")
+	apiTLSKey := fs.String("apiTLSKey", "", "TLS key file of the gRPC API and proxy (required with apiTLSCert)")
+	apiClientCA := fs.String("apiClientCA", "", "CA certificate file used to verify required client certificates of the gRPC API and proxy (requires apiTLSCert)")
 	keepAliveInterval := fs.Duration("keepAliveInterval", time.Second*5, "Websocket keepalive interval (must be >= 1s)")
+	maxWebsockets := fs.Uint("maxWebsockets", 0, "Maximum number of open websocket connections of the API proxy, above which upgrades are rejected with 503 (0 disables the limit)")
+	restHosts := fs.String("restHosts", "", "Comma-separated hostnames the REST API of the proxy is served at, other hosts getting 404 (a *. prefix also serves the collections of each thread at {id}.{hostname}/{collection}; if empty, any host is served)")
 	disableNetPulling := fs.Bool("disableNetPulling", false, "Disables automatic thread record and log pulling from network peers")
 	netPullingLimit := fs.Uint("netPullingLimit", 10000, "Maximum number of records to request from network peers during a single pull (must be > 0)")
 	netPullingStartAfter := fs.Duration("netPullingStartAfter", time.Second, "Delay after which thread pulling from network peers starts (must be > 0)")
 	netPullingInitialInterval := fs.Duration("netPullingInitialInterval", time.Second, "Initial (first run) interval at which threads are pulled from network peers (must be > 0)")
 	netPullingInterval := fs.Duration("netPullingInterval", time.Second*10, "Interval at which threads are pulled from network peers (must be > 0)")
 	disableExchangeEdgesMigration := fs.Bool("disableExchangeEdgesMigration", false, "Disables automatic thread migration to the exchangeEdges protocol")
+	enableThreadDiscovery := fs.Bool("enableThreadDiscovery", false, "Enables advertising threads and discovering their peers in the DHT")
 	enableNetPubsub := fs.Bool("enableNetPubsub", false, "Enables thread networking over libp2p pubsub")
+	netPubsubPeerRateLimit := fs.Int("netPubsubPeerRateLimit", 0, "Maximum number of records per second received over pubsub from a single peer (0 disables the limit)")
+	netPubsubThreadRateLimit := fs.Int("netPubsubThreadRateLimit", 0, "Maximum number of records per second received over pubsub for a single thread (0 disables the limit)")
 	mongoUri := fs.String("mongoUri", "", "MongoDB URI (if not provided, an embedded Badger datastore will be used)")
 	mongoDatabase := fs.String("mongoDatabase", "", "MongoDB database name (required with mongoUri")
 	badgerLowMem := fs.Bool("badgerLowMem", false, "Use Badger's low memory settings")
+	repair := fs.Bool("repair", false, "Repairs Badger datastores left corrupt by an unclean shutdown on startup, dropping the writes which weren't completely written")
+	valueKeyStr := fs.String("valueKey", "", "Multibase-encoded key used to encrypt stored values of collections with encryptValues set")
+	shutdownTimeout := fs.Duration("shutdownTimeout", time.Second*30, "Time to wait for active API requests and streams to finish on shutdown")
+	enableMetrics := fs.Bool("enableMetrics", false, "Enables Prometheus metrics, served at /metrics by the API proxy")
+	trace := fs.Bool("trace", false, "Traces operations of the db datastore, reporting their durations by operation and collection as Prometheus metrics (requires enableMetrics)")
 	debug := fs.Bool("debug", false, "Enables debug logging")
 	logFile := fs.String("logFile", "", "File to write logs to")
+	fs.String(flag.DefaultConfigFlagname, "", "File of flag values (debug, conn* and bootstrap flags are reloaded from it on SIGHUP)")
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		log.Fatal(err)
 	}
+	lowMem, err := applyProfile(fs, *profile)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	hostAddr, err := ma.NewMultiaddr(*hostAddrStr)
 	if err != nil {
@@ -77,6 +119,28 @@ func main() {
 		log.Fatal(err)
 	}
 
+	bootPeers, err := bootstrapPeers(*bootstrap, *noDefaultBootstrap)
+	if err != nil {
+		log.Fatalf("parsing bootstrap: %v", err)
+	}
+
+	var allowPeers, denyPeers []peer.ID
+	if *peerAllowList != "" {
+		if allowPeers, err = common.LoadPeerList(*peerAllowList); err != nil {
+			log.Fatalf("loading peerAllowList: %v", err)
+		}
+	}
+	if *peerDenyList != "" {
+		if denyPeers, err = common.LoadPeerList(*peerDenyList); err != nil {
+			log.Fatalf("loading peerDenyList: %v", err)
+		}
+	}
+
+	tlsConfig, err := apiTLSConfig(*apiTLSCert, *apiTLSKey, *apiClientCA)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	var parsedMongoUri *url.URL
 	if len(*mongoUri) != 0 {
 		parsedMongoUri, err = url.Parse(*mongoUri)
@@ -89,6 +153,16 @@ func main() {
 	} else {
 		log.Debugf("badgerLowMem: %v", *badgerLowMem)
 	}
+	var valueKey *sym.Key
+	if len(*valueKeyStr) != 0 {
+		_, b, err := mbase.Decode(*valueKeyStr)
+		if err != nil {
+			log.Fatalf("decoding valueKey: %v", err)
+		}
+		if valueKey, err = sym.FromBytes(b); err != nil {
+			log.Fatalf("parsing valueKey: %v", err)
+		}
+	}
 
 	if err := util.SetupDefaultLoggingConfig(*logFile); err != nil {
 		log.Fatal(err)
@@ -99,6 +173,7 @@ func main() {
 		log.Fatal(err)
 	}
 
+	log.Debugf("profile: %v", *profile)
 	log.Debugf("repo: %v", *repo)
 	log.Debugf("hostAddr: %v", *hostAddrStr)
 	if announceAddr != nil {
@@ -106,22 +181,70 @@ func main() {
 	}
 	log.Debugf("apiAddr: %v", *apiAddrStr)
 	log.Debugf("apiProxyAddr: %v", *apiProxyAddrStr)
+	if tlsConfig != nil {
+		log.Debugf("apiTLSCert: %v", *apiTLSCert)
+		log.Debugf("apiTLSKey: %v", *apiTLSKey)
+		if *apiClientCA != "" {
+			log.Debugf("apiClientCA: %v", *apiClientCA)
+		}
+	}
 	log.Debugf("connLowWater: %v", *connLowWater)
 	log.Debugf("connHighWater: %v", *connHighWater)
 	log.Debugf("connGracePeriod: %v", *connGracePeriod)
+	if *bootstrap != "" {
+		log.Debugf("bootstrap: %v", *bootstrap)
+	}
+	log.Debugf("noDefaultBootstrap: %v", *noDefaultBootstrap)
+	if *peerAllowList != "" {
+		log.Debugf("peerAllowList: %v (%d peers)", *peerAllowList, len(allowPeers))
+	}
+	if *peerDenyList != "" {
+		log.Debugf("peerDenyList: %v (%d peers)", *peerDenyList, len(denyPeers))
+	}
 	log.Debugf("keepAliveInterval: %v", *keepAliveInterval)
+	log.Debugf("maxWebsockets: %v", *maxWebsockets)
+	if *restHosts != "" {
+		log.Debugf("restHosts: %v", *restHosts)
+	}
+	log.Debugf("enableThreadDiscovery: %v", *enableThreadDiscovery)
 	log.Debugf("enableNetPubsub: %v", *enableNetPubsub)
+	log.Debugf("netPubsubPeerRateLimit: %v", *netPubsubPeerRateLimit)
+	log.Debugf("netPubsubThreadRateLimit: %v", *netPubsubThreadRateLimit)
 	if parsedMongoUri != nil {
 		log.Debugf("mongoUri: %v", parsedMongoUri.Redacted())
 		log.Debugf("mongoDatabase: %v", *mongoDatabase)
 	} else {
 		log.Debugf("badgerLowMem: %v", *badgerLowMem)
+		log.Debugf("repair: %v", *repair)
 	}
+	log.Debugf("shutdownTimeout: %v", *shutdownTimeout)
+	log.Debugf("enableMetrics: %v", *enableMetrics)
+	log.Debugf("trace: %v", *trace)
 	log.Debugf("debug: %v", *debug)
 
-	opts := []common.NetOption{
+	var (
+		metrics        prometheus.Registerer
+		metricsHandler http.Handler
+	)
+	if *enableMetrics {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(
+			prometheus.NewGoCollector(),
+			prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		)
+		metrics = registry
+		metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+
+	connManager := common.NewResizableConnManager(int(*connLowWater), int(*connHighWater), *connGracePeriod)
+	var opts []common.NetOption
+	if lowMem {
+		// Flag values set by the profile are applied by the following options
+		opts = append(opts, common.WithLowMemoryProfile())
+	}
+	opts = append(opts,
 		common.WithNetHostAddr(hostAddr),
-		common.WithConnectionManager(connmgr.NewConnManager(int(*connLowWater), int(*connHighWater), *connGracePeriod)),
+		common.WithConnectionManager(connManager),
 		common.WithNetPulling(
 			*netPullingLimit,
 			*netPullingStartAfter,
@@ -130,10 +253,13 @@ func main() {
 		),
 		common.WithNoNetPulling(*disableNetPulling),
 		common.WithNoExchangeEdgesMigration(*disableExchangeEdgesMigration),
+		common.WithNetThreadDiscovery(*enableThreadDiscovery),
 		common.WithNetPubSub(*enableNetPubsub),
+		common.WithNetPubSubRateLimit(*netPubsubPeerRateLimit, *netPubsubThreadRateLimit),
 		common.WithNetLogstore(common.LogstoreHybrid),
+		common.WithMetrics(metrics),
 		common.WithNetDebug(*debug),
-	}
+	)
 	if parsedMongoUri != nil {
 		opts = append(opts, common.WithNetMongoPersistence(*mongoUri, *mongoDatabase))
 	} else {
@@ -142,12 +268,18 @@ func main() {
 	if announceAddr != nil {
 		opts = append(opts, common.WithAnnounceAddr(announceAddr))
 	}
+	if allowPeers != nil || denyPeers != nil {
+		opts = append(opts, common.WithConnectionGater(common.NewPeerGater(allowPeers, denyPeers)))
+	}
+	if *repair && parsedMongoUri == nil {
+		if err := repairDatastores(*repo, *badgerLowMem); err != nil {
+			log.Fatal(err)
+		}
+	}
 	n, err := common.DefaultNetwork(opts...)
 	if err != nil {
-		log.Fatal(err)
+		log.Fatal(datastoreError(err))
 	}
-	defer n.Close()
-	n.Bootstrap(util.DefaultBoostrapPeers())
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -158,10 +290,22 @@ func main() {
 		store, err = util.NewBadgerDatastore(*repo, "eventstore", *badgerLowMem)
 	}
 	if err != nil {
-		log.Fatal(err)
+		log.Fatal(datastoreError(err))
+	}
+	if *trace {
+		if metrics == nil {
+			log.Fatal("trace requires enableMetrics")
+		}
+		tracer, err := newDatastoreTracer(metrics)
+		if err != nil {
+			log.Fatal(err)
+		}
+		store = kt.WithTracing(store, tracer)
 	}
 	service, err := api.NewService(store, n, api.Config{
-		Debug: *debug,
+		Debug:    *debug,
+		ValueKey: valueKey,
+		Metrics:  metrics,
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -182,7 +326,22 @@ func main() {
 		log.Fatal(err)
 	}
 
-	server := grpc.NewServer()
+	// The node isn't ready until the network has bootstrapped.
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	websockets, err := newWebsocketLimiter(int(*maxWebsockets), metrics)
+	if err != nil {
+		log.Fatal(err)
+	}
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(websockets.UnaryInterceptor(), service.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(websockets.StreamInterceptor(), service.StreamInterceptor()),
+	}
+	if tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(serverOpts...)
 	listener, err := net.Listen("tcp", target)
 	if err != nil {
 		log.Fatal(err)
@@ -190,6 +349,7 @@ func main() {
 	go func() {
 		pb.RegisterAPIServer(server, service)
 		netpb.RegisterAPIServer(server, netService)
+		healthpb.RegisterHealthServer(server, healthServer)
 		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			log.Fatalf("serve error: %v", err)
 		}
@@ -204,18 +364,52 @@ func main() {
 		grpcweb.WithWebsocketOriginFunc(func(req *http.Request) bool {
 			return true
 		}))
+	hosts := parseRESTHosts(*restHosts)
+	restHandler := service.RESTHandler()
+	if len(hosts) > 0 {
+		restHandler = service.RESTHostsHandler(hosts)
+	}
+	websocketHandler := websockets.Handler(webrpc)
 	proxy := &http.Server{
 		Addr: ptarget,
 	}
+	if tlsConfig != nil {
+		proxy.TLSConfig = tlsConfig.Clone()
+	}
 	proxy.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if webrpc.IsGrpcWebRequest(r) ||
-			webrpc.IsAcceptableGrpcCorsRequest(r) ||
-			webrpc.IsGrpcWebSocketRequest(r) {
+		if r.URL.Path == "/health" {
+			handleHealth(w, r, healthServer)
+			return
+		}
+		if metricsHandler != nil && r.URL.Path == "/metrics" {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, api.RESTPrefix) {
+			restHandler.ServeHTTP(w, r)
+			return
+		}
+		if webrpc.IsGrpcWebSocketRequest(r) {
+			websocketHandler.ServeHTTP(w, r)
+			return
+		}
+		if webrpc.IsGrpcWebRequest(r) || webrpc.IsAcceptableGrpcCorsRequest(r) {
 			webrpc.ServeHTTP(w, r)
+			return
+		}
+		if len(hosts) > 0 {
+			// Thread subdomains of REST hosts are served without the REST prefix
+			restHandler.ServeHTTP(w, r)
 		}
 	})
 	go func() {
-		if err := proxy.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if proxy.TLSConfig != nil {
+			err = proxy.ListenAndServeTLS("", "")
+		} else {
+			err = proxy.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			log.Fatalf("proxy error: %v", err)
 		}
 	}()
@@ -223,24 +417,337 @@ func main() {
 	fmt.Println("Welcome to Threads!")
 	fmt.Println("Your peer ID is " + n.Host().ID().String())
 
-	handleInterrupt(func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	go func() {
+		n.Bootstrap(bootPeers)
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+		log.Info("node is ready")
+	}()
+
+	handleReload(fs, connManager, n)
+	handleInterrupt(func() bool {
+		clean := true
+		healthServer.Shutdown()
+		// The proxy and the API share the timeout, since they serve the same requests.
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
 		defer cancel()
 		if err := proxy.Shutdown(ctx); err != nil {
-			log.Fatal(err)
+			log.Errorf("stopping proxy: %v", err)
+			clean = false
+		}
+		deadline, _ := ctx.Deadline()
+		if !util.StopGRPCServerWithTimeout(server, time.Until(deadline)) {
+			log.Error("API requests were still pending after shutdown timeout")
+			clean = false
+		}
+		if err := service.Close(); err != nil {
+			log.Errorf("closing dbs: %v", err)
+			clean = false
 		}
-		util.StopGRPCServer(server)
 		if err := n.Close(); err != nil {
-			log.Fatal(err)
+			log.Errorf("closing network: %v", err)
+			clean = false
+		}
+		if err := store.Close(); err != nil {
+			log.Errorf("closing datastore: %v", err)
+			clean = false
 		}
+		return clean
+	})
+}
+
+// badgerDatastores are the names of the Badger datastores in the repo.
+var badgerDatastores = []string{"ipfslite", "logstore", "eventstore"}
+
+// repairDatastores repairs the Badger datastores in repo, logging the bytes lost by each.
+func repairDatastores(repo string, lowMem bool) error {
+	opts := badger.DefaultOptions
+	if lowMem {
+		opts = util.LowMemBadgerOptions()
+	}
+	for _, name := range badgerDatastores {
+		path := filepath.Join(repo, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		res, err := util.RepairBadgerDatastore(path, opts)
+		if err != nil {
+			return fmt.Errorf("repairing %s datastore: %w", name, err)
+		}
+		if res.Clean() {
+			log.Infof("%s datastore is intact (%d bytes in value log)", name, res.Kept)
+			continue
+		}
+		for file, lost := range res.Lost {
+			log.Warnf("%s datastore repaired: truncated %d bytes of %s", name, lost, file)
+		}
+	}
+	return nil
+}
+
+// datastoreError returns err with a hint on how to recover, if it's caused by a corrupt datastore.
+func datastoreError(err error) error {
+	switch {
+	case errors.Is(err, util.ErrBadgerRepairRequired):
+		return fmt.Errorf("%w (restart with --repair to truncate the value log)", err)
+	case errors.Is(err, util.ErrBadgerCorrupted):
+		return fmt.Errorf("%w (restore the repo from a backup, or remove the datastore to resync it from peers)", err)
+	default:
+		return err
+	}
+}
+
+// lowMemFlags are the flag values of the lowmem profile.
+var lowMemFlags = map[string]string{
+	"connLowWater":    strconv.Itoa(common.LowMemoryConnLowWater),
+	"connHighWater":   strconv.Itoa(common.LowMemoryConnHighWater),
+	"netPullingLimit": strconv.Itoa(common.LowMemoryNetPullingLimit),
+	"badgerLowMem":    "true",
+}
+
+// applyProfile sets the flags of the named profile which weren't set explicitly,
+// and returns whether it's the lowmem profile.
+func applyProfile(fs *flag.FlagSet, profile string) (bool, error) {
+	switch profile {
+	case "default":
+		return false, nil
+	case "lowmem":
+	default:
+		return false, fmt.Errorf("unknown profile: %s", profile)
+	}
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
 	})
+	for name, value := range lowMemFlags {
+		if set[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// apiTLSConfig returns the TLS config of the API listeners, or nil if no certificate is given.
+// Client certificates are required if a client CA is given.
+func apiTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("apiClientCA requires apiTLSCert and apiTLSKey")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading API TLS certificate: %v", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pem, err := ioutil.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading API client CA: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", clientCAFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
 }
 
-func handleInterrupt(stop func()) {
-	quit := make(chan os.Signal)
-	signal.Notify(quit, os.Interrupt)
+// bootstrapPeers parses a comma-separated list of bootstrap peer multiaddrs. An empty list
+// means the default peers, or none if noDefault is set.
+func bootstrapPeers(list string, noDefault bool) ([]peer.AddrInfo, error) {
+	var addrs []string
+	for _, addr := range strings.Split(list, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) > 0 {
+		return util.ParseBootstrapPeers(addrs)
+	}
+	if noDefault {
+		return nil, nil
+	}
+	return util.DefaultBoostrapPeers(), nil
+}
+
+// parseRESTHosts parses a comma-separated list of REST hostnames. Hostnames prefixed
+// with *. serve thread subdomains.
+func parseRESTHosts(list string) []api.RESTHost {
+	var hosts []api.RESTHost
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		h := api.RESTHost{Name: strings.TrimPrefix(name, "*.")}
+		h.Subdomains = h.Name != name
+		hosts = append(hosts, h)
+	}
+	return hosts
+}
+
+// handleHealth responds with 200 if the node is ready, and 503 otherwise.
+func handleHealth(w http.ResponseWriter, r *http.Request, hs *health.Server) {
+	res, err := hs.Check(r.Context(), &healthpb.HealthCheckRequest{})
+	if err != nil || res.Status != healthpb.HealthCheckResponse_SERVING {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleInterrupt waits for an interrupt or termination signal, and then runs stop.
+// It returns if stop reports a clean stop, and otherwise exits with status 1.
+// A second signal exits with status 1 without waiting for stop.
+func handleInterrupt(stop func() bool) {
+	quit := make(chan os.Signal, 2)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	<-quit
 	fmt.Println("Gracefully stopping... (press Ctrl+C again to force)")
-	stop()
-	os.Exit(1)
+	stopped := make(chan bool, 1)
+	go func() {
+		stopped <- stop()
+	}()
+	select {
+	case clean := <-stopped:
+		if !clean {
+			os.Exit(1)
+		}
+	case <-quit:
+		fmt.Println("Forced to stop")
+		os.Exit(1)
+	}
+}
+
+// handleReload re-reads flags from the environment and config file on SIGHUP, and applies
+// changes of the debug, connection manager and bootstrap flags without restarting.
+func handleReload(fs *flag.FlagSet, cm *common.ResizableConnManager, n common.NetBoostrapper) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			if err := reload(fs, cm, n); err != nil {
+				log.Errorf("reloading flags: %v", err)
+			}
+		}
+	}()
+}
+
+func reload(fs *flag.FlagSet, cm *common.ResizableConnManager, n common.NetBoostrapper) error {
+	rfs := flag.NewFlagSetWithEnvPrefix(os.Args[0], "THRDS", 0)
+	fs.VisitAll(func(f *flag.Flag) {
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			rfs.Bool(f.Name, f.DefValue == "true", f.Usage)
+		} else {
+			rfs.String(f.Name, f.DefValue, f.Usage)
+		}
+	})
+	if err := rfs.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+	changed := func(name string) bool {
+		return rfs.Lookup(name).Value.String() != fs.Lookup(name).Value.String()
+	}
+	value := func(name string) string {
+		return rfs.Lookup(name).Value.String()
+	}
+
+	var reloaded bool
+	if changed("debug") {
+		debug, err := strconv.ParseBool(value("debug"))
+		if err != nil {
+			return fmt.Errorf("parsing debug: %v", err)
+		}
+		levels := make(map[string]logging.LogLevel, len(debugLogSystems))
+		for _, sys := range debugLogSystems {
+			levels[sys] = util.LevelFromDebugFlag(debug)
+		}
+		if err := util.SetLogLevels(levels); err != nil {
+			return err
+		}
+		_ = fs.Set("debug", value("debug"))
+		log.Infof("debug changed to %v", debug)
+		reloaded = true
+	}
+	if changed("connLowWater") || changed("connHighWater") || changed("connGracePeriod") {
+		low, err := strconv.ParseUint(value("connLowWater"), 10, 0)
+		if err != nil {
+			return fmt.Errorf("parsing connLowWater: %v", err)
+		}
+		high, err := strconv.ParseUint(value("connHighWater"), 10, 0)
+		if err != nil {
+			return fmt.Errorf("parsing connHighWater: %v", err)
+		}
+		grace, err := time.ParseDuration(value("connGracePeriod"))
+		if err != nil {
+			return fmt.Errorf("parsing connGracePeriod: %v", err)
+		}
+		if err := cm.SetLimits(int(low), int(high), grace); err != nil {
+			return err
+		}
+		for _, name := range []string{"connLowWater", "connHighWater", "connGracePeriod"} {
+			_ = fs.Set(name, value(name))
+		}
+		log.Infof("connection manager limits changed to low %d, high %d, grace period %s", low, high, grace)
+		reloaded = true
+	}
+	if changed("bootstrap") || changed("noDefaultBootstrap") {
+		noDefault, err := strconv.ParseBool(value("noDefaultBootstrap"))
+		if err != nil {
+			return fmt.Errorf("parsing noDefaultBootstrap: %v", err)
+		}
+		peers, err := bootstrapPeers(value("bootstrap"), noDefault)
+		if err != nil {
+			return fmt.Errorf("parsing bootstrap: %v", err)
+		}
+		n.SetBootstrapPeers(peers)
+		for _, name := range []string{"bootstrap", "noDefaultBootstrap"} {
+			_ = fs.Set(name, value(name))
+		}
+		log.Infof("bootstrap peers changed to %d peers, used from the next bootstrap cycle", len(peers))
+		reloaded = true
+	}
+	if !reloaded {
+		log.Info("no reloadable flags changed")
+	}
+	return nil
+}
+
+// newDatastoreTracer returns a tracer observing the durations of datastore operations in a
+// histogram registered with reg, labeled by operation and collection.
+func newDatastoreTracer(reg prometheus.Registerer) (kt.Tracer, error) {
+	c, err := util.RegisterCollector(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "threads",
+		Subsystem: "datastore",
+		Name:      "op_duration_seconds",
+		Help:      "Duration of db datastore operations by operation and collection.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	}, []string{"op", "collection"}))
+	if err != nil {
+		return nil, err
+	}
+	durations := c.(*prometheus.HistogramVec)
+	return kt.TracerFunc(func(span kt.Span) {
+		durations.WithLabelValues(span.Op, keyCollection(span.Key)).Observe(span.Duration.Seconds())
+	}), nil
+}
+
+// keyCollection returns the name of the collection of a db instance key, or an empty string
+// for keys which don't belong to a collection, e.g., schemas and indexes.
+func keyCollection(key ds.Key) string {
+	parts := key.Namespaces()
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "collection" {
+			return parts[i+1]
+		}
+	}
+	return ""
 }
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/textileio/go-threads/util"
+	"google.golang.org/grpc"
+)
+
+// wsContextKey marks the contexts of requests proxied over a websocket.
+type wsContextKey struct{}
+
+// websocketLimiter tracks the websocket connections of the API proxy, rejecting new
+// upgrades once a maximum number of connections is open.
+type websocketLimiter struct {
+	max int
+
+	lk     sync.Mutex
+	opened map[uint64]time.Time
+	next   uint64
+
+	rejected prometheus.Counter
+	duration prometheus.Histogram
+	messages *prometheus.CounterVec
+}
+
+// newWebsocketLimiter returns a limiter of max open connections, or of none if max is zero.
+// Metrics are registered with reg, if not nil.
+func newWebsocketLimiter(max int, reg prometheus.Registerer) (*websocketLimiter, error) {
+	l := &websocketLimiter{
+		max:    max,
+		opened: make(map[uint64]time.Time),
+		rejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "threads",
+			Subsystem: "proxy",
+			Name:      "websockets_rejected_total",
+			Help:      "Number of websocket upgrades rejected because the maximum number of connections was open.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "threads",
+			Subsystem: "proxy",
+			Name:      "websocket_duration_seconds",
+			Help:      "Duration of closed websocket connections.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+		}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "threads",
+			Subsystem: "proxy",
+			Name:      "websocket_messages_total",
+			Help:      "Number of gRPC messages received (in) and sent (out) over websocket connections.",
+		}, []string{"direction"}),
+	}
+	if reg == nil {
+		return l, nil
+	}
+	collectors := []prometheus.Collector{
+		l.rejected,
+		l.duration,
+		l.messages,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "threads",
+			Subsystem: "proxy",
+			Name:      "websockets",
+			Help:      "Number of open websocket connections.",
+		}, func() float64 {
+			l.lk.Lock()
+			defer l.lk.Unlock()
+			return float64(len(l.opened))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "threads",
+			Subsystem: "proxy",
+			Name:      "websocket_oldest_age_seconds",
+			Help:      "Age of the oldest open websocket connection, which grows steadily for leaked connections.",
+		}, func() float64 {
+			return l.oldestAge().Seconds()
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: "threads",
+			Subsystem: "proxy",
+			Name:      "websockets_max",
+			Help:      "Maximum number of open websocket connections, or zero if unlimited.",
+		}, func() float64 {
+			return float64(l.max)
+		}),
+	}
+	for _, c := range collectors {
+		if _, err := util.RegisterCollector(reg, c); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// oldestAge returns the age of the oldest open connection.
+func (l *websocketLimiter) oldestAge() time.Duration {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	var oldest time.Time
+	for _, t := range l.opened {
+		if oldest.IsZero() || t.Before(oldest) {
+			oldest = t
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	return time.Since(oldest)
+}
+
+// open tracks a new connection, returning false if the maximum is reached.
+func (l *websocketLimiter) open() (uint64, bool) {
+	l.lk.Lock()
+	defer l.lk.Unlock()
+	if l.max > 0 && len(l.opened) >= l.max {
+		return 0, false
+	}
+	l.next++
+	l.opened[l.next] = time.Now()
+	return l.next, true
+}
+
+// close stops tracking a connection.
+func (l *websocketLimiter) close(id uint64) {
+	l.lk.Lock()
+	opened := l.opened[id]
+	delete(l.opened, id)
+	l.lk.Unlock()
+	l.duration.Observe(time.Since(opened).Seconds())
+}
+
+// Handler returns a handler which serves websocket upgrades with next while fewer than the
+// maximum number of connections are open, and responds with 503 otherwise.
+// The handler returns once the connection is closed.
+func (l *websocketLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := l.open()
+		if !ok {
+			l.rejected.Inc()
+			log.Warnf("rejecting websocket from %s: %d connections are open", r.RemoteAddr, l.max)
+			http.Error(w, "too many websocket connections", http.StatusServiceUnavailable)
+			return
+		}
+		defer l.close(id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), wsContextKey{}, true)))
+	})
+}
+
+// UnaryInterceptor counts the messages of unary calls proxied over a websocket.
+func (l *websocketLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if ctx.Value(wsContextKey{}) == nil {
+			return handler(ctx, req)
+		}
+		l.messages.WithLabelValues("in").Inc()
+		res, err := handler(ctx, req)
+		if err == nil {
+			l.messages.WithLabelValues("out").Inc()
+		}
+		return res, err
+	}
+}
+
+// StreamInterceptor counts the messages of streams proxied over a websocket.
+func (l *websocketLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if ss.Context().Value(wsContextKey{}) == nil {
+			return handler(srv, ss)
+		}
+		return handler(srv, &countingStream{ServerStream: ss, l: l})
+	}
+}
+
+type countingStream struct {
+	grpc.ServerStream
+	l *websocketLimiter
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.l.messages.WithLabelValues("out").Inc()
+	}
+	return err
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.l.messages.WithLabelValues("in").Inc()
+	}
+	return err
+}
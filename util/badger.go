@@ -0,0 +1,124 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	badgerdb "github.com/dgraph-io/badger"
+	badger "github.com/textileio/go-ds-badger"
+)
+
+var (
+	// ErrBadgerRepairRequired indicates a Badger datastore wasn't closed cleanly, and the tail of
+	// its value log is corrupt. Repairing the datastore with RepairBadgerDatastore truncates the
+	// tail, losing the writes in it.
+	ErrBadgerRepairRequired = errors.New("badger datastore value log is corrupt and must be repaired, which drops the writes at its tail")
+
+	// ErrBadgerCorrupted indicates a Badger datastore is corrupted beyond what repairing can fix,
+	// and must be restored from a backup or removed.
+	ErrBadgerCorrupted = errors.New("badger datastore is corrupted")
+)
+
+// BadgerRepair describes the outcome of repairing a Badger datastore.
+type BadgerRepair struct {
+	// Path is the datastore path.
+	Path string
+	// Kept is the number of bytes of the value log which were kept.
+	Kept int64
+	// Lost is the number of bytes truncated from the value log, by file name. Truncated bytes
+	// hold the writes which weren't completely written before an unclean shutdown.
+	Lost map[string]int64
+}
+
+// Clean returns whether the datastore was repaired without losing data.
+func (r BadgerRepair) Clean() bool {
+	return len(r.Lost) == 0
+}
+
+// OpenBadgerDatastore opens the Badger datastore at path. Writes which weren't flushed to
+// tables before an unclean shutdown are replayed from the value log.
+// The error of a datastore whose value log must be truncated wraps ErrBadgerRepairRequired,
+// and the error of a datastore which can't be opened because it's corrupted, including
+// panics of Badger, wraps ErrBadgerCorrupted.
+func OpenBadgerDatastore(path string, opts badger.Options) (store *badger.Datastore, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			store, err = nil, fmt.Errorf("%w: opening %s: %v", ErrBadgerCorrupted, path, r)
+		}
+	}()
+	store, err = badger.NewDatastore(path, &opts)
+	if errors.Is(err, badgerdb.ErrTruncateNeeded) {
+		return nil, fmt.Errorf("%w: %s", ErrBadgerRepairRequired, path)
+	} else if err != nil && isBadgerCorruption(err) {
+		return nil, fmt.Errorf("%w: opening %s: %v", ErrBadgerCorrupted, path, err)
+	}
+	return store, err
+}
+
+// isBadgerCorruption returns whether an error opening a datastore is caused by corrupted files.
+func isBadgerCorruption(err error) bool {
+	msg := err.Error()
+	for _, s := range []string{
+		"manifest has bad magic",
+		"manifest has checksum mismatch",
+		"MANIFEST",
+		"Opening table",
+		"Unable to replay logfile",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// RepairBadgerDatastore opens the Badger datastore at path, truncating the corrupt tail of its
+// value log, if any, and closes it. The returned BadgerRepair reports how many bytes were lost.
+// Datastores which don't need repair are left unchanged.
+func RepairBadgerDatastore(path string, opts badger.Options) (BadgerRepair, error) {
+	repair := BadgerRepair{Path: path, Lost: make(map[string]int64)}
+	before, err := valueLogSizes(path)
+	if err != nil {
+		return repair, err
+	}
+	opts.Truncate = true
+	store, err := OpenBadgerDatastore(path, opts)
+	if err != nil {
+		return repair, err
+	}
+	if err := store.Close(); err != nil {
+		return repair, err
+	}
+	after, err := valueLogSizes(path)
+	if err != nil {
+		return repair, err
+	}
+	for name, size := range before {
+		kept := after[name]
+		repair.Kept += kept
+		if kept < size {
+			repair.Lost[name] = size - kept
+		}
+	}
+	return repair, nil
+}
+
+// valueLogSizes returns the sizes of the value log files of the datastore at path.
+func valueLogSizes(path string) (map[string]int64, error) {
+	files, err := filepath.Glob(filepath.Join(path, "*.vlog"))
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64, len(files))
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		sizes[filepath.Base(f)] = info.Size()
+	}
+	return sizes, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"os"
@@ -19,6 +20,7 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	mbase "github.com/multiformats/go-multibase"
 	"github.com/phayes/freeport"
+	"github.com/prometheus/client_golang/prometheus"
 	badger "github.com/textileio/go-ds-badger"
 	core "github.com/textileio/go-threads/core/db"
 	"github.com/textileio/go-threads/core/thread"
@@ -52,9 +54,27 @@ func NewBadgerDatastore(dirPath, name string, lowMem bool) (kt.TxnDatastoreExten
 	}
 	opts := badger.DefaultOptions
 	if lowMem {
-		opts.TableLoadingMode = options.FileIO
+		opts = LowMemBadgerOptions()
 	}
-	return badger.NewDatastore(path, &opts)
+	return OpenBadgerDatastore(path, opts)
+}
+
+// LowMemBadgerOptions returns badger options for devices with little memory.
+// Tables are read from disk instead of being memory-mapped, and tables and memtables are
+// smaller and fewer, which bounds memory use to a few tens of MB per datastore at the cost
+// of write throughput. Value log GC runs more often and rewrites files with less garbage,
+// so the value log is kept small too.
+func LowMemBadgerOptions() badger.Options {
+	opts := badger.DefaultOptions
+	opts.GcInterval = 5 * time.Minute
+	opts.GcDiscardRatio = 0.1
+	opts.TableLoadingMode = options.FileIO
+	opts.MaxTableSize = 4 << 20
+	opts.NumMemtables = 2
+	opts.NumLevelZeroTables = 2
+	opts.NumLevelZeroTablesStall = 4
+	opts.ValueLogFileSize = 64 << 20
+	return opts
 }
 
 // SetupDefaultLoggingConfig sets up a standard logging configuration.
@@ -255,17 +275,39 @@ func ComputeAddrsEdge(as []PeerAddr) uint64 {
 }
 
 func StopGRPCServer(server *grpc.Server) {
+	if !StopGRPCServerWithTimeout(server, 10*time.Second) {
+		fmt.Println("warn: server was shutdown ungracefully")
+	}
+}
+
+// StopGRPCServerWithTimeout waits up to timeout for the pending requests and streams of
+// server to finish, and then stops it. It returns false if requests were still pending.
+func StopGRPCServerWithTimeout(server *grpc.Server, timeout time.Duration) bool {
 	stopped := make(chan struct{})
 	go func() {
 		server.GracefulStop()
 		close(stopped)
 	}()
-	timer := time.NewTimer(10 * time.Second)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
 	select {
 	case <-timer.C:
 		server.Stop()
-		fmt.Println("warn: server was shutdown ungracefully")
+		return false
 	case <-stopped:
-		timer.Stop()
+		return true
+	}
+}
+
+// RegisterCollector registers c with reg. If an equal collector is already registered,
+// it's returned instead of c, so several instances can report to the same registry.
+func RegisterCollector(reg prometheus.Registerer, c prometheus.Collector) (prometheus.Collector, error) {
+	if err := reg.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			return are.ExistingCollector, nil
+		}
+		return nil, err
 	}
+	return c, nil
 }